@@ -15,6 +15,12 @@ var (
 	vulnerabilityReportsCRD []byte
 	//go:embed deploy/crd/clustervulnerabilityreports.crd.yaml
 	clusterVulnerabilityReportsCRD []byte
+	//go:embed deploy/crd/vulnerabilityexceptions.crd.yaml
+	vulnerabilityExceptionsCRD []byte
+	//go:embed deploy/crd/scanjobtemplates.crd.yaml
+	scanJobTemplatesCRD []byte
+	//go:embed deploy/crd/sbomreports.crd.yaml
+	sbomReportsCRD []byte
 	//go:embed deploy/crd/configauditreports.crd.yaml
 	configAuditReportsCRD []byte
 	//go:embed deploy/crd/clusterconfigauditreports.crd.yaml
@@ -51,6 +57,18 @@ func GetClusterVulnerabilityReportsCRD() (apiextensionsv1.CustomResourceDefiniti
 	return getCRDFromBytes(clusterVulnerabilityReportsCRD)
 }
 
+func GetVulnerabilityExceptionsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(vulnerabilityExceptionsCRD)
+}
+
+func GetScanJobTemplatesCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(scanJobTemplatesCRD)
+}
+
+func GetSBOMReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(sbomReportsCRD)
+}
+
 func GetConfigAuditReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
 	return getCRDFromBytes(configAuditReportsCRD)
 }
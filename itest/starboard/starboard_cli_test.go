@@ -86,6 +86,48 @@ var _ = Describe("Starboard CLI", func() {
 						"Scope": Equal(apiextensionsv1beta1.ClusterScoped),
 					}),
 				}),
+				"vulnerabilityexceptions.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "vulnerabilityexceptions",
+							Singular:   "vulnerabilityexception",
+							ShortNames: []string{"vulnexception", "vulnexceptions"},
+							Kind:       "VulnerabilityException",
+							ListKind:   "VulnerabilityExceptionList",
+						}),
+						"Scope": Equal(apiextensionsv1beta1.NamespaceScoped),
+					}),
+				}),
+				"scanjobtemplates.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "scanjobtemplates",
+							Singular:   "scanjobtemplate",
+							ShortNames: []string{"sjt"},
+							Kind:       "ScanJobTemplate",
+							ListKind:   "ScanJobTemplateList",
+						}),
+						"Scope": Equal(apiextensionsv1beta1.ClusterScoped),
+					}),
+				}),
+				"sbomreports.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "sbomreports",
+							Singular:   "sbomreport",
+							ShortNames: []string{"sbom", "sboms"},
+							Kind:       "SBOMReport",
+							ListKind:   "SBOMReportList",
+						}),
+						"Scope": Equal(apiextensionsv1beta1.NamespaceScoped),
+					}),
+				}),
 				"clustercompliancereports.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
 					"Spec": MatchFields(IgnoreExtras, Fields{
 						"Group":   Equal("aquasecurity.github.io"),
@@ -0,0 +1,4 @@
+// Package admission provides the validating admission webhook, which checks
+// Kubernetes workloads against security reports and configuration audit
+// policies before they are admitted to the cluster.
+package admission
@@ -0,0 +1,440 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/policy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ValidateWorkloadsPath is the path the Validator is served at by the
+// webhook server of the operator.
+const ValidateWorkloadsPath = "/validate-workloads"
+
+// maxVulnerabilityIDs is the maximum number of vulnerability identifiers
+// listed in a single violation message.
+const maxVulnerabilityIDs = 5
+
+// Validator is an admission.Handler that checks workloads against the
+// admission policy configured in starboard.ConfigData.
+//
+// Vulnerabilities are read from existing VulnerabilityReports of the
+// workload, and they are considered only if a report was generated for the
+// same container image as the one being admitted. Containers without such a
+// report, e.g. of workloads that are being created, are checked against
+// reports of the same image generated for other workloads in the namespace
+// or cached by image digest. Images that have not been scanned yet are
+// admitted. Only reports of the primary vulnerability scanner are taken into
+// account. Configuration audit checks are evaluated against
+// the admitted spec with the same policies that are used by the built-in
+// configuration audit scanner.
+type Validator struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	kube.ObjectResolver
+	vulnerabilityreport.ReadWriter
+	vulnerabilityreport.ExceptionsReader
+
+	decoder *admission.Decoder
+}
+
+func (v *Validator) SetupWithManager(mgr ctrl.Manager) error {
+	// Fail fast on invalid policy rather than on each admission request.
+	if _, err := v.ConfigData.GetAdmissionMode(); err != nil {
+		return err
+	}
+	if _, err := v.ConfigData.GetAdmissionVulnerabilitiesSeverity(); err != nil {
+		return err
+	}
+	if _, err := v.ConfigData.GetAdmissionConfigAuditChecksSeverity(); err != nil {
+		return err
+	}
+	mgr.GetWebhookServer().Register(ValidateWorkloadsPath, &webhook.Admission{Handler: v})
+	return nil
+}
+
+// InjectDecoder implements admission.DecoderInjector.
+func (v *Validator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+// Handle implements admission.Handler.
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := v.Logger.WithValues("kind", req.Kind.Kind, "name", req.Name, "namespace", req.Namespace)
+
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	if !kube.IsWorkload(req.Kind.Kind) {
+		return admission.Allowed("")
+	}
+
+	obj, err := v.decode(req)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if managedBy, ok := obj.GetLabels()[starboard.LabelK8SAppManagedBy]; ok && managedBy == starboard.AppStarboard {
+		return admission.Allowed("")
+	}
+	// Workloads owned by controllers, e.g. ReplicaSets of Deployments, are
+	// checked when their controllers are admitted. Checking them again would
+	// block scaling and recreation of Pods of running workloads.
+	if metav1.GetControllerOf(obj) != nil {
+		return admission.Allowed("")
+	}
+
+	violations, err := v.violations(ctx, obj)
+	if err != nil {
+		// Do not block workloads because of transient errors.
+		log.Error(err, "Failed to evaluate admission policy")
+		return admission.Allowed("").WithWarnings(fmt.Sprintf("starboard: failed to evaluate admission policy: %v", err))
+	}
+	if len(violations) == 0 {
+		return admission.Allowed("")
+	}
+
+	mode, _ := v.ConfigData.GetAdmissionMode()
+	log.V(1).Info("Workload violates admission policy", "mode", mode, "violations", violations)
+	if mode == starboard.AdmissionModeDeny {
+		return admission.Denied(strings.Join(violations, "; "))
+	}
+	warnings := make([]string, len(violations))
+	for i, violation := range violations {
+		warnings[i] = "starboard: " + violation
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+func (v *Validator) decode(req admission.Request) (client.Object, error) {
+	gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
+	runtimeObj, err := v.Client.Scheme().New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := runtimeObj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type: %T", runtimeObj)
+	}
+	err = v.decoder.DecodeRaw(req.Object, obj)
+	if err != nil {
+		return nil, err
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(req.Namespace)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return obj, nil
+}
+
+func (v *Validator) violations(ctx context.Context, obj client.Object) ([]string, error) {
+	var violations []string
+
+	severity, err := v.ConfigData.GetAdmissionVulnerabilitiesSeverity()
+	if err != nil {
+		return nil, err
+	}
+	if severity != "" {
+		vulnerabilities, err := v.vulnerabilityViolations(ctx, obj, severity)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, vulnerabilities...)
+	}
+
+	severity, err = v.ConfigData.GetAdmissionConfigAuditChecksSeverity()
+	if err != nil {
+		return nil, err
+	}
+	if severity != "" {
+		checks, err := v.configAuditViolations(ctx, obj, severity)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, checks...)
+	}
+
+	return violations, nil
+}
+
+func (v *Validator) vulnerabilityViolations(ctx context.Context, obj client.Object, severity v1alpha1.Severity) ([]string, error) {
+	reports, err := v.findVulnerabilityReports(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	reportsByContainer := make(map[string]v1alpha1.VulnerabilityReport)
+	for _, report := range vulnerabilityreport.FilterPrimaryScannerReports(reports, v.ConfigData) {
+		reportsByContainer[report.Labels[starboard.LabelContainerName]] = report
+	}
+
+	spec, err := kube.GetPodSpec(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	fixableOnly := v.ConfigData.AdmissionFixableVulnerabilitiesOnly()
+
+	// Reports of other workloads are listed lazily, i.e. only if a container
+	// does not have a report of its own.
+	var imageReports []imageReport
+	var imageReportsListed bool
+
+	var violations []string
+	for _, container := range kube.GetContainers(spec) {
+		var data v1alpha1.VulnerabilityReportData
+		report, ok := reportsByContainer[container.Name]
+		if ok && sameImage(container, report.Report) {
+			data = report.Report
+		} else {
+			if !imageReportsListed {
+				imageReports, err = v.findImageVulnerabilityReports(ctx, obj)
+				if err != nil {
+					return nil, err
+				}
+				imageReportsListed = true
+			}
+			found, ok := findByImage(imageReports, container)
+			if !ok {
+				continue
+			}
+			data = found
+		}
+		var ids []string
+		for _, vulnerability := range data.Vulnerabilities {
+			if vulnerability.IsExcluded() || vulnerability.Severity.Compare(severity) < 0 {
+				continue
+			}
+			if fixableOnly && vulnerability.FixedVersion == "" {
+				continue
+			}
+			ids = append(ids, vulnerability.VulnerabilityID)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		violations = append(violations, vulnerabilitiesMessage(container, severity, fixableOnly, ids))
+	}
+	return violations, nil
+}
+
+// imageReport is the data of a VulnerabilityReport of another workload or of
+// a cached ClusterVulnerabilityReport along with the digest of the scanned
+// image, which is known for cached reports only.
+type imageReport struct {
+	data   v1alpha1.VulnerabilityReportData
+	digest string
+}
+
+// findImageVulnerabilityReports returns reports of container images generated
+// by the primary scanner for other workloads in the namespace of the given
+// object, as well as reports cached by image digest. Vulnerability exceptions
+// of the namespace are applied to the returned reports as if they were
+// generated for the given object.
+func (v *Validator) findImageVulnerabilityReports(ctx context.Context, obj client.Object) ([]imageReport, error) {
+	var reports []imageReport
+
+	var reportList v1alpha1.VulnerabilityReportList
+	err := v.Client.List(ctx, &reportList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		return nil, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	for _, report := range vulnerabilityreport.FilterPrimaryScannerReports(reportList.Items, v.ConfigData) {
+		reports = append(reports, imageReport{data: report.Report})
+	}
+
+	var cachedList v1alpha1.ClusterVulnerabilityReportList
+	err = v.Client.List(ctx, &cachedList)
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster vulnerability reports: %w", err)
+	}
+	for _, report := range cachedList.Items {
+		if !vulnerabilityreport.IsCachedReport(report.ObjectMeta) ||
+			!vulnerabilityreport.IsGeneratedByPrimaryScanner(report.ObjectMeta, v.ConfigData) {
+			continue
+		}
+		reports = append(reports, imageReport{data: report.Report, digest: report.Annotations[v1alpha1.ImageDigestAnnotation]})
+	}
+
+	if v.ExceptionsReader == nil || len(reports) == 0 {
+		return reports, nil
+	}
+	exceptions, err := v.FindActiveExceptions(ctx, obj.GetNamespace())
+	if err != nil {
+		return nil, fmt.Errorf("listing vulnerability exceptions: %w", err)
+	}
+	for i := range reports {
+		reports[i].data, err = vulnerabilityreport.ApplyExceptions(reports[i].data, exceptions, obj.GetLabels())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return reports, nil
+}
+
+// findByImage returns the most recently updated report generated for the
+// image of the given container.
+func findByImage(reports []imageReport, container corev1.Container) (v1alpha1.VulnerabilityReportData, bool) {
+	var found *imageReport
+	for i, report := range reports {
+		if !sameImage(container, report.data) && !sameDigest(container, report.digest) {
+			continue
+		}
+		if found == nil || found.data.UpdateTimestamp.Before(&report.data.UpdateTimestamp) {
+			found = &reports[i]
+		}
+	}
+	if found == nil {
+		return v1alpha1.VulnerabilityReportData{}, false
+	}
+	return found.data, true
+}
+
+// findVulnerabilityReports returns VulnerabilityReports of the given object.
+// Reports of Pods are owned by their controllers, e.g. ReplicaSets, whereas
+// reports of Deployments are owned by their current revisions. Objects that
+// are being created do not have reports yet, hence not found errors are
+// ignored.
+func (v *Validator) findVulnerabilityReports(ctx context.Context, obj client.Object) ([]v1alpha1.VulnerabilityReport, error) {
+	ref := kube.ObjectRef{
+		Kind:      kube.Kind(obj.GetObjectKind().GroupVersionKind().Kind),
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+
+	var reports []v1alpha1.VulnerabilityReport
+	var err error
+	if ref.Kind == kube.KindPod {
+		reports, err = v.findPodVulnerabilityReports(ctx, obj)
+	} else {
+		reports, err = v.FindByOwnerInHierarchy(ctx, ref)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) || errors.Is(err, kube.ErrReplicaSetNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	return reports, nil
+}
+
+func (v *Validator) findPodVulnerabilityReports(ctx context.Context, pod client.Object) ([]v1alpha1.VulnerabilityReport, error) {
+	owner, err := v.ReportOwner(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	if owner == pod {
+		return v.FindByOwner(ctx, kube.ObjectRef{
+			Kind:      kube.KindPod,
+			Name:      pod.GetName(),
+			Namespace: pod.GetNamespace(),
+		})
+	}
+	return v.FindByOwnerInHierarchy(ctx, kube.ObjectRef{
+		Kind:      kube.Kind(owner.GetObjectKind().GroupVersionKind().Kind),
+		Name:      owner.GetName(),
+		Namespace: owner.GetNamespace(),
+	})
+}
+
+func (v *Validator) configAuditViolations(ctx context.Context, obj client.Object, severity v1alpha1.Severity) ([]string, error) {
+	cm := &corev1.ConfigMap{}
+	err := v.Client.Get(ctx, client.ObjectKey{
+		Namespace: v.Config.Namespace,
+		Name:      starboard.PoliciesConfigMapName,
+	}, cm)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting policies from configmap: %s/%s: %w", v.Config.Namespace, starboard.PoliciesConfigMapName, err)
+	}
+	policies := policy.NewPolicies(cm.Data)
+
+	applicable, _, err := policies.Applicable(obj)
+	if err != nil {
+		return nil, err
+	}
+	if !applicable {
+		return nil, nil
+	}
+
+	results, err := policies.Eval(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	for _, result := range results {
+		if result.Success || result.Metadata.Severity.Compare(severity) < 0 {
+			continue
+		}
+		violations = append(violations, fmt.Sprintf("failed %s check %s: %s",
+			result.Metadata.Severity, result.Metadata.ID, result.Metadata.Title))
+	}
+	sort.Strings(violations)
+	return violations, nil
+}
+
+// sameImage returns true if the VulnerabilityReport was generated for the
+// image of the given container.
+func sameImage(container corev1.Container, data v1alpha1.VulnerabilityReportData) bool {
+	ref, err := name.ParseReference(container.Image)
+	if err != nil {
+		return false
+	}
+	if ref.Context().RegistryStr() != data.Registry.Server || ref.Context().RepositoryStr() != data.Artifact.Repository {
+		return false
+	}
+	switch t := ref.(type) {
+	case name.Digest:
+		return t.DigestStr() == data.Artifact.Digest
+	case name.Tag:
+		return t.TagStr() == data.Artifact.Tag
+	}
+	return false
+}
+
+// sameDigest returns true if the image of the given container is referenced
+// by the specified digest.
+func sameDigest(container corev1.Container, digest string) bool {
+	if digest == "" {
+		return false
+	}
+	ref, err := name.NewDigest(container.Image)
+	if err != nil {
+		return false
+	}
+	return ref.DigestStr() == digest
+}
+
+func vulnerabilitiesMessage(container corev1.Container, severity v1alpha1.Severity, fixableOnly bool, ids []string) string {
+	qualifier := ""
+	if fixableOnly {
+		qualifier = " with fixed version available"
+	}
+	listed := ids
+	if len(listed) > maxVulnerabilityIDs {
+		listed = append(listed[:maxVulnerabilityIDs:maxVulnerabilityIDs], "...")
+	}
+	return fmt.Sprintf("container %s (%s) has %d %s or higher vulnerabilities%s: %s",
+		container.Name, container.Image, len(ids), severity, qualifier, strings.Join(listed, ", "))
+}
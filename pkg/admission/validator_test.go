@@ -0,0 +1,332 @@
+package admission_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/admission"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrladmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const privilegedPolicy = `package appshield.kubernetes.KSV017
+
+__rego_metadata__ := {
+	"id": "KSV017",
+	"title": "Privileged container",
+	"description": "Privileged containers share namespaces with the host system",
+	"severity": "HIGH",
+	"type": "Kubernetes Security Check"
+}
+
+deny[res] {
+	input.spec.containers[_].securityContext.privileged
+	res := {"msg": "Container should not be privileged"}
+}
+`
+
+var replicaSet = &appsv1.ReplicaSet{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "nginx-6d4cf56db6",
+		Namespace: "default",
+		UID:       "aa345200-cf24-443a-8f11-ddb438ff8659",
+	},
+	Spec: appsv1.ReplicaSetSpec{
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "nginx", Image: "nginx:1.16"},
+				},
+			},
+		},
+	},
+}
+
+var vulnerabilityReport = &v1alpha1.VulnerabilityReport{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "replicaset-nginx-6d4cf56db6-nginx",
+		Namespace: "default",
+		Labels: map[string]string{
+			starboard.LabelResourceKind:      "ReplicaSet",
+			starboard.LabelResourceName:      "nginx-6d4cf56db6",
+			starboard.LabelResourceNamespace: "default",
+			starboard.LabelContainerName:     "nginx",
+		},
+	},
+	Report: v1alpha1.VulnerabilityReportData{
+		Registry: v1alpha1.Registry{Server: "index.docker.io"},
+		Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2020-1967", Severity: v1alpha1.SeverityCritical, FixedVersion: "1.1.1g"},
+			{VulnerabilityID: "CVE-2021-3711", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2019-1551", Severity: v1alpha1.SeverityMedium, FixedVersion: "1.1.1e"},
+		},
+	},
+}
+
+func newPod(image string, privileged bool) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "nginx",
+					Image: image,
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: pointer.BoolPtr(privileged),
+					},
+				},
+			},
+		},
+	}
+}
+
+func newDeployment(image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: image},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newRequest(t *testing.T, obj client.Object) ctrladmission.Request {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	require.NoError(t, err)
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return ctrladmission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func newValidator(t *testing.T, config starboard.ConfigData, objects ...client.Object) *admission.Validator {
+	t.Helper()
+	scheme := starboard.NewScheme()
+	testClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithObjects(
+		replicaSet,
+		vulnerabilityReport,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      starboard.PoliciesConfigMapName,
+				Namespace: "starboard-system",
+			},
+			Data: map[string]string{
+				"policy.privileged.kinds": "Pod",
+				"policy.privileged.rego":  privilegedPolicy,
+			},
+		},
+	).Build()
+	resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+	validator := &admission.Validator{
+		Logger:           logr.Discard(),
+		Config:           etc.Config{Namespace: "starboard-system"},
+		ConfigData:       config,
+		ObjectResolver:   resolver,
+		ReadWriter:       vulnerabilityreport.NewReadWriter(&resolver),
+		ExceptionsReader: vulnerabilityreport.NewExceptionsReader(testClient, ext.NewSystemClock()),
+	}
+	decoder, err := ctrladmission.NewDecoder(scheme)
+	require.NoError(t, err)
+	require.NoError(t, validator.InjectDecoder(decoder))
+	return validator
+}
+
+func TestValidator_Handle(t *testing.T) {
+	t.Run("Should deny Pod with fixable critical vulnerabilities in Deny mode", func(t *testing.T) {
+		validator := newValidator(t, starboard.ConfigData{
+			"admission.mode":                        "Deny",
+			"admission.vulnerabilities.severity":    "CRITICAL",
+			"admission.vulnerabilities.fixableOnly": "true",
+		})
+		resp := validator.Handle(context.TODO(), newRequest(t, newPod("nginx:1.16", false)))
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "container nginx (nginx:1.16) has 1 CRITICAL or higher vulnerabilities with fixed version available: CVE-2020-1967", string(resp.Result.Reason))
+	})
+
+	t.Run("Should ignore reports of secondary vulnerability scanners", func(t *testing.T) {
+		grypeReport := vulnerabilityReport.DeepCopy()
+		grypeReport.Name = "replicaset-nginx-6d4cf56db6-nginx-grype"
+		grypeReport.Labels[starboard.LabelVulnerabilityReportScanner] = "Grype"
+		grypeReport.Report.Vulnerabilities = nil
+
+		validator := newValidator(t, starboard.ConfigData{
+			"vulnerabilityReports.scanner":       "Trivy,Grype",
+			"admission.mode":                     "Deny",
+			"admission.vulnerabilities.severity": "CRITICAL",
+		}, grypeReport)
+		resp := validator.Handle(context.TODO(), newRequest(t, newPod("nginx:1.16", false)))
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "container nginx (nginx:1.16) has 2 CRITICAL or higher vulnerabilities: CVE-2020-1967, CVE-2021-3711", string(resp.Result.Reason))
+	})
+
+	t.Run("Should admit Pod with warnings in Warn mode", func(t *testing.T) {
+		validator := newValidator(t, starboard.ConfigData{
+			"admission.vulnerabilities.severity":   "CRITICAL",
+			"admission.configAuditChecks.severity": "HIGH",
+		})
+		resp := validator.Handle(context.TODO(), newRequest(t, newPod("nginx:1.16", true)))
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, []string{
+			"starboard: container nginx (nginx:1.16) has 2 CRITICAL or higher vulnerabilities: CVE-2020-1967, CVE-2021-3711",
+			"starboard: failed HIGH check KSV017: Privileged container",
+		}, resp.Warnings)
+	})
+
+	t.Run("Should admit Pod with image that has not been scanned", func(t *testing.T) {
+		validator := newValidator(t, starboard.ConfigData{
+			"admission.mode":                     "Deny",
+			"admission.vulnerabilities.severity": "CRITICAL",
+		})
+		resp := validator.Handle(context.TODO(), newRequest(t, newPod("nginx:1.17", false)))
+		assert.True(t, resp.Allowed)
+		assert.Empty(t, resp.Warnings)
+	})
+
+	t.Run("Should deny Pod with failed config audit checks in Deny mode", func(t *testing.T) {
+		validator := newValidator(t, starboard.ConfigData{
+			"admission.mode":                       "Deny",
+			"admission.configAuditChecks.severity": "HIGH",
+		})
+		resp := validator.Handle(context.TODO(), newRequest(t, newPod("nginx:1.17", true)))
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "failed HIGH check KSV017: Privileged container", string(resp.Result.Reason))
+	})
+
+	t.Run("Should deny Deployment with known vulnerable image that is being created", func(t *testing.T) {
+		validator := newValidator(t, starboard.ConfigData{
+			"admission.mode":                     "Deny",
+			"admission.vulnerabilities.severity": "CRITICAL",
+		})
+		resp := validator.Handle(context.TODO(), newRequest(t, newDeployment("nginx:1.16")))
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "container nginx (nginx:1.16) has 2 CRITICAL or higher vulnerabilities: CVE-2020-1967, CVE-2021-3711", string(resp.Result.Reason))
+	})
+
+	t.Run("Should admit Deployment with image that has not been scanned", func(t *testing.T) {
+		validator := newValidator(t, starboard.ConfigData{
+			"admission.mode":                     "Deny",
+			"admission.vulnerabilities.severity": "LOW",
+		})
+		resp := validator.Handle(context.TODO(), newRequest(t, newDeployment("nginx:1.17")))
+		assert.True(t, resp.Allowed)
+		assert.Empty(t, resp.Warnings)
+	})
+
+	t.Run("Should deny Deployment with image found in cache of reports by digest", func(t *testing.T) {
+		digest := "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767"
+		cached := &v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: kube.ComputeHash(digest),
+				Labels: map[string]string{
+					starboard.LabelVulnerabilityReportScanner: "Trivy",
+				},
+				Annotations: map[string]string{
+					v1alpha1.ImageDigestAnnotation: digest,
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/redis", Tag: "6.2", Digest: digest},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2022-0543", Severity: v1alpha1.SeverityCritical},
+					{VulnerabilityID: "CVE-2021-32675", Severity: v1alpha1.SeverityHigh},
+				},
+			},
+		}
+		exception := &v1alpha1.VulnerabilityException{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "redis",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityIDs: []string{"CVE-2021-32675"},
+			},
+		}
+		validator := newValidator(t, starboard.ConfigData{
+			"vulnerabilityReports.scanner":       "Trivy",
+			"admission.mode":                     "Deny",
+			"admission.vulnerabilities.severity": "HIGH",
+		}, cached, exception)
+		image := "registry.example.com/redis@" + digest
+		resp := validator.Handle(context.TODO(), newRequest(t, newDeployment(image)))
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "container nginx ("+image+") has 1 HIGH or higher vulnerabilities: CVE-2022-0543", string(resp.Result.Reason))
+	})
+
+	t.Run("Should admit Pods managed by Starboard", func(t *testing.T) {
+		validator := newValidator(t, starboard.ConfigData{
+			"admission.mode":                       "Deny",
+			"admission.configAuditChecks.severity": "LOW",
+		})
+		pod := newPod("nginx:1.16", true)
+		pod.Labels = map[string]string{starboard.LabelK8SAppManagedBy: starboard.AppStarboard}
+		resp := validator.Handle(context.TODO(), newRequest(t, pod))
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("Should admit Pods owned by controllers", func(t *testing.T) {
+		validator := newValidator(t, starboard.ConfigData{
+			"admission.mode":                     "Deny",
+			"admission.vulnerabilities.severity": "CRITICAL",
+		})
+		pod := newPod("nginx:1.16", false)
+		pod.Name = "nginx-6d4cf56db6-x7k2p"
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       "nginx-6d4cf56db6",
+				UID:        "aa345200-cf24-443a-8f11-ddb438ff8659",
+				Controller: pointer.BoolPtr(true),
+			},
+		}
+		resp := validator.Handle(context.TODO(), newRequest(t, pod))
+		assert.True(t, resp.Allowed)
+		assert.Empty(t, resp.Warnings)
+	})
+}
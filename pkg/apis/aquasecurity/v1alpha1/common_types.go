@@ -7,6 +7,14 @@ import (
 
 const (
 	TTLReportAnnotation = "starboard.aquasecurity.github.io/report-ttl"
+
+	// ImageDigestAnnotation holds the digest of the container image which
+	// scan results are cached by a ClusterVulnerabilityReport.
+	ImageDigestAnnotation = "starboard.aquasecurity.github.io/image-digest"
+
+	// ClusterVulnerabilityReportAnnotation holds the name of the
+	// ClusterVulnerabilityReport a VulnerabilityReport was copied from.
+	ClusterVulnerabilityReportAnnotation = "starboard.aquasecurity.github.io/cluster-vulnerability-report-name"
 )
 
 // Severity level of a vulnerability or a configuration audit check.
@@ -44,6 +52,29 @@ func StringToSeverity(name string) (Severity, error) {
 	}
 }
 
+// Compare compares severity levels. It returns a positive number if the
+// severity is higher than the other one, a negative number if it's lower, and
+// zero if both are the same. SeverityUnknown, SeverityNone, and unrecognized
+// values are lower than SeverityLow.
+func (in Severity) Compare(other Severity) int {
+	return in.rank() - other.rank()
+}
+
+func (in Severity) rank() int {
+	switch in {
+	case SeverityCritical:
+		return 4
+	case SeverityHigh:
+		return 3
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // Scanner is the spec for a scanner generating a security assessment report.
 type Scanner struct {
 	// Name the name of the scanner.
@@ -56,3 +56,13 @@ func TestStringToSeverity(t *testing.T) {
 	}
 
 }
+
+func TestSeverity_Compare(t *testing.T) {
+	assert.Positive(t, v1alpha1.SeverityCritical.Compare(v1alpha1.SeverityHigh))
+	assert.Positive(t, v1alpha1.SeverityHigh.Compare(v1alpha1.SeverityMedium))
+	assert.Positive(t, v1alpha1.SeverityMedium.Compare(v1alpha1.SeverityLow))
+	assert.Positive(t, v1alpha1.SeverityLow.Compare(v1alpha1.SeverityUnknown))
+	assert.Negative(t, v1alpha1.SeverityLow.Compare(v1alpha1.SeverityCritical))
+	assert.Zero(t, v1alpha1.SeverityHigh.Compare(v1alpha1.SeverityHigh))
+	assert.Zero(t, v1alpha1.SeverityUnknown.Compare(v1alpha1.Severity("")))
+}
@@ -0,0 +1,100 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NamespaceSecuritySummaryCRName    = "namespacesecuritysummaries.aquasecurity.github.io"
+	NamespaceSecuritySummaryCRVersion = "v1alpha1"
+	NamespaceSecuritySummaryKind      = "NamespaceSecuritySummary"
+	NamespaceSecuritySummaryListKind  = "NamespaceSecuritySummaryList"
+
+	// NamespaceSecuritySummaryName is the name of the only
+	// NamespaceSecuritySummary instance in a given namespace.
+	NamespaceSecuritySummaryName = "security-summary"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceSecuritySummary aggregates VulnerabilityReports and
+// ConfigAuditReports of a given namespace.
+type NamespaceSecuritySummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Summary NamespaceSecuritySummaryData `json:"summary"`
+}
+
+// NamespaceSecuritySummaryData is the aggregated security posture of a namespace.
+type NamespaceSecuritySummaryData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this summary was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Vulnerabilities is the summary of VulnerabilityReports in the namespace.
+	Vulnerabilities NamespaceVulnerabilitySummary `json:"vulnerabilities"`
+
+	// ConfigAudit is the summary of ConfigAuditReports in the namespace.
+	ConfigAudit NamespaceConfigAuditSummary `json:"configAudit"`
+
+	// TopVulnerableImages is the list of container images with the highest
+	// number of vulnerabilities, ordered by severity.
+	// +optional
+	TopVulnerableImages []ImageVulnerabilitySummary `json:"topVulnerableImages,omitempty"`
+
+	// TopFailedChecks is the list of configuration checks that failed for the
+	// highest number of resources, ordered by severity.
+	// +optional
+	TopFailedChecks []FailedCheckSummary `json:"topFailedChecks,omitempty"`
+}
+
+// NamespaceVulnerabilitySummary is the sum of summaries of VulnerabilityReports.
+type NamespaceVulnerabilitySummary struct {
+	// ReportsCount is the number of VulnerabilityReports in the namespace.
+	ReportsCount int `json:"reportsCount"`
+
+	VulnerabilitySummary `json:",inline"`
+}
+
+// NamespaceConfigAuditSummary is the sum of summaries of ConfigAuditReports.
+type NamespaceConfigAuditSummary struct {
+	// ReportsCount is the number of ConfigAuditReports in the namespace.
+	ReportsCount int `json:"reportsCount"`
+
+	ConfigAuditSummary `json:",inline"`
+}
+
+// ImageVulnerabilitySummary is the summary of vulnerabilities in a container
+// image used by one or more workloads.
+type ImageVulnerabilitySummary struct {
+	Registry Registry `json:"registry"`
+	Artifact Artifact `json:"artifact"`
+
+	// Workloads is the number of workload containers that run the image.
+	Workloads int `json:"workloads"`
+
+	Summary VulnerabilitySummary `json:"summary"`
+}
+
+// FailedCheckSummary is a configuration check that failed for one or more
+// resources.
+type FailedCheckSummary struct {
+	ID       string   `json:"checkID"`
+	Title    string   `json:"title,omitempty"`
+	Severity Severity `json:"severity"`
+	Category string   `json:"category,omitempty"`
+
+	// AffectedResources is the number of resources that failed the check.
+	AffectedResources int `json:"affectedResources"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceSecuritySummaryList is a list of NamespaceSecuritySummary resources.
+type NamespaceSecuritySummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NamespaceSecuritySummary `json:"items"`
+}
@@ -34,6 +34,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VulnerabilityReportList{},
 		&ClusterVulnerabilityReport{},
 		&ClusterVulnerabilityReportList{},
+		&VulnerabilityException{},
+		&VulnerabilityExceptionList{},
+		&SBOMReport{},
+		&SBOMReportList{},
+		&ScanFailure{},
+		&ScanFailureList{},
 		&CISKubeBenchReport{},
 		&CISKubeBenchReportList{},
 		&KubeHunterReport{},
@@ -46,6 +52,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterComplianceReportList{},
 		&ClusterComplianceDetailReport{},
 		&ClusterComplianceDetailReportList{},
+		&NamespaceSecuritySummary{},
+		&NamespaceSecuritySummaryList{},
+		&ReportHistory{},
+		&ReportHistoryList{},
+		&ScanJobTemplate{},
+		&ScanJobTemplateList{},
 	)
 	meta.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -0,0 +1,103 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ReportHistoryCRName    = "reporthistories.aquasecurity.github.io"
+	ReportHistoryCRVersion = "v1alpha1"
+	ReportHistoryKind      = "ReportHistory"
+	ReportHistoryListKind  = "ReportHistoryList"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ReportHistory records how security reports of the given kind generated for
+// a Kubernetes workload changed over time. Unlike reports, which are
+// overwritten by each scan, it keeps a bounded timeline of summaries and
+// remembers when each finding was first and last seen.
+type ReportHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	History ReportHistoryData `json:"history"`
+}
+
+// ReportHistoryData is the timeline of security reports of a workload.
+type ReportHistoryData struct {
+	// ReportKind is the kind of security reports, e.g. VulnerabilityReport.
+	ReportKind string `json:"reportKind"`
+
+	// Entries is the list of summaries of reports ordered from the oldest to
+	// the most recent one.
+	Entries []ReportHistoryEntry `json:"entries"`
+
+	// Findings is the list of vulnerabilities or failed checks that were
+	// found in any of the reports.
+	// +optional
+	Findings []ReportHistoryFinding `json:"findings,omitempty"`
+}
+
+// ReportHistoryEntry is the summary of a security report at the time it was
+// updated.
+type ReportHistoryEntry struct {
+	// UpdateTimestamp is the update timestamp of the security report.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// CriticalCount is the number of findings with critical severity.
+	CriticalCount int `json:"criticalCount"`
+
+	// HighCount is the number of findings with high severity.
+	HighCount int `json:"highCount"`
+
+	// MediumCount is the number of findings with medium severity.
+	MediumCount int `json:"mediumCount"`
+
+	// LowCount is the number of findings with low severity.
+	LowCount int `json:"lowCount"`
+
+	// UnknownCount is the number of findings with unknown severity.
+	// +optional
+	UnknownCount int `json:"unknownCount,omitempty"`
+
+	// NewCount is the number of findings that were not present in the
+	// previous report.
+	NewCount int `json:"newCount"`
+
+	// ResolvedCount is the number of findings of the previous report that are
+	// no longer present.
+	ResolvedCount int `json:"resolvedCount"`
+}
+
+// ReportHistoryFinding is a vulnerability or a failed check tracked across
+// security reports.
+type ReportHistoryFinding struct {
+	// ID is the vulnerability ID or the check ID.
+	ID       string   `json:"id"`
+	Severity Severity `json:"severity"`
+
+	// FirstSeen is the update timestamp of the first report with the finding.
+	FirstSeen metav1.Time `json:"firstSeen"`
+
+	// LastSeen is the update timestamp of the most recent report with the
+	// finding.
+	LastSeen metav1.Time `json:"lastSeen"`
+
+	// Resolved is the update timestamp of the first report without the
+	// finding after it was last seen. It is not set for open findings.
+	// +optional
+	Resolved *metav1.Time `json:"resolved,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ReportHistoryList is a list of ReportHistory resources.
+type ReportHistoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ReportHistory `json:"items"`
+}
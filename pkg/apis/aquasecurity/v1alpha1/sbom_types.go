@@ -0,0 +1,136 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SBOMReportCRName    = "sbomreports.aquasecurity.github.io"
+	SBOMReportCRVersion = "v1alpha1"
+	SBOMReportKind      = "SBOMReport"
+	SBOMReportListKind  = "SBOMReportList"
+)
+
+const (
+	// BOMFormatCycloneDX is the format of BOM documents stored in SBOMReports.
+	BOMFormatCycloneDX = "CycloneDX"
+
+	// BOMSpecVersion is the version of the CycloneDX specification BOM
+	// documents stored in SBOMReports conform to.
+	BOMSpecVersion = "1.4"
+)
+
+// ComponentType is the type of a Component as defined by the CycloneDX specification.
+type ComponentType string
+
+const (
+	ComponentTypeApplication     ComponentType = "application"
+	ComponentTypeContainer       ComponentType = "container"
+	ComponentTypeLibrary         ComponentType = "library"
+	ComponentTypeOperatingSystem ComponentType = "operating-system"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SBOMReport is a specification for the SBOMReport resource, which holds the
+// software bill of materials (SBOM) of a container image of a given workload.
+type SBOMReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Report is the actual SBOM report data.
+	Report SBOMReportData `json:"report"`
+}
+
+// SBOMReportData is the spec for the SBOM report.
+type SBOMReportData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Scanner is the scanner that generated this report.
+	Scanner Scanner `json:"scanner"`
+
+	// Registry is the registry the Artifact was pulled from.
+	Registry Registry `json:"registry"`
+
+	// Artifact is a container image the SBOM was generated for.
+	Artifact Artifact `json:"artifact"`
+
+	// Summary is a summary of the SBOM.
+	Summary SBOMSummary `json:"summary"`
+
+	// Components is the SBOM document in the CycloneDX format.
+	Components BOM `json:"components"`
+}
+
+// SBOMSummary is a summary of the SBOM.
+type SBOMSummary struct {
+	// ComponentsCount is the number of components in the SBOM.
+	ComponentsCount int `json:"componentsCount"`
+}
+
+// BOM is a software bill of materials in the CycloneDX format.
+// @see https://cyclonedx.org/docs/1.4/json/
+type BOM struct {
+	BOMFormat    string       `json:"bomFormat"`
+	SpecVersion  string       `json:"specVersion"`
+	SerialNumber string       `json:"serialNumber,omitempty"`
+	Version      int          `json:"version"`
+	Metadata     *BOMMetadata `json:"metadata,omitempty"`
+	Components   []Component  `json:"components,omitempty"`
+}
+
+// BOMMetadata describes the BOM and the Component it was generated for.
+type BOMMetadata struct {
+	Timestamp string     `json:"timestamp,omitempty"`
+	Tools     []Tool     `json:"tools,omitempty"`
+	Component *Component `json:"component,omitempty"`
+}
+
+// Tool is a tool used to generate the BOM.
+type Tool struct {
+	Vendor  string `json:"vendor,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// Component is a software package, library, or operating system included in
+// the Artifact.
+type Component struct {
+	BOMRef     string          `json:"bom-ref,omitempty"`
+	Type       ComponentType   `json:"type"`
+	Group      string          `json:"group,omitempty"`
+	Name       string          `json:"name"`
+	Version    string          `json:"version,omitempty"`
+	PackageURL string          `json:"purl,omitempty"`
+	Licenses   []LicenseChoice `json:"licenses,omitempty"`
+	Properties []Property      `json:"properties,omitempty"`
+}
+
+// LicenseChoice wraps the License of a Component.
+type LicenseChoice struct {
+	License License `json:"license"`
+}
+
+// License is a license of a Component identified by SPDX ID or name.
+type License struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Property is a name-value pair which describes a Component.
+type Property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SBOMReportList is a list of SBOMReport resources.
+type SBOMReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SBOMReport `json:"items"`
+}
@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ScanFailureCRName    = "scanfailures.aquasecurity.github.io"
+	ScanFailureCRVersion = "v1alpha1"
+	ScanFailureKind      = "ScanFailure"
+	ScanFailureListKind  = "ScanFailureList"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanFailure records consecutive failed scans of a Kubernetes resource
+// performed to generate a security report of the given kind. It is deleted
+// once the report is generated successfully.
+type ScanFailure struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status ScanFailureStatus `json:"status"`
+}
+
+// ScanFailureStatus describes the most recent failed scan and when the scan
+// will be retried.
+type ScanFailureStatus struct {
+	// ReportKind is the kind of the security report that the failed scan was
+	// supposed to generate, e.g. VulnerabilityReport.
+	ReportKind string `json:"reportKind"`
+
+	// Scanner is the name of the scanner, e.g. Trivy.
+	// +optional
+	Scanner string `json:"scanner,omitempty"`
+
+	// Reason is a brief CamelCase reason of the most recent failure, e.g. Error or DeadlineExceeded.
+	Reason string `json:"reason"`
+
+	// Message is a human-readable description of the most recent failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Attempts is the number of consecutive failed scans.
+	Attempts int `json:"attempts"`
+
+	// LastFailureTime is the time of the most recent failure.
+	LastFailureTime metav1.Time `json:"lastFailureTime"`
+
+	// NextRetryTime is the time before which the scan will not be retried.
+	NextRetryTime metav1.Time `json:"nextRetryTime"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanFailureList is a list of ScanFailure resources.
+type ScanFailureList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ScanFailure `json:"items"`
+}
@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ScanJobTemplateCRName    = "scanjobtemplates.aquasecurity.github.io"
+	ScanJobTemplateCRVersion = "v1alpha1"
+	ScanJobTemplateKind      = "ScanJobTemplate"
+	ScanJobTemplateListKind  = "ScanJobTemplateList"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanJobTemplate is a specification for the ScanJobTemplate resource, which
+// customizes pods created by scan jobs of the selected scanners for workloads
+// in the selected namespaces.
+type ScanJobTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScanJobTemplateSpec `json:"spec"`
+}
+
+// ScanJobTemplateSpec is the spec of the ScanJobTemplate.
+type ScanJobTemplateSpec struct {
+	// Scanners is a list of names of scanners, e.g. Trivy, Polaris, or
+	// kube-bench, whose scan jobs the template applies to. The template applies
+	// to scan jobs of all scanners if empty.
+	// +optional
+	Scanners []string `json:"scanners,omitempty"`
+
+	// NamespaceSelector is a label selector of namespaces of scanned workloads.
+	// If not set, the template applies to scan jobs of workloads in all
+	// namespaces, as well as to scan jobs of cluster-scoped resources, such as
+	// nodes.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Template is merged into the pod template of scan jobs.
+	Template ScanJobPodTemplate `json:"template"`
+}
+
+// ScanJobPodTemplate describes the pod created by a scan job. Labels,
+// annotations, and the node selector are merged with those set by Starboard,
+// which take precedence. Tolerations and volumes are appended, and volume
+// mounts are appended to each container. Other fields replace those set by
+// Starboard.
+type ScanJobPodTemplate struct {
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// Resources replaces compute resources of each container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts is a list of mounts of Volumes appended to each container.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanJobTemplateList is a list of ScanJobTemplate resources.
+type ScanJobTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ScanJobTemplate `json:"items"`
+}
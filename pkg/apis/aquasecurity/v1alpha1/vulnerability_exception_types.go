@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	VulnerabilityExceptionCRName    = "vulnerabilityexceptions.aquasecurity.github.io"
+	VulnerabilityExceptionCRVersion = "v1alpha1"
+	VulnerabilityExceptionKind      = "VulnerabilityException"
+	VulnerabilityExceptionListKind  = "VulnerabilityExceptionList"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VulnerabilityException is a specification for the VulnerabilityException
+// resource, which accepts the risk of vulnerabilities found in workloads of
+// its namespace. Matching vulnerabilities are marked as suppressed in
+// vulnerability reports and excluded from their summary.
+type VulnerabilityException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VulnerabilityExceptionSpec `json:"spec"`
+}
+
+// VulnerabilityExceptionSpec is the spec of the VulnerabilityException. A
+// Vulnerability matches the exception if it matches all non-empty criteria.
+type VulnerabilityExceptionSpec struct {
+	// VulnerabilityIDs is a list of vulnerability identifiers, e.g. CVE-2022-1234.
+	// +optional
+	VulnerabilityIDs []string `json:"vulnerabilityIDs,omitempty"`
+
+	// Resources is a list of vulnerable packages, applications, or libraries.
+	// +optional
+	Resources []string `json:"resources,omitempty"`
+
+	// Repositories is a list of image repositories. Shell file name patterns,
+	// such as library/* or quay.io/org/*, are supported.
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Selector is a label selector of workloads the exception applies to.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// ExpiresAt is a timestamp after which the exception no longer applies.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Justification explains why the risk of matching vulnerabilities is accepted.
+	Justification string `json:"justification"`
+}
+
+// IsExpired returns true if the exception expired at the given time, false otherwise.
+func (in *VulnerabilityException) IsExpired(now time.Time) bool {
+	return in.Spec.ExpiresAt != nil && !now.Before(in.Spec.ExpiresAt.Time)
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VulnerabilityExceptionList is a list of VulnerabilityException resources.
+type VulnerabilityExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VulnerabilityException `json:"items"`
+}
@@ -56,6 +56,23 @@ type Artifact struct {
 	MimeType string `json:"mimeType,omitempty"`
 }
 
+// ImageRef returns the reference of the container image identified by the
+// specified Registry and Artifact, e.g. quay.io/acme/app@sha256:2e2f. The
+// digest takes precedence over the tag.
+func ImageRef(registry Registry, artifact Artifact) string {
+	ref := artifact.Repository
+	if registry.Server != "" {
+		ref = registry.Server + "/" + ref
+	}
+	if artifact.Digest != "" {
+		return ref + "@" + artifact.Digest
+	}
+	if artifact.Tag != "" {
+		return ref + ":" + artifact.Tag
+	}
+	return ref
+}
+
 // Vulnerability is the spec for a vulnerability record.
 type Vulnerability struct {
 	// VulnerabilityID the vulnerability identifier.
@@ -76,6 +93,42 @@ type Vulnerability struct {
 	PrimaryLink string   `json:"primaryLink,omitempty"`
 	Links       []string `json:"links"`
 	Score       *float64 `json:"score,omitempty"`
+
+	// KnownExploited indicates that this vulnerability is listed in a catalog
+	// of known exploited vulnerabilities, such as the CISA KEV catalog.
+	KnownExploited bool `json:"knownExploited,omitempty"`
+
+	// EPSSScore is the Exploit Prediction Scoring System (EPSS) probability,
+	// between 0 and 1, that this vulnerability will be exploited in the next
+	// 30 days.
+	EPSSScore *float64 `json:"epssScore,omitempty"`
+
+	// EPSSPercentile is the percentile of the EPSSScore among all scored
+	// vulnerabilities.
+	EPSSPercentile *float64 `json:"epssPercentile,omitempty"`
+
+	// Suppressed indicates that the risk of this vulnerability is accepted,
+	// and hence it is excluded from the VulnerabilitySummary.
+	Suppressed bool `json:"suppressed,omitempty"`
+
+	// SuppressedBy is the name of the VulnerabilityException that suppressed
+	// this vulnerability.
+	SuppressedBy string `json:"suppressedBy,omitempty"`
+
+	// VEXStatus is the status of this vulnerability asserted by a VEX document,
+	// i.e. not_affected, affected, fixed, or under_investigation. Vulnerabilities
+	// that are not_affected or fixed are excluded from the VulnerabilitySummary.
+	VEXStatus string `json:"vexStatus,omitempty"`
+
+	// VEXJustification explains the VEXStatus.
+	VEXJustification string `json:"vexJustification,omitempty"`
+}
+
+// IsExcluded returns true if this vulnerability is excluded from the
+// VulnerabilitySummary, i.e. it is suppressed by a VulnerabilityException or
+// a VEX statement asserts that it is not_affected or fixed.
+func (in Vulnerability) IsExcluded() bool {
+	return in.Suppressed || in.VEXStatus == "not_affected" || in.VEXStatus == "fixed"
 }
 
 // +genclient
@@ -130,7 +183,7 @@ type VulnerabilityReportList struct {
 
 // ClusterVulnerabilityReport is a specification for the ClusterVulnerabilityReport resource.
 type ClusterVulnerabilityReport struct {
-	metav1.TypeMeta   `json:",incline"`
+	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Report VulnerabilityReportData `json:"report"`
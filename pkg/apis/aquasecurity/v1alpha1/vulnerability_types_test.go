@@ -0,0 +1,84 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVulnerability_IsExcluded(t *testing.T) {
+	testCases := []struct {
+		name          string
+		vulnerability v1alpha1.Vulnerability
+		expected      bool
+	}{
+		{
+			name:          "Should not exclude vulnerability",
+			vulnerability: v1alpha1.Vulnerability{},
+			expected:      false,
+		},
+		{
+			name:          "Should exclude suppressed vulnerability",
+			vulnerability: v1alpha1.Vulnerability{Suppressed: true},
+			expected:      true,
+		},
+		{
+			name:          "Should exclude vulnerability that is not affected according to VEX",
+			vulnerability: v1alpha1.Vulnerability{VEXStatus: "not_affected"},
+			expected:      true,
+		},
+		{
+			name:          "Should exclude vulnerability that is fixed according to VEX",
+			vulnerability: v1alpha1.Vulnerability{VEXStatus: "fixed"},
+			expected:      true,
+		},
+		{
+			name:          "Should not exclude vulnerability that is affected according to VEX",
+			vulnerability: v1alpha1.Vulnerability{VEXStatus: "affected"},
+			expected:      false,
+		},
+		{
+			name:          "Should not exclude vulnerability that is under investigation according to VEX",
+			vulnerability: v1alpha1.Vulnerability{VEXStatus: "under_investigation"},
+			expected:      false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.vulnerability.IsExcluded())
+		})
+	}
+}
+
+func TestImageRef(t *testing.T) {
+	testCases := []struct {
+		name     string
+		registry v1alpha1.Registry
+		artifact v1alpha1.Artifact
+		expected string
+	}{
+		{
+			name:     "Should return reference with tag",
+			registry: v1alpha1.Registry{Server: "index.docker.io"},
+			artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+			expected: "index.docker.io/library/nginx:1.16",
+		},
+		{
+			name:     "Should prefer digest over tag",
+			registry: v1alpha1.Registry{Server: "quay.io"},
+			artifact: v1alpha1.Artifact{Repository: "acme/app", Tag: "1.0", Digest: "sha256:2e2f"},
+			expected: "quay.io/acme/app@sha256:2e2f",
+		},
+		{
+			name:     "Should return repository without registry server",
+			artifact: v1alpha1.Artifact{Repository: "legacy"},
+			expected: "legacy",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, v1alpha1.ImageRef(tc.registry, tc.artifact))
+		})
+	}
+}
@@ -6,6 +6,8 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -25,6 +27,60 @@ func (in *Artifact) DeepCopy() *Artifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BOM) DeepCopyInto(out *BOM) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(BOMMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]Component, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BOM.
+func (in *BOM) DeepCopy() *BOM {
+	if in == nil {
+		return nil
+	}
+	out := new(BOM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BOMMetadata) DeepCopyInto(out *BOMMetadata) {
+	*out = *in
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]Tool, len(*in))
+		copy(*out, *in)
+	}
+	if in.Component != nil {
+		in, out := &in.Component, &out.Component
+		*out = new(Component)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BOMMetadata.
+func (in *BOMMetadata) DeepCopy() *BOMMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(BOMMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CISKubeBenchReport) DeepCopyInto(out *CISKubeBenchReport) {
 	*out = *in
@@ -528,6 +584,32 @@ func (in *Compliance) DeepCopy() *Compliance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Component) DeepCopyInto(out *Component) {
+	*out = *in
+	if in.Licenses != nil {
+		in, out := &in.Licenses, &out.Licenses
+		*out = make([]LicenseChoice, len(*in))
+		copy(*out, *in)
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make([]Property, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Component.
+func (in *Component) DeepCopy() *Component {
+	if in == nil {
+		return nil
+	}
+	out := new(Component)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigAuditReport) DeepCopyInto(out *ConfigAuditReport) {
 	*out = *in
@@ -715,6 +797,41 @@ func (in *ControlCheckDetails) DeepCopy() *ControlCheckDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedCheckSummary) DeepCopyInto(out *FailedCheckSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedCheckSummary.
+func (in *FailedCheckSummary) DeepCopy() *FailedCheckSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedCheckSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVulnerabilitySummary) DeepCopyInto(out *ImageVulnerabilitySummary) {
+	*out = *in
+	out.Registry = in.Registry
+	out.Artifact = in.Artifact
+	out.Summary = in.Summary
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVulnerabilitySummary.
+func (in *ImageVulnerabilitySummary) DeepCopy() *ImageVulnerabilitySummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVulnerabilitySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeHunterReport) DeepCopyInto(out *KubeHunterReport) {
 	*out = *in
@@ -831,6 +948,39 @@ func (in *KubeHunterVulnerability) DeepCopy() *KubeHunterVulnerability {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *License) DeepCopyInto(out *License) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new License.
+func (in *License) DeepCopy() *License {
+	if in == nil {
+		return nil
+	}
+	out := new(License)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LicenseChoice) DeepCopyInto(out *LicenseChoice) {
+	*out = *in
+	out.License = in.License
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseChoice.
+func (in *LicenseChoice) DeepCopy() *LicenseChoice {
+	if in == nil {
+		return nil
+	}
+	out := new(LicenseChoice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mapping) DeepCopyInto(out *Mapping) {
 	*out = *in
@@ -853,27 +1003,86 @@ func (in *Mapping) DeepCopy() *Mapping {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Registry) DeepCopyInto(out *Registry) {
+func (in *NamespaceConfigAuditSummary) DeepCopyInto(out *NamespaceConfigAuditSummary) {
 	*out = *in
+	out.ConfigAuditSummary = in.ConfigAuditSummary
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Registry.
-func (in *Registry) DeepCopy() *Registry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceConfigAuditSummary.
+func (in *NamespaceConfigAuditSummary) DeepCopy() *NamespaceConfigAuditSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(Registry)
+	out := new(NamespaceConfigAuditSummary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReportSpec) DeepCopyInto(out *ReportSpec) {
+func (in *NamespaceSecuritySummary) DeepCopyInto(out *NamespaceSecuritySummary) {
 	*out = *in
-	if in.Controls != nil {
-		in, out := &in.Controls, &out.Controls
-		*out = make([]Control, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Summary.DeepCopyInto(&out.Summary)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSecuritySummary.
+func (in *NamespaceSecuritySummary) DeepCopy() *NamespaceSecuritySummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSecuritySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceSecuritySummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSecuritySummaryData) DeepCopyInto(out *NamespaceSecuritySummaryData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Vulnerabilities = in.Vulnerabilities
+	out.ConfigAudit = in.ConfigAudit
+	if in.TopVulnerableImages != nil {
+		in, out := &in.TopVulnerableImages, &out.TopVulnerableImages
+		*out = make([]ImageVulnerabilitySummary, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopFailedChecks != nil {
+		in, out := &in.TopFailedChecks, &out.TopFailedChecks
+		*out = make([]FailedCheckSummary, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSecuritySummaryData.
+func (in *NamespaceSecuritySummaryData) DeepCopy() *NamespaceSecuritySummaryData {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSecuritySummaryData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSecuritySummaryList) DeepCopyInto(out *NamespaceSecuritySummaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceSecuritySummary, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -881,130 +1090,806 @@ func (in *ReportSpec) DeepCopyInto(out *ReportSpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportSpec.
-func (in *ReportSpec) DeepCopy() *ReportSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSecuritySummaryList.
+func (in *NamespaceSecuritySummaryList) DeepCopy() *NamespaceSecuritySummaryList {
 	if in == nil {
 		return nil
 	}
-	out := new(ReportSpec)
+	out := new(NamespaceSecuritySummaryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceSecuritySummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReportStatus) DeepCopyInto(out *ReportStatus) {
+func (in *NamespaceVulnerabilitySummary) DeepCopyInto(out *NamespaceVulnerabilitySummary) {
 	*out = *in
-	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
-	out.Summary = in.Summary
-	if in.ControlChecks != nil {
-		in, out := &in.ControlChecks, &out.ControlChecks
-		*out = make([]ControlCheck, len(*in))
-		copy(*out, *in)
+	out.VulnerabilitySummary = in.VulnerabilitySummary
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceVulnerabilitySummary.
+func (in *NamespaceVulnerabilitySummary) DeepCopy() *NamespaceVulnerabilitySummary {
+	if in == nil {
+		return nil
 	}
+	out := new(NamespaceVulnerabilitySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Property) DeepCopyInto(out *Property) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportStatus.
-func (in *ReportStatus) DeepCopy() *ReportStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Property.
+func (in *Property) DeepCopy() *Property {
 	if in == nil {
 		return nil
 	}
-	out := new(ReportStatus)
+	out := new(Property)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResultDetails) DeepCopyInto(out *ResultDetails) {
+func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultDetails.
-func (in *ResultDetails) DeepCopy() *ResultDetails {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Registry.
+func (in *Registry) DeepCopy() *Registry {
 	if in == nil {
 		return nil
 	}
-	out := new(ResultDetails)
+	out := new(Registry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Scanner) DeepCopyInto(out *Scanner) {
+func (in *ReportHistory) DeepCopyInto(out *ReportHistory) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.History.DeepCopyInto(&out.History)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scanner.
-func (in *Scanner) DeepCopy() *Scanner {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportHistory.
+func (in *ReportHistory) DeepCopy() *ReportHistory {
 	if in == nil {
 		return nil
 	}
-	out := new(Scanner)
+	out := new(ReportHistory)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReportHistory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScannerCheckResult) DeepCopyInto(out *ScannerCheckResult) {
+func (in *ReportHistoryData) DeepCopyInto(out *ReportHistoryData) {
 	*out = *in
-	if in.Details != nil {
-		in, out := &in.Details, &out.Details
-		*out = make([]ResultDetails, len(*in))
-		copy(*out, *in)
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]ReportHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]ReportHistoryFinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScannerCheckResult.
-func (in *ScannerCheckResult) DeepCopy() *ScannerCheckResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportHistoryData.
+func (in *ReportHistoryData) DeepCopy() *ReportHistoryData {
 	if in == nil {
 		return nil
 	}
-	out := new(ScannerCheckResult)
+	out := new(ReportHistoryData)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpecCheck) DeepCopyInto(out *SpecCheck) {
+func (in *ReportHistoryEntry) DeepCopyInto(out *ReportHistoryEntry) {
 	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecCheck.
-func (in *SpecCheck) DeepCopy() *SpecCheck {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportHistoryEntry.
+func (in *ReportHistoryEntry) DeepCopy() *ReportHistoryEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(SpecCheck)
+	out := new(ReportHistoryEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
+func (in *ReportHistoryFinding) DeepCopyInto(out *ReportHistoryFinding) {
 	*out = *in
-	if in.Links != nil {
-		in, out := &in.Links, &out.Links
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.FirstSeen.DeepCopyInto(&out.FirstSeen)
+	in.LastSeen.DeepCopyInto(&out.LastSeen)
+	if in.Resolved != nil {
+		in, out := &in.Resolved, &out.Resolved
+		*out = (*in).DeepCopy()
 	}
-	if in.Score != nil {
-		in, out := &in.Score, &out.Score
-		*out = new(float64)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportHistoryFinding.
+func (in *ReportHistoryFinding) DeepCopy() *ReportHistoryFinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportHistoryFinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportHistoryList) DeepCopyInto(out *ReportHistoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReportHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vulnerability.
-func (in *Vulnerability) DeepCopy() *Vulnerability {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportHistoryList.
+func (in *ReportHistoryList) DeepCopy() *ReportHistoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(Vulnerability)
+	out := new(ReportHistoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReportHistoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportSpec) DeepCopyInto(out *ReportSpec) {
+	*out = *in
+	if in.Controls != nil {
+		in, out := &in.Controls, &out.Controls
+		*out = make([]Control, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportSpec.
+func (in *ReportSpec) DeepCopy() *ReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportStatus) DeepCopyInto(out *ReportStatus) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Summary = in.Summary
+	if in.ControlChecks != nil {
+		in, out := &in.ControlChecks, &out.ControlChecks
+		*out = make([]ControlCheck, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportStatus.
+func (in *ReportStatus) DeepCopy() *ReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultDetails) DeepCopyInto(out *ResultDetails) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultDetails.
+func (in *ResultDetails) DeepCopy() *ResultDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMReport) DeepCopyInto(out *SBOMReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMReport.
+func (in *SBOMReport) DeepCopy() *SBOMReport {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SBOMReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMReportData) DeepCopyInto(out *SBOMReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Scanner = in.Scanner
+	out.Registry = in.Registry
+	out.Artifact = in.Artifact
+	out.Summary = in.Summary
+	in.Components.DeepCopyInto(&out.Components)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMReportData.
+func (in *SBOMReportData) DeepCopy() *SBOMReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMReportList) DeepCopyInto(out *SBOMReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SBOMReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMReportList.
+func (in *SBOMReportList) DeepCopy() *SBOMReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SBOMReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMSummary) DeepCopyInto(out *SBOMSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMSummary.
+func (in *SBOMSummary) DeepCopy() *SBOMSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanFailure) DeepCopyInto(out *ScanFailure) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanFailure.
+func (in *ScanFailure) DeepCopy() *ScanFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanFailure) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanFailureList) DeepCopyInto(out *ScanFailureList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScanFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanFailureList.
+func (in *ScanFailureList) DeepCopy() *ScanFailureList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanFailureList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanFailureList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanFailureStatus) DeepCopyInto(out *ScanFailureStatus) {
+	*out = *in
+	in.LastFailureTime.DeepCopyInto(&out.LastFailureTime)
+	in.NextRetryTime.DeepCopyInto(&out.NextRetryTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanFailureStatus.
+func (in *ScanFailureStatus) DeepCopy() *ScanFailureStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanFailureStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanJobPodTemplate) DeepCopyInto(out *ScanJobPodTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanJobPodTemplate.
+func (in *ScanJobPodTemplate) DeepCopy() *ScanJobPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanJobPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanJobTemplate) DeepCopyInto(out *ScanJobTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanJobTemplate.
+func (in *ScanJobTemplate) DeepCopy() *ScanJobTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanJobTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanJobTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanJobTemplateList) DeepCopyInto(out *ScanJobTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScanJobTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanJobTemplateList.
+func (in *ScanJobTemplateList) DeepCopy() *ScanJobTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanJobTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanJobTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanJobTemplateSpec) DeepCopyInto(out *ScanJobTemplateSpec) {
+	*out = *in
+	if in.Scanners != nil {
+		in, out := &in.Scanners, &out.Scanners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanJobTemplateSpec.
+func (in *ScanJobTemplateSpec) DeepCopy() *ScanJobTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanJobTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scanner) DeepCopyInto(out *Scanner) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scanner.
+func (in *Scanner) DeepCopy() *Scanner {
+	if in == nil {
+		return nil
+	}
+	out := new(Scanner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScannerCheckResult) DeepCopyInto(out *ScannerCheckResult) {
+	*out = *in
+	if in.Details != nil {
+		in, out := &in.Details, &out.Details
+		*out = make([]ResultDetails, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScannerCheckResult.
+func (in *ScannerCheckResult) DeepCopy() *ScannerCheckResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ScannerCheckResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpecCheck) DeepCopyInto(out *SpecCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecCheck.
+func (in *SpecCheck) DeepCopy() *SpecCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(SpecCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tool) DeepCopyInto(out *Tool) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tool.
+func (in *Tool) DeepCopy() *Tool {
+	if in == nil {
+		return nil
+	}
+	out := new(Tool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
+	*out = *in
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(float64)
+		**out = **in
+	}
+	if in.EPSSScore != nil {
+		in, out := &in.EPSSScore, &out.EPSSScore
+		*out = new(float64)
+		**out = **in
+	}
+	if in.EPSSPercentile != nil {
+		in, out := &in.EPSSPercentile, &out.EPSSPercentile
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vulnerability.
+func (in *Vulnerability) DeepCopy() *Vulnerability {
+	if in == nil {
+		return nil
+	}
+	out := new(Vulnerability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityException) DeepCopyInto(out *VulnerabilityException) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityException.
+func (in *VulnerabilityException) DeepCopy() *VulnerabilityException {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VulnerabilityException) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityExceptionList) DeepCopyInto(out *VulnerabilityExceptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VulnerabilityException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityExceptionList.
+func (in *VulnerabilityExceptionList) DeepCopy() *VulnerabilityExceptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityExceptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VulnerabilityExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityExceptionSpec) DeepCopyInto(out *VulnerabilityExceptionSpec) {
+	*out = *in
+	if in.VulnerabilityIDs != nil {
+		in, out := &in.VulnerabilityIDs, &out.VulnerabilityIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityExceptionSpec.
+func (in *VulnerabilityExceptionSpec) DeepCopy() *VulnerabilityExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityExceptionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
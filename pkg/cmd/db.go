@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/trivydb"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewDBCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the vulnerability database shared by scan jobs",
+	}
+	cmd.AddCommand(NewDBImportCmd(buildInfo, cf))
+	return cmd
+}
+
+func NewDBImportCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import the Trivy vulnerability database bundle",
+		Long: `Import the Trivy vulnerability database bundle into the persistent volume claim configured with trivy.dbVolumeClaim
+
+The bundle is the db.tar.gz file of the trivy-db OCI artifact, which can be downloaded on a
+connected machine, e.g. with 'oras pull ghcr.io/aquasecurity/trivy-db:2'. The bundle is streamed
+into a temporary pod that mounts the claim, which requires permissions to create pods and pods/exec
+in the plugin namespace. Once imported, scan jobs running in the Standalone mode load the database
+from the claim instead of downloading it.
+`,
+		Example: fmt.Sprintf(`  # Import the database bundle used by the Starboard CLI
+  %[1]s db import db.tar.gz
+
+  # Import the database bundle used by Starboard Operator installed in the starboard-system namespace
+  %[1]s db import db.tar.gz --plugin-namespace starboard-system --service-account starboard-operator`, buildInfo.Executable),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// The import pod is deleted if the command is interrupted.
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			namespace, err := cmd.Flags().GetString("plugin-namespace")
+			if err != nil {
+				return err
+			}
+			serviceAccount, err := cmd.Flags().GetString("service-account")
+			if err != nil {
+				return err
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			config, err := starboard.NewConfigManager(kubeClientset, namespace).Read(ctx)
+			if err != nil {
+				return err
+			}
+			_, pluginContext, err := plugin.NewResolver().
+				WithBuildInfo(buildInfo).
+				WithNamespace(namespace).
+				WithServiceAccountName(serviceAccount).
+				WithConfig(config).
+				WithClient(kubeClient).
+				GetVulnerabilityPlugin()
+			if err != nil {
+				return err
+			}
+			if pluginContext.GetName() != trivy.Plugin {
+				return fmt.Errorf("importing vulnerability database is not supported by %s scanner", pluginContext.GetName())
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = file.Close()
+			}()
+
+			return trivydb.NewImporter(kubeClientset, kubeConfig, ext.NewSystemClock(), pluginContext).Import(ctx, file)
+		},
+	}
+
+	cmd.Flags().String("plugin-namespace", starboard.NamespaceName, "Namespace of the Trivy plugin config and the claim of the vulnerability database")
+	cmd.Flags().String("service-account", starboard.ServiceAccountName, "Service account of the import pod")
+
+	return cmd
+}
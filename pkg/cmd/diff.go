@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/aquasecurity/starboard/pkg/reportdiff"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewDiffCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff TYPE/NAME [TYPE/NAME]",
+		Short: "Compare findings of security reports of two workloads",
+		Long: `Compare findings of security reports of two workloads and print added, removed, and changed findings
+
+Vulnerability and config audit reports are compared for Kubernetes workloads, whereas CIS Kubernetes
+Benchmark reports are compared for nodes. The second workload is looked up in the context and the
+namespace specified with the --to-context and --to-namespace flags, which default to the context and
+the namespace of the first workload. If the second workload is omitted, the first one is compared with
+itself in another context or namespace. If several vulnerability scanners are configured, only vulnerability
+reports generated by the primary scanner of each cluster are compared.
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+`,
+		Example: fmt.Sprintf(`  # Compare findings of two Deployments
+  %[1]s diff deploy/nginx deploy/nginx-canary
+
+  # Compare findings of two revisions of a Deployment
+  %[1]s diff rs/nginx-6d4cf56db6 rs/nginx-7f8d9c5b4f
+
+  # Compare findings of a Deployment in the staging and prod clusters
+  %[1]s diff deploy/nginx --context staging --to-context prod
+
+  # Compare findings of CIS Kubernetes Benchmark reports of two nodes
+  %[1]s diff node/kind-control-plane node/kind-worker
+
+  # Fail a promotion that introduces new HIGH findings
+  %[1]s diff deploy/nginx --context staging --to-context prod -o json | \
+    jq -e '[.added[] | select(.severity == "HIGH")] | length == 0'`, executable),
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			format := cmd.Flag("output").Value.String()
+			if format != "" && format != "table" && format != "json" {
+				return fmt.Errorf("invalid output format %q, allowed formats are: table,json", format)
+			}
+
+			toContext := cmd.Flag("to-context").Value.String()
+			toNamespace := cmd.Flag("to-namespace").Value.String()
+			if len(args) == 1 && toContext == "" && toNamespace == "" {
+				return errors.New("specify the second workload, or the --to-context or --to-namespace flag")
+			}
+
+			toFlags := genericclioptions.NewConfigFlags(true)
+			toFlags.KubeConfig = cf.KubeConfig
+			toFlags.Context = cf.Context
+			toFlags.Namespace = cf.Namespace
+			if toContext != "" {
+				toFlags.Context = pointer.String(toContext)
+			}
+			if toNamespace != "" {
+				toFlags.Namespace = pointer.String(toNamespace)
+			}
+			toArg := args[0]
+			if len(args) == 2 {
+				toArg = args[1]
+			}
+
+			from, err := findingsOf(ctx, cf, args[0])
+			if err != nil {
+				return err
+			}
+			to, err := findingsOf(ctx, toFlags, toArg)
+			if err != nil {
+				return err
+			}
+			result := reportdiff.Compare(from, to)
+
+			if format == "json" {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(result)
+			}
+			if result.IsEmpty() {
+				fmt.Fprintln(out, "No differences found.")
+				return nil
+			}
+			return printDiff(out, result)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output format. One of table|json")
+	cmd.Flags().String("to-context", "", "The name of the kubeconfig context of the second workload")
+	cmd.Flags().String("to-namespace", "", "The namespace of the second workload")
+
+	return cmd
+}
+
+// findingsOf returns findings of security reports of the workload specified
+// by arg in the cluster and the namespace of the given config flags.
+func findingsOf(ctx context.Context, cf *genericclioptions.ConfigFlags, arg string) ([]reportdiff.Finding, error) {
+	kubeConfig, err := cf.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := cf.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	workload, _, err := WorkloadFromArgs(mapper, ns, []string{arg})
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+	if err != nil {
+		return nil, err
+	}
+
+	if workload.Kind == kube.KindNode {
+		workload.Namespace = ""
+		report, err := kubebench.NewReadWriter(kubeClient).FindByOwner(ctx, workload)
+		if err != nil {
+			return nil, fmt.Errorf("find ciskubebench report: %w", err)
+		}
+		if report == nil {
+			return nil, fmt.Errorf("no ciskubebench report found for node %s", workload.Name)
+		}
+		return reportdiff.FromCISKubeBenchReport(*report), nil
+	}
+
+	kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	config, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := kube.InitCompatibleMgr(kubeClient.RESTMapper())
+	if err != nil {
+		return nil, err
+	}
+	objectResolver := kube.NewObjectResolver(kubeClient, cm)
+	vulnerabilityReports, err := vulnerabilityreport.NewReadWriter(&objectResolver).FindByOwnerInHierarchy(ctx, workload)
+	if err != nil {
+		return nil, fmt.Errorf("list vulnerability reports: %w", err)
+	}
+	// Only reports of the primary scanner are compared, otherwise the same
+	// vulnerability reported by different scanners would be merged.
+	vulnerabilityReports = vulnerabilityreport.FilterPrimaryScannerReports(vulnerabilityReports, config)
+	configAuditReport, err := configauditreport.NewReadWriter(&objectResolver).FindReportByOwnerInHierarchy(ctx, workload)
+	if err != nil {
+		return nil, fmt.Errorf("find config audit report: %w", err)
+	}
+	if len(vulnerabilityReports) == 0 && configAuditReport == nil {
+		return nil, fmt.Errorf("no reports found for %s %s in %s namespace", workload.Kind, workload.Name, workload.Namespace)
+	}
+
+	findings := reportdiff.FromVulnerabilityReports(vulnerabilityReports)
+	if configAuditReport != nil {
+		findings = append(findings, reportdiff.FromConfigAuditReport(*configAuditReport)...)
+	}
+	return findings, nil
+}
+
+// printDiff prints added, removed, and changed findings as a kubectl-style
+// table, in which changed findings show the previous and the current values.
+func printDiff(out io.Writer, result reportdiff.Result) error {
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintln(w, "CHANGE\tREPORT KIND\tCONTAINER\tID\tSEVERITY\tSTATUS\tPACKAGE\tINSTALLED\tFIXED")
+	row := func(change string, f reportdiff.Finding) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", change, f.ReportKind, f.Container, f.ID,
+			f.Severity, f.Status, f.Package, f.InstalledVersion, f.FixedVersion)
+	}
+	changed := func(from, to string) string {
+		if from == to {
+			return to
+		}
+		return from + " -> " + to
+	}
+	for _, finding := range result.Added {
+		row("added", finding)
+	}
+	for _, finding := range result.Removed {
+		row("removed", finding)
+	}
+	for _, change := range result.Changed {
+		row("changed", reportdiff.Finding{
+			ReportKind:       change.To.ReportKind,
+			Container:        change.To.Container,
+			ID:               change.To.ID,
+			Severity:         v1alpha1.Severity(changed(string(change.From.Severity), string(change.To.Severity))),
+			Status:           changed(change.From.Status, change.To.Status),
+			Package:          change.To.Package,
+			InstalledVersion: changed(change.From.InstalledVersion, change.To.InstalledVersion),
+			FixedVersion:     changed(change.From.FixedVersion, change.To.FixedVersion),
+		})
+	}
+	return w.Flush()
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewFindCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
+	findCmd := &cobra.Command{
+		Use:   "find",
+		Short: "Find workloads affected by vulnerabilities",
+		Long: `Find workloads affected by a vulnerability or a vulnerable package
+
+Vulnerability reports are searched across all namespaces, along with cluster vulnerability reports of
+static Pods. If several vulnerability scanners are configured, only reports generated by the primary
+scanner are searched. Suppressed vulnerabilities are omitted.
+`,
+	}
+	findCmd.AddCommand(NewFindCVECmd(buildInfo.Executable, cf, outWriter))
+	findCmd.AddCommand(NewFindPackageCmd(buildInfo.Executable, cf, outWriter))
+	findCmd.PersistentFlags().StringP("output", "o", "", "Output format. One of table|json")
+
+	return findCmd
+}
+
+func NewFindCVECmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "cve ID",
+		Aliases: []string{"vulnerability", "vuln"},
+		Short:   "Find workloads affected by the specified vulnerability",
+		Example: fmt.Sprintf(`  # Find workloads affected by the specified vulnerability
+  %[1]s find cve CVE-2021-44228
+
+  # Find workloads affected by the specified vulnerability in JSON output format
+  %[1]s find cve CVE-2021-44228 -o json`, executable),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return find(cmd, cf, out, vulnerabilityreport.MatchVulnerabilityID(args[0]))
+		},
+	}
+}
+
+func NewFindPackageCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "package NAME[@VERSION]",
+		Aliases: []string{"pkg"},
+		Short:   "Find workloads affected by vulnerabilities of the specified package",
+		Example: fmt.Sprintf(`  # Find workloads affected by vulnerabilities of any version of the specified package
+  %[1]s find package openssl
+
+  # Find workloads affected by vulnerabilities of the specified version of a package
+  %[1]s find package org.apache.logging.log4j:log4j-core@2.14.1`, executable),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return find(cmd, cf, out, vulnerabilityreport.MatchPackage(args[0]))
+		},
+	}
+}
+
+// find prints vulnerabilities that match the specified Matcher along with
+// workloads affected by them.
+func find(cmd *cobra.Command, cf *genericclioptions.ConfigFlags, out io.Writer, matcher vulnerabilityreport.Matcher) error {
+	ctx := context.Background()
+
+	format := cmd.Flag("output").Value.String()
+	if format != "" && format != "table" && format != "json" {
+		return fmt.Errorf("invalid output format %q, allowed formats are: table,json", format)
+	}
+
+	kubeConfig, err := cf.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+	if err != nil {
+		return err
+	}
+	kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+	config, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
+	if err != nil {
+		return err
+	}
+	var list v1alpha1.VulnerabilityReportList
+	err = kubeClient.List(ctx, &list)
+	if err != nil {
+		return fmt.Errorf("list vulnerability reports: %w", err)
+	}
+	var clusterList v1alpha1.ClusterVulnerabilityReportList
+	err = kubeClient.List(ctx, &clusterList)
+	if err != nil {
+		return fmt.Errorf("list cluster vulnerability reports: %w", err)
+	}
+	// Only reports of the primary scanner are searched, otherwise the same
+	// vulnerability reported by different scanners would be listed twice.
+	var clusterReports []v1alpha1.ClusterVulnerabilityReport
+	for _, report := range clusterList.Items {
+		if vulnerabilityreport.IsGeneratedByPrimaryScanner(report.ObjectMeta, config) {
+			clusterReports = append(clusterReports, report)
+		}
+	}
+	findings := vulnerabilityreport.Find(vulnerabilityreport.FilterPrimaryScannerReports(list.Items, config), clusterReports, matcher)
+
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(findings)
+	}
+	if len(findings) == 0 {
+		fmt.Fprintln(out, "No affected workloads found.")
+		return nil
+	}
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tCONTAINER\tIMAGE\tVULNERABILITY\tSEVERITY\tPACKAGE\tINSTALLED\tFIXED")
+	for _, finding := range findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			finding.Workload.Namespace,
+			strings.ToLower(string(finding.Workload.Kind))+"/"+finding.Workload.Name,
+			finding.Container,
+			finding.Image,
+			finding.Vulnerability.VulnerabilityID,
+			finding.Vulnerability.Severity,
+			finding.Vulnerability.Resource,
+			finding.Vulnerability.InstalledVersion,
+			finding.Vulnerability.FixedVersion)
+	}
+	return w.Flush()
+}
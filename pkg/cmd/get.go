@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/aquasecurity/starboard/pkg/export"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -14,9 +16,27 @@ func NewGetCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags,
 		Short: "Get security reports",
 	}
 	getCmd.AddCommand(NewGetVulnerabilityReportsCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetClusterVulnerabilityReportsCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetSBOMReportsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.AddCommand(NewGetConfigAuditReportsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.AddCommand(NewGetClusterComplianceReportsCmd(buildInfo.Executable, cf, outWriter))
-	getCmd.PersistentFlags().StringP("output", "o", "", "Output format. One of yaml|json")
+	getCmd.PersistentFlags().StringP("output", "o", "", "Output format. One of yaml|json|table|csv|sarif|junit")
+	getCmd.PersistentFlags().String("sort-by", "", "Sort findings in table, csv, sarif, and junit output. One of severity|exploitability")
 
 	return getCmd
 }
+
+// writeResults prints security reports in one of the export formats, with
+// findings sorted as requested by the sort-by flag.
+func writeResults(cmd *cobra.Command, out io.Writer, format string, results export.Results) error {
+	switch sortBy := cmd.Flag("sort-by").Value.String(); sortBy {
+	case "":
+	case "severity":
+		results.SortBySeverity()
+	case "exploitability":
+		results.SortByExploitability()
+	default:
+		return fmt.Errorf("invalid sort field %q, allowed fields are: severity, exploitability", sortBy)
+	}
+	return export.Write(out, export.Format(format), results)
+}
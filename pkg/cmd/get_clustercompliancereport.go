@@ -9,11 +9,13 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/compliance"
+	"github.com/aquasecurity/starboard/pkg/export"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,7 +30,10 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
   %[1]s get clustercompliancereports nsa -o json
 
   # Get compliance detail report for control checks failure in JSON output format
-  %[1]s get clustercompliancereports nsa -o json --detail`, executable),
+  %[1]s get clustercompliancereports nsa -o json --detail
+
+  # Get compliance detail report in the CSV format
+  %[1]s get clustercompliancereports nsa -o csv --detail`, executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := ctrl.Log.WithName("reconciler").WithName("clustercompliancereport")
 			ctx := context.Background()
@@ -67,12 +72,15 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
 			}
 
 			format := cmd.Flag("output").Value.String()
-			printer, err := genericclioptions.NewPrintFlags("").
-				WithTypeSetter(scheme).
-				WithDefaultOutput(format).
-				ToPrinter()
-			if err != nil {
-				return fmt.Errorf("faild to create printer: %w", err)
+			var printer printers.ResourcePrinter
+			if !export.IsFormat(format) {
+				printer, err = genericclioptions.NewPrintFlags("").
+					WithTypeSetter(scheme).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return fmt.Errorf("faild to create printer: %w", err)
+				}
 			}
 
 			detail, err := cmd.Flags().GetBool("detail")
@@ -85,6 +93,9 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
 				if err != nil {
 					return err
 				}
+				if printer == nil {
+					return writeResults(cmd, out, format, export.FromClusterComplianceReport(complianceReport))
+				}
 				if err := printer.PrintObj(&complianceReport, out); err != nil {
 					return fmt.Errorf("print compliance reports: %w", err)
 				}
@@ -100,6 +111,9 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
 			if err != nil {
 				return err
 			}
+			if printer == nil {
+				return writeResults(cmd, out, format, export.FromClusterComplianceDetailReport(complianceDetailReport))
+			}
 			if err := printer.PrintObj(&complianceDetailReport, out); err != nil {
 				return fmt.Errorf("print compliance reports: %w", err)
 			}
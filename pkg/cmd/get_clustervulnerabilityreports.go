@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/export"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewGetClusterVulnerabilityReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "clustervulnerabilityreports [NAME]",
+		Aliases: []string{"clustervulns", "clustervuln"},
+		Short:   "Get cluster vulnerability reports",
+		Long: `Get vulnerability reports for cluster-scoped and node-level artifacts, such as
+images of static Pods running on control plane nodes
+
+NAME is the name of a particular cluster vulnerability report. If omitted, all reports are listed.
+`,
+		Example: fmt.Sprintf(`  # Get all cluster vulnerability reports
+  %[1]s get clustervulnerabilityreports
+
+  # Get cluster vulnerability reports for the specified container
+  %[1]s get clustervulns --container kube-apiserver
+
+  # Get cluster vulnerability reports generated by the specified scanner
+  %[1]s get clustervulns --scanner Grype
+
+  # Get cluster vulnerability report with the specified name in JSON output format
+  %[1]s get clustervuln pod-kube-system-kube-apiserver-master-kube-apiserver -o json
+
+  # Get all cluster vulnerability reports in the JUnit XML format
+  %[1]s get clustervulns -o junit > starboard.xml`, executable),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+
+			var items []v1alpha1.ClusterVulnerabilityReport
+			if len(args) == 1 {
+				var report v1alpha1.ClusterVulnerabilityReport
+				err = kubeClient.Get(ctx, types.NamespacedName{Name: args[0]}, &report)
+				if err != nil {
+					return fmt.Errorf("get cluster vulnerability report: %w", err)
+				}
+				items = append(items, report)
+			} else {
+				var list v1alpha1.ClusterVulnerabilityReportList
+				err = kubeClient.List(ctx, &list)
+				if err != nil {
+					return fmt.Errorf("list cluster vulnerability reports: %w", err)
+				}
+				items = list.Items
+			}
+
+			format := cmd.Flag("output").Value.String()
+			container := cmd.Flag("container").Value.String()
+			scanner := cmd.Flag("scanner").Value.String()
+
+			var printer printers.ResourcePrinter
+
+			switch format {
+			case "yaml", "json":
+				printer, err = genericclioptions.NewPrintFlags("").
+					WithTypeSetter(starboard.NewScheme()).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return err
+				}
+			case "":
+				printer = printers.NewTablePrinter(printers.PrintOptions{})
+			default:
+				if !export.IsFormat(format) {
+					return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json,table,csv,sarif,junit", format)
+				}
+			}
+
+			list := &v1alpha1.ClusterVulnerabilityReportList{
+				Items: []v1alpha1.ClusterVulnerabilityReport{},
+			}
+
+			for _, item := range items {
+				// Cached scan results are listed only if requested by name.
+				if len(args) == 0 && vulnerabilityreport.IsCachedReport(item.ObjectMeta) {
+					continue
+				}
+				if container != "" && item.Labels[starboard.LabelContainerName] != container {
+					continue
+				}
+				if scanner != "" && !vulnerabilityreport.IsGeneratedBy(item.ObjectMeta, item.Report, scanner) {
+					continue
+				}
+				list.Items = append(list.Items, item)
+			}
+			if len(list.Items) == 0 {
+				fmt.Fprintln(out, "No cluster reports found.")
+				return nil
+			}
+
+			if printer == nil {
+				return writeResults(cmd, out, format, export.FromClusterVulnerabilityReports(list.Items))
+			}
+			return printer.PrintObj(list, out)
+		},
+	}
+
+	cmd.PersistentFlags().StringP("container", "c", "", "Get cluster vulnerability reports of this container")
+	cmd.PersistentFlags().String("scanner", "", "Get cluster vulnerability reports generated by this scanner, e.g. Trivy")
+
+	return cmd
+}
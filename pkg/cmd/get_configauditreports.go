@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/export"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
@@ -33,7 +35,10 @@ NAME is the name of a particular Kubernetes resource.
   %[1]s get configaudit replicaset/nginx
 
   # Get configuration audit report for a CronJob with the specified name in JSON output format
-  %[1]s get configaudit cj/my-job -o json`, executable),
+  %[1]s get configaudit cj/my-job -o json
+
+  # List checks of a Deployment sorted by severity
+  %[1]s get configaudit deploy/nginx -o table --sort-by severity`, executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
@@ -75,6 +80,10 @@ NAME is the name of a particular Kubernetes resource.
 			}
 
 			format := cmd.Flag("output").Value.String()
+			if export.IsFormat(format) {
+				results := export.FromConfigAuditReports([]v1alpha1.ConfigAuditReport{*report})
+				return writeResults(cmd, out, format, results)
+			}
 			printer, err := genericclioptions.NewPrintFlags("").
 				WithTypeSetter(scheme).
 				WithDefaultOutput(format).
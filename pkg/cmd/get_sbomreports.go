@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/export"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewGetSBOMReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sbomreports (NAME | TYPE/NAME)",
+		Aliases: []string{"sboms", "sbom"},
+		Short:   "Get SBOM reports",
+		Long: `Get software bill of materials (SBOM) reports for the specified workload
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+
+Use the cyclonedx output format to print the SBOM of a single container as a CycloneDX JSON document.
+`,
+		Example: fmt.Sprintf(`  # Get SBOM reports for a Deployment with the specified name
+  %[1]s get sbomreports deploy/nginx
+
+  # Get SBOM reports for a Deployment with the specified name in the specified namespace
+  %[1]s get sbom deploy/nginx -n staging
+
+  # List components of a Deployment in the CSV format
+  %[1]s get sbom deploy/nginx -o csv
+
+  # Get SBOM for the specified container of a Deployment in the CycloneDX format
+  %[1]s get sbom deploy/nginx --container nginx -o cyclonedx
+
+  # Get SBOM reports for a CronJob with the specified name in JSON output format
+  %[1]s get sbom cj/my-job -o json`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			workload, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+			cm, err := kube.InitCompatibleMgr(kubeClient.RESTMapper())
+			if err != nil {
+				return err
+			}
+			objectResolver := kube.NewObjectResolver(kubeClient, cm)
+			reader := sbomreport.NewReadWriter(&objectResolver)
+			items, err := reader.FindByOwnerInHierarchy(ctx, workload)
+			if err != nil {
+				return fmt.Errorf("list sbom reports: %w", err)
+			}
+			if len(items) == 0 {
+				fmt.Fprintf(out, "No reports found in %s namespace.\n", workload.Namespace)
+				return nil
+			}
+
+			format := cmd.Flag("output").Value.String()
+			container := cmd.Flag("container").Value.String()
+
+			list := &v1alpha1.SBOMReportList{
+				Items: []v1alpha1.SBOMReport{},
+			}
+
+			for _, item := range items {
+				if container != "" && item.Labels[starboard.LabelContainerName] != container {
+					continue
+				}
+				list.Items = append(list.Items, item)
+			}
+			if len(list.Items) == 0 {
+				return fmt.Errorf("container %s is not valid for %s %s", container, strings.ToLower(string(workload.Kind)), workload.Name)
+			}
+
+			var printer printers.ResourcePrinter
+
+			switch format {
+			case "yaml", "json":
+				printer, err = genericclioptions.NewPrintFlags("").
+					WithTypeSetter(starboard.NewScheme()).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return err
+				}
+			case "cyclonedx":
+				if len(list.Items) > 1 {
+					return fmt.Errorf("%s %s has multiple containers, specify one with the --container flag",
+						strings.ToLower(string(workload.Kind)), workload.Name)
+				}
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(list.Items[0].Report.Components)
+			case "":
+				printer = printers.NewTablePrinter(printers.PrintOptions{})
+			case string(export.FormatTable), string(export.FormatCSV):
+				return writeResults(cmd, out, format, export.FromSBOMReports(list.Items))
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json,table,csv,cyclonedx", format)
+			}
+
+			return printer.PrintObj(list, out)
+		},
+	}
+
+	cmd.PersistentFlags().StringP("container", "c", "", "Get SBOM report of this container")
+
+	return cmd
+}
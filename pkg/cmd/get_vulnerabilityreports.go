@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/export"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
@@ -39,8 +40,17 @@ NAME is the name of a particular Kubernetes workload.
   # a ReplicaSet with the specified name
   %[1]s get vulns replicaset/nginx --container nginx
 
+  # Get vulnerability reports generated by the specified scanner for a Deployment
+  %[1]s get vulns deploy/nginx --scanner Grype
+
   # Get vulnerability reports for a CronJob with the specified name in JSON output format
-  %[1]s get vuln cj/my-job -o json`, executable),
+  %[1]s get vuln cj/my-job -o json
+
+  # List vulnerabilities of a Deployment sorted by severity
+  %[1]s get vulns deploy/nginx -o table --sort-by severity
+
+  # Get vulnerability reports for a Deployment in the SARIF format for GitHub code scanning
+  %[1]s get vulns deploy/nginx -o sarif > starboard.sarif`, executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
@@ -82,6 +92,7 @@ NAME is the name of a particular Kubernetes workload.
 
 			format := cmd.Flag("output").Value.String()
 			container := cmd.Flag("container").Value.String()
+			scanner := cmd.Flag("scanner").Value.String()
 
 			var printer printers.ResourcePrinter
 
@@ -96,11 +107,10 @@ NAME is the name of a particular Kubernetes workload.
 				}
 			case "":
 				printer = printers.NewTablePrinter(printers.PrintOptions{})
-				if err != nil {
-					return err
-				}
 			default:
-				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json", format)
+				if !export.IsFormat(format) {
+					return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json,table,csv,sarif,junit", format)
+				}
 			}
 
 			list := &v1alpha1.VulnerabilityReportList{
@@ -111,17 +121,28 @@ NAME is the name of a particular Kubernetes workload.
 				if container != "" && item.Labels[starboard.LabelContainerName] != container {
 					continue
 				}
+				if scanner != "" && !vulnerabilityreport.IsGeneratedBy(item.ObjectMeta, item.Report, scanner) {
+					continue
+				}
 				list.Items = append(list.Items, item)
 			}
 			if len(items) > 0 && len(list.Items) == 0 {
+				if scanner != "" {
+					fmt.Fprintf(out, "No reports generated by %s scanner found for %s %s.\n", scanner, strings.ToLower(string(workload.Kind)), workload.Name)
+					return nil
+				}
 				return fmt.Errorf("container %s is not valid for %s %s", container, strings.ToLower(string(workload.Kind)), workload.Name)
 			}
 
+			if printer == nil {
+				return writeResults(cmd, out, format, export.FromVulnerabilityReports(list.Items))
+			}
 			return printer.PrintObj(list, out)
 		},
 	}
 
 	cmd.PersistentFlags().StringP("container", "c", "", "Get vulnerability report of this container")
+	cmd.PersistentFlags().String("scanner", "", "Get vulnerability reports generated by this scanner, e.g. Trivy")
 
 	return cmd
 }
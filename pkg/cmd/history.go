@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/reporthistory"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewHistoryCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history (NAME | TYPE/NAME)",
+		Short: "Print the history of security reports of a workload",
+		Long: `Print the timeline of vulnerability and config audit reports of the specified workload
+
+The history is recorded by Starboard Operator in ReportHistory resources. Reports of
+ReplicaSets managed by a Deployment and Jobs managed by a CronJob are recorded in the
+history of the Deployment or the CronJob respectively.
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+`,
+		Example: fmt.Sprintf(`  # Print the history of security reports of a Deployment with the specified name
+  %[1]s history deploy/nginx
+
+  # Print the history of vulnerability reports of the specified container of a Deployment
+  %[1]s history deploy/nginx --container nginx
+
+  # Print the history of security reports of a Deployment in YAML output format
+  %[1]s history deploy/nginx -o yaml`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			ref, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+			cm, err := kube.InitCompatibleMgr(kubeClient.RESTMapper())
+			if err != nil {
+				return err
+			}
+			workload, err := reporthistory.ResolveWorkload(ctx, kube.NewObjectResolver(kubeClient, cm), ref)
+			if err != nil {
+				return fmt.Errorf("resolving workload: %w", err)
+			}
+			ref = kube.ObjectRefFromKindAndObjectKey(kube.Kind(workload.GetObjectKind().GroupVersionKind().Kind),
+				client.ObjectKeyFromObject(workload))
+
+			selector := kube.ObjectRefToLabels(ref)
+			if container := cmd.Flag("container").Value.String(); container != "" {
+				selector[starboard.LabelContainerName] = container
+			}
+			var list v1alpha1.ReportHistoryList
+			err = kubeClient.List(ctx, &list, client.InNamespace(ref.Namespace), client.MatchingLabels(selector))
+			if err != nil {
+				return fmt.Errorf("list report histories: %w", err)
+			}
+			if len(list.Items) == 0 {
+				fmt.Fprintf(out, "No history found for %s %s.\n", strings.ToLower(string(ref.Kind)), ref.Name)
+				return nil
+			}
+
+			switch format := cmd.Flag("output").Value.String(); format {
+			case "yaml", "json":
+				printer, err := genericclioptions.NewPrintFlags("").
+					WithTypeSetter(scheme).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return err
+				}
+				return printer.PrintObj(&list, out)
+			case "":
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json", format)
+			}
+
+			for i, history := range list.Items {
+				if i > 0 {
+					fmt.Fprintln(out)
+				}
+				err = printHistory(out, history)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringP("output", "o", "", "Output format. One of yaml|json")
+	cmd.PersistentFlags().StringP("container", "c", "", "Print the history of vulnerability reports of this container")
+
+	return cmd
+}
+
+// printHistory prints the timeline of the specified history followed by the
+// numbers of open and resolved findings and the mean time to remediate.
+func printHistory(out io.Writer, history v1alpha1.ReportHistory) error {
+	title := history.History.ReportKind
+	if container, ok := history.Labels[starboard.LabelContainerName]; ok {
+		title = fmt.Sprintf("%s (container %s)", title, container)
+	}
+	if scanner, ok := history.Labels[starboard.LabelVulnerabilityReportScanner]; ok {
+		title = fmt.Sprintf("%s [%s]", title, scanner)
+	}
+	fmt.Fprintln(out, title)
+
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintln(w, "UPDATED\tCRITICAL\tHIGH\tMEDIUM\tLOW\tUNKNOWN\tNEW\tRESOLVED")
+	for _, entry := range history.History.Entries {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n",
+			entry.UpdateTimestamp.UTC().Format(time.RFC3339),
+			entry.CriticalCount,
+			entry.HighCount,
+			entry.MediumCount,
+			entry.LowCount,
+			entry.UnknownCount,
+			entry.NewCount,
+			entry.ResolvedCount)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	mttr, resolved := reporthistory.MeanTimeToRemediate(history.History.Findings)
+	open := len(history.History.Findings) - resolved
+	if resolved == 0 {
+		fmt.Fprintf(out, "Open findings: %d, resolved findings: 0\n", open)
+		return nil
+	}
+	fmt.Fprintf(out, "Open findings: %d, resolved findings: %d, mean time to remediate: %s\n",
+		open, resolved, duration.HumanDuration(mttr))
+	return nil
+}
@@ -24,6 +24,9 @@ Kubernetes cluster:
  - CustomResourceDefinition objects:
    - "vulnerabilityreports.aquasecurity.github.io"
    - "clustervulnerabilityreports.aquasecurity.github.io"
+   - "vulnerabilityexceptions.aquasecurity.github.io"
+   - "scanjobtemplates.aquasecurity.github.io"
+   - "sbomreports.aquasecurity.github.io"
    - "configauditreports.aquasecurity.github.io"
    - "clusterconfigauditreports.aquasecurity.github.io"
    - "ciskubebenchreports.aquasecurity.github.io"
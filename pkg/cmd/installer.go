@@ -166,6 +166,30 @@ func (m *Installer) Install(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	vulnerabilityExceptionsCRD, err := embedded.GetVulnerabilityExceptionsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &vulnerabilityExceptionsCRD)
+	if err != nil {
+		return err
+	}
+	scanJobTemplatesCRD, err := embedded.GetScanJobTemplatesCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &scanJobTemplatesCRD)
+	if err != nil {
+		return err
+	}
+	sbomReportsCRD, err := embedded.GetSBOMReportsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &sbomReportsCRD)
+	if err != nil {
+		return err
+	}
 	kubeBenchReportsCRD, err := embedded.GetCISKubeBenchReportsCRD()
 	if err != nil {
 		return err
@@ -250,14 +274,21 @@ func (m *Installer) Install(ctx context.Context) error {
 		WithConfig(config).
 		WithClient(m.client)
 
-	vulnerabilityPlugin, pluginContext, err := pluginResolver.GetVulnerabilityPlugin()
+	scanners, err := config.GetVulnerabilityReportsScanners()
 	if err != nil {
 		return err
 	}
 
-	err = vulnerabilityPlugin.Init(pluginContext)
-	if err != nil {
-		return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
+	for _, scanner := range scanners {
+		vulnerabilityPlugin, pluginContext, err := pluginResolver.GetVulnerabilityPluginForScanner(scanner)
+		if err != nil {
+			return err
+		}
+
+		err = vulnerabilityPlugin.Init(pluginContext)
+		if err != nil {
+			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
+		}
 	}
 
 	configAuditPlugin, pluginContext, err := pluginResolver.GetConfigAuditPlugin()
@@ -448,6 +479,18 @@ func (m *Installer) Uninstall(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = m.deleteCRD(ctx, v1alpha1.VulnerabilityExceptionCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.ScanJobTemplateCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.SBOMReportCRName)
+	if err != nil {
+		return err
+	}
 	err = m.deleteCRD(ctx, v1alpha1.CISKubeBenchReportCRName)
 	if err != nil {
 		return err
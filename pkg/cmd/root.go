@@ -55,9 +55,13 @@ func NewRootCmd(buildInfo starboard.BuildInfo, args []string, outWriter io.Write
 	rootCmd.AddCommand(NewInitCmd(buildInfo, cf))
 	rootCmd.AddCommand(NewScanCmd(buildInfo, cf))
 	rootCmd.AddCommand(NewGetCmd(buildInfo, cf, outWriter))
+	rootCmd.AddCommand(NewFindCmd(buildInfo, cf, outWriter))
+	rootCmd.AddCommand(NewHistoryCmd(buildInfo.Executable, cf, outWriter))
+	rootCmd.AddCommand(NewDiffCmd(buildInfo.Executable, cf, outWriter))
 	rootCmd.AddCommand(NewReportCmd(buildInfo, cf, outWriter))
 	rootCmd.AddCommand(NewCleanupCmd(buildInfo, cf))
 	rootCmd.AddCommand(NewConfigCmd(cf, outWriter))
+	rootCmd.AddCommand(NewDBCmd(buildInfo, cf))
 
 	SetGlobalFlags(cf, rootCmd)
 
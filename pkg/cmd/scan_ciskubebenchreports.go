@@ -7,6 +7,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
@@ -61,7 +62,7 @@ func ScanKubeBenchReports(cf *genericclioptions.ConfigFlags) func(cmd *cobra.Com
 		}
 
 		plugin := kubebench.NewKubeBenchPlugin(ext.NewSystemClock(), config)
-		scanner := kubebench.NewScanner(scheme, kubeClientset, plugin, config, opts)
+		scanner := kubebench.NewScanner(scheme, kubeClientset, plugin, config, scanjobtemplate.NewReader(kubeClient), opts)
 		writer := kubebench.NewReadWriter(kubeClient)
 
 		nodes, err := GetNodes(ctx, kubeClientset, args...)
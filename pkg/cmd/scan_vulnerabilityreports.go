@@ -6,6 +6,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/spf13/cobra"
@@ -114,12 +115,16 @@ func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptio
 			return err
 		}
 		scanner := vulnerabilityreport.NewScanner(kubeClientset, kubeClient, cm, plugin, pluginContext, config, opts)
-		reports, err := scanner.Scan(ctx, workload)
+		reports, sbomReports, err := scanner.Scan(ctx, workload)
 		if err != nil {
 			return err
 		}
 		objectResolver := kube.NewObjectResolver(kubeClient, cm)
 		writer := vulnerabilityreport.NewReadWriter(&objectResolver)
-		return writer.Write(ctx, reports)
+		err = writer.Write(ctx, reports)
+		if err != nil {
+			return err
+		}
+		return sbomreport.NewReadWriter(&objectResolver).Write(ctx, sbomReports)
 	}
 }
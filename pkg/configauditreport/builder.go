@@ -8,6 +8,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ type ScanJobBuilder struct {
 	tolerations       []corev1.Toleration
 	annotations       map[string]string
 	podTemplateLabels labels.Set
+	templates         []v1alpha1.ScanJobTemplate
 }
 
 func NewScanJobBuilder() *ScanJobBuilder {
@@ -69,6 +71,13 @@ func (s *ScanJobBuilder) WithPodTemplateLabels(podTemplateLabels labels.Set) *Sc
 	return s
 }
 
+// WithScanJobTemplates sets ScanJobTemplates merged into the pod template of
+// the scan job.
+func (s *ScanJobBuilder) WithScanJobTemplates(templates []v1alpha1.ScanJobTemplate) *ScanJobBuilder {
+	s.templates = templates
+	return s
+}
+
 func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 	jobSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, s.object)
 	if err != nil {
@@ -143,6 +152,8 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		err = kube.ObjectToObjectMeta(s.object, &secret.ObjectMeta)
 	}
 
+	scanjobtemplate.Apply(&job.Spec.Template, s.templates...)
+
 	return job, secrets, nil
 }
 
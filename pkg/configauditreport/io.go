@@ -6,6 +6,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/notification"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,14 +50,23 @@ type ReadWriter interface {
 
 type readWriter struct {
 	*kube.ObjectResolver
+	notifier notification.Notifier
 }
 
 // NewReadWriter constructs a new ReadWriter which is using the client package
 // provided by the controller-runtime libraries for interacting with the
 // Kubernetes API server.
 func NewReadWriter(resolver *kube.ObjectResolver) ReadWriter {
+	return NewNotifyingReadWriter(resolver, notification.NewNopNotifier())
+}
+
+// NewNotifyingReadWriter constructs a new ReadWriter which, in addition to
+// writing reports, passes checks that did not fail in the previous revision
+// of a report to the given notification.Notifier.
+func NewNotifyingReadWriter(resolver *kube.ObjectResolver, notifier notification.Notifier) ReadWriter {
 	return &readWriter{
 		ObjectResolver: resolver,
+		notifier:       notifier,
 	}
 }
 
@@ -72,11 +82,21 @@ func (r *readWriter) WriteReport(ctx context.Context, report v1alpha1.ConfigAudi
 		copied.Labels = report.Labels
 		copied.Report = report.Report
 
-		return r.Update(ctx, copied)
+		err = r.Update(ctx, copied)
+		if err != nil {
+			return err
+		}
+		r.notifier.Notify(ctx, notification.NewConfigAuditReportEvent(&existing.Report, report))
+		return nil
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		r.notifier.Notify(ctx, notification.NewConfigAuditReportEvent(nil, report))
+		return nil
 	}
 
 	return err
@@ -93,11 +113,21 @@ func (r *readWriter) WriteClusterReport(ctx context.Context, report v1alpha1.Clu
 		copied.Labels = report.Labels
 		copied.Report = report.Report
 
-		return r.Update(ctx, copied)
+		err = r.Update(ctx, copied)
+		if err != nil {
+			return err
+		}
+		r.notifier.Notify(ctx, notification.NewClusterConfigAuditReportEvent(&existing.Report, report))
+		return nil
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		r.notifier.Notify(ctx, notification.NewClusterConfigAuditReportEvent(nil, report))
+		return nil
 	}
 
 	return err
@@ -44,6 +44,27 @@ type Auth struct {
 	Auth     BasicAuth `json:"auth,omitempty"`
 	Username string    `json:"username,omitempty"`
 	Password string    `json:"password,omitempty"`
+
+	// IdentityToken is an OAuth2 refresh token issued by the registry, e.g.
+	// by Azure Container Registry, which is exchanged for access tokens.
+	IdentityToken string `json:"identitytoken,omitempty"`
+
+	// RegistryToken is a bearer token sent to the registry as is.
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// Credentials returns the username and the password to login to a Docker
+// registry with. If the password is not set, the IdentityToken is returned as
+// the password, which follows the convention of Docker credential helpers. In
+// that case the username defaults to <token>.
+func (v Auth) Credentials() (string, string) {
+	if v.Password != "" || v.IdentityToken == "" {
+		return v.Username, v.Password
+	}
+	if v.Username == "" {
+		return "<token>", v.IdentityToken
+	}
+	return v.Username, v.IdentityToken
 }
 
 func (v Auth) String() string {
@@ -53,6 +74,13 @@ func (v Auth) String() string {
 // Config represents Docker configuration which is typically saved as `~/.docker/config.json`.
 type Config struct {
 	Auths map[string]Auth `json:"auths"`
+
+	// CredHelpers maps registry servers to credential helpers, whereas
+	// CredsStore is the default credential helper. Helpers are binaries
+	// installed on the host, which are not available in scan jobs. Therefore,
+	// like the kubelet, Starboard reads credentials from Auths only.
+	CredHelpers map[string]string `json:"credHelpers,omitempty"`
+	CredsStore  string            `json:"credsStore,omitempty"`
 }
 
 func (c *Config) Read(contents []byte) error {
@@ -73,8 +101,10 @@ func decodeAuths(auths map[string]Auth) (map[string]Auth, error) {
 
 		if strings.TrimSpace(string(entry.Auth)) == "" {
 			decodedAuths[server] = Auth{
-				Username: entry.Username,
-				Password: entry.Password,
+				Username:      entry.Username,
+				Password:      entry.Password,
+				IdentityToken: entry.IdentityToken,
+				RegistryToken: entry.RegistryToken,
 			}
 			continue
 		}
@@ -85,9 +115,11 @@ func decodeAuths(auths map[string]Auth) (map[string]Auth, error) {
 		}
 
 		decodedAuths[server] = Auth{
-			Auth:     entry.Auth,
-			Username: username,
-			Password: password,
+			Auth:          entry.Auth,
+			Username:      username,
+			Password:      password,
+			IdentityToken: entry.IdentityToken,
+			RegistryToken: entry.RegistryToken,
 		}
 
 	}
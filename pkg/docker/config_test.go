@@ -107,6 +107,34 @@ func TestConfig_Read(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Should return identity and registry tokens and ignore credential helpers",
+			givenJSON: `{
+							"auths": {
+								"myregistry.azurecr.io": {
+									"auth": "MDAwMDAwMDAtMDAwMC0wMDAwLTAwMDAtMDAwMDAwMDAwMDAwOg==",
+									"identitytoken": "refresh-token"
+								},
+								"registry.example.com": {
+									"registrytoken": "bearer-token"
+								}
+							},
+							"credHelpers": {
+								"123456789012.dkr.ecr.us-east-1.amazonaws.com": "ecr-login"
+							},
+							"credsStore": "desktop"
+						}`,
+			expectedAuth: map[string]docker.Auth{
+				"myregistry.azurecr.io": {
+					Auth:          "MDAwMDAwMDAtMDAwMC0wMDAwLTAwMDAtMDAwMDAwMDAwMDAwOg==",
+					Username:      "00000000-0000-0000-0000-000000000000",
+					IdentityToken: "refresh-token",
+				},
+				"registry.example.com": {
+					RegistryToken: "bearer-token",
+				},
+			},
+		},
 		{
 			name: "Should return error when auth is not username and password concatenated with a colon",
 			givenJSON: `{
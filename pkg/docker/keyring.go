@@ -0,0 +1,136 @@
+package docker
+
+import (
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// Keyring looks up credentials to pull container images the same way the
+// kubelet does. Auth keys are matched against image references by registry
+// server, which may contain wildcards such as *.example.com, and by path
+// prefix. For example, credentials stored for registry.example.com/team-a are
+// used to pull registry.example.com/team-a/app, whereas credentials stored for
+// registry.example.com are used to pull any other image from that registry.
+// The most specific key wins.
+type Keyring struct {
+	index []string
+	auths map[string]Auth
+}
+
+// NewKeyring constructs an empty Keyring.
+func NewKeyring() *Keyring {
+	return &Keyring{
+		auths: make(map[string]Auth),
+	}
+}
+
+// Add adds credentials from the specified Docker config to the Keyring.
+// Credentials added later replace credentials for the same key.
+func (k *Keyring) Add(config Config) error {
+	for authKey, auth := range config.Auths {
+		key, err := normalizeAuthKey(authKey)
+		if err != nil {
+			return err
+		}
+		if _, ok := k.auths[key]; !ok {
+			k.index = append(k.index, key)
+		}
+		k.auths[key] = auth
+	}
+	// The index is reverse-sorted so that more specific keys are matched first,
+	// e.g. registry.example.com/team-a before registry.example.com.
+	sort.Sort(sort.Reverse(sort.StringSlice(k.index)))
+	return nil
+}
+
+// Lookup returns credentials for the specified image reference. Returns false
+// if there are no matching credentials.
+func (k *Keyring) Lookup(imageRef string) (Auth, bool, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return Auth{}, false, err
+	}
+	image := ref.Context().Name()
+	for _, key := range k.index {
+		if keyMatches(key, image) {
+			return k.auths[key], true, nil
+		}
+	}
+	return Auth{}, false, nil
+}
+
+// normalizeAuthKey returns the registry server and the optional repository
+// path of the specified Docker auth key, e.g. https://index.docker.io/v1/ is
+// normalized to index.docker.io, and registry.example.com/team-a is kept as is.
+func normalizeAuthKey(authKey string) (string, error) {
+	absoluteURL := authKey
+	if !(strings.HasPrefix(authKey, "http://") || strings.HasPrefix(authKey, "https://")) {
+		absoluteURL = "https://" + absoluteURL
+	}
+	parsed, err := url.Parse(absoluteURL)
+	if err != nil {
+		return "", err
+	}
+
+	host := parsed.Host
+	if host == "docker.io" || host == "registry-1.docker.io" {
+		host = name.DefaultRegistry
+	}
+
+	// Docker considers the /v1/ and /v2/ API paths equivalent to the host name.
+	path := parsed.Path
+	for _, apiPath := range []string{"/v1", "/v2"} {
+		if path == apiPath || strings.HasPrefix(path, apiPath+"/") {
+			path = strings.TrimPrefix(path, apiPath)
+		}
+	}
+	path = strings.TrimSuffix(path, "/")
+	return host + path, nil
+}
+
+// keyMatches returns true if the specified normalized auth key matches the
+// image, i.e. the registry server matches and the path of the key is a prefix
+// of the repository path of the image.
+func keyMatches(key, image string) bool {
+	keyHost, keyPath := splitHostPath(key)
+	imageHost, imagePath := splitHostPath(image)
+	if !strings.HasPrefix(imagePath, keyPath) {
+		return false
+	}
+
+	keyHost, keyPort := splitHostPort(keyHost)
+	imageHost, imagePort := splitHostPort(imageHost)
+	if keyPort != imagePort {
+		return false
+	}
+	keyParts := strings.Split(keyHost, ".")
+	imageParts := strings.Split(imageHost, ".")
+	if len(keyParts) != len(imageParts) {
+		return false
+	}
+	for i, keyPart := range keyParts {
+		matches, err := filepath.Match(keyPart, imageParts[i])
+		if err != nil || !matches {
+			return false
+		}
+	}
+	return true
+}
+
+func splitHostPath(s string) (string, string) {
+	if i := strings.Index(s, "/"); i >= 0 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+func splitHostPort(s string) (string, string) {
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
@@ -0,0 +1,100 @@
+package docker_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring_Lookup(t *testing.T) {
+	keyring := docker.NewKeyring()
+	err := keyring.Add(docker.Config{
+		Auths: map[string]docker.Auth{
+			"https://index.docker.io/v1/":            {Username: "hub"},
+			"registry.example.com":                   {Username: "default"},
+			"registry.example.com/team-a":            {Username: "team-a"},
+			"https://registry.example.com/team-b":    {Username: "team-b"},
+			"registry.example.com/team-b/production": {Username: "team-b-production"},
+			"*.azurecr.io":                           {Username: "azure"},
+			"registry.example.com:5000":              {Username: "port"},
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		imageRef         string
+		expectedUsername string
+		expectedFound    bool
+	}{
+		{imageRef: "nginx:1.16", expectedUsername: "hub", expectedFound: true},
+		{imageRef: "docker.io/aquasec/trivy:0.25.2", expectedUsername: "hub", expectedFound: true},
+		{imageRef: "registry.example.com/team-a/app:1.0", expectedUsername: "team-a", expectedFound: true},
+		{imageRef: "registry.example.com/team-b/app:1.0", expectedUsername: "team-b", expectedFound: true},
+		{imageRef: "registry.example.com/team-b/production/app:1.0", expectedUsername: "team-b-production", expectedFound: true},
+		{imageRef: "registry.example.com/team-c/app:1.0", expectedUsername: "default", expectedFound: true},
+		{imageRef: "registry.example.com:5000/team-a/app:1.0", expectedUsername: "port", expectedFound: true},
+		{imageRef: "myregistry.azurecr.io/app:1.0", expectedUsername: "azure", expectedFound: true},
+		{imageRef: "quay.io/app:1.0", expectedFound: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.imageRef, func(t *testing.T) {
+			auth, found, err := keyring.Lookup(tc.imageRef)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Equal(t, tc.expectedUsername, auth.Username)
+		})
+	}
+}
+
+func TestKeyring_Add(t *testing.T) {
+	keyring := docker.NewKeyring()
+	require.NoError(t, keyring.Add(docker.Config{
+		Auths: map[string]docker.Auth{"registry.example.com": {Username: "first"}},
+	}))
+	require.NoError(t, keyring.Add(docker.Config{
+		Auths: map[string]docker.Auth{"https://registry.example.com/": {Username: "second"}},
+	}))
+
+	auth, found, err := keyring.Lookup("registry.example.com/app:1.0")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "second", auth.Username)
+}
+
+func TestAuth_Credentials(t *testing.T) {
+	testCases := []struct {
+		name             string
+		auth             docker.Auth
+		expectedUsername string
+		expectedPassword string
+	}{
+		{
+			name:             "Should return username and password",
+			auth:             docker.Auth{Username: "admin", Password: "s3cret", IdentityToken: "ignored"},
+			expectedUsername: "admin",
+			expectedPassword: "s3cret",
+		},
+		{
+			name:             "Should return identity token as password",
+			auth:             docker.Auth{Username: "00000000-0000-0000-0000-000000000000", IdentityToken: "refresh-token"},
+			expectedUsername: "00000000-0000-0000-0000-000000000000",
+			expectedPassword: "refresh-token",
+		},
+		{
+			name:             "Should return token username with identity token",
+			auth:             docker.Auth{IdentityToken: "refresh-token"},
+			expectedUsername: "<token>",
+			expectedPassword: "refresh-token",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			username, password := tc.auth.Credentials()
+			assert.Equal(t, tc.expectedUsername, username)
+			assert.Equal(t, tc.expectedPassword, password)
+		})
+	}
+}
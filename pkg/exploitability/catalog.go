@@ -0,0 +1,135 @@
+package exploitability
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// Score is the EPSS score of a vulnerability.
+type Score struct {
+	// EPSS is the probability, between 0 and 1, that a vulnerability will be
+	// exploited in the next 30 days.
+	EPSS float64
+	// Percentile is the percentile of EPSS among all scored vulnerabilities.
+	Percentile float64
+}
+
+// Catalog holds exploitability data indexed by vulnerability identifier.
+type Catalog struct {
+	KnownExploited map[string]bool
+	Scores         map[string]Score
+}
+
+// Enrich returns a copy of the given report data where each vulnerability is
+// annotated with the exploitability data held by this Catalog. Data that is
+// not held by this Catalog, for example because no EPSS scores were loaded,
+// is left as is.
+func (c Catalog) Enrich(data v1alpha1.VulnerabilityReportData) v1alpha1.VulnerabilityReportData {
+	copied := *data.DeepCopy()
+	for i := range copied.Vulnerabilities {
+		vulnerability := &copied.Vulnerabilities[i]
+		if c.KnownExploited != nil {
+			vulnerability.KnownExploited = c.KnownExploited[vulnerability.VulnerabilityID]
+		}
+		if c.Scores != nil {
+			vulnerability.EPSSScore = nil
+			vulnerability.EPSSPercentile = nil
+			if score, ok := c.Scores[vulnerability.VulnerabilityID]; ok {
+				epss, percentile := score.EPSS, score.Percentile
+				vulnerability.EPSSScore = &epss
+				vulnerability.EPSSPercentile = &percentile
+			}
+		}
+	}
+	return copied
+}
+
+type kevCatalog struct {
+	Vulnerabilities []struct {
+		CveID string `json:"cveID"`
+	} `json:"vulnerabilities"`
+}
+
+// ParseKEV parses a catalog of known exploited vulnerabilities in the CISA KEV
+// JSON format and returns the set of listed vulnerability identifiers.
+func ParseKEV(r io.Reader) (map[string]bool, error) {
+	var catalog kevCatalog
+	err := json.NewDecoder(r).Decode(&catalog)
+	if err != nil {
+		return nil, fmt.Errorf("decoding KEV catalog: %w", err)
+	}
+	knownExploited := make(map[string]bool, len(catalog.Vulnerabilities))
+	for _, vulnerability := range catalog.Vulnerabilities {
+		id := strings.TrimSpace(vulnerability.CveID)
+		if id == "" {
+			continue
+		}
+		knownExploited[id] = true
+	}
+	return knownExploited, nil
+}
+
+// ParseEPSS parses EPSS scores in the CSV format published by FIRST, i.e.
+// an optional comment line with the model version, the cve,epss,percentile
+// header, and one record per vulnerability. Gzip compressed input is detected
+// and decompressed transparently.
+func ParseEPSS(r io.Reader) (map[string]Score, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing EPSS scores: %w", err)
+		}
+		defer func() {
+			_ = gr.Close()
+		}()
+		r = gr
+	} else {
+		r = br
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	scores := make(map[string]Score)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading EPSS scores: %w", err)
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("reading EPSS scores: record %d: expected at least 2 fields, got %d", line, len(record))
+		}
+		if strings.EqualFold(strings.TrimSpace(record[0]), "cve") {
+			continue
+		}
+		var score Score
+		score.EPSS, err = strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("reading EPSS scores: record %d: parsing epss: %w", line, err)
+		}
+		if len(record) > 2 {
+			score.Percentile, err = strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("reading EPSS scores: record %d: parsing percentile: %w", line, err)
+			}
+		}
+		scores[strings.TrimSpace(record[0])] = score
+	}
+	return scores, nil
+}
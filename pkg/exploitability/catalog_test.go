@@ -0,0 +1,112 @@
+package exploitability_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exploitability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+)
+
+const kevCatalog = `{
+  "title": "CISA Catalog of Known Exploited Vulnerabilities",
+  "catalogVersion": "2022.08.10",
+  "count": 2,
+  "vulnerabilities": [
+    {"cveID": "CVE-2021-44228", "vendorProject": "Apache", "product": "Log4j2"},
+    {"cveID": "CVE-2014-0160", "vendorProject": "OpenSSL", "product": "OpenSSL"}
+  ]
+}`
+
+const epssScores = `#model_version:v2022.01.01,score_date:2022-08-10T00:00:00+0000
+cve,epss,percentile
+CVE-2021-44228,0.97565,1.00000
+CVE-2022-0001,0.00044,0.08731
+`
+
+func TestParseKEV(t *testing.T) {
+	knownExploited, err := exploitability.ParseKEV(strings.NewReader(kevCatalog))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"CVE-2021-44228": true,
+		"CVE-2014-0160":  true,
+	}, knownExploited)
+
+	_, err = exploitability.ParseKEV(strings.NewReader("not JSON"))
+	assert.Error(t, err)
+}
+
+func TestParseEPSS(t *testing.T) {
+	expected := map[string]exploitability.Score{
+		"CVE-2021-44228": {EPSS: 0.97565, Percentile: 1},
+		"CVE-2022-0001":  {EPSS: 0.00044, Percentile: 0.08731},
+	}
+
+	t.Run("Should parse CSV", func(t *testing.T) {
+		scores, err := exploitability.ParseEPSS(strings.NewReader(epssScores))
+		require.NoError(t, err)
+		assert.Equal(t, expected, scores)
+	})
+
+	t.Run("Should parse gzip compressed CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		_, err := gw.Write([]byte(epssScores))
+		require.NoError(t, err)
+		require.NoError(t, gw.Close())
+
+		scores, err := exploitability.ParseEPSS(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, expected, scores)
+	})
+
+	t.Run("Should return error when score is invalid", func(t *testing.T) {
+		_, err := exploitability.ParseEPSS(strings.NewReader("cve,epss,percentile\nCVE-2022-0001,high,0.5\n"))
+		assert.EqualError(t, err, "reading EPSS scores: record 2: parsing epss: strconv.ParseFloat: parsing \"high\": invalid syntax")
+	})
+}
+
+func TestCatalog_Enrich(t *testing.T) {
+	data := v1alpha1.VulnerabilityReportData{
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2021-44228"},
+			{VulnerabilityID: "CVE-2022-0001", KnownExploited: true},
+			{VulnerabilityID: "CVE-2022-0002", EPSSScore: pointer.Float64(0.5)},
+		},
+	}
+
+	t.Run("Should annotate vulnerabilities", func(t *testing.T) {
+		catalog := exploitability.Catalog{
+			KnownExploited: map[string]bool{"CVE-2021-44228": true},
+			Scores: map[string]exploitability.Score{
+				"CVE-2021-44228": {EPSS: 0.97565, Percentile: 1},
+			},
+		}
+
+		enriched := catalog.Enrich(data)
+		assert.Equal(t, []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2021-44228", KnownExploited: true, EPSSScore: pointer.Float64(0.97565), EPSSPercentile: pointer.Float64(1)},
+			{VulnerabilityID: "CVE-2022-0001"},
+			{VulnerabilityID: "CVE-2022-0002"},
+		}, enriched.Vulnerabilities)
+		assert.True(t, data.Vulnerabilities[1].KnownExploited, "input must not be modified")
+	})
+
+	t.Run("Should leave data that is not held by catalog", func(t *testing.T) {
+		catalog := exploitability.Catalog{
+			KnownExploited: map[string]bool{"CVE-2021-44228": true},
+		}
+
+		enriched := catalog.Enrich(data)
+		assert.Equal(t, []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2021-44228", KnownExploited: true},
+			{VulnerabilityID: "CVE-2022-0001"},
+			{VulnerabilityID: "CVE-2022-0002", EPSSScore: pointer.Float64(0.5)},
+		}, enriched.Vulnerabilities)
+	})
+}
@@ -0,0 +1,6 @@
+// Package exploitability provides primitives for enriching vulnerabilities
+// with exploitability data, i.e. whether a vulnerability is known to be
+// exploited in the wild and its Exploit Prediction Scoring System (EPSS) score.
+// The data is read from offline files, such as the CISA Known Exploited
+// Vulnerabilities (KEV) catalog and the daily EPSS scores published by FIRST.
+package exploitability
@@ -0,0 +1,127 @@
+package exploitability
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// Enricher annotates vulnerabilities with exploitability data.
+type Enricher interface {
+	// Enrich returns a copy of the given report data where vulnerabilities
+	// are annotated with exploitability data.
+	Enrich(data v1alpha1.VulnerabilityReportData) (v1alpha1.VulnerabilityReportData, error)
+}
+
+// NewEnricher constructs an Enricher that reads exploitability data from the
+// files configured with the specified starboard.ConfigData. If no files are
+// configured the returned Enricher returns report data unchanged.
+func NewEnricher(config starboard.ConfigData) Enricher {
+	return NewFileEnricher(config.GetExploitabilityKEVFile(), config.GetExploitabilityEPSSFile())
+}
+
+// NewFileEnricher constructs an Enricher that reads the KEV catalog and EPSS
+// scores from the specified files. An empty path disables the corresponding
+// kind of exploitability data. Files are read lazily and reloaded whenever
+// their modification time changes, which allows updating data files mounted
+// from a ConfigMap or a volume without restarting.
+func NewFileEnricher(kevFile, epssFile string) Enricher {
+	if kevFile == "" && epssFile == "" {
+		return &nopEnricher{}
+	}
+	return &fileEnricher{
+		kev:  &dataFile{path: kevFile},
+		epss: &dataFile{path: epssFile},
+	}
+}
+
+type nopEnricher struct {
+}
+
+func (e *nopEnricher) Enrich(data v1alpha1.VulnerabilityReportData) (v1alpha1.VulnerabilityReportData, error) {
+	return data, nil
+}
+
+type dataFile struct {
+	path    string
+	modTime time.Time
+}
+
+// load calls the parse function if the file was modified since it was last
+// loaded.
+func (f *dataFile) load(parse func(r io.Reader) error) error {
+	if f.path == "" {
+		return nil
+	}
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return fmt.Errorf("reading exploitability data: %w", err)
+	}
+	if info.ModTime().Equal(f.modTime) {
+		return nil
+	}
+	file, err := os.Open(f.path)
+	if err != nil {
+		return fmt.Errorf("reading exploitability data: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	err = parse(file)
+	if err != nil {
+		return fmt.Errorf("reading exploitability data from %s: %w", f.path, err)
+	}
+	f.modTime = info.ModTime()
+	return nil
+}
+
+type fileEnricher struct {
+	sync.Mutex
+	kev     *dataFile
+	epss    *dataFile
+	catalog Catalog
+}
+
+func (e *fileEnricher) Enrich(data v1alpha1.VulnerabilityReportData) (v1alpha1.VulnerabilityReportData, error) {
+	catalog, err := e.load()
+	if err != nil {
+		return data, err
+	}
+	return catalog.Enrich(data), nil
+}
+
+func (e *fileEnricher) load() (Catalog, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	err := e.kev.load(func(r io.Reader) error {
+		knownExploited, err := ParseKEV(r)
+		if err != nil {
+			return err
+		}
+		e.catalog.KnownExploited = knownExploited
+		return nil
+	})
+	if err != nil {
+		return Catalog{}, err
+	}
+
+	err = e.epss.load(func(r io.Reader) error {
+		scores, err := ParseEPSS(r)
+		if err != nil {
+			return err
+		}
+		e.catalog.Scores = scores
+		return nil
+	})
+	if err != nil {
+		return Catalog{}, err
+	}
+
+	return e.catalog, nil
+}
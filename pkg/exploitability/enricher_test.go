@@ -0,0 +1,59 @@
+package exploitability_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exploitability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileEnricher(t *testing.T) {
+	data := v1alpha1.VulnerabilityReportData{
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2021-44228"},
+			{VulnerabilityID: "CVE-2014-0160"},
+		},
+	}
+
+	t.Run("Should return data unchanged when no files are configured", func(t *testing.T) {
+		enriched, err := exploitability.NewFileEnricher("", "").Enrich(data)
+		require.NoError(t, err)
+		assert.Equal(t, data, enriched)
+	})
+
+	t.Run("Should return error when file does not exist", func(t *testing.T) {
+		_, err := exploitability.NewFileEnricher(filepath.Join(t.TempDir(), "kev.json"), "").Enrich(data)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should reload modified files", func(t *testing.T) {
+		dir := t.TempDir()
+		kevFile := filepath.Join(dir, "kev.json")
+		epssFile := filepath.Join(dir, "epss.csv")
+		require.NoError(t, os.WriteFile(kevFile, []byte(kevCatalog), 0600))
+		require.NoError(t, os.WriteFile(epssFile, []byte(epssScores), 0600))
+
+		enricher := exploitability.NewFileEnricher(kevFile, epssFile)
+
+		enriched, err := enricher.Enrich(data)
+		require.NoError(t, err)
+		assert.True(t, enriched.Vulnerabilities[0].KnownExploited)
+		assert.Equal(t, 0.97565, *enriched.Vulnerabilities[0].EPSSScore)
+		assert.True(t, enriched.Vulnerabilities[1].KnownExploited)
+		assert.Nil(t, enriched.Vulnerabilities[1].EPSSScore)
+
+		require.NoError(t, os.WriteFile(kevFile, []byte(`{"vulnerabilities":[{"cveID":"CVE-2021-44228"}]}`), 0600))
+		modTime := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(kevFile, modTime, modTime))
+
+		enriched, err = enricher.Enrich(data)
+		require.NoError(t, err)
+		assert.True(t, enriched.Vulnerabilities[0].KnownExploited)
+		assert.False(t, enriched.Vulnerabilities[1].KnownExploited)
+	})
+}
@@ -0,0 +1,4 @@
+// Package export provides encoders of security reports in formats consumed
+// by third-party tools, such as SARIF for code scanning dashboards, JUnit XML
+// for CI test reports, and CSV for spreadsheets.
+package export
@@ -0,0 +1,45 @@
+package export
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format is an output format of security reports.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatCSV   Format = "csv"
+	FormatSARIF Format = "sarif"
+	FormatJUnit Format = "junit"
+)
+
+// Formats lists all supported output formats.
+var Formats = []Format{FormatTable, FormatCSV, FormatSARIF, FormatJUnit}
+
+// IsFormat returns true if the specified output format is one of Formats.
+func IsFormat(format string) bool {
+	for _, f := range Formats {
+		if string(f) == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Write encodes the specified Results in the given output format.
+func Write(out io.Writer, format Format, results Results) error {
+	switch format {
+	case FormatTable:
+		return WriteTable(out, results)
+	case FormatCSV:
+		return WriteCSV(out, results)
+	case FormatSARIF:
+		return WriteSARIF(out, results)
+	case FormatJUnit:
+		return WriteJUnit(out, results)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
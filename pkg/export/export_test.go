@@ -0,0 +1,294 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/export"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var vulnerabilityReport = v1alpha1.VulnerabilityReport{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "replicaset-nginx-6d4cf56db6-nginx",
+		Namespace: "default",
+		Labels: map[string]string{
+			starboard.LabelResourceKind:      "ReplicaSet",
+			starboard.LabelResourceName:      "nginx-6d4cf56db6",
+			starboard.LabelResourceNamespace: "default",
+			starboard.LabelContainerName:     "nginx",
+		},
+	},
+	Report: v1alpha1.VulnerabilityReportData{
+		Scanner:  v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.25.2"},
+		Registry: v1alpha1.Registry{Server: "index.docker.io"},
+		Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{
+				VulnerabilityID:  "CVE-2020-3810",
+				Resource:         "apt",
+				InstalledVersion: "1.8.2",
+				FixedVersion:     "1.8.2.1",
+				Severity:         v1alpha1.SeverityMedium,
+				Title:            "Out-of-bounds read in .ar and .tar implementation",
+			},
+			{
+				VulnerabilityID:  "CVE-2020-1967",
+				Resource:         "libssl1.1",
+				InstalledVersion: "1.1.1d-0+deb10u2",
+				FixedVersion:     "1.1.1d-0+deb10u3",
+				Severity:         v1alpha1.SeverityCritical,
+				Title:            "openssl: Segmentation fault in SSL_check_chain",
+				PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2020-1967",
+				EPSSScore:        pointer.Float64(0.01524),
+				EPSSPercentile:   pointer.Float64(0.85043),
+			},
+			{
+				VulnerabilityID:  "CVE-2019-18276",
+				Resource:         "bash",
+				InstalledVersion: "5.0-4",
+				Severity:         v1alpha1.SeverityLow,
+				Title:            "bash: when effective UID is not equal to its real UID",
+				Suppressed:       true,
+			},
+			{
+				VulnerabilityID:  "CVE-2021-33574",
+				Resource:         "libc6",
+				InstalledVersion: "2.28-10",
+				Severity:         v1alpha1.SeverityCritical,
+				Title:            "glibc: mq_notify does not handle separately allocated thread attributes",
+				VEXStatus:        "not_affected",
+			},
+		},
+	},
+}
+
+var configAuditReport = v1alpha1.ConfigAuditReport{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "replicaset-nginx-6d4cf56db6",
+		Namespace: "default",
+		Labels: map[string]string{
+			starboard.LabelResourceKind:      "ReplicaSet",
+			starboard.LabelResourceName:      "nginx-6d4cf56db6",
+			starboard.LabelResourceNamespace: "default",
+		},
+	},
+	Report: v1alpha1.ConfigAuditReportData{
+		Scanner: v1alpha1.Scanner{Name: "Starboard", Vendor: "Aqua Security", Version: "dev"},
+		Checks: []v1alpha1.Check{
+			{
+				ID:       "KSV001",
+				Title:    "Process can elevate its own privileges",
+				Severity: v1alpha1.SeverityMedium,
+				Category: "Kubernetes Security Check",
+				Success:  true,
+			},
+			{
+				ID:       "KSV017",
+				Title:    "Privileged container",
+				Severity: v1alpha1.SeverityHigh,
+				Category: "Kubernetes Security Check",
+				Messages: []string{"Container 'nginx' should set 'securityContext.privileged' to false"},
+				Scope:    &v1alpha1.CheckScope{Type: "Container", Value: "nginx"},
+			},
+		},
+	},
+}
+
+func TestFromVulnerabilityReports(t *testing.T) {
+	results := export.FromVulnerabilityReports([]v1alpha1.VulnerabilityReport{vulnerabilityReport})
+	assert.Equal(t, v1alpha1.VulnerabilityReportKind, results.Kind)
+	assert.Equal(t, "Trivy", results.Scanner.Name)
+	require.Len(t, results.Items, 2)
+	assert.Equal(t, export.Result{
+		Report:            "replicaset-nginx-6d4cf56db6-nginx",
+		Namespace:         "default",
+		ResourceKind:      "ReplicaSet",
+		ResourceName:      "nginx-6d4cf56db6",
+		ResourceNamespace: "default",
+		Container:         "nginx",
+		Image:             "index.docker.io/library/nginx:1.16",
+		ID:                "CVE-2020-1967",
+		Title:             "openssl: Segmentation fault in SSL_check_chain",
+		Severity:          v1alpha1.SeverityCritical,
+		Status:            export.StatusFail,
+		Package:           "libssl1.1",
+		InstalledVersion:  "1.1.1d-0+deb10u2",
+		FixedVersion:      "1.1.1d-0+deb10u3",
+		PrimaryLink:       "https://avd.aquasec.com/nvd/cve-2020-1967",
+		EPSSScore:         pointer.Float64(0.01524),
+	}, results.Items[1])
+	assert.Equal(t, "replicaset/nginx-6d4cf56db6", results.Items[1].Resource())
+}
+
+func TestResults_SortBySeverity(t *testing.T) {
+	results := export.Results{Items: []export.Result{
+		{ID: "1", Severity: v1alpha1.SeverityLow},
+		{ID: "2", Severity: ""},
+		{ID: "3", Severity: v1alpha1.SeverityCritical},
+		{ID: "4", Severity: v1alpha1.SeverityMedium},
+		{ID: "5", Severity: v1alpha1.SeverityCritical},
+	}}
+	results.SortBySeverity()
+	var ids []string
+	for _, item := range results.Items {
+		ids = append(ids, item.ID)
+	}
+	assert.Equal(t, []string{"3", "5", "4", "1", "2"}, ids)
+}
+
+func TestResults_SortByExploitability(t *testing.T) {
+	results := export.Results{Items: []export.Result{
+		{ID: "1", Severity: v1alpha1.SeverityCritical},
+		{ID: "2", Severity: v1alpha1.SeverityLow, EPSSScore: pointer.Float64(0.2)},
+		{ID: "3", Severity: v1alpha1.SeverityMedium, KnownExploited: true},
+		{ID: "4", Severity: v1alpha1.SeverityHigh, EPSSScore: pointer.Float64(0.9)},
+		{ID: "5", Severity: v1alpha1.SeverityCritical, KnownExploited: true, EPSSScore: pointer.Float64(0.1)},
+		{ID: "6", Severity: v1alpha1.SeverityHigh},
+	}}
+	results.SortByExploitability()
+	var ids []string
+	for _, item := range results.Items {
+		ids = append(ids, item.ID)
+	}
+	assert.Equal(t, []string{"5", "3", "4", "2", "1", "6"}, ids)
+}
+
+func TestWriteTable(t *testing.T) {
+	results := export.FromVulnerabilityReports([]v1alpha1.VulnerabilityReport{vulnerabilityReport})
+	results.SortBySeverity()
+
+	var out bytes.Buffer
+	require.NoError(t, export.Write(&out, export.FormatTable, results))
+	assert.Equal(t,
+		"RESOURCE                      CONTAINER   ID              SEVERITY   KEV   EPSS      PACKAGE     INSTALLED          FIXED              TITLE\n"+
+			"replicaset/nginx-6d4cf56db6   nginx       CVE-2020-1967   CRITICAL         0.01524   libssl1.1   1.1.1d-0+deb10u2   1.1.1d-0+deb10u3   openssl: Segmentation fault in SSL_check_chain\n"+
+			"replicaset/nginx-6d4cf56db6   nginx       CVE-2020-3810   MEDIUM                     apt         1.8.2              1.8.2.1            Out-of-bounds read in .ar and .tar implementation\n",
+		out.String())
+}
+
+func TestWriteCSV(t *testing.T) {
+	results := export.FromConfigAuditReports([]v1alpha1.ConfigAuditReport{configAuditReport})
+
+	var out bytes.Buffer
+	require.NoError(t, export.Write(&out, export.FormatCSV, results))
+	assert.Equal(t,
+		"REPORT NAMESPACE,REPORT NAME,RESOURCE,ID,SEVERITY,CATEGORY,STATUS,TITLE\n"+
+			"default,replicaset-nginx-6d4cf56db6,replicaset/nginx-6d4cf56db6,KSV001,MEDIUM,Kubernetes Security Check,PASS,Process can elevate its own privileges\n"+
+			"default,replicaset-nginx-6d4cf56db6,replicaset/nginx-6d4cf56db6,KSV017,HIGH,Kubernetes Security Check,FAIL,Privileged container\n",
+		out.String())
+}
+
+func TestWriteSARIF(t *testing.T) {
+	results := export.FromConfigAuditReports([]v1alpha1.ConfigAuditReport{configAuditReport})
+
+	var out bytes.Buffer
+	require.NoError(t, export.Write(&out, export.FormatSARIF, results))
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID         string `json:"id"`
+						Properties struct {
+							SecuritySeverity string `json:"security-severity"`
+						} `json:"properties"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "Starboard", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 1)
+	assert.Equal(t, "KSV017", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "8.0", run.Tool.Driver.Rules[0].Properties.SecuritySeverity)
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, "KSV017", run.Results[0].RuleID)
+	assert.Equal(t, "error", run.Results[0].Level)
+	require.Len(t, run.Results[0].Locations, 1)
+	assert.Equal(t, "default/replicaset/nginx-6d4cf56db6", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	results := export.FromConfigAuditReports([]v1alpha1.ConfigAuditReport{configAuditReport})
+
+	var out bytes.Buffer
+	require.NoError(t, export.Write(&out, export.FormatJUnit, results))
+
+	var report struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Name      string `xml:"name,attr"`
+			TestCases []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 2, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	require.Len(t, report.Suites, 1)
+	assert.Equal(t, "default/replicaset-nginx-6d4cf56db6", report.Suites[0].Name)
+	require.Len(t, report.Suites[0].TestCases, 2)
+	assert.Equal(t, "[MEDIUM] KSV001", report.Suites[0].TestCases[0].Name)
+	assert.Nil(t, report.Suites[0].TestCases[0].Failure)
+	assert.Equal(t, "[HIGH] KSV017", report.Suites[0].TestCases[1].Name)
+	require.NotNil(t, report.Suites[0].TestCases[1].Failure)
+	assert.Equal(t, "Privileged container", report.Suites[0].TestCases[1].Failure.Message)
+}
+
+func TestWrite_SBOMReport(t *testing.T) {
+	results := export.FromSBOMReports([]v1alpha1.SBOMReport{{
+		ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6-nginx", Namespace: "default"},
+		Report: v1alpha1.SBOMReportData{
+			Components: v1alpha1.BOM{
+				Components: []v1alpha1.Component{{
+					Type:       "library",
+					Name:       "libbsd0",
+					Version:    "0.9.1-2",
+					PackageURL: "pkg:deb/debian/libbsd0@0.9.1-2",
+					Licenses:   []v1alpha1.LicenseChoice{{License: v1alpha1.License{Name: "BSD-3-Clause"}}},
+				}},
+			},
+		},
+	}})
+
+	var out bytes.Buffer
+	require.NoError(t, export.Write(&out, export.FormatCSV, results))
+	assert.Equal(t,
+		"REPORT NAMESPACE,REPORT NAME,RESOURCE,CONTAINER,NAME,VERSION,TYPE,LICENSES,PURL\n"+
+			"default,replicaset-nginx-6d4cf56db6-nginx,,,libbsd0,0.9.1-2,library,BSD-3-Clause,pkg:deb/debian/libbsd0@0.9.1-2\n",
+		out.String())
+
+	assert.EqualError(t, export.Write(&out, export.FormatSARIF, results), "sarif output is not supported for SBOMReport")
+	assert.EqualError(t, export.Write(&out, export.FormatJUnit, results), "junit output is not supported for SBOMReport")
+}
@@ -0,0 +1,107 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// WriteJUnit writes Results as a JUnit XML report with a test suite per
+// security report and a test case per vulnerability, check, or control.
+// Vulnerabilities and failed checks or controls are reported as failures.
+func WriteJUnit(out io.Writer, results Results) error {
+	if results.Kind == v1alpha1.SBOMReportKind {
+		return fmt.Errorf("%s output is not supported for %s", FormatJUnit, results.Kind)
+	}
+	report := junitTestSuites{Name: results.Scanner.Name}
+	suiteIndex := make(map[string]int)
+	for _, item := range results.Items {
+		index, ok := suiteIndex[item.Report]
+		if !ok {
+			index = len(report.Suites)
+			suiteIndex[item.Report] = index
+			report.Suites = append(report.Suites, junitTestSuite{Name: junitSuiteName(item)})
+		}
+		suite := &report.Suites[index]
+		testCase := junitTestCase{
+			ClassName: junitClassName(item),
+			Name:      fmt.Sprintf("[%s] %s", item.Severity, item.ID),
+		}
+		if item.Status == StatusFail {
+			testCase.Failure = &junitFailure{
+				Message: item.Title,
+				Type:    string(item.Severity),
+				Content: sarifResultMessage(item),
+			}
+			suite.Failures++
+			report.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		report.Tests++
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+func junitSuiteName(item Result) string {
+	if item.Namespace == "" {
+		return item.Report
+	}
+	return item.Namespace + "/" + item.Report
+}
+
+func junitClassName(item Result) string {
+	var parts []string
+	for _, part := range []string{item.Image, item.Package, item.Category} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) > 0 {
+		return strings.Join(parts, ": ")
+	}
+	if resource := item.Resource(); resource != "" {
+		return resource
+	}
+	return item.Report
+}
@@ -0,0 +1,297 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ClusterVulnerabilityReportKind    = "ClusterVulnerabilityReport"
+	ClusterComplianceReportKind       = "ClusterComplianceReport"
+	ClusterComplianceDetailReportKind = "ClusterComplianceDetailReport"
+)
+
+// Status indicates whether a Result passed or failed.
+type Status string
+
+const (
+	StatusPass Status = "PASS"
+	StatusFail Status = "FAIL"
+)
+
+// Results is a flattened, format-agnostic view of security reports of the
+// same kind.
+type Results struct {
+	// Kind is the kind of security reports, e.g. VulnerabilityReport.
+	Kind string
+
+	// Scanner is the scanner that generated security reports.
+	Scanner v1alpha1.Scanner
+
+	Items []Result
+}
+
+// Result is a single finding of a security report, i.e. a vulnerability,
+// a configuration audit check, a compliance control, or a component of an SBOM.
+type Result struct {
+	// Report is the name of the security report.
+	Report    string
+	Namespace string
+
+	// ResourceKind, ResourceName, and ResourceNamespace identify the
+	// Kubernetes resource that the security report was generated for.
+	ResourceKind      string
+	ResourceName      string
+	ResourceNamespace string
+	Container         string
+	Image             string
+
+	// ID is a vulnerability ID, a check ID, a control ID, or a package URL.
+	ID          string
+	Title       string
+	Description string
+	Severity    v1alpha1.Severity
+	Score       *float64
+	Status      Status
+
+	// KnownExploited and EPSSScore describe the exploitability of a
+	// vulnerability.
+	KnownExploited bool
+	EPSSScore      *float64
+
+	// Category is a check category or a component type.
+	Category         string
+	Package          string
+	InstalledVersion string
+	FixedVersion     string
+	PrimaryLink      string
+
+	// Message provides additional details, e.g. messages of a failed check
+	// or licenses of a component.
+	Message string
+}
+
+// Resource returns the kubectl-style reference to the Kubernetes resource,
+// e.g. replicaset/nginx-6d4cf56db6.
+func (r Result) Resource() string {
+	if r.ResourceKind == "" {
+		return r.ResourceName
+	}
+	return strings.ToLower(r.ResourceKind) + "/" + r.ResourceName
+}
+
+// FromVulnerabilityReports returns Results of the specified vulnerability
+// reports. Vulnerabilities excluded by exceptions or VEX statements are
+// omitted.
+func FromVulnerabilityReports(reports []v1alpha1.VulnerabilityReport) Results {
+	results := Results{Kind: v1alpha1.VulnerabilityReportKind}
+	for i, report := range reports {
+		if i == 0 {
+			results.Scanner = report.Report.Scanner
+		}
+		results.Items = append(results.Items, vulnerabilityResults(report.ObjectMeta, report.Report)...)
+	}
+	return results
+}
+
+// FromClusterVulnerabilityReports returns Results of the specified cluster
+// vulnerability reports. Vulnerabilities excluded by exceptions or VEX
+// statements are omitted.
+func FromClusterVulnerabilityReports(reports []v1alpha1.ClusterVulnerabilityReport) Results {
+	results := Results{Kind: ClusterVulnerabilityReportKind}
+	for i, report := range reports {
+		if i == 0 {
+			results.Scanner = report.Report.Scanner
+		}
+		results.Items = append(results.Items, vulnerabilityResults(report.ObjectMeta, report.Report)...)
+	}
+	return results
+}
+
+func vulnerabilityResults(meta metav1.ObjectMeta, data v1alpha1.VulnerabilityReportData) []Result {
+	var items []Result
+	for _, v := range data.Vulnerabilities {
+		if v.IsExcluded() {
+			continue
+		}
+		item := newResult(meta)
+		item.Image = v1alpha1.ImageRef(data.Registry, data.Artifact)
+		item.ID = v.VulnerabilityID
+		item.Title = v.Title
+		item.Description = v.Description
+		item.Severity = v.Severity
+		item.Score = v.Score
+		item.KnownExploited = v.KnownExploited
+		item.EPSSScore = v.EPSSScore
+		item.Status = StatusFail
+		item.Package = v.Resource
+		item.InstalledVersion = v.InstalledVersion
+		item.FixedVersion = v.FixedVersion
+		item.PrimaryLink = v.PrimaryLink
+		items = append(items, item)
+	}
+	return items
+}
+
+// FromConfigAuditReports returns Results of the specified configuration
+// audit reports.
+func FromConfigAuditReports(reports []v1alpha1.ConfigAuditReport) Results {
+	results := Results{Kind: v1alpha1.ConfigAuditReportKind}
+	for i, report := range reports {
+		if i == 0 {
+			results.Scanner = report.Report.Scanner
+		}
+		for _, check := range report.Report.Checks {
+			item := newResult(report.ObjectMeta)
+			if check.Scope != nil && check.Scope.Type == "Container" {
+				item.Container = check.Scope.Value
+			}
+			item.ID = check.ID
+			item.Title = check.Title
+			item.Description = check.Description
+			item.Severity = check.Severity
+			item.Status = StatusPass
+			if !check.Success {
+				item.Status = StatusFail
+			}
+			item.Category = check.Category
+			item.Message = strings.Join(check.Messages, "; ")
+			results.Items = append(results.Items, item)
+		}
+	}
+	return results
+}
+
+// FromClusterComplianceReport returns Results of the specified compliance
+// report, one per control.
+func FromClusterComplianceReport(report v1alpha1.ClusterComplianceReport) Results {
+	results := Results{
+		Kind:    ClusterComplianceReportKind,
+		Scanner: v1alpha1.Scanner{Name: "Starboard", Vendor: "Aqua Security"},
+	}
+	for _, control := range report.Status.ControlChecks {
+		item := newResult(report.ObjectMeta)
+		item.ID = control.ID
+		item.Title = control.Name
+		item.Description = control.Description
+		item.Severity = control.Severity
+		item.Status = StatusPass
+		if control.FailTotal > 0 {
+			item.Status = StatusFail
+		}
+		item.Message = fmt.Sprintf("%d passed, %d failed", control.PassTotal, control.FailTotal)
+		results.Items = append(results.Items, item)
+	}
+	return results
+}
+
+// FromClusterComplianceDetailReport returns Results of the specified
+// compliance detail report, one per checked resource.
+func FromClusterComplianceDetailReport(report v1alpha1.ClusterComplianceDetailReport) Results {
+	results := Results{
+		Kind:    ClusterComplianceDetailReportKind,
+		Scanner: v1alpha1.Scanner{Name: "Starboard", Vendor: "Aqua Security"},
+	}
+	for _, control := range report.Report.ControlChecks {
+		for _, check := range control.ScannerCheckResult {
+			for _, detail := range check.Details {
+				item := newResult(report.ObjectMeta)
+				item.ResourceNamespace = detail.Namespace
+				item.ResourceKind = check.ObjectType
+				item.ResourceName = detail.Name
+				item.ID = control.ID
+				item.Title = control.Name
+				item.Description = control.Description
+				item.Severity = control.Severity
+				item.Status = Status(detail.Status)
+				item.Category = check.ID
+				item.Message = detail.Msg
+				results.Items = append(results.Items, item)
+			}
+		}
+	}
+	return results
+}
+
+// FromSBOMReports returns Results of the specified SBOM reports, one per
+// component.
+func FromSBOMReports(reports []v1alpha1.SBOMReport) Results {
+	results := Results{Kind: v1alpha1.SBOMReportKind}
+	for i, report := range reports {
+		if i == 0 {
+			results.Scanner = report.Report.Scanner
+		}
+		for _, component := range report.Report.Components.Components {
+			item := newResult(report.ObjectMeta)
+			item.Image = v1alpha1.ImageRef(report.Report.Registry, report.Report.Artifact)
+			item.ID = component.PackageURL
+			item.Category = string(component.Type)
+			item.Package = component.Name
+			if component.Group != "" {
+				item.Package = component.Group + "/" + component.Name
+			}
+			item.InstalledVersion = component.Version
+			var licenses []string
+			for _, license := range component.Licenses {
+				if license.License.ID != "" {
+					licenses = append(licenses, license.License.ID)
+					continue
+				}
+				licenses = append(licenses, license.License.Name)
+			}
+			item.Message = strings.Join(licenses, ", ")
+			results.Items = append(results.Items, item)
+		}
+	}
+	return results
+}
+
+func newResult(meta metav1.ObjectMeta) Result {
+	return Result{
+		Report:            meta.Name,
+		Namespace:         meta.Namespace,
+		ResourceKind:      meta.Labels[starboard.LabelResourceKind],
+		ResourceName:      meta.Labels[starboard.LabelResourceName],
+		ResourceNamespace: meta.Labels[starboard.LabelResourceNamespace],
+		Container:         meta.Labels[starboard.LabelContainerName],
+	}
+}
+
+// SortBySeverity sorts Results from the most to the least severe. Results
+// with the same severity keep their original order.
+func (r Results) SortBySeverity() {
+	sort.SliceStable(r.Items, func(i, j int) bool {
+		return r.Items[i].Severity.Compare(r.Items[j].Severity) > 0
+	})
+}
+
+// SortByExploitability sorts Results from the most to the least likely to be
+// exploited. Known exploited vulnerabilities come first, followed by
+// vulnerabilities ordered by their EPSS score, and vulnerabilities without EPSS
+// score. Ties are broken by severity.
+func (r Results) SortByExploitability() {
+	sort.SliceStable(r.Items, func(i, j int) bool {
+		a, b := r.Items[i], r.Items[j]
+		if a.KnownExploited != b.KnownExploited {
+			return a.KnownExploited
+		}
+		if epss(a) != epss(b) {
+			return epss(a) > epss(b)
+		}
+		return a.Severity.Compare(b.Severity) > 0
+	})
+}
+
+// epss returns the EPSS score of the given Result, or -1 if the Result has no
+// EPSS score.
+func epss(r Result) float64 {
+	if r.EPSSScore == nil {
+		return -1
+	}
+	return *r.EPSSScore
+}
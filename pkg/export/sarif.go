@@ -0,0 +1,238 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Organization   string      `json:"organization,omitempty"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name,omitempty"`
+	ShortDescription     sarifMessage        `json:"shortDescription"`
+	FullDescription      *sarifMessage       `json:"fullDescription,omitempty"`
+	HelpURI              string              `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration  `json:"defaultConfiguration"`
+	Properties           sarifRuleProperties `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProperties struct {
+	Tags             []string `json:"tags"`
+	SecuritySeverity string   `json:"security-severity"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// securitySeverity maps severity levels to the security-severity scores
+// recognized by GitHub code scanning.
+var securitySeverity = map[v1alpha1.Severity]string{
+	v1alpha1.SeverityCritical: "9.5",
+	v1alpha1.SeverityHigh:     "8.0",
+	v1alpha1.SeverityMedium:   "5.5",
+	v1alpha1.SeverityLow:      "2.0",
+	v1alpha1.SeverityUnknown:  "0.0",
+}
+
+// WriteSARIF writes failed Results as a SARIF 2.1.0 log, which can be
+// uploaded to GitHub code scanning. Each distinct vulnerability or check is
+// reported as a rule, and each occurrence as a result located in the
+// container image or the Kubernetes resource.
+func WriteSARIF(out io.Writer, results Results) error {
+	if results.Kind == v1alpha1.SBOMReportKind {
+		return fmt.Errorf("%s output is not supported for %s", FormatSARIF, results.Kind)
+	}
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           results.Scanner.Name,
+				Organization:   results.Scanner.Vendor,
+				Version:        results.Scanner.Version,
+				InformationURI: "https://github.com/aquasecurity/starboard",
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+	ruleIndex := make(map[string]int)
+	for _, item := range results.Items {
+		if item.Status != StatusFail {
+			continue
+		}
+		index, ok := ruleIndex[item.ID]
+		if !ok {
+			index = len(run.Tool.Driver.Rules)
+			ruleIndex[item.ID] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSARIFRule(results.Kind, item))
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    item.ID,
+			RuleIndex: index,
+			Level:     sarifLevel(item.Severity),
+			Message:   sarifMessage{Text: sarifResultMessage(item)},
+			Locations: []sarifLocation{sarifResultLocation(item)},
+		})
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	})
+}
+
+func newSARIFRule(kind string, item Result) sarifRule {
+	rule := sarifRule{
+		ID:                   item.ID,
+		Name:                 kind,
+		ShortDescription:     sarifMessage{Text: item.Title},
+		HelpURI:              item.PrimaryLink,
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(item.Severity)},
+		Properties: sarifRuleProperties{
+			Tags:             []string{"security", string(item.Severity)},
+			SecuritySeverity: securitySeverity[item.Severity],
+		},
+	}
+	if rule.ShortDescription.Text == "" {
+		rule.ShortDescription.Text = item.ID
+	}
+	if item.Description != "" {
+		rule.FullDescription = &sarifMessage{Text: item.Description}
+	}
+	if item.Score != nil {
+		rule.Properties.SecuritySeverity = fmt.Sprintf("%.1f", *item.Score)
+	}
+	if rule.Properties.SecuritySeverity == "" {
+		rule.Properties.SecuritySeverity = securitySeverity[v1alpha1.SeverityUnknown]
+	}
+	return rule
+}
+
+func sarifLevel(severity v1alpha1.Severity) string {
+	switch severity {
+	case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh:
+		return "error"
+	case v1alpha1.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func sarifResultMessage(item Result) string {
+	var parts []string
+	if item.Package != "" {
+		parts = append(parts, fmt.Sprintf("Package: %s", item.Package))
+		parts = append(parts, fmt.Sprintf("Installed Version: %s", item.InstalledVersion))
+		if item.FixedVersion != "" {
+			parts = append(parts, fmt.Sprintf("Fixed Version: %s", item.FixedVersion))
+		}
+	}
+	if item.Image != "" {
+		parts = append(parts, fmt.Sprintf("Image: %s", item.Image))
+	}
+	if resource := item.Resource(); resource != "" {
+		parts = append(parts, fmt.Sprintf("Resource: %s", resource))
+	}
+	if item.Message != "" {
+		parts = append(parts, item.Message)
+	}
+	if len(parts) == 0 {
+		return item.Title
+	}
+	return strings.Join(parts, "\n")
+}
+
+// sarifResultLocation locates a result in the container image if known, or
+// otherwise in the Kubernetes resource, e.g. default/replicaset/nginx.
+func sarifResultLocation(item Result) sarifLocation {
+	uri := item.Image
+	if uri == "" {
+		uri = path.Join(item.ResourceNamespace, item.Resource())
+	}
+	location := sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: uri},
+			Region:           sarifRegion{StartLine: 1},
+		},
+	}
+	if item.ResourceName != "" {
+		location.LogicalLocations = []sarifLogicalLocation{{
+			Name:               item.ResourceName,
+			FullyQualifiedName: path.Join(item.ResourceNamespace, item.Resource()),
+			Kind:               item.ResourceKind,
+		}}
+	}
+	return location
+}
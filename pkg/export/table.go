@@ -0,0 +1,153 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+type column struct {
+	header string
+	value  func(r Result) string
+}
+
+var (
+	resourceColumn  = column{"RESOURCE", func(r Result) string { return r.Resource() }}
+	containerColumn = column{"CONTAINER", func(r Result) string { return r.Container }}
+	idColumn        = column{"ID", func(r Result) string { return r.ID }}
+	severityColumn  = column{"SEVERITY", func(r Result) string { return string(r.Severity) }}
+	statusColumn    = column{"STATUS", func(r Result) string { return string(r.Status) }}
+	titleColumn     = column{"TITLE", func(r Result) string { return r.Title }}
+	messageColumn   = column{"MESSAGE", func(r Result) string { return r.Message }}
+)
+
+var vulnerabilityColumns = []column{
+	resourceColumn,
+	containerColumn,
+	idColumn,
+	severityColumn,
+	{"KEV", func(r Result) string {
+		if r.KnownExploited {
+			return "yes"
+		}
+		return ""
+	}},
+	{"EPSS", func(r Result) string {
+		if r.EPSSScore == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*r.EPSSScore, 'f', -1, 64)
+	}},
+	{"PACKAGE", func(r Result) string { return r.Package }},
+	{"INSTALLED", func(r Result) string { return r.InstalledVersion }},
+	{"FIXED", func(r Result) string { return r.FixedVersion }},
+	titleColumn,
+}
+
+var columns = map[string][]column{
+	v1alpha1.VulnerabilityReportKind: vulnerabilityColumns,
+	ClusterVulnerabilityReportKind:   vulnerabilityColumns,
+	v1alpha1.ConfigAuditReportKind: {
+		resourceColumn,
+		idColumn,
+		severityColumn,
+		{"CATEGORY", func(r Result) string { return r.Category }},
+		statusColumn,
+		titleColumn,
+	},
+	ClusterComplianceReportKind: {
+		idColumn,
+		severityColumn,
+		statusColumn,
+		{"NAME", func(r Result) string { return r.Title }},
+		messageColumn,
+	},
+	ClusterComplianceDetailReportKind: {
+		idColumn,
+		{"NAMESPACE", func(r Result) string { return r.ResourceNamespace }},
+		resourceColumn,
+		severityColumn,
+		statusColumn,
+		messageColumn,
+	},
+	v1alpha1.SBOMReportKind: {
+		resourceColumn,
+		containerColumn,
+		{"NAME", func(r Result) string { return r.Package }},
+		{"VERSION", func(r Result) string { return r.InstalledVersion }},
+		{"TYPE", func(r Result) string { return r.Category }},
+		{"LICENSES", func(r Result) string { return r.Message }},
+		{"PURL", func(r Result) string { return r.ID }},
+	},
+}
+
+func columnsOf(results Results) ([]column, error) {
+	cols, ok := columns[results.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported report kind: %s", results.Kind)
+	}
+	return cols, nil
+}
+
+// WriteTable writes Results as a kubectl-style table with columns that
+// depend on the kind of security reports.
+func WriteTable(out io.Writer, results Results) error {
+	cols, err := columnsOf(results)
+	if err != nil {
+		return err
+	}
+	w := printers.GetNewTabWriter(out)
+	headers := make([]string, len(cols))
+	for i, col := range cols {
+		headers[i] = col.header
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(headers, "\t")); err != nil {
+		return err
+	}
+	for _, item := range results.Items {
+		values := make([]string, len(cols))
+		for i, col := range cols {
+			values[i] = col.value(item)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(values, "\t")); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// WriteCSV writes Results as comma-separated values with the same columns as
+// WriteTable preceded by the namespace and the name of the security report.
+func WriteCSV(out io.Writer, results Results) error {
+	cols, err := columnsOf(results)
+	if err != nil {
+		return err
+	}
+	cols = append([]column{
+		{"REPORT NAMESPACE", func(r Result) string { return r.Namespace }},
+		{"REPORT NAME", func(r Result) string { return r.Report }},
+	}, cols...)
+	w := csv.NewWriter(out)
+	record := make([]string, len(cols))
+	for i, col := range cols {
+		record[i] = col.header
+	}
+	if err := w.Write(record); err != nil {
+		return err
+	}
+	for _, item := range results.Items {
+		for i, col := range cols {
+			record[i] = col.value(item)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -19,6 +19,12 @@ type AquasecurityV1alpha1Interface interface {
 	ClusterVulnerabilityReportsGetter
 	ConfigAuditReportsGetter
 	KubeHunterReportsGetter
+	NamespaceSecuritySummariesGetter
+	ReportHistoriesGetter
+	SBOMReportsGetter
+	ScanFailuresGetter
+	ScanJobTemplatesGetter
+	VulnerabilityExceptionsGetter
 	VulnerabilityReportsGetter
 }
 
@@ -55,6 +61,30 @@ func (c *AquasecurityV1alpha1Client) KubeHunterReports() KubeHunterReportInterfa
 	return newKubeHunterReports(c)
 }
 
+func (c *AquasecurityV1alpha1Client) NamespaceSecuritySummaries(namespace string) NamespaceSecuritySummaryInterface {
+	return newNamespaceSecuritySummaries(c, namespace)
+}
+
+func (c *AquasecurityV1alpha1Client) ReportHistories(namespace string) ReportHistoryInterface {
+	return newReportHistories(c, namespace)
+}
+
+func (c *AquasecurityV1alpha1Client) SBOMReports(namespace string) SBOMReportInterface {
+	return newSBOMReports(c, namespace)
+}
+
+func (c *AquasecurityV1alpha1Client) ScanFailures(namespace string) ScanFailureInterface {
+	return newScanFailures(c, namespace)
+}
+
+func (c *AquasecurityV1alpha1Client) ScanJobTemplates() ScanJobTemplateInterface {
+	return newScanJobTemplates(c)
+}
+
+func (c *AquasecurityV1alpha1Client) VulnerabilityExceptions(namespace string) VulnerabilityExceptionInterface {
+	return newVulnerabilityExceptions(c, namespace)
+}
+
 func (c *AquasecurityV1alpha1Client) VulnerabilityReports(namespace string) VulnerabilityReportInterface {
 	return newVulnerabilityReports(c, namespace)
 }
@@ -40,6 +40,30 @@ func (c *FakeAquasecurityV1alpha1) KubeHunterReports() v1alpha1.KubeHunterReport
 	return &FakeKubeHunterReports{c}
 }
 
+func (c *FakeAquasecurityV1alpha1) NamespaceSecuritySummaries(namespace string) v1alpha1.NamespaceSecuritySummaryInterface {
+	return &FakeNamespaceSecuritySummaries{c, namespace}
+}
+
+func (c *FakeAquasecurityV1alpha1) ReportHistories(namespace string) v1alpha1.ReportHistoryInterface {
+	return &FakeReportHistories{c, namespace}
+}
+
+func (c *FakeAquasecurityV1alpha1) SBOMReports(namespace string) v1alpha1.SBOMReportInterface {
+	return &FakeSBOMReports{c, namespace}
+}
+
+func (c *FakeAquasecurityV1alpha1) ScanFailures(namespace string) v1alpha1.ScanFailureInterface {
+	return &FakeScanFailures{c, namespace}
+}
+
+func (c *FakeAquasecurityV1alpha1) ScanJobTemplates() v1alpha1.ScanJobTemplateInterface {
+	return &FakeScanJobTemplates{c}
+}
+
+func (c *FakeAquasecurityV1alpha1) VulnerabilityExceptions(namespace string) v1alpha1.VulnerabilityExceptionInterface {
+	return &FakeVulnerabilityExceptions{c, namespace}
+}
+
 func (c *FakeAquasecurityV1alpha1) VulnerabilityReports(namespace string) v1alpha1.VulnerabilityReportInterface {
 	return &FakeVulnerabilityReports{c, namespace}
 }
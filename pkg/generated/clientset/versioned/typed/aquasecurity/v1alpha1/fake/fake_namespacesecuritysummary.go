@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeNamespaceSecuritySummaries implements NamespaceSecuritySummaryInterface
+type FakeNamespaceSecuritySummaries struct {
+	Fake *FakeAquasecurityV1alpha1
+	ns   string
+}
+
+var namespacesecuritysummariesResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "namespacesecuritysummaries"}
+
+var namespacesecuritysummariesKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "NamespaceSecuritySummary"}
+
+// Get takes name of the namespaceSecuritySummary, and returns the corresponding namespaceSecuritySummary object, and an error if there is any.
+func (c *FakeNamespaceSecuritySummaries) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.NamespaceSecuritySummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(namespacesecuritysummariesResource, c.ns, name), &v1alpha1.NamespaceSecuritySummary{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespaceSecuritySummary), err
+}
+
+// List takes label and field selectors, and returns the list of NamespaceSecuritySummaries that match those selectors.
+func (c *FakeNamespaceSecuritySummaries) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NamespaceSecuritySummaryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(namespacesecuritysummariesResource, namespacesecuritysummariesKind, c.ns, opts), &v1alpha1.NamespaceSecuritySummaryList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.NamespaceSecuritySummaryList{ListMeta: obj.(*v1alpha1.NamespaceSecuritySummaryList).ListMeta}
+	for _, item := range obj.(*v1alpha1.NamespaceSecuritySummaryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested namespaceSecuritySummaries.
+func (c *FakeNamespaceSecuritySummaries) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(namespacesecuritysummariesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a namespaceSecuritySummary and creates it.  Returns the server's representation of the namespaceSecuritySummary, and an error, if there is any.
+func (c *FakeNamespaceSecuritySummaries) Create(ctx context.Context, namespaceSecuritySummary *v1alpha1.NamespaceSecuritySummary, opts v1.CreateOptions) (result *v1alpha1.NamespaceSecuritySummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(namespacesecuritysummariesResource, c.ns, namespaceSecuritySummary), &v1alpha1.NamespaceSecuritySummary{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespaceSecuritySummary), err
+}
+
+// Update takes the representation of a namespaceSecuritySummary and updates it. Returns the server's representation of the namespaceSecuritySummary, and an error, if there is any.
+func (c *FakeNamespaceSecuritySummaries) Update(ctx context.Context, namespaceSecuritySummary *v1alpha1.NamespaceSecuritySummary, opts v1.UpdateOptions) (result *v1alpha1.NamespaceSecuritySummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(namespacesecuritysummariesResource, c.ns, namespaceSecuritySummary), &v1alpha1.NamespaceSecuritySummary{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespaceSecuritySummary), err
+}
+
+// Delete takes name of the namespaceSecuritySummary and deletes it. Returns an error if one occurs.
+func (c *FakeNamespaceSecuritySummaries) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(namespacesecuritysummariesResource, c.ns, name, opts), &v1alpha1.NamespaceSecuritySummary{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNamespaceSecuritySummaries) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(namespacesecuritysummariesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.NamespaceSecuritySummaryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched namespaceSecuritySummary.
+func (c *FakeNamespaceSecuritySummaries) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NamespaceSecuritySummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(namespacesecuritysummariesResource, c.ns, name, pt, data, subresources...), &v1alpha1.NamespaceSecuritySummary{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespaceSecuritySummary), err
+}
@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeReportHistories implements ReportHistoryInterface
+type FakeReportHistories struct {
+	Fake *FakeAquasecurityV1alpha1
+	ns   string
+}
+
+var reporthistoriesResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "reporthistories"}
+
+var reporthistoriesKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "ReportHistory"}
+
+// Get takes name of the reportHistory, and returns the corresponding reportHistory object, and an error if there is any.
+func (c *FakeReportHistories) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ReportHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(reporthistoriesResource, c.ns, name), &v1alpha1.ReportHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ReportHistory), err
+}
+
+// List takes label and field selectors, and returns the list of ReportHistories that match those selectors.
+func (c *FakeReportHistories) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ReportHistoryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(reporthistoriesResource, reporthistoriesKind, c.ns, opts), &v1alpha1.ReportHistoryList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ReportHistoryList{ListMeta: obj.(*v1alpha1.ReportHistoryList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ReportHistoryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested reportHistories.
+func (c *FakeReportHistories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(reporthistoriesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a reportHistory and creates it.  Returns the server's representation of the reportHistory, and an error, if there is any.
+func (c *FakeReportHistories) Create(ctx context.Context, reportHistory *v1alpha1.ReportHistory, opts v1.CreateOptions) (result *v1alpha1.ReportHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(reporthistoriesResource, c.ns, reportHistory), &v1alpha1.ReportHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ReportHistory), err
+}
+
+// Update takes the representation of a reportHistory and updates it. Returns the server's representation of the reportHistory, and an error, if there is any.
+func (c *FakeReportHistories) Update(ctx context.Context, reportHistory *v1alpha1.ReportHistory, opts v1.UpdateOptions) (result *v1alpha1.ReportHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(reporthistoriesResource, c.ns, reportHistory), &v1alpha1.ReportHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ReportHistory), err
+}
+
+// Delete takes name of the reportHistory and deletes it. Returns an error if one occurs.
+func (c *FakeReportHistories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(reporthistoriesResource, c.ns, name, opts), &v1alpha1.ReportHistory{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeReportHistories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(reporthistoriesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ReportHistoryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched reportHistory.
+func (c *FakeReportHistories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ReportHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(reporthistoriesResource, c.ns, name, pt, data, subresources...), &v1alpha1.ReportHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ReportHistory), err
+}
@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeSBOMReports implements SBOMReportInterface
+type FakeSBOMReports struct {
+	Fake *FakeAquasecurityV1alpha1
+	ns   string
+}
+
+var sbomreportsResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "sbomreports"}
+
+var sbomreportsKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "SBOMReport"}
+
+// Get takes name of the sBOMReport, and returns the corresponding sBOMReport object, and an error if there is any.
+func (c *FakeSBOMReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.SBOMReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(sbomreportsResource, c.ns, name), &v1alpha1.SBOMReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SBOMReport), err
+}
+
+// List takes label and field selectors, and returns the list of SBOMReports that match those selectors.
+func (c *FakeSBOMReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.SBOMReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(sbomreportsResource, sbomreportsKind, c.ns, opts), &v1alpha1.SBOMReportList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.SBOMReportList{ListMeta: obj.(*v1alpha1.SBOMReportList).ListMeta}
+	for _, item := range obj.(*v1alpha1.SBOMReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested sBOMReports.
+func (c *FakeSBOMReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(sbomreportsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a sBOMReport and creates it.  Returns the server's representation of the sBOMReport, and an error, if there is any.
+func (c *FakeSBOMReports) Create(ctx context.Context, sBOMReport *v1alpha1.SBOMReport, opts v1.CreateOptions) (result *v1alpha1.SBOMReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(sbomreportsResource, c.ns, sBOMReport), &v1alpha1.SBOMReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SBOMReport), err
+}
+
+// Update takes the representation of a sBOMReport and updates it. Returns the server's representation of the sBOMReport, and an error, if there is any.
+func (c *FakeSBOMReports) Update(ctx context.Context, sBOMReport *v1alpha1.SBOMReport, opts v1.UpdateOptions) (result *v1alpha1.SBOMReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(sbomreportsResource, c.ns, sBOMReport), &v1alpha1.SBOMReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SBOMReport), err
+}
+
+// Delete takes name of the sBOMReport and deletes it. Returns an error if one occurs.
+func (c *FakeSBOMReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(sbomreportsResource, c.ns, name, opts), &v1alpha1.SBOMReport{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeSBOMReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(sbomreportsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.SBOMReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched sBOMReport.
+func (c *FakeSBOMReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.SBOMReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(sbomreportsResource, c.ns, name, pt, data, subresources...), &v1alpha1.SBOMReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SBOMReport), err
+}
@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeScanFailures implements ScanFailureInterface
+type FakeScanFailures struct {
+	Fake *FakeAquasecurityV1alpha1
+	ns   string
+}
+
+var scanfailuresResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "scanfailures"}
+
+var scanfailuresKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "ScanFailure"}
+
+// Get takes name of the scanFailure, and returns the corresponding scanFailure object, and an error if there is any.
+func (c *FakeScanFailures) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ScanFailure, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(scanfailuresResource, c.ns, name), &v1alpha1.ScanFailure{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScanFailure), err
+}
+
+// List takes label and field selectors, and returns the list of ScanFailures that match those selectors.
+func (c *FakeScanFailures) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ScanFailureList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(scanfailuresResource, scanfailuresKind, c.ns, opts), &v1alpha1.ScanFailureList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ScanFailureList{ListMeta: obj.(*v1alpha1.ScanFailureList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ScanFailureList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested scanFailures.
+func (c *FakeScanFailures) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(scanfailuresResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a scanFailure and creates it.  Returns the server's representation of the scanFailure, and an error, if there is any.
+func (c *FakeScanFailures) Create(ctx context.Context, scanFailure *v1alpha1.ScanFailure, opts v1.CreateOptions) (result *v1alpha1.ScanFailure, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(scanfailuresResource, c.ns, scanFailure), &v1alpha1.ScanFailure{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScanFailure), err
+}
+
+// Update takes the representation of a scanFailure and updates it. Returns the server's representation of the scanFailure, and an error, if there is any.
+func (c *FakeScanFailures) Update(ctx context.Context, scanFailure *v1alpha1.ScanFailure, opts v1.UpdateOptions) (result *v1alpha1.ScanFailure, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(scanfailuresResource, c.ns, scanFailure), &v1alpha1.ScanFailure{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScanFailure), err
+}
+
+// Delete takes name of the scanFailure and deletes it. Returns an error if one occurs.
+func (c *FakeScanFailures) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(scanfailuresResource, c.ns, name, opts), &v1alpha1.ScanFailure{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeScanFailures) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(scanfailuresResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ScanFailureList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched scanFailure.
+func (c *FakeScanFailures) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ScanFailure, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(scanfailuresResource, c.ns, name, pt, data, subresources...), &v1alpha1.ScanFailure{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScanFailure), err
+}
@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeScanJobTemplates implements ScanJobTemplateInterface
+type FakeScanJobTemplates struct {
+	Fake *FakeAquasecurityV1alpha1
+}
+
+var scanjobtemplatesResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "scanjobtemplates"}
+
+var scanjobtemplatesKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "ScanJobTemplate"}
+
+// Get takes name of the scanJobTemplate, and returns the corresponding scanJobTemplate object, and an error if there is any.
+func (c *FakeScanJobTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ScanJobTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(scanjobtemplatesResource, name), &v1alpha1.ScanJobTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScanJobTemplate), err
+}
+
+// List takes label and field selectors, and returns the list of ScanJobTemplates that match those selectors.
+func (c *FakeScanJobTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ScanJobTemplateList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(scanjobtemplatesResource, scanjobtemplatesKind, opts), &v1alpha1.ScanJobTemplateList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ScanJobTemplateList{ListMeta: obj.(*v1alpha1.ScanJobTemplateList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ScanJobTemplateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested scanJobTemplates.
+func (c *FakeScanJobTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(scanjobtemplatesResource, opts))
+}
+
+// Create takes the representation of a scanJobTemplate and creates it.  Returns the server's representation of the scanJobTemplate, and an error, if there is any.
+func (c *FakeScanJobTemplates) Create(ctx context.Context, scanJobTemplate *v1alpha1.ScanJobTemplate, opts v1.CreateOptions) (result *v1alpha1.ScanJobTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(scanjobtemplatesResource, scanJobTemplate), &v1alpha1.ScanJobTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScanJobTemplate), err
+}
+
+// Update takes the representation of a scanJobTemplate and updates it. Returns the server's representation of the scanJobTemplate, and an error, if there is any.
+func (c *FakeScanJobTemplates) Update(ctx context.Context, scanJobTemplate *v1alpha1.ScanJobTemplate, opts v1.UpdateOptions) (result *v1alpha1.ScanJobTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(scanjobtemplatesResource, scanJobTemplate), &v1alpha1.ScanJobTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScanJobTemplate), err
+}
+
+// Delete takes name of the scanJobTemplate and deletes it. Returns an error if one occurs.
+func (c *FakeScanJobTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(scanjobtemplatesResource, name, opts), &v1alpha1.ScanJobTemplate{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeScanJobTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(scanjobtemplatesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ScanJobTemplateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched scanJobTemplate.
+func (c *FakeScanJobTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ScanJobTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(scanjobtemplatesResource, name, pt, data, subresources...), &v1alpha1.ScanJobTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScanJobTemplate), err
+}
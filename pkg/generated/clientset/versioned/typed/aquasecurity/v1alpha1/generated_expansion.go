@@ -16,4 +16,16 @@ type ConfigAuditReportExpansion interface{}
 
 type KubeHunterReportExpansion interface{}
 
+type NamespaceSecuritySummaryExpansion interface{}
+
+type ReportHistoryExpansion interface{}
+
+type SBOMReportExpansion interface{}
+
+type ScanFailureExpansion interface{}
+
+type ScanJobTemplateExpansion interface{}
+
+type VulnerabilityExceptionExpansion interface{}
+
 type VulnerabilityReportExpansion interface{}
@@ -0,0 +1,162 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	scheme "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// NamespaceSecuritySummariesGetter has a method to return a NamespaceSecuritySummaryInterface.
+// A group's client should implement this interface.
+type NamespaceSecuritySummariesGetter interface {
+	NamespaceSecuritySummaries(namespace string) NamespaceSecuritySummaryInterface
+}
+
+// NamespaceSecuritySummaryInterface has methods to work with NamespaceSecuritySummary resources.
+type NamespaceSecuritySummaryInterface interface {
+	Create(ctx context.Context, namespaceSecuritySummary *v1alpha1.NamespaceSecuritySummary, opts v1.CreateOptions) (*v1alpha1.NamespaceSecuritySummary, error)
+	Update(ctx context.Context, namespaceSecuritySummary *v1alpha1.NamespaceSecuritySummary, opts v1.UpdateOptions) (*v1alpha1.NamespaceSecuritySummary, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.NamespaceSecuritySummary, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.NamespaceSecuritySummaryList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NamespaceSecuritySummary, err error)
+	NamespaceSecuritySummaryExpansion
+}
+
+// namespaceSecuritySummaries implements NamespaceSecuritySummaryInterface
+type namespaceSecuritySummaries struct {
+	client rest.Interface
+	ns     string
+}
+
+// newNamespaceSecuritySummaries returns a NamespaceSecuritySummaries
+func newNamespaceSecuritySummaries(c *AquasecurityV1alpha1Client, namespace string) *namespaceSecuritySummaries {
+	return &namespaceSecuritySummaries{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the namespaceSecuritySummary, and returns the corresponding namespaceSecuritySummary object, and an error if there is any.
+func (c *namespaceSecuritySummaries) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.NamespaceSecuritySummary, err error) {
+	result = &v1alpha1.NamespaceSecuritySummary{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("namespacesecuritysummaries").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of NamespaceSecuritySummaries that match those selectors.
+func (c *namespaceSecuritySummaries) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NamespaceSecuritySummaryList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.NamespaceSecuritySummaryList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("namespacesecuritysummaries").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested namespaceSecuritySummaries.
+func (c *namespaceSecuritySummaries) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("namespacesecuritysummaries").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a namespaceSecuritySummary and creates it.  Returns the server's representation of the namespaceSecuritySummary, and an error, if there is any.
+func (c *namespaceSecuritySummaries) Create(ctx context.Context, namespaceSecuritySummary *v1alpha1.NamespaceSecuritySummary, opts v1.CreateOptions) (result *v1alpha1.NamespaceSecuritySummary, err error) {
+	result = &v1alpha1.NamespaceSecuritySummary{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("namespacesecuritysummaries").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespaceSecuritySummary).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a namespaceSecuritySummary and updates it. Returns the server's representation of the namespaceSecuritySummary, and an error, if there is any.
+func (c *namespaceSecuritySummaries) Update(ctx context.Context, namespaceSecuritySummary *v1alpha1.NamespaceSecuritySummary, opts v1.UpdateOptions) (result *v1alpha1.NamespaceSecuritySummary, err error) {
+	result = &v1alpha1.NamespaceSecuritySummary{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("namespacesecuritysummaries").
+		Name(namespaceSecuritySummary.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespaceSecuritySummary).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the namespaceSecuritySummary and deletes it. Returns an error if one occurs.
+func (c *namespaceSecuritySummaries) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("namespacesecuritysummaries").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *namespaceSecuritySummaries) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("namespacesecuritysummaries").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched namespaceSecuritySummary.
+func (c *namespaceSecuritySummaries) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NamespaceSecuritySummary, err error) {
+	result = &v1alpha1.NamespaceSecuritySummary{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("namespacesecuritysummaries").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
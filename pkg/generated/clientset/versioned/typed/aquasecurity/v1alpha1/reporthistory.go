@@ -0,0 +1,162 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	scheme "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ReportHistoriesGetter has a method to return a ReportHistoryInterface.
+// A group's client should implement this interface.
+type ReportHistoriesGetter interface {
+	ReportHistories(namespace string) ReportHistoryInterface
+}
+
+// ReportHistoryInterface has methods to work with ReportHistory resources.
+type ReportHistoryInterface interface {
+	Create(ctx context.Context, reportHistory *v1alpha1.ReportHistory, opts v1.CreateOptions) (*v1alpha1.ReportHistory, error)
+	Update(ctx context.Context, reportHistory *v1alpha1.ReportHistory, opts v1.UpdateOptions) (*v1alpha1.ReportHistory, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ReportHistory, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ReportHistoryList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ReportHistory, err error)
+	ReportHistoryExpansion
+}
+
+// reportHistories implements ReportHistoryInterface
+type reportHistories struct {
+	client rest.Interface
+	ns     string
+}
+
+// newReportHistories returns a ReportHistories
+func newReportHistories(c *AquasecurityV1alpha1Client, namespace string) *reportHistories {
+	return &reportHistories{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the reportHistory, and returns the corresponding reportHistory object, and an error if there is any.
+func (c *reportHistories) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ReportHistory, err error) {
+	result = &v1alpha1.ReportHistory{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("reporthistories").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ReportHistories that match those selectors.
+func (c *reportHistories) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ReportHistoryList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ReportHistoryList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("reporthistories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested reportHistories.
+func (c *reportHistories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("reporthistories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a reportHistory and creates it.  Returns the server's representation of the reportHistory, and an error, if there is any.
+func (c *reportHistories) Create(ctx context.Context, reportHistory *v1alpha1.ReportHistory, opts v1.CreateOptions) (result *v1alpha1.ReportHistory, err error) {
+	result = &v1alpha1.ReportHistory{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("reporthistories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(reportHistory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a reportHistory and updates it. Returns the server's representation of the reportHistory, and an error, if there is any.
+func (c *reportHistories) Update(ctx context.Context, reportHistory *v1alpha1.ReportHistory, opts v1.UpdateOptions) (result *v1alpha1.ReportHistory, err error) {
+	result = &v1alpha1.ReportHistory{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("reporthistories").
+		Name(reportHistory.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(reportHistory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the reportHistory and deletes it. Returns an error if one occurs.
+func (c *reportHistories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("reporthistories").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *reportHistories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("reporthistories").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched reportHistory.
+func (c *reportHistories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ReportHistory, err error) {
+	result = &v1alpha1.ReportHistory{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("reporthistories").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
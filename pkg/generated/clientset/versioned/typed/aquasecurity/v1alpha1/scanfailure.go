@@ -0,0 +1,162 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	scheme "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ScanFailuresGetter has a method to return a ScanFailureInterface.
+// A group's client should implement this interface.
+type ScanFailuresGetter interface {
+	ScanFailures(namespace string) ScanFailureInterface
+}
+
+// ScanFailureInterface has methods to work with ScanFailure resources.
+type ScanFailureInterface interface {
+	Create(ctx context.Context, scanFailure *v1alpha1.ScanFailure, opts v1.CreateOptions) (*v1alpha1.ScanFailure, error)
+	Update(ctx context.Context, scanFailure *v1alpha1.ScanFailure, opts v1.UpdateOptions) (*v1alpha1.ScanFailure, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ScanFailure, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ScanFailureList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ScanFailure, err error)
+	ScanFailureExpansion
+}
+
+// scanFailures implements ScanFailureInterface
+type scanFailures struct {
+	client rest.Interface
+	ns     string
+}
+
+// newScanFailures returns a ScanFailures
+func newScanFailures(c *AquasecurityV1alpha1Client, namespace string) *scanFailures {
+	return &scanFailures{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the scanFailure, and returns the corresponding scanFailure object, and an error if there is any.
+func (c *scanFailures) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ScanFailure, err error) {
+	result = &v1alpha1.ScanFailure{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("scanfailures").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ScanFailures that match those selectors.
+func (c *scanFailures) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ScanFailureList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ScanFailureList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("scanfailures").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested scanFailures.
+func (c *scanFailures) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("scanfailures").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a scanFailure and creates it.  Returns the server's representation of the scanFailure, and an error, if there is any.
+func (c *scanFailures) Create(ctx context.Context, scanFailure *v1alpha1.ScanFailure, opts v1.CreateOptions) (result *v1alpha1.ScanFailure, err error) {
+	result = &v1alpha1.ScanFailure{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("scanfailures").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(scanFailure).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a scanFailure and updates it. Returns the server's representation of the scanFailure, and an error, if there is any.
+func (c *scanFailures) Update(ctx context.Context, scanFailure *v1alpha1.ScanFailure, opts v1.UpdateOptions) (result *v1alpha1.ScanFailure, err error) {
+	result = &v1alpha1.ScanFailure{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("scanfailures").
+		Name(scanFailure.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(scanFailure).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the scanFailure and deletes it. Returns an error if one occurs.
+func (c *scanFailures) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("scanfailures").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *scanFailures) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("scanfailures").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched scanFailure.
+func (c *scanFailures) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ScanFailure, err error) {
+	result = &v1alpha1.ScanFailure{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("scanfailures").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	scheme "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ScanJobTemplatesGetter has a method to return a ScanJobTemplateInterface.
+// A group's client should implement this interface.
+type ScanJobTemplatesGetter interface {
+	ScanJobTemplates() ScanJobTemplateInterface
+}
+
+// ScanJobTemplateInterface has methods to work with ScanJobTemplate resources.
+type ScanJobTemplateInterface interface {
+	Create(ctx context.Context, scanJobTemplate *v1alpha1.ScanJobTemplate, opts v1.CreateOptions) (*v1alpha1.ScanJobTemplate, error)
+	Update(ctx context.Context, scanJobTemplate *v1alpha1.ScanJobTemplate, opts v1.UpdateOptions) (*v1alpha1.ScanJobTemplate, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ScanJobTemplate, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ScanJobTemplateList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ScanJobTemplate, err error)
+	ScanJobTemplateExpansion
+}
+
+// scanJobTemplates implements ScanJobTemplateInterface
+type scanJobTemplates struct {
+	client rest.Interface
+}
+
+// newScanJobTemplates returns a ScanJobTemplates
+func newScanJobTemplates(c *AquasecurityV1alpha1Client) *scanJobTemplates {
+	return &scanJobTemplates{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the scanJobTemplate, and returns the corresponding scanJobTemplate object, and an error if there is any.
+func (c *scanJobTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ScanJobTemplate, err error) {
+	result = &v1alpha1.ScanJobTemplate{}
+	err = c.client.Get().
+		Resource("scanjobtemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ScanJobTemplates that match those selectors.
+func (c *scanJobTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ScanJobTemplateList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ScanJobTemplateList{}
+	err = c.client.Get().
+		Resource("scanjobtemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested scanJobTemplates.
+func (c *scanJobTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("scanjobtemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a scanJobTemplate and creates it.  Returns the server's representation of the scanJobTemplate, and an error, if there is any.
+func (c *scanJobTemplates) Create(ctx context.Context, scanJobTemplate *v1alpha1.ScanJobTemplate, opts v1.CreateOptions) (result *v1alpha1.ScanJobTemplate, err error) {
+	result = &v1alpha1.ScanJobTemplate{}
+	err = c.client.Post().
+		Resource("scanjobtemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(scanJobTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a scanJobTemplate and updates it. Returns the server's representation of the scanJobTemplate, and an error, if there is any.
+func (c *scanJobTemplates) Update(ctx context.Context, scanJobTemplate *v1alpha1.ScanJobTemplate, opts v1.UpdateOptions) (result *v1alpha1.ScanJobTemplate, err error) {
+	result = &v1alpha1.ScanJobTemplate{}
+	err = c.client.Put().
+		Resource("scanjobtemplates").
+		Name(scanJobTemplate.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(scanJobTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the scanJobTemplate and deletes it. Returns an error if one occurs.
+func (c *scanJobTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("scanjobtemplates").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *scanJobTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("scanjobtemplates").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched scanJobTemplate.
+func (c *scanJobTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ScanJobTemplate, err error) {
+	result = &v1alpha1.ScanJobTemplate{}
+	err = c.client.Patch(pt).
+		Resource("scanjobtemplates").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -22,6 +22,18 @@ type Interface interface {
 	ConfigAuditReports() ConfigAuditReportInformer
 	// KubeHunterReports returns a KubeHunterReportInformer.
 	KubeHunterReports() KubeHunterReportInformer
+	// NamespaceSecuritySummaries returns a NamespaceSecuritySummaryInformer.
+	NamespaceSecuritySummaries() NamespaceSecuritySummaryInformer
+	// ReportHistories returns a ReportHistoryInformer.
+	ReportHistories() ReportHistoryInformer
+	// SBOMReports returns a SBOMReportInformer.
+	SBOMReports() SBOMReportInformer
+	// ScanFailures returns a ScanFailureInformer.
+	ScanFailures() ScanFailureInformer
+	// ScanJobTemplates returns a ScanJobTemplateInformer.
+	ScanJobTemplates() ScanJobTemplateInformer
+	// VulnerabilityExceptions returns a VulnerabilityExceptionInformer.
+	VulnerabilityExceptions() VulnerabilityExceptionInformer
 	// VulnerabilityReports returns a VulnerabilityReportInformer.
 	VulnerabilityReports() VulnerabilityReportInformer
 }
@@ -72,6 +84,36 @@ func (v *version) KubeHunterReports() KubeHunterReportInformer {
 	return &kubeHunterReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// NamespaceSecuritySummaries returns a NamespaceSecuritySummaryInformer.
+func (v *version) NamespaceSecuritySummaries() NamespaceSecuritySummaryInformer {
+	return &namespaceSecuritySummaryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ReportHistories returns a ReportHistoryInformer.
+func (v *version) ReportHistories() ReportHistoryInformer {
+	return &reportHistoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// SBOMReports returns a SBOMReportInformer.
+func (v *version) SBOMReports() SBOMReportInformer {
+	return &sBOMReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ScanFailures returns a ScanFailureInformer.
+func (v *version) ScanFailures() ScanFailureInformer {
+	return &scanFailureInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ScanJobTemplates returns a ScanJobTemplateInformer.
+func (v *version) ScanJobTemplates() ScanJobTemplateInformer {
+	return &scanJobTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// VulnerabilityExceptions returns a VulnerabilityExceptionInformer.
+func (v *version) VulnerabilityExceptions() VulnerabilityExceptionInformer {
+	return &vulnerabilityExceptionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VulnerabilityReports returns a VulnerabilityReportInformer.
 func (v *version) VulnerabilityReports() VulnerabilityReportInformer {
 	return &vulnerabilityReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
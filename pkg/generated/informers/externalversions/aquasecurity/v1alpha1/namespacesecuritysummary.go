@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	aquasecurityv1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	versioned "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/aquasecurity/starboard/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/generated/listers/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NamespaceSecuritySummaryInformer provides access to a shared informer and lister for
+// NamespaceSecuritySummaries.
+type NamespaceSecuritySummaryInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.NamespaceSecuritySummaryLister
+}
+
+type namespaceSecuritySummaryInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewNamespaceSecuritySummaryInformer constructs a new informer for NamespaceSecuritySummary type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewNamespaceSecuritySummaryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredNamespaceSecuritySummaryInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredNamespaceSecuritySummaryInformer constructs a new informer for NamespaceSecuritySummary type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredNamespaceSecuritySummaryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().NamespaceSecuritySummaries(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().NamespaceSecuritySummaries(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&aquasecurityv1alpha1.NamespaceSecuritySummary{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *namespaceSecuritySummaryInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredNamespaceSecuritySummaryInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *namespaceSecuritySummaryInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&aquasecurityv1alpha1.NamespaceSecuritySummary{}, f.defaultInformer)
+}
+
+func (f *namespaceSecuritySummaryInformer) Lister() v1alpha1.NamespaceSecuritySummaryLister {
+	return v1alpha1.NewNamespaceSecuritySummaryLister(f.Informer().GetIndexer())
+}
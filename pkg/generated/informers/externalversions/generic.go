@@ -51,6 +51,18 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ConfigAuditReports().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("kubehunterreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().KubeHunterReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("namespacesecuritysummaries"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().NamespaceSecuritySummaries().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("reporthistories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ReportHistories().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("sbomreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().SBOMReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("scanfailures"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ScanFailures().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("scanjobtemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ScanJobTemplates().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("vulnerabilityexceptions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().VulnerabilityExceptions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("vulnerabilityreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().VulnerabilityReports().Informer()}, nil
 
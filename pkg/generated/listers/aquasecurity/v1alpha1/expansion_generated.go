@@ -42,6 +42,50 @@ type ConfigAuditReportNamespaceListerExpansion interface{}
 // KubeHunterReportLister.
 type KubeHunterReportListerExpansion interface{}
 
+// NamespaceSecuritySummaryListerExpansion allows custom methods to be added to
+// NamespaceSecuritySummaryLister.
+type NamespaceSecuritySummaryListerExpansion interface{}
+
+// NamespaceSecuritySummaryNamespaceListerExpansion allows custom methods to be added to
+// NamespaceSecuritySummaryNamespaceLister.
+type NamespaceSecuritySummaryNamespaceListerExpansion interface{}
+
+// ReportHistoryListerExpansion allows custom methods to be added to
+// ReportHistoryLister.
+type ReportHistoryListerExpansion interface{}
+
+// ReportHistoryNamespaceListerExpansion allows custom methods to be added to
+// ReportHistoryNamespaceLister.
+type ReportHistoryNamespaceListerExpansion interface{}
+
+// SBOMReportListerExpansion allows custom methods to be added to
+// SBOMReportLister.
+type SBOMReportListerExpansion interface{}
+
+// SBOMReportNamespaceListerExpansion allows custom methods to be added to
+// SBOMReportNamespaceLister.
+type SBOMReportNamespaceListerExpansion interface{}
+
+// ScanFailureListerExpansion allows custom methods to be added to
+// ScanFailureLister.
+type ScanFailureListerExpansion interface{}
+
+// ScanFailureNamespaceListerExpansion allows custom methods to be added to
+// ScanFailureNamespaceLister.
+type ScanFailureNamespaceListerExpansion interface{}
+
+// ScanJobTemplateListerExpansion allows custom methods to be added to
+// ScanJobTemplateLister.
+type ScanJobTemplateListerExpansion interface{}
+
+// VulnerabilityExceptionListerExpansion allows custom methods to be added to
+// VulnerabilityExceptionLister.
+type VulnerabilityExceptionListerExpansion interface{}
+
+// VulnerabilityExceptionNamespaceListerExpansion allows custom methods to be added to
+// VulnerabilityExceptionNamespaceLister.
+type VulnerabilityExceptionNamespaceListerExpansion interface{}
+
 // VulnerabilityReportListerExpansion allows custom methods to be added to
 // VulnerabilityReportLister.
 type VulnerabilityReportListerExpansion interface{}
@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NamespaceSecuritySummaryLister helps list NamespaceSecuritySummaries.
+// All objects returned here must be treated as read-only.
+type NamespaceSecuritySummaryLister interface {
+	// List lists all NamespaceSecuritySummaries in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.NamespaceSecuritySummary, err error)
+	// NamespaceSecuritySummaries returns an object that can list and get NamespaceSecuritySummaries.
+	NamespaceSecuritySummaries(namespace string) NamespaceSecuritySummaryNamespaceLister
+	NamespaceSecuritySummaryListerExpansion
+}
+
+// namespaceSecuritySummaryLister implements the NamespaceSecuritySummaryLister interface.
+type namespaceSecuritySummaryLister struct {
+	indexer cache.Indexer
+}
+
+// NewNamespaceSecuritySummaryLister returns a new NamespaceSecuritySummaryLister.
+func NewNamespaceSecuritySummaryLister(indexer cache.Indexer) NamespaceSecuritySummaryLister {
+	return &namespaceSecuritySummaryLister{indexer: indexer}
+}
+
+// List lists all NamespaceSecuritySummaries in the indexer.
+func (s *namespaceSecuritySummaryLister) List(selector labels.Selector) (ret []*v1alpha1.NamespaceSecuritySummary, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.NamespaceSecuritySummary))
+	})
+	return ret, err
+}
+
+// NamespaceSecuritySummaries returns an object that can list and get NamespaceSecuritySummaries.
+func (s *namespaceSecuritySummaryLister) NamespaceSecuritySummaries(namespace string) NamespaceSecuritySummaryNamespaceLister {
+	return namespaceSecuritySummaryNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// NamespaceSecuritySummaryNamespaceLister helps list and get NamespaceSecuritySummaries.
+// All objects returned here must be treated as read-only.
+type NamespaceSecuritySummaryNamespaceLister interface {
+	// List lists all NamespaceSecuritySummaries in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.NamespaceSecuritySummary, err error)
+	// Get retrieves the NamespaceSecuritySummary from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.NamespaceSecuritySummary, error)
+	NamespaceSecuritySummaryNamespaceListerExpansion
+}
+
+// namespaceSecuritySummaryNamespaceLister implements the NamespaceSecuritySummaryNamespaceLister
+// interface.
+type namespaceSecuritySummaryNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all NamespaceSecuritySummaries in the indexer for a given namespace.
+func (s namespaceSecuritySummaryNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.NamespaceSecuritySummary, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.NamespaceSecuritySummary))
+	})
+	return ret, err
+}
+
+// Get retrieves the NamespaceSecuritySummary from the indexer for a given namespace and name.
+func (s namespaceSecuritySummaryNamespaceLister) Get(name string) (*v1alpha1.NamespaceSecuritySummary, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("namespacesecuritysummary"), name)
+	}
+	return obj.(*v1alpha1.NamespaceSecuritySummary), nil
+}
@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ReportHistoryLister helps list ReportHistories.
+// All objects returned here must be treated as read-only.
+type ReportHistoryLister interface {
+	// List lists all ReportHistories in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ReportHistory, err error)
+	// ReportHistories returns an object that can list and get ReportHistories.
+	ReportHistories(namespace string) ReportHistoryNamespaceLister
+	ReportHistoryListerExpansion
+}
+
+// reportHistoryLister implements the ReportHistoryLister interface.
+type reportHistoryLister struct {
+	indexer cache.Indexer
+}
+
+// NewReportHistoryLister returns a new ReportHistoryLister.
+func NewReportHistoryLister(indexer cache.Indexer) ReportHistoryLister {
+	return &reportHistoryLister{indexer: indexer}
+}
+
+// List lists all ReportHistories in the indexer.
+func (s *reportHistoryLister) List(selector labels.Selector) (ret []*v1alpha1.ReportHistory, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ReportHistory))
+	})
+	return ret, err
+}
+
+// ReportHistories returns an object that can list and get ReportHistories.
+func (s *reportHistoryLister) ReportHistories(namespace string) ReportHistoryNamespaceLister {
+	return reportHistoryNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ReportHistoryNamespaceLister helps list and get ReportHistories.
+// All objects returned here must be treated as read-only.
+type ReportHistoryNamespaceLister interface {
+	// List lists all ReportHistories in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ReportHistory, err error)
+	// Get retrieves the ReportHistory from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ReportHistory, error)
+	ReportHistoryNamespaceListerExpansion
+}
+
+// reportHistoryNamespaceLister implements the ReportHistoryNamespaceLister
+// interface.
+type reportHistoryNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ReportHistories in the indexer for a given namespace.
+func (s reportHistoryNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.ReportHistory, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ReportHistory))
+	})
+	return ret, err
+}
+
+// Get retrieves the ReportHistory from the indexer for a given namespace and name.
+func (s reportHistoryNamespaceLister) Get(name string) (*v1alpha1.ReportHistory, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("reporthistory"), name)
+	}
+	return obj.(*v1alpha1.ReportHistory), nil
+}
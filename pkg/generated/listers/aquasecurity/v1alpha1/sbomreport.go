@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SBOMReportLister helps list SBOMReports.
+// All objects returned here must be treated as read-only.
+type SBOMReportLister interface {
+	// List lists all SBOMReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.SBOMReport, err error)
+	// SBOMReports returns an object that can list and get SBOMReports.
+	SBOMReports(namespace string) SBOMReportNamespaceLister
+	SBOMReportListerExpansion
+}
+
+// sBOMReportLister implements the SBOMReportLister interface.
+type sBOMReportLister struct {
+	indexer cache.Indexer
+}
+
+// NewSBOMReportLister returns a new SBOMReportLister.
+func NewSBOMReportLister(indexer cache.Indexer) SBOMReportLister {
+	return &sBOMReportLister{indexer: indexer}
+}
+
+// List lists all SBOMReports in the indexer.
+func (s *sBOMReportLister) List(selector labels.Selector) (ret []*v1alpha1.SBOMReport, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.SBOMReport))
+	})
+	return ret, err
+}
+
+// SBOMReports returns an object that can list and get SBOMReports.
+func (s *sBOMReportLister) SBOMReports(namespace string) SBOMReportNamespaceLister {
+	return sBOMReportNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// SBOMReportNamespaceLister helps list and get SBOMReports.
+// All objects returned here must be treated as read-only.
+type SBOMReportNamespaceLister interface {
+	// List lists all SBOMReports in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.SBOMReport, err error)
+	// Get retrieves the SBOMReport from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.SBOMReport, error)
+	SBOMReportNamespaceListerExpansion
+}
+
+// sBOMReportNamespaceLister implements the SBOMReportNamespaceLister
+// interface.
+type sBOMReportNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all SBOMReports in the indexer for a given namespace.
+func (s sBOMReportNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.SBOMReport, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.SBOMReport))
+	})
+	return ret, err
+}
+
+// Get retrieves the SBOMReport from the indexer for a given namespace and name.
+func (s sBOMReportNamespaceLister) Get(name string) (*v1alpha1.SBOMReport, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("sbomreport"), name)
+	}
+	return obj.(*v1alpha1.SBOMReport), nil
+}
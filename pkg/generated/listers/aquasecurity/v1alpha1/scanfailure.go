@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ScanFailureLister helps list ScanFailures.
+// All objects returned here must be treated as read-only.
+type ScanFailureLister interface {
+	// List lists all ScanFailures in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ScanFailure, err error)
+	// ScanFailures returns an object that can list and get ScanFailures.
+	ScanFailures(namespace string) ScanFailureNamespaceLister
+	ScanFailureListerExpansion
+}
+
+// scanFailureLister implements the ScanFailureLister interface.
+type scanFailureLister struct {
+	indexer cache.Indexer
+}
+
+// NewScanFailureLister returns a new ScanFailureLister.
+func NewScanFailureLister(indexer cache.Indexer) ScanFailureLister {
+	return &scanFailureLister{indexer: indexer}
+}
+
+// List lists all ScanFailures in the indexer.
+func (s *scanFailureLister) List(selector labels.Selector) (ret []*v1alpha1.ScanFailure, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ScanFailure))
+	})
+	return ret, err
+}
+
+// ScanFailures returns an object that can list and get ScanFailures.
+func (s *scanFailureLister) ScanFailures(namespace string) ScanFailureNamespaceLister {
+	return scanFailureNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ScanFailureNamespaceLister helps list and get ScanFailures.
+// All objects returned here must be treated as read-only.
+type ScanFailureNamespaceLister interface {
+	// List lists all ScanFailures in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ScanFailure, err error)
+	// Get retrieves the ScanFailure from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ScanFailure, error)
+	ScanFailureNamespaceListerExpansion
+}
+
+// scanFailureNamespaceLister implements the ScanFailureNamespaceLister
+// interface.
+type scanFailureNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ScanFailures in the indexer for a given namespace.
+func (s scanFailureNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.ScanFailure, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ScanFailure))
+	})
+	return ret, err
+}
+
+// Get retrieves the ScanFailure from the indexer for a given namespace and name.
+func (s scanFailureNamespaceLister) Get(name string) (*v1alpha1.ScanFailure, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("scanfailure"), name)
+	}
+	return obj.(*v1alpha1.ScanFailure), nil
+}
@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ScanJobTemplateLister helps list ScanJobTemplates.
+// All objects returned here must be treated as read-only.
+type ScanJobTemplateLister interface {
+	// List lists all ScanJobTemplates in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ScanJobTemplate, err error)
+	// Get retrieves the ScanJobTemplate from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ScanJobTemplate, error)
+	ScanJobTemplateListerExpansion
+}
+
+// scanJobTemplateLister implements the ScanJobTemplateLister interface.
+type scanJobTemplateLister struct {
+	indexer cache.Indexer
+}
+
+// NewScanJobTemplateLister returns a new ScanJobTemplateLister.
+func NewScanJobTemplateLister(indexer cache.Indexer) ScanJobTemplateLister {
+	return &scanJobTemplateLister{indexer: indexer}
+}
+
+// List lists all ScanJobTemplates in the indexer.
+func (s *scanJobTemplateLister) List(selector labels.Selector) (ret []*v1alpha1.ScanJobTemplate, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ScanJobTemplate))
+	})
+	return ret, err
+}
+
+// Get retrieves the ScanJobTemplate from the index for a given name.
+func (s *scanJobTemplateLister) Get(name string) (*v1alpha1.ScanJobTemplate, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("scanjobtemplate"), name)
+	}
+	return obj.(*v1alpha1.ScanJobTemplate), nil
+}
@@ -99,6 +99,16 @@ func IsBuiltInWorkload(controller *metav1.OwnerReference) bool {
 			controller.Kind == string(KindJob))
 }
 
+// IsStaticPod returns true if the specified object is a mirror Pod created by
+// the kubelet for a static Pod, i.e. a Pod controlled by a Node, false otherwise.
+func IsStaticPod(obj client.Object) bool {
+	if _, ok := obj.(*corev1.Pod); !ok {
+		return false
+	}
+	controller := metav1.GetControllerOf(obj)
+	return controller != nil && controller.Kind == string(KindNode)
+}
+
 // IsWorkload returns true if the specified resource kinds represents Kubernetes
 // workload, false otherwise.
 func IsWorkload(kind string) bool {
@@ -631,55 +641,45 @@ func (o *ObjectResolver) RelatedReplicaSetName(ctx context.Context, object Objec
 // ErrReplicaSetNotFound error is returned. If the specified workload is a
 // CronJob the ErrUnSupportedKind error is returned.
 func (o *ObjectResolver) GetNodeName(ctx context.Context, obj client.Object) (string, error) {
+	pods, err := o.GetActivePods(ctx, obj)
+	if err != nil {
+		return "", err
+	}
+	return pods[0].Spec.NodeName, nil
+}
+
+// GetActivePods returns the pods controlled by the given workload. If the
+// workload is a Pod, the Pod itself is returned. If there are no running pods
+// then the ErrNoRunningPods error is returned. If there are no active
+// ReplicaSets for the Deployment the ErrReplicaSetNotFound error is returned.
+// If the specified workload is a CronJob the ErrUnSupportedKind error is
+// returned.
+func (o *ObjectResolver) GetActivePods(ctx context.Context, obj client.Object) ([]corev1.Pod, error) {
 	switch obj.(type) {
 	case *corev1.Pod:
-		return (obj.(*corev1.Pod)).Spec.NodeName, nil
+		return []corev1.Pod{*obj.(*corev1.Pod)}, nil
 	case *appsv1.Deployment:
 		replicaSet, err := o.ReplicaSetByDeployment(ctx, obj.(*appsv1.Deployment))
 		if err != nil {
-			return "", err
-		}
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), replicaSet.Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
+			return nil, err
 		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), replicaSet.Spec.Selector.MatchLabels)
 	case *appsv1.ReplicaSet:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.ReplicaSet).Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.ReplicaSet).Spec.Selector.MatchLabels)
 	case *corev1.ReplicationController:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*corev1.ReplicationController).Spec.Selector)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*corev1.ReplicationController).Spec.Selector)
 	case *appsv1.StatefulSet:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.StatefulSet).Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.StatefulSet).Spec.Selector.MatchLabels)
 	case *appsv1.DaemonSet:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.DaemonSet).Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*appsv1.DaemonSet).Spec.Selector.MatchLabels)
 	case *batchv1beta1.CronJob:
-		return "", ErrUnSupportedKind
+		return nil, ErrUnSupportedKind
 	case *batchv1.CronJob:
-		return "", ErrUnSupportedKind
+		return nil, ErrUnSupportedKind
 	case *batchv1.Job:
-		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*batchv1.Job).Spec.Selector.MatchLabels)
-		if err != nil {
-			return "", err
-		}
-		return pods[0].Spec.NodeName, nil
+		return o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), obj.(*batchv1.Job).Spec.Selector.MatchLabels)
 	default:
-		return "", ErrUnSupportedKind
+		return nil, ErrUnSupportedKind
 	}
 }
 
@@ -57,6 +57,52 @@ func TestIsBuiltInWorkload(t *testing.T) {
 	}
 }
 
+func TestIsStaticPod(t *testing.T) {
+	testCases := []struct {
+		name   string
+		object client.Object
+		want   bool
+	}{
+		{
+			name: "Should return true for Pod controlled by Node",
+			object: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "Node", Name: "master", Controller: pointer.BoolPtr(true)},
+				},
+			}},
+			want: true,
+		},
+		{
+			name: "Should return false for Pod controlled by ReplicaSet",
+			object: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "ReplicaSet", Name: "nginx-6d4cf56db6", Controller: pointer.BoolPtr(true)},
+				},
+			}},
+			want: false,
+		},
+		{
+			name:   "Should return false for unmanaged Pod",
+			object: &corev1.Pod{},
+			want:   false,
+		},
+		{
+			name: "Should return false for object other than Pod",
+			object: &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "Node", Name: "master", Controller: pointer.BoolPtr(true)},
+				},
+			}},
+			want: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, kube.IsStaticPod(tc.object))
+		})
+	}
+}
+
 func TestIsWorkload(t *testing.T) {
 	testCases := []struct {
 		kind string
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"hash"
 	"hash/fnv"
+	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/davecgh/go-spew/spew"
@@ -12,16 +13,81 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 )
 
+// ContainerClass indicates whether a container is a regular container,
+// an init container, or an ephemeral container of a Pod.
+type ContainerClass string
+
+const (
+	ContainerClassContainer          ContainerClass = "Container"
+	ContainerClassInitContainer      ContainerClass = "InitContainer"
+	ContainerClassEphemeralContainer ContainerClass = "EphemeralContainer"
+)
+
+// GetContainers returns init containers, containers, and ephemeral containers
+// from the specified v1.PodSpec. Ephemeral containers are converted to
+// v1.Container, which has the same fields as v1.EphemeralContainerCommon.
+func GetContainers(spec corev1.PodSpec) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, container := range spec.EphemeralContainers {
+		containers = append(containers, corev1.Container(container.EphemeralContainerCommon))
+	}
+	return containers
+}
+
+// GetContainerClasses returns a map of container names to container classes
+// from the specified v1.PodSpec.
+func GetContainerClasses(spec corev1.PodSpec) map[string]ContainerClass {
+	classes := make(map[string]ContainerClass)
+	for _, container := range spec.InitContainers {
+		classes[container.Name] = ContainerClassInitContainer
+	}
+	for _, container := range spec.Containers {
+		classes[container.Name] = ContainerClassContainer
+	}
+	for _, container := range spec.EphemeralContainers {
+		classes[container.Name] = ContainerClassEphemeralContainer
+	}
+	return classes
+}
+
 // GetContainerImagesFromPodSpec returns a map of container names
-// to container images from the specified v1.PodSpec.
+// to container images from the specified v1.PodSpec, including
+// init containers and ephemeral containers.
 func GetContainerImagesFromPodSpec(spec corev1.PodSpec) ContainerImages {
 	images := ContainerImages{}
-	for _, container := range spec.Containers {
+	for _, container := range GetContainers(spec) {
 		images[container.Name] = container.Image
 	}
 	return images
 }
 
+// GetContainerImageDigestsFromPod returns a map of container names to
+// digests of container images resolved by the container runtime, as reported
+// in container statuses of the specified v1.Pod. Containers which are not
+// started yet or which run images without a repo digest are omitted.
+func GetContainerImageDigestsFromPod(pod corev1.Pod) ContainerImages {
+	digests := ContainerImages{}
+	var statuses []corev1.ContainerStatus
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	statuses = append(statuses, pod.Status.EphemeralContainerStatuses...)
+	for _, status := range statuses {
+		imageID := status.ImageID
+		// Docker runtime prefixes the image ID, e.g. docker-pullable://nginx@sha256:...
+		if index := strings.Index(imageID, "://"); index != -1 {
+			imageID = imageID[index+3:]
+		}
+		index := strings.LastIndex(imageID, "@")
+		if index == -1 {
+			continue
+		}
+		digests[status.Name] = imageID[index+1:]
+	}
+	return digests
+}
+
 // GetContainerImagesFromJob returns a map of container names
 // to container images from the specified v1.Job.
 // The mapping is encoded as JSON value of the AnnotationContainerImages
@@ -12,23 +12,109 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var podSpecWithAllContainerClasses = corev1.PodSpec{
+	InitContainers: []corev1.Container{
+		{
+			Name:  "init",
+			Image: "busybox:1.34",
+		},
+	},
+	Containers: []corev1.Container{
+		{
+			Name:  "nginx",
+			Image: "nginx:1.16",
+		},
+		{
+			Name:  "sidecar",
+			Image: "sidecar:1.32.7",
+		},
+	},
+	EphemeralContainers: []corev1.EphemeralContainer{
+		{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:  "debugger",
+				Image: "alpine:3.15",
+			},
+			TargetContainerName: "nginx",
+		},
+	},
+}
+
+func TestGetContainers(t *testing.T) {
+	containers := kube.GetContainers(podSpecWithAllContainerClasses)
+	assert.Equal(t, []corev1.Container{
+		{
+			Name:  "init",
+			Image: "busybox:1.34",
+		},
+		{
+			Name:  "nginx",
+			Image: "nginx:1.16",
+		},
+		{
+			Name:  "sidecar",
+			Image: "sidecar:1.32.7",
+		},
+		{
+			Name:  "debugger",
+			Image: "alpine:3.15",
+		},
+	}, containers)
+}
+
+func TestGetContainerClasses(t *testing.T) {
+	classes := kube.GetContainerClasses(podSpecWithAllContainerClasses)
+	assert.Equal(t, map[string]kube.ContainerClass{
+		"init":     kube.ContainerClassInitContainer,
+		"nginx":    kube.ContainerClassContainer,
+		"sidecar":  kube.ContainerClassContainer,
+		"debugger": kube.ContainerClassEphemeralContainer,
+	}, classes)
+}
+
 func TestGetContainerImagesFromPodSpec(t *testing.T) {
-	images := kube.GetContainerImagesFromPodSpec(corev1.PodSpec{
-		Containers: []corev1.Container{
-			{
-				Name:  "nginx",
-				Image: "nginx:1.16",
+	images := kube.GetContainerImagesFromPodSpec(podSpecWithAllContainerClasses)
+	assert.Equal(t, kube.ContainerImages{
+		"init":     "busybox:1.34",
+		"nginx":    "nginx:1.16",
+		"sidecar":  "sidecar:1.32.7",
+		"debugger": "alpine:3.15",
+	}, images)
+}
+
+func TestGetContainerImageDigestsFromPod(t *testing.T) {
+	digests := kube.GetContainerImageDigestsFromPod(corev1.Pod{
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:    "init",
+					ImageID: "docker.io/library/busybox@sha256:15e927f78df2cc772b70713543d6b651e3cd8370abf86b2ea4644a9fba21107f",
+				},
 			},
-			{
-				Name:  "sidecar",
-				Image: "sidecar:1.32.7",
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:    "nginx",
+					ImageID: "docker-pullable://nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+				},
+				{
+					Name:    "sidecar",
+					ImageID: "docker.io/library/sidecar@sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+				},
+				{
+					Name:    "local",
+					ImageID: "sha256:8a3b5e1b4bc7ee8b3ba1c9b88c5d6e3d2f8b27f1fd2f1fb45b9a5b6aa1f7b1c4",
+				},
+				{
+					Name: "waiting",
+				},
 			},
 		},
 	})
 	assert.Equal(t, kube.ContainerImages{
-		"nginx":   "nginx:1.16",
-		"sidecar": "sidecar:1.32.7",
-	}, images)
+		"init":    "sha256:15e927f78df2cc772b70713543d6b651e3cd8370abf86b2ea4644a9fba21107f",
+		"nginx":   "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+		"sidecar": "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+	}, digests)
 }
 
 func TestGetContainerImagesFromJob(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/docker"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -35,21 +36,29 @@ func NewImagePullSecret(meta metav1.ObjectMeta, server, username, password strin
 }
 
 // MapContainerNamesToDockerAuths creates the mapping from a container name to the Docker authentication
-// credentials for the specified kube.ContainerImages and image pull Secrets.
+// credentials for the specified kube.ContainerImages and image pull Secrets. Credentials are matched by
+// registry server and repository path prefix the same way the kubelet does, i.e. the most specific auth key wins.
 func MapContainerNamesToDockerAuths(images ContainerImages, secrets []corev1.Secret) (map[string]docker.Auth, error) {
-	auths, err := MapDockerRegistryServersToAuths(secrets)
+	configs, err := readDockerConfigs(secrets)
 	if err != nil {
 		return nil, err
 	}
+	keyring := docker.NewKeyring()
+	for _, config := range configs {
+		err = keyring.Add(config)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	mapping := make(map[string]docker.Auth)
 
 	for containerName, imageRef := range images {
-		server, err := docker.GetServerFromImageRef(imageRef)
+		auth, ok, err := keyring.Lookup(imageRef)
 		if err != nil {
 			return nil, err
 		}
-		if auth, ok := auths[server]; ok {
+		if ok {
 			mapping[containerName] = auth
 		}
 	}
@@ -60,7 +69,27 @@ func MapContainerNamesToDockerAuths(images ContainerImages, secrets []corev1.Sec
 // MapDockerRegistryServersToAuths creates the mapping from a Docker registry server
 // to the Docker authentication credentials for the specified slice of image pull Secrets.
 func MapDockerRegistryServersToAuths(imagePullSecrets []corev1.Secret) (map[string]docker.Auth, error) {
+	configs, err := readDockerConfigs(imagePullSecrets)
+	if err != nil {
+		return nil, err
+	}
 	auths := make(map[string]docker.Auth)
+	for _, dockerConfig := range configs {
+		for authKey, auth := range dockerConfig.Auths {
+			server, err := docker.GetServerFromDockerAuthKey(authKey)
+			if err != nil {
+				return nil, err
+			}
+			auths[server] = auth
+		}
+	}
+	return auths, nil
+}
+
+// readDockerConfigs returns Docker configs stored in the specified slice of
+// image pull Secrets.
+func readDockerConfigs(imagePullSecrets []corev1.Secret) ([]docker.Config, error) {
+	var configs []docker.Config
 	for _, secret := range imagePullSecrets {
 		// Skip a deprecated secret of type "kubernetes.io/dockercfg" which contains a dockercfg file
 		// that follows the same format rules as ~/.dockercfg
@@ -79,15 +108,12 @@ func MapDockerRegistryServersToAuths(imagePullSecrets []corev1.Secret) (map[stri
 		if err != nil {
 			return nil, fmt.Errorf("reading %s field of %q secret: %w", corev1.DockerConfigJsonKey, secret.Namespace+"/"+secret.Name, err)
 		}
-		for authKey, auth := range dockerConfig.Auths {
-			server, err := docker.GetServerFromDockerAuthKey(authKey)
-			if err != nil {
-				return nil, err
-			}
-			auths[server] = auth
+		if len(dockerConfig.CredHelpers) > 0 || dockerConfig.CredsStore != "" {
+			klog.Warningf("Ignoring credential helpers configured in %q secret, which are not available in scan jobs", secret.Namespace+"/"+secret.Name)
 		}
+		configs = append(configs, *dockerConfig)
 	}
-	return auths, nil
+	return configs, nil
 }
 
 func AggregateImagePullSecretsData(images ContainerImages, credentials map[string]docker.Auth) map[string][]byte {
@@ -95,8 +121,12 @@ func AggregateImagePullSecretsData(images ContainerImages, credentials map[strin
 
 	for containerName := range images {
 		if dockerAuth, ok := credentials[containerName]; ok {
-			secretData[fmt.Sprintf("%s.username", containerName)] = []byte(dockerAuth.Username)
-			secretData[fmt.Sprintf("%s.password", containerName)] = []byte(dockerAuth.Password)
+			username, password := dockerAuth.Credentials()
+			secretData[fmt.Sprintf("%s.username", containerName)] = []byte(username)
+			secretData[fmt.Sprintf("%s.password", containerName)] = []byte(password)
+			if dockerAuth.RegistryToken != "" {
+				secretData[fmt.Sprintf("%s.registryToken", containerName)] = []byte(dockerAuth.RegistryToken)
+			}
 		}
 	}
 
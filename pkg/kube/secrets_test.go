@@ -174,4 +174,50 @@ func TestMapContainerNamesToDockerAuths(t *testing.T) {
 			}),
 		}))
 	})
+
+	t.Run("should map container images to credentials of the most specific repository path", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		auths, err := kube.MapContainerNamesToDockerAuths(kube.ContainerImages{
+			"container-1": "registry.example.com/team-a/app:1.0",
+			"container-2": "registry.example.com/team-b/app:1.0",
+			"container-3": "registry.example.com/team-c/app:1.0",
+		}, []corev1.Secret{
+			{
+				Type: corev1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{
+					corev1.DockerConfigJsonKey: []byte(`{
+  "auths": {
+    "registry.example.com/team-a": {
+      "username": "team-a",
+      "password": "s3cret-a"
+    }
+  }
+}`),
+				},
+			},
+			{
+				Type: corev1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{
+					corev1.DockerConfigJsonKey: []byte(`{
+  "auths": {
+    "registry.example.com/team-b": {
+      "registrytoken": "bearer-token"
+    }
+  }
+}`),
+				},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(auths).To(MatchAllKeys(Keys{
+			"container-1": Equal(docker.Auth{
+				Username: "team-a",
+				Password: "s3cret-a",
+			}),
+			"container-2": Equal(docker.Auth{
+				RegistryToken: "bearer-token",
+			}),
+		}))
+	})
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/notification"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,12 +25,21 @@ type ReadWriter interface {
 }
 
 type rw struct {
-	client client.Client
+	client   client.Client
+	notifier notification.Notifier
 }
 
 func NewReadWriter(client client.Client) ReadWriter {
+	return NewNotifyingReadWriter(client, notification.NewNopNotifier())
+}
+
+// NewNotifyingReadWriter constructs a new ReadWriter which, in addition to
+// writing reports, passes tests that did not fail in the previous revision
+// of a report to the given notification.Notifier.
+func NewNotifyingReadWriter(client client.Client, notifier notification.Notifier) ReadWriter {
 	return &rw{
-		client: client,
+		client:   client,
+		notifier: notifier,
 	}
 }
 
@@ -45,11 +55,21 @@ func (w *rw) Write(ctx context.Context, report v1alpha1.CISKubeBenchReport) erro
 		copied.Labels = report.Labels
 		copied.Report = report.Report
 
-		return w.client.Update(ctx, copied)
+		err = w.client.Update(ctx, copied)
+		if err != nil {
+			return err
+		}
+		w.notifier.Notify(ctx, notification.NewCISKubeBenchReportEvent(&existing.Report, report))
+		return nil
 	}
 
 	if errors.IsNotFound(err) {
-		return w.client.Create(ctx, &report)
+		err = w.client.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		w.notifier.Notify(ctx, notification.NewCISKubeBenchReportEvent(nil, report))
+		return nil
 	}
 
 	return err
@@ -10,6 +10,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/runner"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ type Scanner struct {
 	logsReader kube.LogsReader
 	plugin     Plugin
 	config     starboard.ConfigData
+	templates  scanjobtemplate.Reader
 	opts       kube.ScannerOpts
 }
 
@@ -36,6 +38,7 @@ func NewScanner(
 	clientset kubernetes.Interface,
 	plugin Plugin,
 	config starboard.ConfigData,
+	templates scanjobtemplate.Reader,
 	opts kube.ScannerOpts,
 ) *Scanner {
 	return &Scanner{
@@ -44,13 +47,14 @@ func NewScanner(
 		logsReader: kube.NewLogsReader(clientset),
 		plugin:     plugin,
 		config:     config,
+		templates:  templates,
 		opts:       opts,
 	}
 }
 
 func (s *Scanner) Scan(ctx context.Context, node corev1.Node) (v1alpha1.CISKubeBenchReport, error) {
 	// 1. Prepare descriptor for the Kubernetes Job which will run kube-bench
-	job, err := s.prepareKubeBenchJob(node)
+	job, err := s.prepareKubeBenchJob(ctx, node)
 	if err != nil {
 		return v1alpha1.CISKubeBenchReport{}, err
 	}
@@ -103,7 +107,7 @@ func (s *Scanner) Scan(ctx context.Context, node corev1.Node) (v1alpha1.CISKubeB
 	return report, nil
 }
 
-func (s *Scanner) prepareKubeBenchJob(node corev1.Node) (*batchv1.Job, error) {
+func (s *Scanner) prepareKubeBenchJob(ctx context.Context, node corev1.Node) (*batchv1.Job, error) {
 	templateSpec, err := s.plugin.GetScanJobSpec(node)
 	if err != nil {
 		return nil, err
@@ -138,7 +142,12 @@ func (s *Scanner) prepareKubeBenchJob(node corev1.Node) (*batchv1.Job, error) {
 		podTemplateLabelsSet[index] = element
 	}
 
-	return &batchv1.Job{
+	scanJobTemplates, err := s.templates.FindTemplates(ctx, kubeBenchContainerName, "")
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job templates: %w", err)
+	}
+
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "scan-cisbenchmark-" + kube.ComputeHash(node.Name),
 			Namespace: starboard.NamespaceName,
@@ -156,7 +165,9 @@ func (s *Scanner) prepareKubeBenchJob(node corev1.Node) (*batchv1.Job, error) {
 				Spec: templateSpec,
 			},
 		},
-	}, nil
+	}
+	scanjobtemplate.Apply(&job.Spec.Template, scanJobTemplates...)
+	return job, nil
 }
 
 const (
@@ -0,0 +1,218 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	vulnerabilitiesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "vulnerabilityreport", "vulnerabilities"),
+		"Number of vulnerabilities found in a container image of a workload by scanner and severity.",
+		[]string{"namespace", "resource_kind", "resource_name", "container_name",
+			"image_registry", "image_repository", "image_tag", "image_digest", "scanner", "severity"},
+		nil,
+	)
+
+	configAuditFailedChecksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "configauditreport", "failed_checks"),
+		"Number of failed configuration audit checks of a resource by severity.",
+		[]string{"namespace", "resource_kind", "resource_name", "severity"},
+		nil,
+	)
+
+	kubeBenchChecksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ciskubebenchreport", "checks"),
+		"Number of CIS Kubernetes Benchmark checks of a node by status.",
+		[]string{"node_name", "status"},
+		nil,
+	)
+
+	complianceControlStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "clustercompliancereport", "control_status"),
+		"Status of a control of a cluster compliance report. The value is always 1.",
+		[]string{"report_name", "control_id", "control_name", "severity", "status"},
+		nil,
+	)
+)
+
+// ReportsCollector is the prometheus.Collector which exposes contents of
+// security reports generated by the operator as gauges. Reports are listed
+// on each scrape, therefore the client.Reader should read from the cache.
+type ReportsCollector struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Reader
+}
+
+// Describe implements prometheus.Collector.
+func (c *ReportsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- vulnerabilitiesDesc
+	ch <- configAuditFailedChecksDesc
+	ch <- kubeBenchChecksDesc
+	ch <- complianceControlStatusDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *ReportsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+
+	if c.VulnerabilityScannerEnabled {
+		c.collectVulnerabilityReports(ctx, ch)
+	}
+	if c.ConfigAuditScannerEnabled || c.ConfigAuditScannerBuiltIn {
+		c.collectConfigAuditReports(ctx, ch)
+	}
+	if c.CISKubernetesBenchmarkEnabled {
+		c.collectKubeBenchReports(ctx, ch)
+	}
+	if c.ClusterComplianceEnabled {
+		c.collectClusterComplianceReports(ctx, ch)
+	}
+}
+
+func (c *ReportsCollector) collectVulnerabilityReports(ctx context.Context, ch chan<- prometheus.Metric) {
+	var reports v1alpha1.VulnerabilityReportList
+	if err := c.List(ctx, &reports); err != nil {
+		c.Error(err, "Listing vulnerability reports")
+		return
+	}
+	for _, report := range reports.Items {
+		c.collectVulnerabilitySummary(ch, report.Namespace, report.Labels, report.Report)
+	}
+
+	var clusterReports v1alpha1.ClusterVulnerabilityReportList
+	if err := c.List(ctx, &clusterReports); err != nil {
+		c.Error(err, "Listing cluster vulnerability reports")
+		return
+	}
+	for _, report := range clusterReports.Items {
+		// Cached scan results are not associated with any workload.
+		if _, ok := report.Labels[starboard.LabelResourceKind]; !ok {
+			continue
+		}
+		c.collectVulnerabilitySummary(ch, report.Labels[starboard.LabelResourceNamespace], report.Labels, report.Report)
+	}
+}
+
+func (c *ReportsCollector) collectVulnerabilitySummary(ch chan<- prometheus.Metric, namespace string,
+	labels map[string]string, data v1alpha1.VulnerabilityReportData) {
+	counts := map[v1alpha1.Severity]int{
+		v1alpha1.SeverityCritical: data.Summary.CriticalCount,
+		v1alpha1.SeverityHigh:     data.Summary.HighCount,
+		v1alpha1.SeverityMedium:   data.Summary.MediumCount,
+		v1alpha1.SeverityLow:      data.Summary.LowCount,
+		v1alpha1.SeverityUnknown:  data.Summary.UnknownCount,
+	}
+	scanner := c.scanner(labels, data)
+	for severity, count := range counts {
+		ch <- prometheus.MustNewConstMetric(vulnerabilitiesDesc, prometheus.GaugeValue, float64(count),
+			namespace,
+			labels[starboard.LabelResourceKind],
+			labels[starboard.LabelResourceName],
+			labels[starboard.LabelContainerName],
+			data.Registry.Server,
+			data.Artifact.Repository,
+			data.Artifact.Tag,
+			data.Artifact.Digest,
+			scanner,
+			string(severity),
+		)
+	}
+}
+
+// scanner returns the name of the scanner that generated the report with the
+// given labels and data. Reports that were created before they were labeled
+// with scanner names were generated by the primary scanner.
+func (c *ReportsCollector) scanner(labels map[string]string, data v1alpha1.VulnerabilityReportData) string {
+	if scanner, ok := labels[starboard.LabelVulnerabilityReportScanner]; ok {
+		return scanner
+	}
+	if primary, err := c.ConfigData.GetVulnerabilityReportsScanner(); err == nil {
+		return string(primary)
+	}
+	return data.Scanner.Name
+}
+
+func (c *ReportsCollector) collectConfigAuditReports(ctx context.Context, ch chan<- prometheus.Metric) {
+	var reports v1alpha1.ConfigAuditReportList
+	if err := c.List(ctx, &reports); err != nil {
+		c.Error(err, "Listing config audit reports")
+		return
+	}
+	for _, report := range reports.Items {
+		c.collectConfigAuditSummary(ch, report.Namespace, report.Labels, report.Report.Summary)
+	}
+
+	var clusterReports v1alpha1.ClusterConfigAuditReportList
+	if err := c.List(ctx, &clusterReports); err != nil {
+		c.Error(err, "Listing cluster config audit reports")
+		return
+	}
+	for _, report := range clusterReports.Items {
+		c.collectConfigAuditSummary(ch, "", report.Labels, report.Report.Summary)
+	}
+}
+
+func (c *ReportsCollector) collectConfigAuditSummary(ch chan<- prometheus.Metric, namespace string,
+	labels map[string]string, summary v1alpha1.ConfigAuditSummary) {
+	counts := map[v1alpha1.Severity]int{
+		v1alpha1.SeverityCritical: summary.CriticalCount,
+		v1alpha1.SeverityHigh:     summary.HighCount,
+		v1alpha1.SeverityMedium:   summary.MediumCount,
+		v1alpha1.SeverityLow:      summary.LowCount,
+	}
+	for severity, count := range counts {
+		ch <- prometheus.MustNewConstMetric(configAuditFailedChecksDesc, prometheus.GaugeValue, float64(count),
+			namespace,
+			labels[starboard.LabelResourceKind],
+			labels[starboard.LabelResourceName],
+			string(severity),
+		)
+	}
+}
+
+func (c *ReportsCollector) collectKubeBenchReports(ctx context.Context, ch chan<- prometheus.Metric) {
+	var reports v1alpha1.CISKubeBenchReportList
+	if err := c.List(ctx, &reports); err != nil {
+		c.Error(err, "Listing CIS Kubernetes Benchmark reports")
+		return
+	}
+	for _, report := range reports.Items {
+		counts := map[string]int{
+			"PASS": report.Report.Summary.PassCount,
+			"INFO": report.Report.Summary.InfoCount,
+			"WARN": report.Report.Summary.WarnCount,
+			"FAIL": report.Report.Summary.FailCount,
+		}
+		for status, count := range counts {
+			ch <- prometheus.MustNewConstMetric(kubeBenchChecksDesc, prometheus.GaugeValue, float64(count),
+				report.Name, status)
+		}
+	}
+}
+
+func (c *ReportsCollector) collectClusterComplianceReports(ctx context.Context, ch chan<- prometheus.Metric) {
+	var reports v1alpha1.ClusterComplianceReportList
+	if err := c.List(ctx, &reports); err != nil {
+		c.Error(err, "Listing cluster compliance reports")
+		return
+	}
+	for _, report := range reports.Items {
+		for _, control := range report.Status.ControlChecks {
+			status := v1alpha1.PassStatus
+			if control.FailTotal > 0 {
+				status = v1alpha1.FailStatus
+			}
+			ch <- prometheus.MustNewConstMetric(complianceControlStatusDesc, prometheus.GaugeValue, 1,
+				report.Name, control.ID, control.Name, string(control.Severity), string(status))
+		}
+	}
+}
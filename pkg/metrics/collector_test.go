@@ -0,0 +1,229 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/metrics"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReportsCollector(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-nginx",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "nginx-6d4cf56db6",
+					starboard.LabelResourceNamespace: "default",
+					starboard.LabelContainerName:     "nginx",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Summary: v1alpha1.VulnerabilitySummary{
+					CriticalCount: 1,
+					HighCount:     2,
+					MediumCount:   3,
+					LowCount:      4,
+					UnknownCount:  5,
+				},
+			},
+		},
+		&v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-etcd-master-etcd",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Pod",
+					starboard.LabelResourceName:      "etcd-master",
+					starboard.LabelResourceNamespace: "kube-system",
+					starboard.LabelContainerName:     "etcd",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Registry: v1alpha1.Registry{Server: "k8s.gcr.io"},
+				Artifact: v1alpha1.Artifact{Repository: "etcd", Tag: "3.4.13-0"},
+				Summary:  v1alpha1.VulnerabilitySummary{HighCount: 1},
+			},
+		},
+		&v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "image-9bb3d8b5bd",
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 7},
+			},
+		},
+		&v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind: "ReplicaSet",
+					starboard.LabelResourceName: "nginx-6d4cf56db6",
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{
+				Summary: v1alpha1.ConfigAuditSummary{HighCount: 2, LowCount: 1},
+			},
+		},
+		&v1alpha1.CISKubeBenchReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "control-plane",
+			},
+			Report: v1alpha1.CISKubeBenchReportData{
+				Summary: v1alpha1.CISKubeBenchSummary{PassCount: 40, InfoCount: 1, WarnCount: 2, FailCount: 3},
+			},
+		},
+		&v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "nsa",
+			},
+			Status: v1alpha1.ReportStatus{
+				ControlChecks: []v1alpha1.ControlCheck{
+					{ID: "1.0", Name: "Non-root containers", PassTotal: 3, FailTotal: 1, Severity: v1alpha1.SeverityMedium},
+					{ID: "1.1", Name: "Immutable container file systems", PassTotal: 4, Severity: v1alpha1.SeverityLow},
+				},
+			},
+		},
+	).Build()
+
+	collector := &metrics.ReportsCollector{
+		Logger: logr.Discard(),
+		Config: etc.Config{
+			VulnerabilityScannerEnabled:   true,
+			ConfigAuditScannerBuiltIn:     true,
+			CISKubernetesBenchmarkEnabled: true,
+			ClusterComplianceEnabled:      true,
+		},
+		ConfigData: starboard.ConfigData{
+			"vulnerabilityReports.scanner": "Trivy",
+		},
+		Reader: client,
+	}
+
+	expected := `
+# HELP starboard_ciskubebenchreport_checks Number of CIS Kubernetes Benchmark checks of a node by status.
+# TYPE starboard_ciskubebenchreport_checks gauge
+starboard_ciskubebenchreport_checks{node_name="control-plane",status="FAIL"} 3
+starboard_ciskubebenchreport_checks{node_name="control-plane",status="INFO"} 1
+starboard_ciskubebenchreport_checks{node_name="control-plane",status="PASS"} 40
+starboard_ciskubebenchreport_checks{node_name="control-plane",status="WARN"} 2
+# HELP starboard_clustercompliancereport_control_status Status of a control of a cluster compliance report. The value is always 1.
+# TYPE starboard_clustercompliancereport_control_status gauge
+starboard_clustercompliancereport_control_status{control_id="1.0",control_name="Non-root containers",report_name="nsa",severity="MEDIUM",status="FAIL"} 1
+starboard_clustercompliancereport_control_status{control_id="1.1",control_name="Immutable container file systems",report_name="nsa",severity="LOW",status="PASS"} 1
+# HELP starboard_configauditreport_failed_checks Number of failed configuration audit checks of a resource by severity.
+# TYPE starboard_configauditreport_failed_checks gauge
+starboard_configauditreport_failed_checks{namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",severity="CRITICAL"} 0
+starboard_configauditreport_failed_checks{namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",severity="HIGH"} 2
+starboard_configauditreport_failed_checks{namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",severity="LOW"} 1
+starboard_configauditreport_failed_checks{namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",severity="MEDIUM"} 0
+# HELP starboard_vulnerabilityreport_vulnerabilities Number of vulnerabilities found in a container image of a workload by scanner and severity.
+# TYPE starboard_vulnerabilityreport_vulnerabilities gauge
+starboard_vulnerabilityreport_vulnerabilities{container_name="etcd",image_digest="",image_registry="k8s.gcr.io",image_repository="etcd",image_tag="3.4.13-0",namespace="kube-system",resource_kind="Pod",resource_name="etcd-master",scanner="Trivy",severity="CRITICAL"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="etcd",image_digest="",image_registry="k8s.gcr.io",image_repository="etcd",image_tag="3.4.13-0",namespace="kube-system",resource_kind="Pod",resource_name="etcd-master",scanner="Trivy",severity="HIGH"} 1
+starboard_vulnerabilityreport_vulnerabilities{container_name="etcd",image_digest="",image_registry="k8s.gcr.io",image_repository="etcd",image_tag="3.4.13-0",namespace="kube-system",resource_kind="Pod",resource_name="etcd-master",scanner="Trivy",severity="LOW"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="etcd",image_digest="",image_registry="k8s.gcr.io",image_repository="etcd",image_tag="3.4.13-0",namespace="kube-system",resource_kind="Pod",resource_name="etcd-master",scanner="Trivy",severity="MEDIUM"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="etcd",image_digest="",image_registry="k8s.gcr.io",image_repository="etcd",image_tag="3.4.13-0",namespace="kube-system",resource_kind="Pod",resource_name="etcd-master",scanner="Trivy",severity="UNKNOWN"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="CRITICAL"} 1
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="HIGH"} 2
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="LOW"} 4
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="MEDIUM"} 3
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="UNKNOWN"} 5
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+	require.NoError(t, err)
+}
+
+func TestReportsCollector_MultipleScanners(t *testing.T) {
+	newReport := func(name string, labels map[string]string, criticalCount int) *v1alpha1.VulnerabilityReport {
+		reportLabels := map[string]string{
+			starboard.LabelResourceKind:      "ReplicaSet",
+			starboard.LabelResourceName:      "nginx-6d4cf56db6",
+			starboard.LabelResourceNamespace: "default",
+			starboard.LabelContainerName:     "nginx",
+		}
+		for key, value := range labels {
+			reportLabels[key] = value
+		}
+		return &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    reportLabels,
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Summary:  v1alpha1.VulnerabilitySummary{CriticalCount: criticalCount},
+			},
+		}
+	}
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		// The report of the primary scanner created before reports were
+		// labeled with scanner names.
+		newReport("replicaset-nginx-6d4cf56db6-nginx", nil, 1),
+		newReport("replicaset-nginx-6d4cf56db6-nginx-grype", map[string]string{
+			starboard.LabelVulnerabilityReportScanner: "Grype",
+		}, 2),
+	).Build()
+
+	collector := &metrics.ReportsCollector{
+		Logger: logr.Discard(),
+		Config: etc.Config{
+			VulnerabilityScannerEnabled: true,
+		},
+		ConfigData: starboard.ConfigData{
+			"vulnerabilityReports.scanner": "Trivy,Grype",
+		},
+		Reader: client,
+	}
+
+	expected := `
+# HELP starboard_vulnerabilityreport_vulnerabilities Number of vulnerabilities found in a container image of a workload by scanner and severity.
+# TYPE starboard_vulnerabilityreport_vulnerabilities gauge
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Grype",severity="CRITICAL"} 2
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Grype",severity="HIGH"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Grype",severity="LOW"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Grype",severity="MEDIUM"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Grype",severity="UNKNOWN"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="CRITICAL"} 1
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="HIGH"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="LOW"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="MEDIUM"} 0
+starboard_vulnerabilityreport_vulnerabilities{container_name="nginx",image_digest="",image_registry="index.docker.io",image_repository="library/nginx",image_tag="1.16",namespace="default",resource_kind="ReplicaSet",resource_name="nginx-6d4cf56db6",scanner="Trivy",severity="UNKNOWN"} 0
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"starboard_vulnerabilityreport_vulnerabilities")
+	require.NoError(t, err)
+}
+
+func TestReportsCollector_Disabled(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.CISKubeBenchReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "control-plane",
+			},
+		},
+	).Build()
+
+	collector := &metrics.ReportsCollector{
+		Logger: logr.Discard(),
+		Reader: client,
+	}
+	require.Equal(t, 0, testutil.CollectAndCount(collector))
+}
@@ -0,0 +1,3 @@
+// Package metrics provides Prometheus metrics exposed by the operator, i.e.
+// contents of security reports and scan jobs statistics.
+package metrics
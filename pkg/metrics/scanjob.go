@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "starboard"
+
+	scanJobStatusComplete = "complete"
+	scanJobStatusFailed   = "failed"
+)
+
+var (
+	scanJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "scan_job",
+		Name:      "duration_seconds",
+		Help:      "Time elapsed between the start and the completion or failure of a scan job.",
+		Buckets:   prometheus.ExponentialBuckets(5, 2, 10),
+	}, []string{"kind", "scanner", "status"})
+
+	scanJobFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scan_job",
+		Name:      "failures_total",
+		Help:      "Total number of failed scan jobs.",
+	}, []string{"kind", "scanner"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(scanJobDuration, scanJobFailures)
+}
+
+// RecordScanJob records the duration of the specified scan job, which is
+// either complete or failed, and counts it if it's failed. The kind is the
+// kind of reports generated by the scan job, e.g. VulnerabilityReport.
+//
+// A finished job might be reconciled several times before it's gone from the
+// informer cache, therefore RecordScanJob should be called once per job, e.g.
+// after the job has been deleted.
+func RecordScanJob(kind, scanner string, job *batchv1.Job) {
+	if len(job.Status.Conditions) == 0 {
+		return
+	}
+	condition := job.Status.Conditions[0]
+
+	var status string
+	switch condition.Type {
+	case batchv1.JobComplete:
+		status = scanJobStatusComplete
+	case batchv1.JobFailed:
+		status = scanJobStatusFailed
+		scanJobFailures.WithLabelValues(kind, scanner).Inc()
+	default:
+		return
+	}
+
+	if job.Status.StartTime == nil {
+		return
+	}
+	finishTime := condition.LastTransitionTime.Time
+	if job.Status.CompletionTime != nil {
+		finishTime = job.Status.CompletionTime.Time
+	}
+	duration := finishTime.Sub(job.Status.StartTime.Time)
+	if duration < 0 {
+		duration = time.Duration(0)
+	}
+	scanJobDuration.WithLabelValues(kind, scanner, status).Observe(duration.Seconds())
+}
@@ -0,0 +1,77 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func TestRecordScanJob(t *testing.T) {
+	startTime := time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC)
+
+	newJob := func(conditionType batchv1.JobConditionType, duration time.Duration) *batchv1.Job {
+		return &batchv1.Job{
+			Status: batchv1.JobStatus{
+				StartTime: &metav1.Time{Time: startTime},
+				Conditions: []batchv1.JobCondition{
+					{
+						Type:               conditionType,
+						LastTransitionTime: metav1.Time{Time: startTime.Add(duration)},
+					},
+				},
+			},
+		}
+	}
+
+	metrics.RecordScanJob("VulnerabilityReport", "Trivy", newJob(batchv1.JobComplete, 7*time.Second))
+	metrics.RecordScanJob("VulnerabilityReport", "Trivy", newJob(batchv1.JobFailed, 30*time.Second))
+	metrics.RecordScanJob("VulnerabilityReport", "Trivy", &batchv1.Job{})
+
+	expected := `
+# HELP starboard_scan_job_failures_total Total number of failed scan jobs.
+# TYPE starboard_scan_job_failures_total counter
+starboard_scan_job_failures_total{kind="VulnerabilityReport",scanner="Trivy"} 1
+`
+	err := testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected), "starboard_scan_job_failures_total")
+	require.NoError(t, err)
+
+	expected = `
+# HELP starboard_scan_job_duration_seconds Time elapsed between the start and the completion or failure of a scan job.
+# TYPE starboard_scan_job_duration_seconds histogram
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="5"} 0
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="10"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="20"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="40"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="80"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="160"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="320"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="640"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="1280"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="2560"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="complete",le="+Inf"} 1
+starboard_scan_job_duration_seconds_sum{kind="VulnerabilityReport",scanner="Trivy",status="complete"} 7
+starboard_scan_job_duration_seconds_count{kind="VulnerabilityReport",scanner="Trivy",status="complete"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="5"} 0
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="10"} 0
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="20"} 0
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="40"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="80"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="160"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="320"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="640"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="1280"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="2560"} 1
+starboard_scan_job_duration_seconds_bucket{kind="VulnerabilityReport",scanner="Trivy",status="failed",le="+Inf"} 1
+starboard_scan_job_duration_seconds_sum{kind="VulnerabilityReport",scanner="Trivy",status="failed"} 30
+starboard_scan_job_duration_seconds_count{kind="VulnerabilityReport",scanner="Trivy",status="failed"} 1
+`
+	err = testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected), "starboard_scan_job_duration_seconds")
+	require.NoError(t, err)
+}
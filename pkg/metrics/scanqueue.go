@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	scanQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "scan_queue",
+		Name:      "depth",
+		Help:      "Number of scan jobs waiting in the scan queue.",
+	}, []string{"namespace"})
+
+	scanQueueWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "scan_queue",
+		Name:      "wait_duration_seconds",
+		Help:      "Time elapsed between queueing and admitting a scan job.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(scanQueueDepth, scanQueueWait)
+}
+
+// SetScanQueueDepth records the number of scan jobs waiting for workloads in
+// the specified namespace. The namespace is empty for cluster-scoped
+// resources, such as nodes.
+func SetScanQueueDepth(namespace string, depth int) {
+	if depth == 0 {
+		scanQueueDepth.DeleteLabelValues(namespace)
+		return
+	}
+	scanQueueDepth.WithLabelValues(namespace).Set(float64(depth))
+}
+
+// RecordScanQueueWait records the time that a scan job waited in the scan
+// queue before it was admitted.
+func RecordScanQueueWait(wait time.Duration) {
+	if wait < 0 {
+		wait = time.Duration(0)
+	}
+	scanQueueWait.Observe(wait.Seconds())
+}
@@ -0,0 +1,53 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func TestSetScanQueueDepth(t *testing.T) {
+	metrics.SetScanQueueDepth("default", 3)
+	metrics.SetScanQueueDepth("prod", 1)
+	metrics.SetScanQueueDepth("prod", 0)
+
+	expected := `
+# HELP starboard_scan_queue_depth Number of scan jobs waiting in the scan queue.
+# TYPE starboard_scan_queue_depth gauge
+starboard_scan_queue_depth{namespace="default"} 3
+`
+	err := testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected), "starboard_scan_queue_depth")
+	require.NoError(t, err)
+}
+
+func TestRecordScanQueueWait(t *testing.T) {
+	metrics.RecordScanQueueWait(3 * time.Second)
+	metrics.RecordScanQueueWait(-time.Second)
+
+	expected := `
+# HELP starboard_scan_queue_wait_duration_seconds Time elapsed between queueing and admitting a scan job.
+# TYPE starboard_scan_queue_wait_duration_seconds histogram
+starboard_scan_queue_wait_duration_seconds_bucket{le="1"} 1
+starboard_scan_queue_wait_duration_seconds_bucket{le="2"} 1
+starboard_scan_queue_wait_duration_seconds_bucket{le="4"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="8"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="16"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="32"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="64"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="128"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="256"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="512"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="1024"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="2048"} 2
+starboard_scan_queue_wait_duration_seconds_bucket{le="+Inf"} 2
+starboard_scan_queue_wait_duration_seconds_sum 3
+starboard_scan_queue_wait_duration_seconds_count 2
+`
+	err := testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected), "starboard_scan_queue_wait_duration_seconds")
+	require.NoError(t, err)
+}
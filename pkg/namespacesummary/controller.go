@@ -0,0 +1,139 @@
+package namespacesummary
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Controller watches v1alpha1.VulnerabilityReport and
+// v1alpha1.ConfigAuditReport instances and keeps the
+// v1alpha1.NamespaceSecuritySummary of their namespace up to date. Only
+// VulnerabilityReports generated by the primary vulnerability scanner are
+// summarized so that findings are not counted once per scanner.
+type Controller struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	ext.Clock
+	client.Client
+}
+
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	summaryOfNamespace := handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		return []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: v1alpha1.NamespaceSecuritySummaryName}},
+		}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.NamespaceSecuritySummary{}, builder.WithPredicates(installModePredicate)).
+		Watches(&source.Kind{Type: &v1alpha1.VulnerabilityReport{}}, summaryOfNamespace, builder.WithPredicates(installModePredicate)).
+		Watches(&source.Kind{Type: &v1alpha1.ConfigAuditReport{}}, summaryOfNamespace, builder.WithPredicates(installModePredicate)).
+		Complete(r)
+}
+
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("namespace", req.Namespace)
+
+	if req.Name != v1alpha1.NamespaceSecuritySummaryName {
+		log.V(1).Info("Ignoring namespace security summary with unexpected name", "name", req.Name)
+		return ctrl.Result{}, nil
+	}
+
+	var vulnerabilityReportList v1alpha1.VulnerabilityReportList
+	err := r.Client.List(ctx, &vulnerabilityReportList, client.InNamespace(req.Namespace))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+
+	var configAuditReportList v1alpha1.ConfigAuditReportList
+	err = r.Client.List(ctx, &configAuditReportList, client.InNamespace(req.Namespace))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing config audit reports: %w", err)
+	}
+
+	vulnerabilityReports := vulnerabilityreport.FilterPrimaryScannerReports(vulnerabilityReportList.Items, r.ConfigData)
+
+	var summary v1alpha1.NamespaceSecuritySummary
+	err = r.Client.Get(ctx, req.NamespacedName, &summary)
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("getting namespace security summary: %w", err)
+	}
+	exists := err == nil
+
+	if len(vulnerabilityReports) == 0 && len(configAuditReportList.Items) == 0 {
+		if !exists {
+			return ctrl.Result{}, nil
+		}
+		log.V(1).Info("Deleting namespace security summary without reports")
+		err = r.Client.Delete(ctx, &summary)
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("deleting namespace security summary: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	data := Summarize(vulnerabilityReports, configAuditReportList.Items, DefaultTopN)
+
+	if exists {
+		current := summary.Summary
+		current.UpdateTimestamp = metav1.Time{}
+		if reflect.DeepEqual(current, data) {
+			log.V(1).Info("Namespace security summary is up to date")
+			return ctrl.Result{}, nil
+		}
+	}
+
+	data.UpdateTimestamp = metav1.NewTime(r.Clock.Now())
+
+	if !exists {
+		log.V(1).Info("Creating namespace security summary")
+		err = r.Client.Create(ctx, &v1alpha1.NamespaceSecuritySummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1alpha1.NamespaceSecuritySummaryName,
+				Namespace: req.Namespace,
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+				},
+			},
+			Summary: data,
+		})
+		if err != nil && !k8sapierror.IsAlreadyExists(err) {
+			return ctrl.Result{}, fmt.Errorf("creating namespace security summary: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	log.V(1).Info("Updating namespace security summary")
+	copied := summary.DeepCopy()
+	copied.Summary = data
+	err = r.Client.Update(ctx, copied)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating namespace security summary: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,107 @@
+package namespacesummary_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/namespacesummary"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestController_Reconcile(t *testing.T) {
+	now := time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC)
+	key := types.NamespacedName{Namespace: "default", Name: v1alpha1.NamespaceSecuritySummaryName}
+
+	report := &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaset-nginx-6d4cf56db6-nginx",
+			Namespace: "default",
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+			Summary:  v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2},
+		},
+	}
+
+	newController := func(c client.Client, now time.Time) *namespacesummary.Controller {
+		return &namespacesummary.Controller{
+			Logger:     logr.Discard(),
+			ConfigData: starboard.ConfigData{"vulnerabilityReports.scanner": "Trivy,Grype"},
+			Clock:      ext.NewFixedClock(now),
+			Client:     c,
+		}
+	}
+
+	t.Run("Should create summary of namespace with reports", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(report).Build()
+
+		_, err := newController(testClient, now).Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+
+		var summary v1alpha1.NamespaceSecuritySummary
+		require.NoError(t, testClient.Get(context.TODO(), key, &summary))
+		assert.Equal(t, starboard.AppStarboard, summary.Labels[starboard.LabelK8SAppManagedBy])
+		assert.Equal(t, 1, summary.Summary.Vulnerabilities.ReportsCount)
+		assert.Equal(t, 1, summary.Summary.Vulnerabilities.CriticalCount)
+		assert.Len(t, summary.Summary.TopVulnerableImages, 1)
+		assert.True(t, now.Equal(summary.Summary.UpdateTimestamp.Time))
+	})
+
+	t.Run("Should summarize reports of primary vulnerability scanner only", func(t *testing.T) {
+		grypeReport := report.DeepCopy()
+		grypeReport.Name = "replicaset-nginx-6d4cf56db6-nginx-grype"
+		grypeReport.Labels = map[string]string{starboard.LabelVulnerabilityReportScanner: "Grype"}
+		grypeReport.Report.Summary = v1alpha1.VulnerabilitySummary{CriticalCount: 3}
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(report, grypeReport).Build()
+
+		_, err := newController(testClient, now).Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+
+		var summary v1alpha1.NamespaceSecuritySummary
+		require.NoError(t, testClient.Get(context.TODO(), key, &summary))
+		assert.Equal(t, 1, summary.Summary.Vulnerabilities.ReportsCount)
+		assert.Equal(t, 1, summary.Summary.Vulnerabilities.CriticalCount)
+		require.Len(t, summary.Summary.TopVulnerableImages, 1)
+		assert.Equal(t, 1, summary.Summary.TopVulnerableImages[0].Workloads)
+	})
+
+	t.Run("Should not update summary that is up to date", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(report).Build()
+
+		_, err := newController(testClient, now).Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		_, err = newController(testClient, now.Add(time.Hour)).Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+
+		var summary v1alpha1.NamespaceSecuritySummary
+		require.NoError(t, testClient.Get(context.TODO(), key, &summary))
+		assert.True(t, now.Equal(summary.Summary.UpdateTimestamp.Time))
+	})
+
+	t.Run("Should delete summary of namespace without reports", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&v1alpha1.NamespaceSecuritySummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1alpha1.NamespaceSecuritySummaryName,
+				Namespace: "default",
+			},
+		}).Build()
+
+		_, err := newController(testClient, now).Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+
+		var list v1alpha1.NamespaceSecuritySummaryList
+		require.NoError(t, testClient.List(context.TODO(), &list))
+		assert.Empty(t, list.Items)
+	})
+}
@@ -0,0 +1,3 @@
+// Package namespacesummary provides primitives for aggregating security
+// reports of a namespace into a v1alpha1.NamespaceSecuritySummary.
+package namespacesummary
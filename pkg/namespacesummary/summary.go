@@ -0,0 +1,155 @@
+package namespacesummary
+
+import (
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+)
+
+// DefaultTopN is the default number of top vulnerable images and most common
+// failed checks included in a v1alpha1.NamespaceSecuritySummary.
+const DefaultTopN = 10
+
+// Summarize aggregates the given VulnerabilityReports and ConfigAuditReports
+// of a namespace. Only the topN vulnerable images and failed checks are
+// included in the returned summary. The UpdateTimestamp is left unset.
+func Summarize(vulnerabilityReports []v1alpha1.VulnerabilityReport, configAuditReports []v1alpha1.ConfigAuditReport, topN int) v1alpha1.NamespaceSecuritySummaryData {
+	return v1alpha1.NamespaceSecuritySummaryData{
+		Vulnerabilities:     summarizeVulnerabilities(vulnerabilityReports),
+		ConfigAudit:         summarizeConfigAudit(configAuditReports),
+		TopVulnerableImages: topVulnerableImages(vulnerabilityReports, topN),
+		TopFailedChecks:     topFailedChecks(configAuditReports, topN),
+	}
+}
+
+func summarizeVulnerabilities(reports []v1alpha1.VulnerabilityReport) v1alpha1.NamespaceVulnerabilitySummary {
+	summary := v1alpha1.NamespaceVulnerabilitySummary{
+		ReportsCount: len(reports),
+	}
+	for _, report := range reports {
+		summary.CriticalCount += report.Report.Summary.CriticalCount
+		summary.HighCount += report.Report.Summary.HighCount
+		summary.MediumCount += report.Report.Summary.MediumCount
+		summary.LowCount += report.Report.Summary.LowCount
+		summary.UnknownCount += report.Report.Summary.UnknownCount
+	}
+	return summary
+}
+
+func summarizeConfigAudit(reports []v1alpha1.ConfigAuditReport) v1alpha1.NamespaceConfigAuditSummary {
+	summary := v1alpha1.NamespaceConfigAuditSummary{
+		ReportsCount: len(reports),
+	}
+	for _, report := range reports {
+		summary.CriticalCount += report.Report.Summary.CriticalCount
+		summary.HighCount += report.Report.Summary.HighCount
+		summary.MediumCount += report.Report.Summary.MediumCount
+		summary.LowCount += report.Report.Summary.LowCount
+	}
+	return summary
+}
+
+// topVulnerableImages groups reports by container image and returns images
+// with at least one vulnerability ordered by the number of critical, high,
+// medium, low, and unknown vulnerabilities.
+func topVulnerableImages(reports []v1alpha1.VulnerabilityReport, topN int) []v1alpha1.ImageVulnerabilitySummary {
+	var images []v1alpha1.ImageVulnerabilitySummary
+	index := make(map[string]int)
+
+	for _, report := range reports {
+		summary := report.Report.Summary
+		if summary.CriticalCount+summary.HighCount+summary.MediumCount+summary.LowCount+summary.UnknownCount == 0 {
+			continue
+		}
+		key := v1alpha1.ImageRef(report.Report.Registry, report.Report.Artifact)
+		if i, ok := index[key]; ok {
+			images[i].Workloads++
+			continue
+		}
+		index[key] = len(images)
+		images = append(images, v1alpha1.ImageVulnerabilitySummary{
+			Registry:  report.Report.Registry,
+			Artifact:  report.Report.Artifact,
+			Workloads: 1,
+			Summary:   summary,
+		})
+	}
+
+	sort.SliceStable(images, func(i, j int) bool {
+		a, b := images[i].Summary, images[j].Summary
+		switch {
+		case a.CriticalCount != b.CriticalCount:
+			return a.CriticalCount > b.CriticalCount
+		case a.HighCount != b.HighCount:
+			return a.HighCount > b.HighCount
+		case a.MediumCount != b.MediumCount:
+			return a.MediumCount > b.MediumCount
+		case a.LowCount != b.LowCount:
+			return a.LowCount > b.LowCount
+		case a.UnknownCount != b.UnknownCount:
+			return a.UnknownCount > b.UnknownCount
+		}
+		return v1alpha1.ImageRef(images[i].Registry, images[i].Artifact) < v1alpha1.ImageRef(images[j].Registry, images[j].Artifact)
+	})
+
+	return images[:ext.MinInt(topN, len(images))]
+}
+
+// topFailedChecks returns checks ordered by the number of resources that
+// failed them, and then by severity.
+func topFailedChecks(reports []v1alpha1.ConfigAuditReport, topN int) []v1alpha1.FailedCheckSummary {
+	var checks []v1alpha1.FailedCheckSummary
+	index := make(map[string]int)
+
+	for _, report := range reports {
+		failed := make(map[string]bool)
+		for _, check := range allChecks(report.Report) {
+			if check.Success || failed[check.ID] {
+				continue
+			}
+			failed[check.ID] = true
+			if i, ok := index[check.ID]; ok {
+				checks[i].AffectedResources++
+				continue
+			}
+			index[check.ID] = len(checks)
+			checks = append(checks, v1alpha1.FailedCheckSummary{
+				ID:                check.ID,
+				Title:             check.Title,
+				Severity:          check.Severity,
+				Category:          check.Category,
+				AffectedResources: 1,
+			})
+		}
+	}
+
+	sort.SliceStable(checks, func(i, j int) bool {
+		a, b := checks[i], checks[j]
+		if a.AffectedResources != b.AffectedResources {
+			return a.AffectedResources > b.AffectedResources
+		}
+		if c := a.Severity.Compare(b.Severity); c != 0 {
+			return c > 0
+		}
+		return a.ID < b.ID
+	})
+
+	return checks[:ext.MinInt(topN, len(checks))]
+}
+
+// allChecks returns checks of the given report including the deprecated pod
+// and container checks.
+func allChecks(data v1alpha1.ConfigAuditReportData) []v1alpha1.Check {
+	checks := append(data.Checks[:0:0], data.Checks...)
+	checks = append(checks, data.PodChecks...)
+	containers := make([]string, 0, len(data.ContainerChecks))
+	for container := range data.ContainerChecks {
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+	for _, container := range containers {
+		checks = append(checks, data.ContainerChecks[container]...)
+	}
+	return checks
+}
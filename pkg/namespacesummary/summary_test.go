@@ -0,0 +1,97 @@
+package namespacesummary_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/namespacesummary"
+	"github.com/stretchr/testify/assert"
+)
+
+func vulnerabilityReport(repository, tag string, summary v1alpha1.VulnerabilitySummary) v1alpha1.VulnerabilityReport {
+	return v1alpha1.VulnerabilityReport{
+		Report: v1alpha1.VulnerabilityReportData{
+			Registry: v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact: v1alpha1.Artifact{Repository: repository, Tag: tag},
+			Summary:  summary,
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	vulnerabilityReports := []v1alpha1.VulnerabilityReport{
+		vulnerabilityReport("library/nginx", "1.16", v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 5, MediumCount: 3}),
+		vulnerabilityReport("library/nginx", "1.16", v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 5, MediumCount: 3}),
+		vulnerabilityReport("library/redis", "6", v1alpha1.VulnerabilitySummary{CriticalCount: 2, LowCount: 1}),
+		vulnerabilityReport("library/alpine", "3.16", v1alpha1.VulnerabilitySummary{NoneCount: 14}),
+		vulnerabilityReport("library/busybox", "1.35", v1alpha1.VulnerabilitySummary{UnknownCount: 1}),
+	}
+
+	configAuditReports := []v1alpha1.ConfigAuditReport{
+		{
+			Report: v1alpha1.ConfigAuditReportData{
+				Summary: v1alpha1.ConfigAuditSummary{HighCount: 1, LowCount: 1},
+				Checks: []v1alpha1.Check{
+					{ID: "KSV001", Title: "Process can elevate its own privileges", Severity: v1alpha1.SeverityMedium, Category: "Kubernetes Security Check"},
+					{ID: "KSV012", Title: "Runs as root user", Severity: v1alpha1.SeverityMedium, Category: "Kubernetes Security Check"},
+					{ID: "KSV003", Severity: v1alpha1.SeverityLow, Success: true},
+				},
+			},
+		},
+		{
+			Report: v1alpha1.ConfigAuditReportData{
+				Summary: v1alpha1.ConfigAuditSummary{CriticalCount: 1},
+				ContainerChecks: map[string][]v1alpha1.Check{
+					"nginx":   {{ID: "KSV012", Severity: v1alpha1.SeverityMedium}},
+					"sidecar": {{ID: "KSV012", Severity: v1alpha1.SeverityMedium}},
+				},
+				PodChecks: []v1alpha1.Check{
+					{ID: "KSV017", Title: "Privileged container", Severity: v1alpha1.SeverityHigh},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, v1alpha1.NamespaceSecuritySummaryData{
+		Vulnerabilities: v1alpha1.NamespaceVulnerabilitySummary{
+			ReportsCount: 5,
+			VulnerabilitySummary: v1alpha1.VulnerabilitySummary{
+				CriticalCount: 4,
+				HighCount:     10,
+				MediumCount:   6,
+				LowCount:      1,
+				UnknownCount:  1,
+			},
+		},
+		ConfigAudit: v1alpha1.NamespaceConfigAuditSummary{
+			ReportsCount: 2,
+			ConfigAuditSummary: v1alpha1.ConfigAuditSummary{
+				CriticalCount: 1,
+				HighCount:     1,
+				LowCount:      1,
+			},
+		},
+		TopVulnerableImages: []v1alpha1.ImageVulnerabilitySummary{
+			{
+				Registry:  v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact:  v1alpha1.Artifact{Repository: "library/redis", Tag: "6"},
+				Workloads: 1,
+				Summary:   v1alpha1.VulnerabilitySummary{CriticalCount: 2, LowCount: 1},
+			},
+			{
+				Registry:  v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact:  v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Workloads: 2,
+				Summary:   v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 5, MediumCount: 3},
+			},
+		},
+		TopFailedChecks: []v1alpha1.FailedCheckSummary{
+			{ID: "KSV012", Title: "Runs as root user", Severity: v1alpha1.SeverityMedium, Category: "Kubernetes Security Check", AffectedResources: 2},
+			{ID: "KSV017", Title: "Privileged container", Severity: v1alpha1.SeverityHigh, AffectedResources: 1},
+		},
+	}, namespacesummary.Summarize(vulnerabilityReports, configAuditReports, 2))
+}
+
+func TestSummarize_NoReports(t *testing.T) {
+	assert.Equal(t, v1alpha1.NamespaceSecuritySummaryData{}, namespacesummary.Summarize(nil, nil, namespacesummary.DefaultTopN))
+}
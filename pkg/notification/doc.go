@@ -0,0 +1,3 @@
+// Package notification provides primitives for notifying external systems,
+// such as paging or chat tools, about new findings in security reports.
+package notification
@@ -0,0 +1,225 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ClusterVulnerabilityReportKind = "ClusterVulnerabilityReport"
+	ClusterConfigAuditReportKind   = "ClusterConfigAuditReport"
+)
+
+// Event describes findings newly introduced by a security report compared
+// with the previous revision of the same report.
+type Event struct {
+	// ReportKind is the kind of the security report, e.g. VulnerabilityReport.
+	ReportKind string `json:"reportKind"`
+
+	// ReportName is the name of the security report.
+	ReportName string `json:"reportName"`
+
+	// ReportNamespace is the namespace of the security report, or an empty
+	// string if the report is cluster-scoped.
+	ReportNamespace string `json:"reportNamespace,omitempty"`
+
+	// Resource is the Kubernetes object the security report was generated for.
+	Resource Resource `json:"resource"`
+
+	// Image is the container image reference for reports generated by
+	// vulnerability scanners.
+	Image string `json:"image,omitempty"`
+
+	// Findings is the list of findings that were not present in the previous
+	// revision of the security report.
+	Findings []Finding `json:"findings"`
+}
+
+// Resource identifies a Kubernetes object and optionally its container.
+type Resource struct {
+	Kind          string `json:"kind"`
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace,omitempty"`
+	ContainerName string `json:"containerName,omitempty"`
+}
+
+// Finding is a vulnerability, a failed configuration audit check, or a failed
+// CIS Kubernetes Benchmark test.
+type Finding struct {
+	ID               string            `json:"id"`
+	Title            string            `json:"title,omitempty"`
+	Severity         v1alpha1.Severity `json:"severity,omitempty"`
+	Resource         string            `json:"resource,omitempty"`
+	InstalledVersion string            `json:"installedVersion,omitempty"`
+	FixedVersion     string            `json:"fixedVersion,omitempty"`
+	PrimaryLink      string            `json:"primaryLink,omitempty"`
+}
+
+// NewVulnerabilityReportEvent returns an Event with vulnerabilities of the
+// current report that are not present in the previous report. Vulnerabilities
+// excluded by exceptions or VEX statements are ignored. The previous report
+// may be nil, in which case all vulnerabilities are considered new.
+func NewVulnerabilityReportEvent(previous *v1alpha1.VulnerabilityReportData, current v1alpha1.VulnerabilityReport) Event {
+	return Event{
+		ReportKind:      v1alpha1.VulnerabilityReportKind,
+		ReportName:      current.Name,
+		ReportNamespace: current.Namespace,
+		Resource:        resourceFromObjectMeta(current.ObjectMeta),
+		Image:           v1alpha1.ImageRef(current.Report.Registry, current.Report.Artifact),
+		Findings:        newVulnerabilities(previous, current.Report),
+	}
+}
+
+// NewClusterVulnerabilityReportEvent is the cluster-scoped counterpart of
+// NewVulnerabilityReportEvent.
+func NewClusterVulnerabilityReportEvent(previous *v1alpha1.VulnerabilityReportData, current v1alpha1.ClusterVulnerabilityReport) Event {
+	return Event{
+		ReportKind: ClusterVulnerabilityReportKind,
+		ReportName: current.Name,
+		Resource:   resourceFromObjectMeta(current.ObjectMeta),
+		Image:      v1alpha1.ImageRef(current.Report.Registry, current.Report.Artifact),
+		Findings:   newVulnerabilities(previous, current.Report),
+	}
+}
+
+// NewConfigAuditReportEvent returns an Event with failed checks of the
+// current report that did not fail in the previous report. The previous
+// report may be nil, in which case all failed checks are considered new.
+func NewConfigAuditReportEvent(previous *v1alpha1.ConfigAuditReportData, current v1alpha1.ConfigAuditReport) Event {
+	return Event{
+		ReportKind:      v1alpha1.ConfigAuditReportKind,
+		ReportName:      current.Name,
+		ReportNamespace: current.Namespace,
+		Resource:        resourceFromObjectMeta(current.ObjectMeta),
+		Findings:        newFailedChecks(previous, current.Report),
+	}
+}
+
+// NewClusterConfigAuditReportEvent is the cluster-scoped counterpart of
+// NewConfigAuditReportEvent.
+func NewClusterConfigAuditReportEvent(previous *v1alpha1.ConfigAuditReportData, current v1alpha1.ClusterConfigAuditReport) Event {
+	return Event{
+		ReportKind: ClusterConfigAuditReportKind,
+		ReportName: current.Name,
+		Resource:   resourceFromObjectMeta(current.ObjectMeta),
+		Findings:   newFailedChecks(previous, current.Report),
+	}
+}
+
+// NewCISKubeBenchReportEvent returns an Event with tests of the current
+// report that failed, but did not fail in the previous report. CIS Kubernetes
+// Benchmark tests do not have severity levels, therefore findings of the
+// returned Event do not have the Severity set.
+func NewCISKubeBenchReportEvent(previous *v1alpha1.CISKubeBenchReportData, current v1alpha1.CISKubeBenchReport) Event {
+	failed := make(map[string]bool)
+	if previous != nil {
+		for _, result := range kubeBenchResults(*previous) {
+			if result.Status == "FAIL" {
+				failed[result.TestNumber] = true
+			}
+		}
+	}
+
+	var findings []Finding
+	for _, result := range kubeBenchResults(current.Report) {
+		if result.Status != "FAIL" || failed[result.TestNumber] {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:    result.TestNumber,
+			Title: result.TestDesc,
+		})
+	}
+
+	return Event{
+		ReportKind: v1alpha1.CISKubeBenchReportKind,
+		ReportName: current.Name,
+		Resource:   resourceFromObjectMeta(current.ObjectMeta),
+		Findings:   findings,
+	}
+}
+
+func newVulnerabilities(previous *v1alpha1.VulnerabilityReportData, current v1alpha1.VulnerabilityReportData) []Finding {
+	found := make(map[string]bool)
+	if previous != nil {
+		for _, v := range previous.Vulnerabilities {
+			if !v.IsExcluded() {
+				found[v.VulnerabilityID+"/"+v.Resource] = true
+			}
+		}
+	}
+
+	var findings []Finding
+	for _, v := range current.Vulnerabilities {
+		if v.IsExcluded() || found[v.VulnerabilityID+"/"+v.Resource] {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:               v.VulnerabilityID,
+			Title:            v.Title,
+			Severity:         v.Severity,
+			Resource:         v.Resource,
+			InstalledVersion: v.InstalledVersion,
+			FixedVersion:     v.FixedVersion,
+			PrimaryLink:      v.PrimaryLink,
+		})
+	}
+	return findings
+}
+
+func newFailedChecks(previous *v1alpha1.ConfigAuditReportData, current v1alpha1.ConfigAuditReportData) []Finding {
+	failed := make(map[string]bool)
+	if previous != nil {
+		for _, check := range previous.Checks {
+			if !check.Success {
+				failed[checkKey(check)] = true
+			}
+		}
+	}
+
+	var findings []Finding
+	for _, check := range current.Checks {
+		if check.Success || failed[checkKey(check)] {
+			continue
+		}
+		finding := Finding{
+			ID:       check.ID,
+			Title:    check.Title,
+			Severity: check.Severity,
+		}
+		if check.Scope != nil {
+			finding.Resource = check.Scope.Value
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+func checkKey(check v1alpha1.Check) string {
+	if check.Scope == nil {
+		return check.ID
+	}
+	return fmt.Sprintf("%s/%s/%s", check.ID, check.Scope.Type, check.Scope.Value)
+}
+
+func kubeBenchResults(data v1alpha1.CISKubeBenchReportData) []v1alpha1.CISKubeBenchResult {
+	var results []v1alpha1.CISKubeBenchResult
+	for _, section := range data.Sections {
+		for _, tests := range section.Tests {
+			results = append(results, tests.Results...)
+		}
+	}
+	return results
+}
+
+func resourceFromObjectMeta(meta metav1.ObjectMeta) Resource {
+	return Resource{
+		Kind:          meta.Labels[starboard.LabelResourceKind],
+		Name:          meta.Labels[starboard.LabelResourceName],
+		Namespace:     meta.Labels[starboard.LabelResourceNamespace],
+		ContainerName: meta.Labels[starboard.LabelContainerName],
+	}
+}
@@ -0,0 +1,162 @@
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/notification"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewVulnerabilityReportEvent(t *testing.T) {
+	report := v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaset-nginx-6d4cf56db6-nginx",
+			Namespace: "default",
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "nginx-6d4cf56db6",
+				starboard.LabelResourceNamespace: "default",
+				starboard.LabelContainerName:     "nginx",
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			Registry: v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2020-1967", Resource: "openssl", InstalledVersion: "1.1.1d", FixedVersion: "1.1.1g", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2019-1551", Resource: "openssl", InstalledVersion: "1.1.1d", FixedVersion: "1.1.1g", Severity: v1alpha1.SeverityMedium},
+				{VulnerabilityID: "CVE-2020-8169", Resource: "curl", InstalledVersion: "7.64.0", Severity: v1alpha1.SeverityHigh, Suppressed: true},
+			},
+		},
+	}
+
+	t.Run("Should return all unsuppressed vulnerabilities when previous report is nil", func(t *testing.T) {
+		event := notification.NewVulnerabilityReportEvent(nil, report)
+		assert.Equal(t, notification.Event{
+			ReportKind:      "VulnerabilityReport",
+			ReportName:      "replicaset-nginx-6d4cf56db6-nginx",
+			ReportNamespace: "default",
+			Resource: notification.Resource{
+				Kind:          "ReplicaSet",
+				Name:          "nginx-6d4cf56db6",
+				Namespace:     "default",
+				ContainerName: "nginx",
+			},
+			Image: "index.docker.io/library/nginx:1.16",
+			Findings: []notification.Finding{
+				{ID: "CVE-2020-1967", Resource: "openssl", InstalledVersion: "1.1.1d", FixedVersion: "1.1.1g", Severity: v1alpha1.SeverityCritical},
+				{ID: "CVE-2019-1551", Resource: "openssl", InstalledVersion: "1.1.1d", FixedVersion: "1.1.1g", Severity: v1alpha1.SeverityMedium},
+			},
+		}, event)
+	})
+
+	t.Run("Should return vulnerabilities not present in previous report", func(t *testing.T) {
+		previous := &v1alpha1.VulnerabilityReportData{
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2019-1551", Resource: "openssl", InstalledVersion: "1.1.1c", Severity: v1alpha1.SeverityMedium},
+				{VulnerabilityID: "CVE-2020-1967", Resource: "openssl", InstalledVersion: "1.1.1d", Severity: v1alpha1.SeverityCritical, Suppressed: true},
+			},
+		}
+		event := notification.NewVulnerabilityReportEvent(previous, report)
+		assert.Equal(t, []notification.Finding{
+			{ID: "CVE-2020-1967", Resource: "openssl", InstalledVersion: "1.1.1d", FixedVersion: "1.1.1g", Severity: v1alpha1.SeverityCritical},
+		}, event.Findings)
+	})
+}
+
+func TestNewConfigAuditReportEvent(t *testing.T) {
+	report := v1alpha1.ConfigAuditReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaset-nginx-6d4cf56db6",
+			Namespace: "default",
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "nginx-6d4cf56db6",
+				starboard.LabelResourceNamespace: "default",
+			},
+		},
+		Report: v1alpha1.ConfigAuditReportData{
+			Checks: []v1alpha1.Check{
+				{ID: "KSV001", Title: "Process can elevate its own privileges", Severity: v1alpha1.SeverityMedium, Scope: &v1alpha1.CheckScope{Type: "Container", Value: "nginx"}},
+				{ID: "KSV001", Title: "Process can elevate its own privileges", Severity: v1alpha1.SeverityMedium, Scope: &v1alpha1.CheckScope{Type: "Container", Value: "sidecar"}},
+				{ID: "KSV017", Title: "Privileged container", Severity: v1alpha1.SeverityHigh},
+				{ID: "KSV012", Title: "Runs as root user", Severity: v1alpha1.SeverityMedium, Success: true},
+			},
+		},
+	}
+	previous := &v1alpha1.ConfigAuditReportData{
+		Checks: []v1alpha1.Check{
+			{ID: "KSV001", Severity: v1alpha1.SeverityMedium, Scope: &v1alpha1.CheckScope{Type: "Container", Value: "nginx"}},
+			{ID: "KSV017", Severity: v1alpha1.SeverityHigh, Success: true},
+		},
+	}
+
+	event := notification.NewConfigAuditReportEvent(previous, report)
+	assert.Equal(t, "ConfigAuditReport", event.ReportKind)
+	assert.Equal(t, notification.Resource{
+		Kind:      "ReplicaSet",
+		Name:      "nginx-6d4cf56db6",
+		Namespace: "default",
+	}, event.Resource)
+	assert.Equal(t, []notification.Finding{
+		{ID: "KSV001", Title: "Process can elevate its own privileges", Severity: v1alpha1.SeverityMedium, Resource: "sidecar"},
+		{ID: "KSV017", Title: "Privileged container", Severity: v1alpha1.SeverityHigh},
+	}, event.Findings)
+}
+
+func TestNewCISKubeBenchReportEvent(t *testing.T) {
+	report := v1alpha1.CISKubeBenchReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kind-control-plane",
+			Labels: map[string]string{
+				starboard.LabelResourceKind: "Node",
+				starboard.LabelResourceName: "kind-control-plane",
+			},
+		},
+		Report: v1alpha1.CISKubeBenchReportData{
+			Sections: []v1alpha1.CISKubeBenchSection{
+				{
+					Tests: []v1alpha1.CISKubeBenchTests{
+						{
+							Results: []v1alpha1.CISKubeBenchResult{
+								{TestNumber: "1.1.1", TestDesc: "Ensure that the API server pod specification file permissions are set", Status: "FAIL"},
+								{TestNumber: "1.1.2", TestDesc: "Ensure that the API server pod specification file ownership is set", Status: "FAIL"},
+								{TestNumber: "1.1.3", TestDesc: "Ensure that the controller manager pod specification file permissions are set", Status: "WARN"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	previous := &v1alpha1.CISKubeBenchReportData{
+		Sections: []v1alpha1.CISKubeBenchSection{
+			{
+				Tests: []v1alpha1.CISKubeBenchTests{
+					{
+						Results: []v1alpha1.CISKubeBenchResult{
+							{TestNumber: "1.1.1", Status: "FAIL"},
+							{TestNumber: "1.1.2", Status: "PASS"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	event := notification.NewCISKubeBenchReportEvent(previous, report)
+	assert.Equal(t, notification.Event{
+		ReportKind: "CISKubeBenchReport",
+		ReportName: "kind-control-plane",
+		Resource: notification.Resource{
+			Kind: "Node",
+			Name: "kind-control-plane",
+		},
+		Findings: []notification.Finding{
+			{ID: "1.1.2", Title: "Ensure that the API server pod specification file ownership is set"},
+		},
+	}, event)
+}
@@ -0,0 +1,260 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+)
+
+const (
+	contentTypeJSON        = "application/json"
+	contentTypeCloudEvents = "application/cloudevents+json"
+
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsTypePrefix  = "io.github.aquasecurity.starboard."
+
+	// queueSize is the maximum number of events waiting to be sent. Events
+	// are dropped if the queue is full, e.g. because the webhook is down.
+	queueSize = 100
+	// maxAttempts is the maximum number of calls made to send an event.
+	maxAttempts = 5
+	// initialBackoff is the delay before the first retry of a failed call. It
+	// is doubled with each consecutive failure up to maxBackoff.
+	initialBackoff = time.Second
+	maxBackoff     = 30 * time.Second
+)
+
+// Notifier is the interface that wraps the Notify method.
+//
+// Notify sends the given Event to an external system. Implementations decide
+// which findings are worth a notification and must not block writing security
+// reports, therefore events are sent asynchronously and delivery errors are
+// logged rather than returned.
+type Notifier interface {
+	Notify(ctx context.Context, event Event)
+}
+
+type nopNotifier struct{}
+
+func (n *nopNotifier) Notify(_ context.Context, _ Event) {}
+
+// NewNopNotifier constructs a Notifier that discards all events.
+func NewNopNotifier() Notifier {
+	return &nopNotifier{}
+}
+
+// NewNotifier constructs a Notifier configured with the given
+// starboard.ConfigData. If the webhook URL is not set, it returns a Notifier
+// that discards all events.
+//
+// The webhook Notifier queues events and sends them from a single worker,
+// which retries failed calls with exponential backoff. It also implements
+// manager.Runnable and does not send any events until it is started.
+func NewNotifier(logger logr.Logger, clock ext.Clock, config starboard.ConfigData) (Notifier, error) {
+	url := config.GetNotificationWebhookURL()
+	if url == "" {
+		return NewNopNotifier(), nil
+	}
+	severity, err := config.GetNotificationSeverity()
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := config.GetNotificationWebhookTimeout()
+	if err != nil {
+		return nil, err
+	}
+	return &webhook{
+		logger:      logger,
+		clock:       clock,
+		idGenerator: ext.NewGoogleUUIDGenerator(),
+		client:      &http.Client{Timeout: timeout},
+		url:         url,
+		cloudEvents: config.NotificationWebhookCloudEventsEnabled(),
+		severity:    severity,
+		queue:       make(chan Event, queueSize),
+	}, nil
+}
+
+// webhook is a Notifier that sends events to the configured URL as JSON
+// payloads, optionally wrapped in the CloudEvents envelope.
+type webhook struct {
+	logger      logr.Logger
+	clock       ext.Clock
+	idGenerator ext.IDGenerator
+	client      *http.Client
+	url         string
+	cloudEvents bool
+	severity    v1alpha1.Severity
+	queue       chan Event
+}
+
+// cloudEvent represents a CloudEvent in the structured content mode.
+// @see https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md
+type cloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Event     `json:"data"`
+}
+
+// Notify queues the given event to be sent by the worker. The context is not
+// used to send the event, because sending outlives the reconciliation that
+// wrote the report.
+func (w *webhook) Notify(_ context.Context, event Event) {
+	event.Findings = w.filter(event.Findings)
+	if len(event.Findings) == 0 {
+		return
+	}
+	select {
+	case w.queue <- event:
+	default:
+		w.logger.Error(errors.New("notification queue is full"), "Dropping notification",
+			"report", event.ReportName, "kind", event.ReportKind, "findings", len(event.Findings))
+	}
+}
+
+// Start sends queued events until the given context is done.
+func (w *webhook) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-w.queue:
+			w.deliver(ctx, event)
+		}
+	}
+}
+
+// deliver sends the given event and retries failed calls with exponential
+// backoff until the event is sent, the call fails with a non-retryable error,
+// or maxAttempts is reached.
+func (w *webhook) deliver(ctx context.Context, event Event) {
+	log := w.logger.WithValues("report", event.ReportName, "kind", event.ReportKind, "findings", len(event.Findings))
+
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := w.send(ctx, event)
+		if err == nil {
+			log.V(1).Info("Sent notification")
+			return
+		}
+		if attempt == maxAttempts || !isRetryable(err) {
+			log.Error(err, "Failed to send notification", "attempts", attempt)
+			return
+		}
+		log.V(1).Info("Retrying notification", "attempt", attempt, "retryAfter", backoff, "reason", err.Error())
+		select {
+		case <-ctx.Done():
+			log.Error(ctx.Err(), "Failed to send notification", "attempts", attempt)
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// filter returns findings with severity at or above the configured severity.
+// Findings without severity, i.e. failed CIS Kubernetes Benchmark tests, are
+// always returned.
+func (w *webhook) filter(findings []Finding) []Finding {
+	var filtered []Finding
+	for _, finding := range findings {
+		if finding.Severity == "" || finding.Severity.Compare(w.severity) >= 0 {
+			filtered = append(filtered, finding)
+		}
+	}
+	return filtered
+}
+
+func (w *webhook) send(ctx context.Context, event Event) error {
+	var payload interface{} = event
+	contentType := contentTypeJSON
+	if w.cloudEvents {
+		payload = w.newCloudEvent(event)
+		contentType = contentTypeCloudEvents
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshalling notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &statusError{code: resp.StatusCode, status: resp.Status}
+	}
+	return nil
+}
+
+// statusError is returned when the webhook responds with a non-2xx status.
+type statusError struct {
+	code   int
+	status string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected response status: %s", e.status)
+}
+
+// isRetryable returns true if the call that failed with the given error may
+// succeed if retried, i.e. unless the webhook rejected the request with a
+// client error other than 408 Request Timeout or 429 Too Many Requests.
+func isRetryable(err error) bool {
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	return statusErr.code >= 500 ||
+		statusErr.code == http.StatusRequestTimeout ||
+		statusErr.code == http.StatusTooManyRequests
+}
+
+func (w *webhook) newCloudEvent(event Event) cloudEvent {
+	kind := strings.ToLower(event.ReportKind)
+	source := fmt.Sprintf("/apis/%s/%ss/%s", v1alpha1.SchemeGroupVersion, kind, event.ReportName)
+	if event.ReportNamespace != "" {
+		source = fmt.Sprintf("/apis/%s/namespaces/%s/%ss/%s", v1alpha1.SchemeGroupVersion, event.ReportNamespace, kind, event.ReportName)
+	}
+	subject := strings.ToLower(event.Resource.Kind) + "/" + event.Resource.Name
+	if event.Resource.Namespace != "" {
+		subject = event.Resource.Namespace + "/" + subject
+	}
+	return cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              w.idGenerator.GenerateID(),
+		Source:          source,
+		Type:            cloudEventsTypePrefix + kind + ".findings",
+		Subject:         subject,
+		Time:            w.clock.Now().UTC(),
+		DataContentType: contentTypeJSON,
+		Data:            event,
+	}
+}
@@ -0,0 +1,230 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/notification"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+type request struct {
+	contentType string
+	body        map[string]interface{}
+}
+
+// newTestServer starts the server that responds to consecutive requests with
+// the given statuses. The last status is repeated for subsequent requests.
+func newTestServer(t *testing.T, statuses ...int) (*httptest.Server, <-chan request) {
+	t.Helper()
+	requests := make(chan request, 10)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		requests <- request{contentType: r.Header.Get("Content-Type"), body: payload}
+		call := int(atomic.AddInt32(&calls, 1))
+		if call > len(statuses) {
+			call = len(statuses)
+		}
+		w.WriteHeader(statuses[call-1])
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+// start starts the worker of the given notifier until the test is finished.
+func start(t *testing.T, notifier notification.Notifier) {
+	t.Helper()
+	runnable, ok := notifier.(manager.Runnable)
+	require.True(t, ok)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = runnable.Start(ctx)
+	}()
+}
+
+func newEvent() notification.Event {
+	return notification.Event{
+		ReportKind:      "VulnerabilityReport",
+		ReportName:      "replicaset-nginx-6d4cf56db6-nginx",
+		ReportNamespace: "default",
+		Resource: notification.Resource{
+			Kind:          "ReplicaSet",
+			Name:          "nginx-6d4cf56db6",
+			Namespace:     "default",
+			ContainerName: "nginx",
+		},
+		Image: "index.docker.io/library/nginx:1.16",
+		Findings: []notification.Finding{
+			{ID: "CVE-2020-1967", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			{ID: "CVE-2019-1551", Resource: "openssl", Severity: v1alpha1.SeverityMedium},
+		},
+	}
+}
+
+func TestNewNotifier(t *testing.T) {
+	clock := ext.NewFixedClock(time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC))
+
+	t.Run("Should send findings at or above configured severity", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusOK)
+		notifier, err := notification.NewNotifier(logr.Discard(), clock, starboard.ConfigData{
+			"notification.webhook.url": server.URL,
+		})
+		require.NoError(t, err)
+		start(t, notifier)
+
+		notifier.Notify(context.TODO(), newEvent())
+
+		req := <-requests
+		assert.Equal(t, "application/json", req.contentType)
+		assert.Equal(t, map[string]interface{}{
+			"reportKind":      "VulnerabilityReport",
+			"reportName":      "replicaset-nginx-6d4cf56db6-nginx",
+			"reportNamespace": "default",
+			"resource": map[string]interface{}{
+				"kind":          "ReplicaSet",
+				"name":          "nginx-6d4cf56db6",
+				"namespace":     "default",
+				"containerName": "nginx",
+			},
+			"image": "index.docker.io/library/nginx:1.16",
+			"findings": []interface{}{
+				map[string]interface{}{
+					"id":       "CVE-2020-1967",
+					"resource": "openssl",
+					"severity": "CRITICAL",
+				},
+			},
+		}, req.body)
+	})
+
+	t.Run("Should wrap event in CloudEvents envelope", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusAccepted)
+		notifier, err := notification.NewNotifier(logr.Discard(), clock, starboard.ConfigData{
+			"notification.webhook.url":         server.URL,
+			"notification.webhook.cloudEvents": "true",
+			"notification.severity":            "MEDIUM",
+		})
+		require.NoError(t, err)
+		start(t, notifier)
+
+		notifier.Notify(context.TODO(), newEvent())
+
+		req := <-requests
+		assert.Equal(t, "application/cloudevents+json", req.contentType)
+		assert.NotEmpty(t, req.body["id"])
+		assert.Equal(t, "1.0", req.body["specversion"])
+		assert.Equal(t, "/apis/aquasecurity.github.io/v1alpha1/namespaces/default/vulnerabilityreports/replicaset-nginx-6d4cf56db6-nginx", req.body["source"])
+		assert.Equal(t, "io.github.aquasecurity.starboard.vulnerabilityreport.findings", req.body["type"])
+		assert.Equal(t, "default/replicaset/nginx-6d4cf56db6", req.body["subject"])
+		assert.Equal(t, "2022-08-10T10:00:00Z", req.body["time"])
+		assert.Equal(t, "application/json", req.body["datacontenttype"])
+		data, ok := req.body["data"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Len(t, data["findings"], 2)
+	})
+
+	t.Run("Should not send event without findings at or above configured severity", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusOK)
+		notifier, err := notification.NewNotifier(logr.Discard(), clock, starboard.ConfigData{
+			"notification.webhook.url": server.URL,
+		})
+		require.NoError(t, err)
+		start(t, notifier)
+
+		event := newEvent()
+		event.Findings = event.Findings[1:]
+		notifier.Notify(context.TODO(), event)
+
+		assert.Never(t, func() bool {
+			return len(requests) > 0
+		}, 100*time.Millisecond, 10*time.Millisecond)
+	})
+
+	t.Run("Should retry failed call", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusServiceUnavailable, http.StatusOK)
+		notifier, err := notification.NewNotifier(logr.Discard(), clock, starboard.ConfigData{
+			"notification.webhook.url": server.URL,
+		})
+		require.NoError(t, err)
+		start(t, notifier)
+
+		notifier.Notify(context.TODO(), newEvent())
+
+		for i := 0; i < 2; i++ {
+			select {
+			case req := <-requests:
+				assert.Equal(t, "VulnerabilityReport", req.body["reportKind"])
+			case <-time.After(5 * time.Second):
+				t.Fatalf("expected request %d", i+1)
+			}
+		}
+	})
+
+	t.Run("Should not retry call rejected with client error", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusBadRequest, http.StatusOK)
+		notifier, err := notification.NewNotifier(logr.Discard(), clock, starboard.ConfigData{
+			"notification.webhook.url": server.URL,
+		})
+		require.NoError(t, err)
+		start(t, notifier)
+
+		notifier.Notify(context.TODO(), newEvent())
+
+		<-requests
+		assert.Never(t, func() bool {
+			return len(requests) > 0
+		}, 1500*time.Millisecond, 100*time.Millisecond)
+	})
+
+	t.Run("Should not block when webhook is slow", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		t.Cleanup(server.Close)
+		t.Cleanup(func() { close(release) })
+		notifier, err := notification.NewNotifier(logr.Discard(), clock, starboard.ConfigData{
+			"notification.webhook.url": server.URL,
+		})
+		require.NoError(t, err)
+		start(t, notifier)
+
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i < 200; i++ {
+				notifier.Notify(context.TODO(), newEvent())
+			}
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected Notify not to block")
+		}
+	})
+
+	t.Run("Should return error when severity is invalid", func(t *testing.T) {
+		_, err := notification.NewNotifier(logr.Discard(), clock, starboard.ConfigData{
+			"notification.webhook.url": "http://localhost:8080",
+			"notification.severity":    "SEVERE",
+		})
+		require.EqualError(t, err, "parsing notification.severity: unrecognized name literal: SEVERE")
+	})
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/aquasecurity/starboard/pkg/metrics"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/scanfailure"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
@@ -21,9 +24,18 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// kubeBenchScanner is the name of the scanner that runs CIS Kubernetes
+// Benchmark checks.
+const kubeBenchScanner = "kube-bench"
+
+// kubeBenchScanQueueConsumer is the name of the ScanQueue source of the
+// controller of nodes.
+const kubeBenchScanQueueConsumer = "ciskubebenchreport"
+
 // CISKubeBenchReportReconciler reconciles corev1.Node and corev1.Job objects
 // to check cluster nodes configuration with CIS Kubernetes Benchmark and saves
 // results as v1alpha1.CISKubeBenchReport objects.
@@ -38,16 +50,20 @@ type CISKubeBenchReportReconciler struct {
 	etc.Config
 	client.Client
 	kube.LogsReader
-	LimitChecker
+	ScanQueue
 	kubebench.ReadWriter
 	kubebench.Plugin
+	scanfailure.Recorder
 	starboard.ConfigData
+	ScanJobTemplates scanjobtemplate.Reader
 }
 
 func (r *CISKubeBenchReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Node{}, builder.WithPredicates(IsLinuxNode)).
 		Owns(&v1alpha1.CISKubeBenchReport{}).
+		Owns(&v1alpha1.ScanFailure{}).
+		Watches(r.ScanQueue.Source(kubeBenchScanQueueConsumer), &handler.EnqueueRequestForObject{}).
 		Complete(r.reconcileNodes())
 	if err != nil {
 		return err
@@ -73,7 +89,7 @@ func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
 		if err != nil {
 			if errors.IsNotFound(err) {
 				log.V(1).Info("Ignoring cached node that must have been deleted")
-				return ctrl.Result{}, nil
+				return ctrl.Result{}, r.ScanQueue.Forget(ctx, kubeBenchScanQueueConsumer, req.NamespacedName)
 			}
 			return ctrl.Result{}, fmt.Errorf("getting node from cache: %w", err)
 		}
@@ -100,18 +116,30 @@ func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
 			return ctrl.Result{}, nil
 		}
 
-		limitExceeded, jobsCount, err := r.LimitChecker.Check(ctx)
+		retryAfter, err := r.RetryAfter(ctx, node, v1alpha1.CISKubeBenchReportKind, kubeBenchScanner, "")
 		if err != nil {
-			return ctrl.Result{}, err
+			return ctrl.Result{}, fmt.Errorf("checking scan failures: %w", err)
+		}
+		if retryAfter > 0 {
+			log.V(1).Info("Backing off failed CIS Kubernetes Benchmark checks", "retryAfter", retryAfter)
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
 		}
-		log.V(1).Info("Checking scan jobs limit", "count", jobsCount, "limit", r.ConcurrentScanJobsLimit)
 
-		if limitExceeded {
-			log.V(1).Info("Pushing back scan job", "count", jobsCount, "retryAfter", r.ScanJobRetryAfter)
-			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		admitted, err := r.ScanQueue.Admit(ctx, ScanRequest{
+			Consumer:     kubeBenchScanQueueConsumer,
+			Kind:         kube.KindNode,
+			Object:       node,
+			NeverScanned: true,
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("admitting scan job: %w", err)
+		}
+		if !admitted {
+			log.V(1).Info("Queueing CIS Kubernetes Benchmark checks")
+			return ctrl.Result{}, nil
 		}
 
-		job, err = r.newScanJob(node)
+		job, err = r.newScanJob(ctx, node)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("preparing job: %w", err)
 		}
@@ -150,7 +178,7 @@ func (r *CISKubeBenchReportReconciler) hasScanJob(ctx context.Context, node *cor
 	return true, job, nil
 }
 
-func (r *CISKubeBenchReportReconciler) newScanJob(node *corev1.Node) (*batchv1.Job, error) {
+func (r *CISKubeBenchReportReconciler) newScanJob(ctx context.Context, node *corev1.Node) (*batchv1.Job, error) {
 	templateSpec, err := r.Plugin.GetScanJobSpec(*node)
 	if err != nil {
 		return nil, err
@@ -189,7 +217,12 @@ func (r *CISKubeBenchReportReconciler) newScanJob(node *corev1.Node) (*batchv1.J
 		podTemplateLabelsSet[index] = element
 	}
 
-	return &batchv1.Job{
+	scanJobTemplates, err := r.ScanJobTemplates.FindTemplates(ctx, kubeBenchScanner, "")
+	if err != nil {
+		return nil, fmt.Errorf("getting scan job templates: %w", err)
+	}
+
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      r.getScanJobName(node),
 			Namespace: r.Config.Namespace,
@@ -207,7 +240,9 @@ func (r *CISKubeBenchReportReconciler) newScanJob(node *corev1.Node) (*batchv1.J
 				Spec: templateSpec,
 			},
 		},
-	}, nil
+	}
+	scanjobtemplate.Apply(&job.Spec.Template, scanJobTemplates...)
+	return job, nil
 }
 
 func (r *CISKubeBenchReportReconciler) getScanJobName(node *corev1.Node) string {
@@ -242,7 +277,6 @@ func (r *CISKubeBenchReportReconciler) reconcileJobs() reconcile.Func {
 		default:
 			err = fmt.Errorf("unrecognized job condition: %v", jobCondition)
 		}
-
 		return ctrl.Result{}, err
 	}
 }
@@ -309,6 +343,10 @@ func (r *CISKubeBenchReportReconciler) processCompleteScanJob(ctx context.Contex
 	if err != nil {
 		return fmt.Errorf("writing report: %w", err)
 	}
+	err = r.ClearFailure(ctx, node, v1alpha1.CISKubeBenchReportKind, kubeBenchScanner)
+	if err != nil {
+		return err
+	}
 	log.V(1).Info("Deleting complete scan job")
 	return r.deleteJob(ctx, job)
 }
@@ -321,6 +359,7 @@ func (r *CISKubeBenchReportReconciler) deleteJob(ctx context.Context, job *batch
 		}
 		return fmt.Errorf("deleting job: %w", err)
 	}
+	metrics.RecordScanJob(v1alpha1.CISKubeBenchReportKind, kubeBenchScanner, job)
 	return nil
 }
 
@@ -333,11 +372,10 @@ func (r *CISKubeBenchReportReconciler) processFailedScanJob(ctx context.Context,
 			log.V(1).Info("Cached job must have been deleted")
 			return nil
 		}
-		if kube.IsPodControlledByJobNotFound(err) {
-			log.V(1).Info("Pod must have been deleted")
-			return r.deleteJob(ctx, job)
+		if !kube.IsPodControlledByJobNotFound(err) {
+			return err
 		}
-		return err
+		log.V(1).Info("Pod must have been deleted")
 	}
 	for container, status := range statuses {
 		if status.ExitCode == 0 {
@@ -345,6 +383,25 @@ func (r *CISKubeBenchReportReconciler) processFailedScanJob(ctx context.Context,
 		}
 		log.Error(nil, "Scan job container", "container", container, "status.reason", status.Reason, "status.message", status.Message)
 	}
+
+	nodeRef, err := kube.ObjectRefFromObjectMeta(job.ObjectMeta)
+	if err != nil {
+		return fmt.Errorf("getting owner ref from scan job metadata: %w", err)
+	}
+	node := &corev1.Node{}
+	err = r.Client.Get(ctx, client.ObjectKey{Name: nodeRef.Name}, node)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("getting node from cache: %w", err)
+	}
+	if err == nil {
+		failure := scanfailure.NewFailure(v1alpha1.CISKubeBenchReportKind, kubeBenchScanner, "", job, statuses)
+		retryAfter, err := r.RecordFailure(ctx, node, failure)
+		if err != nil {
+			return fmt.Errorf("recording scan failure: %w", err)
+		}
+		log.V(1).Info("Recorded scan failure", "node", node.Name, "reason", failure.Reason, "retryAfter", retryAfter)
+	}
+
 	log.V(1).Info("Deleting failed scan job")
 	return r.deleteJob(ctx, job)
 }
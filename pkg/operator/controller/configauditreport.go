@@ -9,7 +9,10 @@ import (
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/metrics"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/scanfailure"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -24,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -33,11 +37,13 @@ type ConfigAuditReportReconciler struct {
 	starboard.ConfigData
 	client.Client
 	kube.ObjectResolver
-	LimitChecker
+	ScanQueue
 	kube.LogsReader
 	configauditreport.Plugin
 	starboard.PluginContext
 	configauditreport.ReadWriter
+	scanfailure.Recorder
+	ScanJobTemplates scanjobtemplate.Reader
 }
 
 func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -87,6 +93,8 @@ func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				installModePredicate,
 			)).
 			Owns(resource.ownsObject).
+			Owns(&v1alpha1.ScanFailure{}).
+			Watches(r.ScanQueue.Source(r.scanQueueConsumer(resource.kind)), &handler.EnqueueRequestForObject{}).
 			Complete(r.reconcileResource(resource.kind))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
@@ -104,6 +112,8 @@ func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				Not(IsBeingTerminated),
 			)).
 			Owns(resource.ownsObject).
+			Owns(&v1alpha1.ScanFailure{}).
+			Watches(r.ScanQueue.Source(r.scanQueueConsumer(resource.kind)), &handler.EnqueueRequestForObject{}).
 			Complete(r.reconcileResource(resource.kind))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
@@ -130,9 +140,22 @@ func (r *ConfigAuditReportReconciler) supportsKind(kind kube.Kind) bool {
 }
 
 func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind) reconcile.Func {
-	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return func(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 		log := r.Logger.WithValues("kind", resourceKind, "name", req.NamespacedName)
 
+		// The resource is forgotten by the scan queue unless it's admitted
+		// below, so that it doesn't hold a reserved slot or its position in the
+		// queue once it needs no scan, e.g. an old ReplicaSet scaled down to 0.
+		admitting := false
+		defer func() {
+			if admitting {
+				return
+			}
+			if forgetErr := r.ScanQueue.Forget(ctx, r.scanQueueConsumer(resourceKind), req.NamespacedName); forgetErr != nil && err == nil {
+				err = forgetErr
+			}
+		}()
+
 		resourceRef := kube.ObjectRefFromKindAndObjectKey(resourceKind, req.NamespacedName)
 
 		log.V(1).Info("Getting resource from cache")
@@ -244,18 +267,35 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			return ctrl.Result{}, nil
 		}
 
-		limitExceeded, scanJobsCount, err := r.LimitChecker.Check(ctx)
+		retryAfter, err := r.RetryAfter(ctx, resource, v1alpha1.ConfigAuditReportKind, r.PluginContext.GetName(), resourceSpecHash)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("checking scan failures: %w", err)
+		}
+		if retryAfter > 0 {
+			log.V(1).Info("Pushing back reconcile key",
+				"reason", "backing off failed scan",
+				"retryAfter", retryAfter)
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
+		}
+
+		scanned, err := r.isScanned(ctx, resourceRef)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		log.V(1).Info("Checking scan jobs limit", "count", scanJobsCount, "limit", r.ConcurrentScanJobsLimit)
 
-		if limitExceeded {
-			log.V(1).Info("Pushing back reconcile key",
-				"reason", "scan jobs limit exceeded",
-				"scanJobsCount", scanJobsCount,
-				"retryAfter", r.ScanJobRetryAfter)
-			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		admitting = true
+		admitted, err := r.ScanQueue.Admit(ctx, ScanRequest{
+			Consumer:     r.scanQueueConsumer(resourceKind),
+			Kind:         resourceKind,
+			Object:       resource,
+			NeverScanned: !scanned,
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("admitting scan job: %w", err)
+		}
+		if !admitted {
+			log.V(1).Info("Queueing configuration audit")
+			return ctrl.Result{}, nil
 		}
 
 		scanJobTolerations, err := r.ConfigData.GetScanJobTolerations()
@@ -273,6 +313,11 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			return ctrl.Result{}, fmt.Errorf("getting scan job template labels: %w", err)
 		}
 
+		scanJobTemplates, err := r.ScanJobTemplates.FindTemplates(ctx, r.PluginContext.GetName(), resource.GetNamespace())
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting scan job templates: %w", err)
+		}
+
 		job, secrets, err := configauditreport.NewScanJobBuilder().
 			WithPlugin(r.Plugin).
 			WithPluginContext(r.PluginContext).
@@ -281,6 +326,7 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			WithTolerations(scanJobTolerations).
 			WithAnnotations(scanJobAnnotations).
 			WithPodTemplateLabels(scanJobPodTemplateLabels).
+			WithScanJobTemplates(scanJobTemplates).
 			Get()
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("constructing scan job: %w", err)
@@ -339,6 +385,29 @@ func (r *ConfigAuditReportReconciler) hasReport(ctx context.Context, owner kube.
 	return false, nil
 }
 
+// isScanned checks if the given owner has a configuration audit report,
+// regardless of the spec and plugin config it was generated for.
+func (r *ConfigAuditReportReconciler) isScanned(ctx context.Context, owner kube.ObjectRef) (bool, error) {
+	if kube.IsClusterScopedKind(string(owner.Kind)) {
+		report, err := r.ReadWriter.FindClusterReportByOwner(ctx, owner)
+		if err != nil {
+			return false, err
+		}
+		return report != nil, nil
+	}
+	report, err := r.ReadWriter.FindReportByOwner(ctx, owner)
+	if err != nil {
+		return false, err
+	}
+	return report != nil, nil
+}
+
+// scanQueueConsumer returns the name of the ScanQueue source of the controller
+// of the given resource kind.
+func (r *ConfigAuditReportReconciler) scanQueueConsumer(kind kube.Kind) string {
+	return ConfigAuditReportScanQueueConsumer(r.PluginContext.GetName(), kind)
+}
+
 func (r *ConfigAuditReportReconciler) hasClusterReport(ctx context.Context, owner kube.ObjectRef, podSpecHash string, pluginConfigHash string) (bool, error) {
 	report, err := r.ReadWriter.FindClusterReportByOwner(ctx, owner)
 	if err != nil {
@@ -395,7 +464,6 @@ func (r *ConfigAuditReportReconciler) reconcileJobs() reconcile.Func {
 		default:
 			err = fmt.Errorf("unrecognized job condition: %v", jobCondition)
 		}
-
 		return ctrl.Result{}, err
 	}
 
@@ -469,6 +537,11 @@ func (r *ConfigAuditReportReconciler) processCompleteScanJob(ctx context.Context
 		return err
 	}
 
+	err = r.ClearFailure(ctx, owner, v1alpha1.ConfigAuditReportKind, r.PluginContext.GetName())
+	if err != nil {
+		return err
+	}
+
 	log.V(1).Info("Deleting complete scan job", "owner", owner)
 	return r.deleteJob(ctx, job)
 }
@@ -482,11 +555,10 @@ func (r *ConfigAuditReportReconciler) processFailedScanJob(ctx context.Context,
 			log.V(1).Info("Cached job must have been deleted")
 			return nil
 		}
-		if kube.IsPodControlledByJobNotFound(err) {
-			log.V(1).Info("Pod must have been deleted")
-			return r.deleteJob(ctx, scanJob)
+		if !kube.IsPodControlledByJobNotFound(err) {
+			return err
 		}
-		return err
+		log.V(1).Info("Pod must have been deleted")
 	}
 	for container, status := range statuses {
 		if status.ExitCode == 0 {
@@ -494,10 +566,31 @@ func (r *ConfigAuditReportReconciler) processFailedScanJob(ctx context.Context,
 		}
 		log.Error(nil, "Scan job container", "container", container, "status.reason", status.Reason, "status.message", status.Message)
 	}
+
+	ownerRef, err := kube.ObjectRefFromObjectMeta(scanJob.ObjectMeta)
+	if err != nil {
+		return fmt.Errorf("getting owner ref from scan job metadata: %w", err)
+	}
+	owner, err := r.ObjectFromObjectRef(ctx, ownerRef)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("getting object from object ref: %w", err)
+	}
+	if err == nil {
+		failure := scanfailure.NewFailure(v1alpha1.ConfigAuditReportKind, r.PluginContext.GetName(),
+			scanJob.Labels[starboard.LabelResourceSpecHash], scanJob, statuses)
+		retryAfter, err := r.RecordFailure(ctx, owner, failure)
+		if err != nil {
+			return fmt.Errorf("recording scan failure: %w", err)
+		}
+		log.V(1).Info("Recorded scan failure", "owner", ownerRef, "reason", failure.Reason, "retryAfter", retryAfter)
+	}
+
 	log.V(1).Info("Deleting failed scan job")
-	return r.Client.Delete(ctx, scanJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return r.deleteJob(ctx, scanJob)
 }
 
+// deleteJob deletes the processed scan job. Metrics are recorded only if the
+// job hasn't been deleted yet, so that each job is recorded once.
 func (r *ConfigAuditReportReconciler) deleteJob(ctx context.Context, job *batchv1.Job) error {
 	err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
 	if err != nil {
@@ -506,5 +599,6 @@ func (r *ConfigAuditReportReconciler) deleteJob(ctx context.Context, job *batchv
 		}
 		return fmt.Errorf("deleting job: %w", err)
 	}
+	metrics.RecordScanJob(v1alpha1.ConfigAuditReportKind, r.PluginContext.GetName(), job)
 	return nil
 }
@@ -0,0 +1,498 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/metrics"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// reservationTimeout is the duration for which a slot is reserved for an
+// admitted scan job until the job shows up in the cache.
+const reservationTimeout = 15 * time.Second
+
+// ScanRequest describes a scan job that a controller is about to create for
+// the specified Object.
+type ScanRequest struct {
+	// Consumer is the name of the source returned by ScanQueue.Source to
+	// which the Object is sent when the scan job is admitted.
+	Consumer string
+	Kind     kube.Kind
+	Object   client.Object
+	// NeverScanned is true if the Object does not have any reports yet.
+	NeverScanned bool
+}
+
+func (r ScanRequest) key() string {
+	return scanKey(r.Consumer, r.Object.GetNamespace(), r.Object.GetName())
+}
+
+func scanKey(consumer, namespace, name string) string {
+	return consumer + "/" + namespace + "/" + name
+}
+
+// VulnerabilityReportScanQueueConsumer returns the name of the consumer of
+// ScanRequests submitted by the controller of the specified vulnerability
+// scanner for workloads of the given kind.
+func VulnerabilityReportScanQueueConsumer(scanner string, kind kube.Kind) string {
+	return fmt.Sprintf("vulnerabilityreport/%s/%s", scanner, kind)
+}
+
+// ConfigAuditReportScanQueueConsumer returns the name of the consumer of
+// ScanRequests submitted by the controller of the specified configuration
+// audit plugin for resources of the given kind.
+func ConfigAuditReportScanQueueConsumer(plugin string, kind kube.Kind) string {
+	return fmt.Sprintf("configauditreport/%s/%s", plugin, kind)
+}
+
+// scanJobKey returns the key of the ScanRequest that the specified scan job
+// was created for, and the reference to the scanned object. The consumer of
+// the request is derived from the scanner labels of the job. Returns false if
+// the job is not a scan job, e.g. the job that updates the vulnerability
+// database.
+func scanJobKey(job batchv1.Job) (string, kube.ObjectRef, bool) {
+	ref, err := kube.ObjectRefFromObjectMeta(job.ObjectMeta)
+	if err != nil {
+		return "", ref, false
+	}
+	var consumer string
+	if scanner, ok := job.Labels[starboard.LabelVulnerabilityReportScanner]; ok {
+		consumer = VulnerabilityReportScanQueueConsumer(scanner, ref.Kind)
+	} else if plugin, ok := job.Labels[starboard.LabelConfigAuditReportScanner]; ok {
+		consumer = ConfigAuditReportScanQueueConsumer(plugin, ref.Kind)
+	} else if _, ok := job.Labels[starboard.LabelKubeBenchReportScanner]; ok {
+		consumer = kubeBenchScanQueueConsumer
+	} else {
+		return "", ref, false
+	}
+	return scanKey(consumer, ref.Namespace, ref.Name), ref, true
+}
+
+// isJobFinished returns true if the specified job has completed or failed.
+func isJobFinished(job batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) &&
+			condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanQueue limits the number of scan jobs created concurrently by the
+// operator.
+//
+// Source returns a source.Source of events for the named consumer, which
+// should be watched by the controller that submits ScanRequests with that
+// consumer name.
+//
+// Admit returns true if the scan job described by the specified ScanRequest
+// may be created. Otherwise, the request is queued and its Object is sent to
+// the consumer's source as soon as the scan job is admitted.
+//
+// Forget drops the queued request and releases the slot reserved for the
+// object with the specified key, e.g. because the object was deleted before
+// its scan job was created.
+type ScanQueue interface {
+	Source(consumer string) source.Source
+	Admit(ctx context.Context, request ScanRequest) (bool, error)
+	Forget(ctx context.Context, consumer string, key client.ObjectKey) error
+}
+
+type queuedScan struct {
+	key      string
+	request  ScanRequest
+	priority int
+	enqueued time.Time
+}
+
+type reservation struct {
+	namespace string
+	expires   time.Time
+}
+
+// FairScanQueue is a ScanQueue which admits scan jobs in order of their
+// priority. The priority is read from the starboard.AnnotationScanPriority
+// annotation of the scanned object, or the starboard.LabelScanPriority label
+// of its namespace, and defaults to 0. Objects that have never been scanned
+// go first among scan jobs of the same priority. Otherwise, namespaces take
+// turns, so that a namespace with many workloads does not starve others.
+//
+// FairScanQueue also reconciles scan jobs to admit queued scan jobs when
+// running ones complete and are deleted.
+type FairScanQueue struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Client
+	ext.Clock
+
+	mu       sync.Mutex
+	sources  map[string]chan event.GenericEvent
+	queued   map[string]*queuedScan
+	pending  map[string][]*queuedScan
+	served   map[string]uint64
+	sequence uint64
+	reserved map[string]reservation
+}
+
+// NewFairScanQueue constructs a new FairScanQueue.
+func NewFairScanQueue(logger logr.Logger, config etc.Config, starboardConfig starboard.ConfigData, c client.Client, clock ext.Clock) *FairScanQueue {
+	return &FairScanQueue{
+		Logger:     logger,
+		Config:     config,
+		ConfigData: starboardConfig,
+		Client:     c,
+		Clock:      clock,
+		sources:    make(map[string]chan event.GenericEvent),
+		queued:     make(map[string]*queuedScan),
+		pending:    make(map[string][]*queuedScan),
+		served:     make(map[string]uint64),
+		reserved:   make(map[string]reservation),
+	}
+}
+
+func (q *FairScanQueue) SetupWithManager(mgr ctrl.Manager) error {
+	var predicates []predicate.Predicate
+	if !q.ConfigData.VulnerabilityScanJobsInSameNamespace() {
+		predicates = append(predicates, InNamespace(q.Config.Namespace))
+	}
+	predicates = append(predicates, ManagedByStarboardOperator)
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("scanqueue").
+		For(&batchv1.Job{}, builder.WithPredicates(predicates...)).
+		Complete(q)
+}
+
+func (q *FairScanQueue) Source(consumer string) source.Source {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ch, ok := q.sources[consumer]
+	if !ok {
+		ch = make(chan event.GenericEvent)
+		q.sources[consumer] = ch
+	}
+	return &source.Channel{Source: ch}
+}
+
+func (q *FairScanQueue) Admit(ctx context.Context, request ScanRequest) (bool, error) {
+	priority, err := q.getPriority(ctx, request.Object)
+	if err != nil {
+		return false, err
+	}
+	jobs, err := q.listScanJobs(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	key := request.key()
+	q.mu.Lock()
+	now := q.Clock.Now()
+	if r, ok := q.reserved[key]; ok && now.Before(r.expires) {
+		q.mu.Unlock()
+		return true, nil
+	}
+	q.push(&queuedScan{
+		key:      key,
+		request:  request,
+		priority: priority,
+		enqueued: now,
+	})
+	admitted := q.dispatch(jobs, now)
+	q.mu.Unlock()
+
+	_, ok := admitted[key]
+	delete(admitted, key)
+	q.notify(admitted)
+	return ok, nil
+}
+
+func (q *FairScanQueue) Forget(ctx context.Context, consumer string, key client.ObjectKey) error {
+	requestKey := scanKey(consumer, key.Namespace, key.Name)
+	q.mu.Lock()
+	if queued, ok := q.queued[requestKey]; ok {
+		q.remove(key.Namespace, queued)
+	}
+	_, isReserved := q.reserved[requestKey]
+	delete(q.reserved, requestKey)
+	q.mu.Unlock()
+	if !isReserved {
+		return nil
+	}
+
+	// Admit the next queued scan job in place of the released one.
+	jobs, err := q.listScanJobs(ctx)
+	if err != nil {
+		return err
+	}
+	q.mu.Lock()
+	admitted := q.dispatch(jobs, q.Clock.Now())
+	q.mu.Unlock()
+	q.notify(admitted)
+	return nil
+}
+
+// Reconcile admits queued scan jobs whenever a scan job is created, updated,
+// or deleted.
+func (q *FairScanQueue) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	jobs, err := q.listScanJobs(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	q.mu.Lock()
+	now := q.Clock.Now()
+	admitted := q.dispatch(jobs, now)
+	requeueAfter := q.nextExpiry(now)
+	q.mu.Unlock()
+
+	q.notify(admitted)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (q *FairScanQueue) listScanJobs(ctx context.Context) ([]batchv1.Job, error) {
+	var scanJobs batchv1.JobList
+	listOptions := []client.ListOption{client.MatchingLabels{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+	}}
+	if !q.ConfigData.VulnerabilityScanJobsInSameNamespace() {
+		// scan jobs are running in only starboard operator namespace
+		listOptions = append(listOptions, client.InNamespace(q.Config.Namespace))
+	}
+	err := q.Client.List(ctx, &scanJobs, listOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("listing scan jobs: %w", err)
+	}
+	return scanJobs.Items, nil
+}
+
+// getPriority returns the priority of scan jobs of the specified object.
+func (q *FairScanQueue) getPriority(ctx context.Context, obj client.Object) (int, error) {
+	if priority, ok := parsePriority(obj.GetAnnotations()[starboard.AnnotationScanPriority]); ok {
+		return priority, nil
+	}
+	if obj.GetNamespace() == "" {
+		return 0, nil
+	}
+	var ns corev1.Namespace
+	err := q.Client.Get(ctx, client.ObjectKey{Name: obj.GetNamespace()}, &ns)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("getting namespace: %w", err)
+	}
+	priority, _ := parsePriority(ns.Labels[starboard.LabelScanPriority])
+	return priority, nil
+}
+
+func parsePriority(value string) (int, bool) {
+	if value == "" {
+		return 0, false
+	}
+	priority, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return priority, true
+}
+
+// push adds the specified scan to the queue of its namespace, or updates the
+// priority of the scan if it's already queued.
+func (q *FairScanQueue) push(scan *queuedScan) {
+	namespace := scan.request.Object.GetNamespace()
+	if queued, ok := q.queued[scan.key]; ok {
+		scan.enqueued = queued.enqueued
+		q.remove(namespace, queued)
+	}
+	scans := q.pending[namespace]
+	i := sort.Search(len(scans), func(i int) bool {
+		return scanLess(scan, scans[i])
+	})
+	scans = append(scans, nil)
+	copy(scans[i+1:], scans[i:])
+	scans[i] = scan
+	q.pending[namespace] = scans
+	q.queued[scan.key] = scan
+	metrics.SetScanQueueDepth(namespace, len(scans))
+}
+
+func (q *FairScanQueue) remove(namespace string, scan *queuedScan) {
+	scans := q.pending[namespace]
+	for i := range scans {
+		if scans[i] == scan {
+			scans = append(scans[:i], scans[i+1:]...)
+			break
+		}
+	}
+	q.setPending(namespace, scans)
+	delete(q.queued, scan.key)
+}
+
+func (q *FairScanQueue) setPending(namespace string, scans []*queuedScan) {
+	if len(scans) == 0 {
+		delete(q.pending, namespace)
+	} else {
+		q.pending[namespace] = scans
+	}
+	metrics.SetScanQueueDepth(namespace, len(scans))
+}
+
+// scanLess orders scans of a namespace by priority, then never scanned
+// objects first, and then by the time they were queued.
+func scanLess(a, b *queuedScan) bool {
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	if a.request.NeverScanned != b.request.NeverScanned {
+		return a.request.NeverScanned
+	}
+	if !a.enqueued.Equal(b.enqueued) {
+		return a.enqueued.Before(b.enqueued)
+	}
+	return a.key < b.key
+}
+
+// dispatch admits queued scans while the number of running scan jobs and
+// reserved slots is below the configured limits. Jobs other than scan jobs,
+// and scan jobs that have already finished, do not count against the limits.
+// It returns admitted scan requests by their keys.
+func (q *FairScanQueue) dispatch(jobs []batchv1.Job, now time.Time) map[string]ScanRequest {
+	total := 0
+	running := make(map[string]int)
+	observed := make(map[string]bool)
+	for _, job := range jobs {
+		key, ref, ok := scanJobKey(job)
+		if !ok {
+			continue
+		}
+		observed[key] = true
+		if isJobFinished(job) {
+			continue
+		}
+		total++
+		running[ref.Namespace]++
+	}
+	for key, r := range q.reserved {
+		if observed[key] || !now.Before(r.expires) {
+			delete(q.reserved, key)
+			continue
+		}
+		total++
+		running[r.namespace]++
+	}
+
+	admitted := make(map[string]ScanRequest)
+	for total < q.Config.ConcurrentScanJobsLimit {
+		scan := q.pop(running)
+		if scan == nil {
+			break
+		}
+		namespace := scan.request.Object.GetNamespace()
+		q.reserved[scan.key] = reservation{namespace: namespace, expires: now.Add(reservationTimeout)}
+		total++
+		running[namespace]++
+		metrics.RecordScanQueueWait(now.Sub(scan.enqueued))
+		admitted[scan.key] = scan.request
+	}
+	if len(q.queued) > 0 {
+		q.Logger.V(1).Info("Queueing scan jobs", "running", total, "limit", q.Config.ConcurrentScanJobsLimit,
+			"queued", len(q.queued))
+	}
+	return admitted
+}
+
+// pop removes and returns the next scan to admit, or nil if the queue is
+// empty or all namespaces with queued scans reached the limit of scan jobs
+// per namespace.
+func (q *FairScanQueue) pop(running map[string]int) *queuedScan {
+	var next string
+	found := false
+	for namespace := range q.pending {
+		limit := q.Config.ConcurrentScanJobsPerNamespaceLimit
+		if namespace != "" && limit > 0 && running[namespace] >= limit {
+			continue
+		}
+		if !found || q.namespaceLess(namespace, next) {
+			next = namespace
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	scans := q.pending[next]
+	scan := scans[0]
+	q.setPending(next, scans[1:])
+	delete(q.queued, scan.key)
+	q.sequence++
+	q.served[next] = q.sequence
+	return scan
+}
+
+// namespaceLess orders namespaces by the next scan in their queues, and then
+// by the least recently served namespace.
+func (q *FairScanQueue) namespaceLess(a, b string) bool {
+	headA, headB := q.pending[a][0], q.pending[b][0]
+	if headA.priority != headB.priority {
+		return headA.priority > headB.priority
+	}
+	if headA.request.NeverScanned != headB.request.NeverScanned {
+		return headA.request.NeverScanned
+	}
+	if q.served[a] != q.served[b] {
+		return q.served[a] < q.served[b]
+	}
+	return a < b
+}
+
+// nextExpiry returns the duration until the earliest reservation expires if
+// there are queued scans waiting for a slot.
+func (q *FairScanQueue) nextExpiry(now time.Time) time.Duration {
+	if len(q.queued) == 0 {
+		return 0
+	}
+	var next time.Duration
+	for _, r := range q.reserved {
+		if d := r.expires.Sub(now); next == 0 || d < next {
+			next = d
+		}
+	}
+	return next
+}
+
+// notify sends objects of the specified admitted scan requests to sources of
+// their consumers.
+func (q *FairScanQueue) notify(admitted map[string]ScanRequest) {
+	for _, request := range admitted {
+		q.mu.Lock()
+		ch, ok := q.sources[request.Consumer]
+		q.mu.Unlock()
+		if !ok {
+			continue
+		}
+		go func(ch chan<- event.GenericEvent, obj client.Object) {
+			ch <- event.GenericEvent{Object: obj}
+		}(ch, request.Object)
+	}
+}
@@ -0,0 +1,290 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var _ = Describe("FairScanQueue", func() {
+
+	config := etc.Config{
+		Namespace:               "starboard-operator",
+		ConcurrentScanJobsLimit: 2,
+	}
+	defaultStarboardConfig := starboard.GetDefaultConfig()
+	now := time.Date(2022, time.September, 1, 10, 0, 0, 0, time.UTC)
+
+	newScanJob := func(name, namespace string, workload kube.ObjectRef) *batchv1.Job {
+		labels := kube.ObjectRefToLabels(workload)
+		labels[starboard.LabelK8SAppManagedBy] = starboard.AppStarboard
+		labels[starboard.LabelVulnerabilityReportScanner] = "Trivy"
+		return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		}}
+	}
+
+	newPod := func(name, namespace string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: annotations,
+		}}
+	}
+
+	newRequest := func(pod *corev1.Pod, neverScanned bool) controller.ScanRequest {
+		return controller.ScanRequest{
+			Consumer:     "vulnerabilityreport/Trivy/Pod",
+			Kind:         kube.KindPod,
+			Object:       pod,
+			NeverScanned: neverScanned,
+		}
+	}
+
+	admit := func(queue controller.ScanQueue, request controller.ScanRequest) bool {
+		admitted, err := queue.Admit(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		return admitted
+	}
+
+	// admitNext frees a slot by deleting the specified job and returns the
+	// name of the workload that is admitted next.
+	admitNext := func(queue *controller.FairScanQueue, c client.Client, job *batchv1.Job, events <-chan event.GenericEvent) string {
+		Expect(c.Delete(context.TODO(), job)).To(Succeed())
+		_, err := queue.Reconcile(context.TODO(), ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		var e event.GenericEvent
+		Eventually(events).Should(Receive(&e))
+		return e.Object.GetName()
+	}
+
+	newQueue := func(c client.Client, config etc.Config) (*controller.FairScanQueue, <-chan event.GenericEvent) {
+		queue := controller.NewFairScanQueue(logr.Discard(), config, defaultStarboardConfig, c, ext.NewFixedClock(now))
+		return queue, queue.Source("vulnerabilityreport/Trivy/Pod").(*source.Channel).Source
+	}
+
+	Context("When there are less jobs than limit", func() {
+
+		It("Should admit scan job", func() {
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:      "logs-exporter",
+					Namespace: "starboard-operator",
+				}},
+				newScanJob("scan-vulnerabilityreport-hash1", "starboard-operator",
+					kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "default"}),
+			).Build()
+
+			queue, _ := newQueue(c, config)
+			Expect(admit(queue, newRequest(newPod("redis", "default", nil), false))).To(BeTrue())
+			Expect(admit(queue, newRequest(newPod("mysql", "default", nil), false))).To(BeFalse())
+		})
+
+	})
+
+	Context("When there are finished scan jobs and jobs other than scan jobs", func() {
+
+		It("Should not count them against the limit", func() {
+			completed := newScanJob("scan-vulnerabilityreport-hash1", "starboard-operator",
+				kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "default"})
+			completed.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}
+			failed := newScanJob("scan-vulnerabilityreport-hash2", "starboard-operator",
+				kube.ObjectRef{Kind: kube.KindPod, Name: "mongo", Namespace: "default"})
+			failed.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+			}
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:      "update-trivy-db",
+					Namespace: "starboard-operator",
+					Labels: map[string]string{
+						starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+					},
+				}},
+				completed,
+				failed,
+			).Build()
+
+			queue, _ := newQueue(c, config)
+			Expect(admit(queue, newRequest(newPod("redis", "default", nil), false))).To(BeTrue())
+			Expect(admit(queue, newRequest(newPod("mysql", "default", nil), false))).To(BeTrue())
+			Expect(admit(queue, newRequest(newPod("postgres", "default", nil), false))).To(BeFalse())
+		})
+
+	})
+
+	Context("When there are more jobs than limit running in different namespace", func() {
+
+		It("Should queue scan job", func() {
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				newScanJob("scan-vulnerabilityreport-hash1", "default",
+					kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "default"}),
+				newScanJob("scan-configauditreport-hash2", "prod",
+					kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "prod"}),
+			).Build()
+			starboardConfig := starboard.ConfigData{starboard.KeyVulnerabilityScansInSameNamespace: "true"}
+
+			queue := controller.NewFairScanQueue(logr.Discard(), config, starboardConfig, c, ext.NewFixedClock(now))
+			Expect(admit(queue, newRequest(newPod("redis", "stage", nil), false))).To(BeFalse())
+		})
+
+	})
+
+	Context("When scan jobs are queued", func() {
+
+		It("Should admit scan jobs by priority", func() {
+			running := newScanJob("scan-vulnerabilityreport-hash1", "starboard-operator",
+				kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "default"})
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:   "prod",
+					Labels: map[string]string{starboard.LabelScanPriority: "10"},
+				}},
+				running,
+				newScanJob("scan-vulnerabilityreport-hash2", "starboard-operator",
+					kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "prod"}),
+			).Build()
+
+			queue, events := newQueue(c, config)
+			Expect(admit(queue, newRequest(newPod("low", "default", nil), false))).To(BeFalse())
+			Expect(admit(queue, newRequest(newPod("never-scanned", "default", nil), true))).To(BeFalse())
+			Expect(admit(queue, newRequest(newPod("high", "default", map[string]string{
+				starboard.AnnotationScanPriority: "100",
+			}), false))).To(BeFalse())
+			Expect(admit(queue, newRequest(newPod("prod", "prod", nil), false))).To(BeFalse())
+
+			Expect(admitNext(queue, c, running, events)).To(Equal("high"))
+			Expect(admit(queue, newRequest(newPod("high", "default", nil), false))).To(BeTrue())
+			Expect(admit(queue, newRequest(newPod("prod", "prod", nil), false))).To(BeFalse())
+		})
+
+		It("Should take turns between namespaces", func() {
+			running := newScanJob("scan-vulnerabilityreport-hash1", "starboard-operator",
+				kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "default"})
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(running).Build()
+
+			queue, events := newQueue(c, etc.Config{
+				Namespace:               "starboard-operator",
+				ConcurrentScanJobsLimit: 1,
+			})
+			Expect(admit(queue, newRequest(newPod("pod-1", "default", nil), false))).To(BeFalse())
+			Expect(admit(queue, newRequest(newPod("pod-2", "default", nil), false))).To(BeFalse())
+			Expect(admit(queue, newRequest(newPod("pod-3", "stage", nil), false))).To(BeFalse())
+
+			Expect(admitNext(queue, c, running, events)).To(Equal("pod-1"))
+			running = newScanJob("scan-vulnerabilityreport-hash2", "starboard-operator",
+				kube.ObjectRef{Kind: kube.KindPod, Name: "pod-1", Namespace: "default"})
+			Expect(c.Create(context.TODO(), running)).To(Succeed())
+			_, err := queue.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(admitNext(queue, c, running, events)).To(Equal("pod-3"))
+		})
+
+		It("Should admit next scan job when admitted one is forgotten", func() {
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+
+			queue, events := newQueue(c, etc.Config{
+				Namespace:               "starboard-operator",
+				ConcurrentScanJobsLimit: 1,
+			})
+			Expect(admit(queue, newRequest(newPod("pod-1", "default", nil), false))).To(BeTrue())
+			Expect(admit(queue, newRequest(newPod("pod-2", "default", nil), false))).To(BeFalse())
+
+			Expect(queue.Forget(context.TODO(), "vulnerabilityreport/Trivy/Pod",
+				client.ObjectKey{Namespace: "default", Name: "pod-1"})).To(Succeed())
+			var e event.GenericEvent
+			Eventually(events).Should(Receive(&e))
+			Expect(e.Object.GetName()).To(Equal("pod-2"))
+		})
+
+		It("Should drop forgotten scan job from queue", func() {
+			running := newScanJob("scan-vulnerabilityreport-hash1", "starboard-operator",
+				kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "default"})
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(running).Build()
+
+			queue, events := newQueue(c, etc.Config{
+				Namespace:               "starboard-operator",
+				ConcurrentScanJobsLimit: 1,
+			})
+			Expect(admit(queue, newRequest(newPod("deleted", "default", nil), true))).To(BeFalse())
+			Expect(admit(queue, newRequest(newPod("redis", "default", nil), false))).To(BeFalse())
+
+			Expect(queue.Forget(context.TODO(), "vulnerabilityreport/Trivy/Pod",
+				client.ObjectKey{Namespace: "default", Name: "deleted"})).To(Succeed())
+			Expect(admitNext(queue, c, running, events)).To(Equal("redis"))
+		})
+
+		It("Should not exceed limit of scan jobs per namespace", func() {
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				newScanJob("scan-vulnerabilityreport-hash1", "starboard-operator",
+					kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "default"}),
+			).Build()
+
+			queue, _ := newQueue(c, etc.Config{
+				Namespace:                           "starboard-operator",
+				ConcurrentScanJobsLimit:             10,
+				ConcurrentScanJobsPerNamespaceLimit: 1,
+			})
+			Expect(admit(queue, newRequest(newPod("redis", "default", nil), false))).To(BeFalse())
+			Expect(admit(queue, newRequest(newPod("redis", "stage", nil), false))).To(BeTrue())
+		})
+
+	})
+
+	Context("When a slot is reserved for an admitted scan job", func() {
+
+		It("Should keep reservation until scan job of the same consumer is observed", func() {
+			c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+
+			queue, _ := newQueue(c, config)
+			Expect(admit(queue, newRequest(newPod("redis", "default", nil), false))).To(BeTrue())
+
+			// A config audit scan job of the same Pod must not release the
+			// slot reserved for the vulnerability scan job.
+			configAuditJob := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name:      "scan-configauditreport-hash1",
+				Namespace: "starboard-operator",
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy:          starboard.AppStarboard,
+					starboard.LabelResourceKind:             "Pod",
+					starboard.LabelResourceName:             "redis",
+					starboard.LabelResourceNamespace:        "default",
+					starboard.LabelConfigAuditReportScanner: "Starboard",
+				},
+			}}
+			Expect(c.Create(context.TODO(), configAuditJob)).To(Succeed())
+			Expect(admit(queue, newRequest(newPod("mysql", "default", nil), false))).To(BeFalse())
+
+			// The reserved slot is taken by the vulnerability scan job once
+			// it's observed, hence completing the config audit scan job
+			// frees a slot.
+			Expect(c.Create(context.TODO(), newScanJob("scan-vulnerabilityreport-hash2", "starboard-operator",
+				kube.ObjectRef{Kind: kube.KindPod, Name: "redis", Namespace: "default"}))).To(Succeed())
+			Expect(c.Delete(context.TODO(), configAuditJob)).To(Succeed())
+			Expect(admit(queue, newRequest(newPod("mysql", "default", nil), false))).To(BeTrue())
+		})
+
+	})
+
+})
@@ -40,6 +40,18 @@ func (r *TTLReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err != nil {
 		return err
 	}
+
+	// ClusterVulnerabilityReports used to cache scan results by image digest
+	// are annotated with the cache TTL.
+	if r.Config.VulnerabilityScannerCacheTTL != nil {
+		err = ctrl.NewControllerManagedBy(mgr).
+			For(&v1alpha1.ClusterVulnerabilityReport{}, builder.WithPredicates(
+				predicate.Not(predicate.IsBeingTerminated))).
+			Complete(r.reconcileClusterReport())
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -57,27 +69,49 @@ func (r *TTLReportReconciler) reconcileReport() reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("getting report from cache: %w", err)
 		}
 
-		ttlReportAnnotationStr, ok := report.Annotations[v1alpha1.TTLReportAnnotation]
-		if !ok {
-			log.V(1).Info("Ignoring report without TTL set")
-			return ctrl.Result{}, nil
-		}
+		return r.deleteReportIfExpired(ctx, log, report, report.Report.UpdateTimestamp.Time)
+	}
+}
+
+func (r *TTLReportReconciler) reconcileClusterReport() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("report", req.Name)
 
-		reportTTLTime, err := time.ParseDuration(ttlReportAnnotationStr)
+		report := &v1alpha1.ClusterVulnerabilityReport{}
+		err := r.Client.Get(ctx, req.NamespacedName, report)
 		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed parsing %v with value %v %w", v1alpha1.TTLReportAnnotation, ttlReportAnnotationStr, err)
-		}
-		ttlExpired, durationToTTLExpiration := utils.IsTTLExpired(reportTTLTime, report.Report.UpdateTimestamp.Time, r.Clock)
-		if ttlExpired {
-			log.V(1).Info("Removing vulnerabilityReport with expired TTL")
-			err := r.Client.Delete(ctx, report, &client.DeleteOptions{})
-			if err != nil && !errors.IsNotFound(err) {
-				return ctrl.Result{}, err
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached report that must have been deleted")
+				return ctrl.Result{}, nil
 			}
-			// Since the report is deleted there is no reason to requeue
-			return ctrl.Result{}, nil
+			return ctrl.Result{}, fmt.Errorf("getting report from cache: %w", err)
+		}
+
+		return r.deleteReportIfExpired(ctx, log, report, report.Report.UpdateTimestamp.Time)
+	}
+}
+
+func (r *TTLReportReconciler) deleteReportIfExpired(ctx context.Context, log logr.Logger, report client.Object, updateTimestamp time.Time) (ctrl.Result, error) {
+	ttlReportAnnotationStr, ok := report.GetAnnotations()[v1alpha1.TTLReportAnnotation]
+	if !ok {
+		log.V(1).Info("Ignoring report without TTL set")
+		return ctrl.Result{}, nil
+	}
+
+	reportTTLTime, err := time.ParseDuration(ttlReportAnnotationStr)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed parsing %v with value %v %w", v1alpha1.TTLReportAnnotation, ttlReportAnnotationStr, err)
+	}
+	ttlExpired, durationToTTLExpiration := utils.IsTTLExpired(reportTTLTime, updateTimestamp, r.Clock)
+	if ttlExpired {
+		log.V(1).Info("Removing report with expired TTL")
+		err := r.Client.Delete(ctx, report, &client.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
 		}
-		log.V(1).Info("RequeueAfter", "durationToTTLExpiration", durationToTTLExpiration)
-		return ctrl.Result{RequeueAfter: durationToTTLExpiration}, nil
+		// Since the report is deleted there is no reason to requeue
+		return ctrl.Result{}, nil
 	}
+	log.V(1).Info("RequeueAfter", "durationToTTLExpiration", durationToTTLExpiration)
+	return ctrl.Result{RequeueAfter: durationToTTLExpiration}, nil
 }
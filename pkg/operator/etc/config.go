@@ -17,16 +17,28 @@ type Config struct {
 	LogDevMode                                   bool           `env:"OPERATOR_LOG_DEV_MODE" envDefault:"false"`
 	ScanJobTimeout                               time.Duration  `env:"OPERATOR_SCAN_JOB_TIMEOUT" envDefault:"5m"`
 	ConcurrentScanJobsLimit                      int            `env:"OPERATOR_CONCURRENT_SCAN_JOBS_LIMIT" envDefault:"10"`
+	ConcurrentScanJobsPerNamespaceLimit          int            `env:"OPERATOR_CONCURRENT_SCAN_JOBS_PER_NAMESPACE_LIMIT" envDefault:"0"`
 	ScanJobRetryAfter                            time.Duration  `env:"OPERATOR_SCAN_JOB_RETRY_AFTER" envDefault:"30s"`
+	ScanJobFailureBackoff                        time.Duration  `env:"OPERATOR_SCAN_JOB_FAILURE_BACKOFF" envDefault:"1m"`
+	ScanJobFailureMaxBackoff                     time.Duration  `env:"OPERATOR_SCAN_JOB_FAILURE_MAX_BACKOFF" envDefault:"1h"`
 	BatchDeleteLimit                             int            `env:"OPERATOR_BATCH_DELETE_LIMIT" envDefault:"10"`
 	BatchDeleteDelay                             time.Duration  `env:"OPERATOR_BATCH_DELETE_DELAY" envDefault:"10s"`
 	MetricsBindAddress                           string         `env:"OPERATOR_METRICS_BIND_ADDRESS" envDefault:":8080"`
 	HealthProbeBindAddress                       string         `env:"OPERATOR_HEALTH_PROBE_BIND_ADDRESS" envDefault:":9090"`
+	MetricsReportsEnabled                        bool           `env:"OPERATOR_METRICS_REPORTS_ENABLED" envDefault:"false"`
+	WebhookBindPort                              int            `env:"OPERATOR_WEBHOOK_BIND_PORT" envDefault:"9443"`
+	WebhookCertDir                               string         `env:"OPERATOR_WEBHOOK_CERT_DIR" envDefault:"/tmp/k8s-webhook-server/serving-certs"`
+	AdmissionWebhookEnabled                      bool           `env:"OPERATOR_ADMISSION_WEBHOOK_ENABLED" envDefault:"false"`
 	CISKubernetesBenchmarkEnabled                bool           `env:"OPERATOR_CIS_KUBERNETES_BENCHMARK_ENABLED" envDefault:"true"`
 	VulnerabilityScannerEnabled                  bool           `env:"OPERATOR_VULNERABILITY_SCANNER_ENABLED" envDefault:"true"`
 	VulnerabilityScannerScanOnlyCurrentRevisions bool           `env:"OPERATOR_VULNERABILITY_SCANNER_SCAN_ONLY_CURRENT_REVISIONS" envDefault:"false"`
 	VulnerabilityScannerReportTTL                *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL"`
+	VulnerabilityScannerCacheTTL                 *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_CACHE_TTL"`
+	VulnerabilityScannerRescanInterval           *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_RESCAN_INTERVAL"`
 	ClusterComplianceEnabled                     bool           `env:"OPERATOR_CLUSTER_COMPLIANCE_ENABLED" envDefault:"true"`
+	NamespaceSecuritySummaryEnabled              bool           `env:"OPERATOR_NAMESPACE_SECURITY_SUMMARY_ENABLED" envDefault:"true"`
+	ReportHistoryEnabled                         bool           `env:"OPERATOR_REPORT_HISTORY_ENABLED" envDefault:"true"`
+	ReportHistoryLimit                           int            `env:"OPERATOR_REPORT_HISTORY_LIMIT" envDefault:"50"`
 	ConfigAuditScannerEnabled                    bool           `env:"OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED" envDefault:"false"`
 	ConfigAuditScannerScanOnlyCurrentRevisions   bool           `env:"OPERATOR_CONFIG_AUDIT_SCANNER_SCAN_ONLY_CURRENT_REVISIONS" envDefault:"false"`
 
@@ -4,15 +4,28 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aquasecurity/starboard/pkg/admission"
 	"github.com/aquasecurity/starboard/pkg/compliance"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/exploitability"
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/aquasecurity/starboard/pkg/metrics"
+	"github.com/aquasecurity/starboard/pkg/namespacesummary"
+	"github.com/aquasecurity/starboard/pkg/notification"
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/reporthistory"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/scanfailure"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/trivydb"
+	"github.com/aquasecurity/starboard/pkg/trivyserver"
+	"github.com/aquasecurity/starboard/pkg/vex"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 var (
@@ -45,6 +59,11 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		HealthProbeBindAddress: operatorConfig.HealthProbeBindAddress,
 	}
 
+	if operatorConfig.AdmissionWebhookEnabled {
+		options.Port = operatorConfig.WebhookBindPort
+		options.CertDir = operatorConfig.WebhookCertDir
+	}
+
 	if operatorConfig.LeaderElectionEnabled {
 		options.LeaderElection = operatorConfig.LeaderElectionEnabled
 		options.LeaderElectionID = operatorConfig.LeaderElectionID
@@ -129,44 +148,127 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		return err
 	}
 	objectResolver := kube.NewObjectResolver(mgr.GetClient(), compatibleObjectMapper)
-	limitChecker := controller.NewLimitChecker(operatorConfig, mgr.GetClient(), starboardConfig)
+	scanQueue := controller.NewFairScanQueue(ctrl.Log.WithName("scanqueue"), operatorConfig, starboardConfig, mgr.GetClient(), ext.NewSystemClock())
+	if err = scanQueue.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to setup scan queue: %w", err)
+	}
 	logsReader := kube.NewLogsReader(kubeClientset)
 	secretsReader := kube.NewSecretsReader(mgr.GetClient())
+	notifier, err := notification.NewNotifier(ctrl.Log.WithName("notification"), ext.NewSystemClock(), starboardConfig)
+	if err != nil {
+		return fmt.Errorf("constructing notifier: %w", err)
+	}
+	if runnable, ok := notifier.(manager.Runnable); ok {
+		if err = mgr.Add(runnable); err != nil {
+			return fmt.Errorf("unable to setup notifier: %w", err)
+		}
+	}
+	scanFailureRecorder := scanfailure.NewRecorder(mgr.GetClient(), ext.NewSystemClock(),
+		mgr.GetEventRecorderFor("starboard-operator"), operatorNamespace,
+		operatorConfig.ScanJobFailureBackoff, operatorConfig.ScanJobFailureMaxBackoff)
 
 	if operatorConfig.VulnerabilityScannerEnabled {
-		plugin, pluginContext, err := plugin.NewResolver().
-			WithBuildInfo(buildInfo).
-			WithNamespace(operatorNamespace).
-			WithServiceAccountName(operatorConfig.ServiceAccount).
-			WithConfig(starboardConfig).
-			WithClient(mgr.GetClient()).
-			GetVulnerabilityPlugin()
+		scanners, err := starboardConfig.GetVulnerabilityReportsScanners()
 		if err != nil {
 			return err
 		}
 
-		err = plugin.Init(pluginContext)
-		if err != nil {
-			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
+		// Each scanner is run by its own controller, so that reports of multiple
+		// scanners can be compared side by side.
+		for _, scanner := range scanners {
+			plugin, pluginContext, err := plugin.NewResolver().
+				WithBuildInfo(buildInfo).
+				WithNamespace(operatorNamespace).
+				WithServiceAccountName(operatorConfig.ServiceAccount).
+				WithConfig(starboardConfig).
+				WithClient(mgr.GetClient()).
+				GetVulnerabilityPluginForScanner(scanner)
+			if err != nil {
+				return err
+			}
+
+			err = plugin.Init(pluginContext)
+			if err != nil {
+				return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
+			}
+
+			var readinessChecker vulnerabilityreport.ReadinessChecker
+			if scanner == trivy.Plugin {
+				readinessChecker = vulnerabilityreport.AllReady(
+					trivyserver.NewReadinessChecker(mgr.GetClient(), pluginContext),
+					trivydb.NewReadinessChecker(mgr.GetClient(), pluginContext),
+				)
+				if err = (&trivyserver.Controller{
+					Logger: ctrl.Log.WithName("reconciler").WithName("trivyserver"),
+					Config: operatorConfig,
+					Client: mgr.GetClient(),
+				}).SetupWithManager(mgr); err != nil {
+					return fmt.Errorf("unable to setup trivyserver reconciler: %w", err)
+				}
+				if err = (&trivydb.Controller{
+					Logger: ctrl.Log.WithName("reconciler").WithName("trivydb"),
+					Config: operatorConfig,
+					Clock:  ext.NewSystemClock(),
+					Client: mgr.GetClient(),
+				}).SetupWithManager(mgr); err != nil {
+					return fmt.Errorf("unable to setup trivydb reconciler: %w", err)
+				}
+			}
+
+			var reportCache vulnerabilityreport.ReportCache
+			if operatorConfig.VulnerabilityScannerCacheTTL != nil {
+				reportCache = vulnerabilityreport.NewReportCache(mgr.GetClient(), ext.NewSystemClock(), pluginContext, *operatorConfig.VulnerabilityScannerCacheTTL)
+			}
+
+			if err = (&vulnerabilityreport.WorkloadController{
+				Logger:           ctrl.Log.WithName("reconciler").WithName("vulnerabilityreport").WithValues("scanner", scanner),
+				Config:           operatorConfig,
+				Clock:            ext.NewSystemClock(),
+				ConfigData:       starboardConfig,
+				Client:           mgr.GetClient(),
+				ObjectResolver:   objectResolver,
+				ScanQueue:        scanQueue,
+				LogsReader:       logsReader,
+				SecretsReader:    secretsReader,
+				Plugin:           plugin,
+				PluginContext:    pluginContext,
+				ReadWriter:       vulnerabilityreport.NewNotifyingReadWriter(&objectResolver, notifier),
+				SBOMReadWriter:   sbomreport.NewReadWriter(&objectResolver),
+				ReportCache:      reportCache,
+				ExceptionsReader: vulnerabilityreport.NewExceptionsReader(mgr.GetClient(), ext.NewSystemClock()),
+				Recorder:         scanFailureRecorder,
+				Enricher:         exploitability.NewEnricher(starboardConfig),
+				StatementsReader: vex.NewStatementsReader(ctrl.Log.WithName("vex"), mgr.GetClient(), operatorNamespace),
+				ReadinessChecker: readinessChecker,
+				ScanJobTemplates: scanjobtemplate.NewReader(mgr.GetClient()),
+			}).SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup vulnerabilityreport reconciler for %s: %w", scanner, err)
+			}
 		}
 
-		if err = (&vulnerabilityreport.WorkloadController{
-			Logger:         ctrl.Log.WithName("reconciler").WithName("vulnerabilityreport"),
-			Config:         operatorConfig,
-			ConfigData:     starboardConfig,
-			Client:         mgr.GetClient(),
-			ObjectResolver: objectResolver,
-			LimitChecker:   limitChecker,
-			LogsReader:     logsReader,
-			SecretsReader:  secretsReader,
-			Plugin:         plugin,
-			PluginContext:  pluginContext,
-			ReadWriter:     vulnerabilityreport.NewReadWriter(&objectResolver),
+		if err = (&vulnerabilityreport.ExceptionController{
+			Logger:           ctrl.Log.WithName("reconciler").WithName("vulnerabilityexception"),
+			Config:           operatorConfig,
+			Clock:            ext.NewSystemClock(),
+			Client:           mgr.GetClient(),
+			ObjectResolver:   objectResolver,
+			ExceptionsReader: vulnerabilityreport.NewExceptionsReader(mgr.GetClient(), ext.NewSystemClock()),
+			ReadWriter:       vulnerabilityreport.NewNotifyingReadWriter(&objectResolver, notifier),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup vulnerabilityexception reconciler: %w", err)
+		}
+
+		if err = (&vulnerabilityreport.VEXController{
+			Logger:           ctrl.Log.WithName("reconciler").WithName("vex"),
+			Config:           operatorConfig,
+			Client:           mgr.GetClient(),
+			StatementsReader: vex.NewStatementsReader(ctrl.Log.WithName("vex"), mgr.GetClient(), operatorNamespace),
+			ReadWriter:       vulnerabilityreport.NewNotifyingReadWriter(&objectResolver, notifier),
 		}).SetupWithManager(mgr); err != nil {
-			return fmt.Errorf("unable to setup vulnerabilityreport reconciler: %w", err)
+			return fmt.Errorf("unable to setup vex reconciler: %w", err)
 		}
 
-		if operatorConfig.VulnerabilityScannerReportTTL != nil {
+		if operatorConfig.VulnerabilityScannerReportTTL != nil || operatorConfig.VulnerabilityScannerCacheTTL != nil {
 			if err = (&controller.TTLReportReconciler{
 				Logger: ctrl.Log.WithName("reconciler").WithName("ttlreport"),
 				Config: operatorConfig,
@@ -196,16 +298,18 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		}
 
 		if err = (&controller.ConfigAuditReportReconciler{
-			Logger:         ctrl.Log.WithName("reconciler").WithName("configauditreport"),
-			Config:         operatorConfig,
-			ConfigData:     starboardConfig,
-			Client:         mgr.GetClient(),
-			ObjectResolver: objectResolver,
-			LimitChecker:   limitChecker,
-			LogsReader:     logsReader,
-			Plugin:         plugin,
-			PluginContext:  pluginContext,
-			ReadWriter:     configauditreport.NewReadWriter(&objectResolver),
+			Logger:           ctrl.Log.WithName("reconciler").WithName("configauditreport"),
+			Config:           operatorConfig,
+			ConfigData:       starboardConfig,
+			Client:           mgr.GetClient(),
+			ObjectResolver:   objectResolver,
+			ScanQueue:        scanQueue,
+			LogsReader:       logsReader,
+			Plugin:           plugin,
+			PluginContext:    pluginContext,
+			ReadWriter:       configauditreport.NewNotifyingReadWriter(&objectResolver, notifier),
+			Recorder:         scanFailureRecorder,
+			ScanJobTemplates: scanjobtemplate.NewReader(mgr.GetClient()),
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup configauditreport reconciler: %w", err)
 		}
@@ -223,14 +327,16 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 
 	if operatorConfig.CISKubernetesBenchmarkEnabled {
 		if err = (&controller.CISKubeBenchReportReconciler{
-			Logger:       ctrl.Log.WithName("reconciler").WithName("ciskubebenchreport"),
-			Config:       operatorConfig,
-			ConfigData:   starboardConfig,
-			Client:       mgr.GetClient(),
-			LogsReader:   logsReader,
-			LimitChecker: limitChecker,
-			ReadWriter:   kubebench.NewReadWriter(mgr.GetClient()),
-			Plugin:       kubebench.NewKubeBenchPlugin(ext.NewSystemClock(), starboardConfig),
+			Logger:           ctrl.Log.WithName("reconciler").WithName("ciskubebenchreport"),
+			Config:           operatorConfig,
+			ConfigData:       starboardConfig,
+			Client:           mgr.GetClient(),
+			LogsReader:       logsReader,
+			ScanQueue:        scanQueue,
+			ReadWriter:       kubebench.NewNotifyingReadWriter(mgr.GetClient(), notifier),
+			Plugin:           kubebench.NewKubeBenchPlugin(ext.NewSystemClock(), starboardConfig),
+			Recorder:         scanFailureRecorder,
+			ScanJobTemplates: scanjobtemplate.NewReader(mgr.GetClient()),
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup ciskubebenchreport reconciler: %w", err)
 		}
@@ -244,7 +350,7 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			ConfigData:     starboardConfig,
 			Client:         mgr.GetClient(),
 			ObjectResolver: objectResolver,
-			ReadWriter:     configauditreport.NewReadWriter(&objectResolver),
+			ReadWriter:     configauditreport.NewNotifyingReadWriter(&objectResolver, notifier),
 			BuildInfo:      buildInfo,
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup resource controller: %w", err)
@@ -263,6 +369,58 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			return fmt.Errorf("unable to setup clustercompliancereport reconciler: %w", err)
 		}
 	}
+
+	if operatorConfig.NamespaceSecuritySummaryEnabled {
+		if err = (&namespacesummary.Controller{
+			Logger:     ctrl.Log.WithName("reconciler").WithName("namespacesecuritysummary"),
+			Config:     operatorConfig,
+			ConfigData: starboardConfig,
+			Clock:      ext.NewSystemClock(),
+			Client:     mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup namespacesecuritysummary reconciler: %w", err)
+		}
+	}
+
+	if operatorConfig.ReportHistoryEnabled {
+		if err = (&reporthistory.Controller{
+			Logger:         ctrl.Log.WithName("reconciler").WithName("reporthistory"),
+			Config:         operatorConfig,
+			ConfigData:     starboardConfig,
+			Client:         mgr.GetClient(),
+			ObjectResolver: objectResolver,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup reporthistory reconciler: %w", err)
+		}
+	}
+
+	if operatorConfig.AdmissionWebhookEnabled {
+		setupLog.Info("Enabling admission webhook")
+		if err = (&admission.Validator{
+			Logger:           ctrl.Log.WithName("webhook").WithName("admission"),
+			Config:           operatorConfig,
+			ConfigData:       starboardConfig,
+			ObjectResolver:   objectResolver,
+			ReadWriter:       vulnerabilityreport.NewReadWriter(&objectResolver),
+			ExceptionsReader: vulnerabilityreport.NewExceptionsReader(mgr.GetClient(), ext.NewSystemClock()),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup admission webhook: %w", err)
+		}
+	}
+
+	if operatorConfig.MetricsReportsEnabled {
+		setupLog.Info("Enabling security reports metrics")
+		err = ctrlmetrics.Registry.Register(&metrics.ReportsCollector{
+			Logger:     ctrl.Log.WithName("metrics").WithName("reports"),
+			Config:     operatorConfig,
+			ConfigData: starboardConfig,
+			Reader:     mgr.GetClient(),
+		})
+		if err != nil {
+			return fmt.Errorf("registering security reports metrics: %w", err)
+		}
+	}
+
 	setupLog.Info("Starting controllers manager")
 	if err := mgr.Start(ctx); err != nil {
 		return fmt.Errorf("starting controllers manager: %w", err)
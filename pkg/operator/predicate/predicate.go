@@ -111,6 +111,21 @@ var IsVulnerabilityReportScan = predicate.NewPredicateFuncs(func(obj client.Obje
 	return false
 })
 
+// IsVulnerabilityReportScanBy is a predicate.Predicate that returns true if
+// the specified client.Object is a vulnerability scan job run by the desired
+// scanner.
+var IsVulnerabilityReportScanBy = func(scanner string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return scanner == obj.GetLabels()[starboard.LabelVulnerabilityReportScanner]
+	})
+}
+
+// IsVEXDocument is a predicate.Predicate that returns true if the specified
+// client.Object is a ConfigMap that stores VEX documents.
+var IsVEXDocument = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	return obj.GetLabels()[starboard.LabelVEX] == "true"
+})
+
 var IsConfigAuditReportScan = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	if _, ok := obj.GetLabels()[starboard.LabelConfigAuditReportScanner]; ok {
 		return true
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -243,6 +244,78 @@ var _ = Describe("Predicate", func() {
 		})
 	})
 
+	Describe("When checking a IsVulnerabilityReportScanBy predicate", func() {
+		Context("When job is run by desired scanner", func() {
+			It("Should return true", func() {
+				instance := predicate.IsVulnerabilityReportScanBy("Grype")
+				obj := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							starboard.LabelVulnerabilityReportScanner: "Grype",
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeTrue())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+
+		Context("When job is run by another scanner", func() {
+			It("Should return false", func() {
+				instance := predicate.IsVulnerabilityReportScanBy("Grype")
+				obj := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							starboard.LabelVulnerabilityReportScanner: "Trivy",
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("When checking a IsVEXDocument predicate", func() {
+		Context("When config map is labeled as VEX document", func() {
+			It("Should return true", func() {
+				obj := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							starboard.LabelVEX: "true",
+						},
+					},
+				}
+
+				Expect(predicate.IsVEXDocument.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+				Expect(predicate.IsVEXDocument.Update(event.UpdateEvent{ObjectNew: obj})).To(BeTrue())
+				Expect(predicate.IsVEXDocument.Delete(event.DeleteEvent{Object: obj})).To(BeTrue())
+				Expect(predicate.IsVEXDocument.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+
+		Context("When config map is not labeled as VEX document", func() {
+			It("Should return false", func() {
+				obj := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "starboard",
+					},
+				}
+
+				Expect(predicate.IsVEXDocument.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(predicate.IsVEXDocument.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(predicate.IsVEXDocument.Delete(event.DeleteEvent{Object: obj})).To(BeFalse())
+				Expect(predicate.IsVEXDocument.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("When checking a ManagedByStarboardOperator predicate", func() {
 		instance := predicate.ManagedByStarboardOperator
 
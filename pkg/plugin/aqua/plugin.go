@@ -1,6 +1,7 @@
 package aqua
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -138,7 +139,7 @@ func (s *plugin) Init(_ starboard.PluginContext) error {
 	return nil
 }
 
-func (s *plugin) GetScanJobSpec(ctx starboard.PluginContext, object client.Object,
+func (s *plugin) GetScanJobSpec(_ context.Context, ctx starboard.PluginContext, object client.Object,
 	_ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
 	config, err := s.newConfigFrom(ctx)
 	if err != nil {
@@ -175,8 +176,9 @@ func (s *plugin) getPodSpecForImageCommand(ctx starboard.PluginContext, config C
 		return corev1.PodSpec{}, nil, err
 	}
 
-	scanJobContainers := make([]corev1.Container, len(spec.Containers))
-	for i, container := range spec.Containers {
+	containers := kube.GetContainers(spec)
+	scanJobContainers := make([]corev1.Container, len(containers))
+	for i, container := range containers {
 		var err error
 		scanJobContainers[i], err = s.newScanJobContainer(ctx, config, container)
 		if err != nil {
@@ -299,15 +301,16 @@ func (s *plugin) getPodSpecForFileSystemCommand(ctx starboard.PluginContext, con
 	if err != nil {
 		return corev1.PodSpec{}, nil, err
 	}
-	secretName := vulnerabilityreport.GetScanJobName(object) + "-volume"
+	secretName := vulnerabilityreport.GetScanJobName(ctx, object) + "-volume"
 	var env []corev1.EnvVar
 	envVars, err := s.getEnvFromConfig(ctx, secretName)
 	if err != nil {
 		return corev1.PodSpec{}, nil, err
 	}
 	env = append(env, envVars...)
-	scanJobContainers := make([]corev1.Container, len(spec.Containers))
-	for i, container := range spec.Containers {
+	containers := kube.GetContainers(spec)
+	scanJobContainers := make([]corev1.Container, len(containers))
+	for i, container := range containers {
 		var err error
 		scanJobContainers[i], err = s.newScanJobContainerFSCommand(config, container, env)
 		if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/plugin/aqua"
 	"github.com/aquasecurity/starboard/pkg/plugin/conftest"
+	"github.com/aquasecurity/starboard/pkg/plugin/grype"
 	"github.com/aquasecurity/starboard/pkg/plugin/polaris"
 	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
@@ -18,6 +19,7 @@ import (
 const (
 	Trivy    starboard.Scanner = "Trivy"
 	Aqua     starboard.Scanner = "Aqua"
+	Grype    starboard.Scanner = "Grype"
 	Polaris  starboard.Scanner = "Polaris"
 	Conftest starboard.Scanner = "Conftest"
 )
@@ -68,15 +70,23 @@ func (r *Resolver) WithClient(client client.Client) *Resolver {
 // GetVulnerabilityPlugin is a factory method that instantiates the vulnerabilityreport.Plugin.
 //
 // Starboard currently supports Trivy scanner in Standalone and ClientServer
-// mode, and Aqua Enterprise scanner.
+// mode, Aqua Enterprise scanner, and Anchore Grype scanner.
 //
 // You could add your own scanner by implementing the vulnerabilityreport.Plugin interface.
+//
+// If multiple scanners are configured, the primary one is instantiated.
 func (r *Resolver) GetVulnerabilityPlugin() (vulnerabilityreport.Plugin, starboard.PluginContext, error) {
 	scanner, err := r.config.GetVulnerabilityReportsScanner()
 	if err != nil {
 		return nil, nil, err
 	}
+	return r.GetVulnerabilityPluginForScanner(scanner)
+}
 
+// GetVulnerabilityPluginForScanner is a factory method that instantiates the
+// vulnerabilityreport.Plugin for the given scanner, which is one of the
+// scanners returned by starboard.ConfigData.GetVulnerabilityReportsScanners.
+func (r *Resolver) GetVulnerabilityPluginForScanner(scanner starboard.Scanner) (vulnerabilityreport.Plugin, starboard.PluginContext, error) {
 	pluginContext := starboard.NewPluginContext().
 		WithName(string(scanner)).
 		WithNamespace(r.namespace).
@@ -90,6 +100,8 @@ func (r *Resolver) GetVulnerabilityPlugin() (vulnerabilityreport.Plugin, starboa
 		return trivy.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator(), r.objectResolver), pluginContext, nil
 	case Aqua:
 		return aqua.NewPlugin(ext.NewGoogleUUIDGenerator(), r.buildInfo), pluginContext, nil
+	case Grype:
+		return grype.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator()), pluginContext, nil
 	}
 	return nil, nil, fmt.Errorf("unsupported vulnerability scanner plugin: %s", scanner)
 }
@@ -0,0 +1,2 @@
+// Package grype provides primitives for working with Anchore Grype.
+package grype
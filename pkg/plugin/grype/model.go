@@ -0,0 +1,67 @@
+package grype
+
+// Document is the JSON output of the grype command.
+type Document struct {
+	Matches    []Match    `json:"matches"`
+	Descriptor Descriptor `json:"descriptor"`
+}
+
+// Match is a vulnerability found in a package.
+type Match struct {
+	Vulnerability          Vulnerability           `json:"vulnerability"`
+	RelatedVulnerabilities []VulnerabilityMetadata `json:"relatedVulnerabilities"`
+	Artifact               Package                 `json:"artifact"`
+}
+
+type VulnerabilityMetadata struct {
+	ID          string   `json:"id"`
+	DataSource  string   `json:"dataSource"`
+	Namespace   string   `json:"namespace"`
+	Severity    string   `json:"severity"`
+	URLs        []string `json:"urls"`
+	Description string   `json:"description"`
+	Cvss        []Cvss   `json:"cvss"`
+}
+
+type Vulnerability struct {
+	VulnerabilityMetadata
+	Fix Fix `json:"fix"`
+}
+
+// FixState tells whether a vulnerability is fixed in any version of the
+// vulnerable package.
+type FixState string
+
+const (
+	FixStateFixed    FixState = "fixed"
+	FixStateNotFixed FixState = "not-fixed"
+	FixStateWontFix  FixState = "wont-fix"
+	FixStateUnknown  FixState = "unknown"
+)
+
+type Fix struct {
+	Versions []string `json:"versions"`
+	State    FixState `json:"state"`
+}
+
+type Cvss struct {
+	Version string      `json:"version"`
+	Vector  string      `json:"vector"`
+	Metrics CvssMetrics `json:"metrics"`
+}
+
+type CvssMetrics struct {
+	BaseScore float64 `json:"baseScore"`
+}
+
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	PURL    string `json:"purl"`
+}
+
+type Descriptor struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
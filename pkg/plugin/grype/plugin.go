@@ -0,0 +1,610 @@
+package grype
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Plugin the name of this plugin.
+	Plugin = "Grype"
+)
+
+const (
+	keyGrypeImageRef               = "grype.imageRef"
+	keyGrypeDBUpdateURL            = "grype.dbUpdateURL"
+	keyGrypeOnlyFixed              = "grype.onlyFixed"
+	keyGrypeAddCPEsIfNone          = "grype.addCPEsIfNone"
+	keyGrypeInsecureRegistryPrefix = "grype.insecureRegistry."
+	keyGrypeNonSslRegistryPrefix   = "grype.nonSslRegistry."
+	keyGrypeMirrorPrefix           = "grype.registry.mirror."
+	keyGrypeHTTPProxy              = "grype.httpProxy"
+	keyGrypeHTTPSProxy             = "grype.httpsProxy"
+	keyGrypeNoProxy                = "grype.noProxy"
+
+	keyResourcesRequestsCPU    = "grype.resources.requests.cpu"
+	keyResourcesRequestsMemory = "grype.resources.requests.memory"
+	keyResourcesLimitsCPU      = "grype.resources.limits.cpu"
+	keyResourcesLimitsMemory   = "grype.resources.limits.memory"
+)
+
+const (
+	tmpVolumeName = "tmp"
+	dbCacheDir    = "/tmp/grype/db"
+)
+
+// Config defines configuration params for this plugin.
+type Config struct {
+	starboard.PluginConfig
+}
+
+// GetImageRef returns upstream Grype container image reference.
+func (c Config) GetImageRef() (string, error) {
+	return c.GetRequiredData(keyGrypeImageRef)
+}
+
+func (c Config) GetInsecureRegistries() map[string]bool {
+	insecureRegistries := make(map[string]bool)
+	for key, val := range c.Data {
+		if strings.HasPrefix(key, keyGrypeInsecureRegistryPrefix) {
+			insecureRegistries[val] = true
+		}
+	}
+	return insecureRegistries
+}
+
+func (c Config) GetNonSSLRegistries() map[string]bool {
+	nonSSLRegistries := make(map[string]bool)
+	for key, val := range c.Data {
+		if strings.HasPrefix(key, keyGrypeNonSslRegistryPrefix) {
+			nonSSLRegistries[val] = true
+		}
+	}
+	return nonSSLRegistries
+}
+
+func (c Config) GetMirrors() map[string]string {
+	res := make(map[string]string)
+	for registryKey, mirror := range c.Data {
+		if !strings.HasPrefix(registryKey, keyGrypeMirrorPrefix) {
+			continue
+		}
+		res[strings.TrimPrefix(registryKey, keyGrypeMirrorPrefix)] = mirror
+	}
+	return res
+}
+
+// GetResourceRequirements creates ResourceRequirements from the Config.
+func (c Config) GetResourceRequirements() (corev1.ResourceRequirements, error) {
+	requirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+
+	err := c.setResourceLimit(keyResourcesRequestsCPU, &requirements.Requests, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesRequestsMemory, &requirements.Requests, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesLimitsCPU, &requirements.Limits, corev1.ResourceCPU)
+	if err != nil {
+		return requirements, err
+	}
+
+	err = c.setResourceLimit(keyResourcesLimitsMemory, &requirements.Limits, corev1.ResourceMemory)
+	if err != nil {
+		return requirements, err
+	}
+
+	return requirements, nil
+}
+
+func (c Config) setResourceLimit(configKey string, k8sResourceList *corev1.ResourceList, k8sResourceName corev1.ResourceName) error {
+	if value, found := c.Data[configKey]; found {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("parsing resource definition %s: %s %w", configKey, value, err)
+		}
+
+		(*k8sResourceList)[k8sResourceName] = quantity
+	}
+	return nil
+}
+
+type plugin struct {
+	clock       ext.Clock
+	idGenerator ext.IDGenerator
+}
+
+// NewPlugin constructs a new vulnerabilityreport.Plugin, which is using an
+// upstream Anchore Grype container image to scan Kubernetes workloads.
+//
+// Grype pulls scanned images directly from container registries, hence it
+// does not require access to the container runtime of cluster nodes.
+func NewPlugin(clock ext.Clock, idGenerator ext.IDGenerator) vulnerabilityreport.Plugin {
+	return &plugin{
+		clock:       clock,
+		idGenerator: idGenerator,
+	}
+}
+
+// Init ensures the default Config required by this plugin.
+func (p *plugin) Init(ctx starboard.PluginContext) error {
+	return ctx.EnsureConfig(starboard.PluginConfig{
+		Data: map[string]string{
+			keyGrypeImageRef: "docker.io/anchore/grype:v0.50.1",
+
+			keyResourcesRequestsCPU:    "100m",
+			keyResourcesRequestsMemory: "100M",
+			keyResourcesLimitsCPU:      "500m",
+			keyResourcesLimitsMemory:   "1G",
+		},
+	})
+}
+
+// GetScanJobSpec describes the pod of a scan job. There is the init container
+// that downloads the Grype vulnerability database and stores it in the
+// emptyDir volume shared with main containers:
+//
+//	grype db update
+//
+// The number of main containers correspond to the number of containers
+// defined for the scanned workload. Each container pulls the container image
+// from the registry and scans it without updating the database:
+//
+//	grype registry:<container image> --output json --quiet
+func (p *plugin) GetScanJobSpec(_ context.Context, ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	var secret *corev1.Secret
+	var secrets []*corev1.Secret
+	if len(credentials) > 0 {
+		secret = p.newSecretWithAggregateImagePullCredentials(ctx, workload, spec, credentials)
+		secrets = append(secrets, secret)
+	}
+
+	grypeImageRef, err := config.GetImageRef()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	requirements, err := config.GetResourceRequirements()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	grypeConfigName := starboard.GetPluginConfigMapName(Plugin)
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      tmpVolumeName,
+			MountPath: "/tmp",
+			ReadOnly:  false,
+		},
+	}
+
+	proxyEnv := []corev1.EnvVar{
+		constructEnvVarSourceFromConfigMap("HTTP_PROXY", grypeConfigName, keyGrypeHTTPProxy),
+		constructEnvVarSourceFromConfigMap("HTTPS_PROXY", grypeConfigName, keyGrypeHTTPSProxy),
+		constructEnvVarSourceFromConfigMap("NO_PROXY", grypeConfigName, keyGrypeNoProxy),
+	}
+
+	initContainer := corev1.Container{
+		Name:                     p.idGenerator.GenerateID(),
+		Image:                    grypeImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Env: append([]corev1.EnvVar{
+			{
+				Name:  "GRYPE_DB_CACHE_DIR",
+				Value: dbCacheDir,
+			},
+			{
+				Name:  "GRYPE_CHECK_FOR_APP_UPDATE",
+				Value: "false",
+			},
+			constructEnvVarSourceFromConfigMap("GRYPE_DB_UPDATE_URL", grypeConfigName, keyGrypeDBUpdateURL),
+		}, proxyEnv...),
+		Command: []string{
+			"/grype",
+		},
+		Args: []string{
+			"db",
+			"update",
+		},
+		Resources:    requirements,
+		VolumeMounts: volumeMounts,
+	}
+
+	var containers []corev1.Container
+
+	for _, c := range kube.GetContainers(spec) {
+		env := append([]corev1.EnvVar{
+			{
+				Name:  "GRYPE_DB_CACHE_DIR",
+				Value: dbCacheDir,
+			},
+			{
+				Name:  "GRYPE_DB_AUTO_UPDATE",
+				Value: "false",
+			},
+			{
+				Name:  "GRYPE_CHECK_FOR_APP_UPDATE",
+				Value: "false",
+			},
+			constructEnvVarSourceFromConfigMap("GRYPE_ONLY_FIXED", grypeConfigName, keyGrypeOnlyFixed),
+			constructEnvVarSourceFromConfigMap("GRYPE_ADD_CPES_IF_NONE", grypeConfigName, keyGrypeAddCPEsIfNone),
+		}, proxyEnv...)
+
+		optionalMirroredImage, err := trivy.GetMirroredImage(c.Image, config.GetMirrors())
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+
+		ref, err := name.ParseReference(optionalMirroredImage)
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+		registry := ref.Context().RegistryStr()
+
+		if config.GetInsecureRegistries()[registry] {
+			env = append(env, corev1.EnvVar{
+				Name:  "GRYPE_REGISTRY_INSECURE_SKIP_TLS_VERIFY",
+				Value: "true",
+			})
+		}
+
+		if config.GetNonSSLRegistries()[registry] {
+			env = append(env, corev1.EnvVar{
+				Name:  "GRYPE_REGISTRY_INSECURE_USE_HTTP",
+				Value: "true",
+			})
+		}
+
+		if auth, ok := credentials[c.Name]; ok && secret != nil {
+			registryUsernameKey := fmt.Sprintf("%s.username", c.Name)
+			registryPasswordKey := fmt.Sprintf("%s.password", c.Name)
+
+			env = append(env, corev1.EnvVar{
+				Name:  "GRYPE_REGISTRY_AUTH_AUTHORITY",
+				Value: registry,
+			}, corev1.EnvVar{
+				Name: "GRYPE_REGISTRY_AUTH_USERNAME",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: registryUsernameKey,
+					},
+				},
+			}, corev1.EnvVar{
+				Name: "GRYPE_REGISTRY_AUTH_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: registryPasswordKey,
+					},
+				},
+			})
+
+			if auth.RegistryToken != "" {
+				env = append(env, corev1.EnvVar{
+					Name: "GRYPE_REGISTRY_AUTH_TOKEN",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: secret.Name,
+							},
+							Key: fmt.Sprintf("%s.registryToken", c.Name),
+						},
+					},
+				})
+			}
+		}
+
+		containers = append(containers, corev1.Container{
+			Name:                     c.Name,
+			Image:                    grypeImageRef,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			Env:                      env,
+			Command: []string{
+				"/grype",
+			},
+			Args: []string{
+				"registry:" + optionalMirroredImage,
+				"--output",
+				"json",
+				"--quiet",
+			},
+			Resources:    requirements,
+			VolumeMounts: volumeMounts,
+			SecurityContext: &corev1.SecurityContext{
+				Privileged:               pointer.BoolPtr(false),
+				AllowPrivilegeEscalation: pointer.BoolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"all"},
+				},
+				ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+			},
+		})
+	}
+
+	return corev1.PodSpec{
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           ctx.GetServiceAccountName(),
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Volumes: []corev1.Volume{
+			{
+				Name: tmpVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium: corev1.StorageMediumDefault,
+					},
+				},
+			},
+		},
+		InitContainers:  []corev1.Container{initContainer},
+		Containers:      containers,
+		SecurityContext: &corev1.PodSecurityContext{},
+	}, secrets, nil
+}
+
+func (p *plugin) newSecretWithAggregateImagePullCredentials(ctx starboard.PluginContext, obj client.Object, spec corev1.PodSpec, credentials map[string]docker.Auth) *corev1.Secret {
+	containerImages := kube.GetContainerImagesFromPodSpec(spec)
+	secretData := kube.AggregateImagePullSecretsData(containerImages, credentials)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: vulnerabilityreport.RegistryCredentialsSecretName(ctx, obj),
+		},
+		Data: secretData,
+	}
+}
+
+// ParseVulnerabilityReportData converts the JSON output of Grype. Severities
+// are mapped to the closest v1alpha1.Severity, i.e. Negligible vulnerabilities
+// are reported as v1alpha1.SeverityLow. The FixedVersion is only set for
+// vulnerabilities in the fixed state, whereas vulnerabilities in the not-fixed,
+// wont-fix, or unknown states are reported without FixedVersion.
+func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	var document Document
+	err = json.NewDecoder(logsReader).Decode(&document)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+	seen := make(map[string]bool)
+
+	for _, match := range document.Matches {
+		key := match.Vulnerability.ID + "/" + match.Artifact.Name + "/" + match.Artifact.Version
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		description := match.Vulnerability.Description
+		for _, related := range match.RelatedVulnerabilities {
+			if description != "" {
+				break
+			}
+			description = related.Description
+		}
+
+		links := append(match.Vulnerability.URLs[:0:0], match.Vulnerability.URLs...)
+		if links == nil {
+			links = []string{}
+		}
+
+		vulnerabilities = append(vulnerabilities, v1alpha1.Vulnerability{
+			VulnerabilityID:  match.Vulnerability.ID,
+			Resource:         match.Artifact.Name,
+			InstalledVersion: match.Artifact.Version,
+			FixedVersion:     GetFixedVersion(match.Vulnerability.Fix),
+			Severity:         GetSeverity(match.Vulnerability.Severity),
+			Title:            getTitle(description),
+			Description:      description,
+			PrimaryLink:      match.Vulnerability.DataSource,
+			Links:            links,
+			Score:            GetScoreFromCVSS(match),
+		})
+	}
+
+	registry, artifact, err := p.parseImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+
+	version := document.Descriptor.Version
+	if version == "" {
+		grypeImageRef, err := config.GetImageRef()
+		if err != nil {
+			return v1alpha1.VulnerabilityReportData{}, err
+		}
+		version, err = starboard.GetVersionFromImageRef(grypeImageRef)
+		if err != nil {
+			return v1alpha1.VulnerabilityReportData{}, err
+		}
+	}
+
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:    Plugin,
+			Vendor:  "Anchore",
+			Version: version,
+		},
+		Registry:        registry,
+		Artifact:        artifact,
+		Summary:         p.toSummary(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
+	pluginConfig, err := ctx.GetConfig()
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{PluginConfig: pluginConfig}, nil
+}
+
+func (p *plugin) toSummary(vulnerabilities []v1alpha1.Vulnerability) v1alpha1.VulnerabilitySummary {
+	var vs v1alpha1.VulnerabilitySummary
+	for _, v := range vulnerabilities {
+		switch v.Severity {
+		case v1alpha1.SeverityCritical:
+			vs.CriticalCount++
+		case v1alpha1.SeverityHigh:
+			vs.HighCount++
+		case v1alpha1.SeverityMedium:
+			vs.MediumCount++
+		case v1alpha1.SeverityLow:
+			vs.LowCount++
+		default:
+			vs.UnknownCount++
+		}
+	}
+	return vs
+}
+
+func (p *plugin) parseImageRef(imageRef string) (v1alpha1.Registry, v1alpha1.Artifact, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.Registry{}, v1alpha1.Artifact{}, err
+	}
+	registry := v1alpha1.Registry{
+		Server: ref.Context().RegistryStr(),
+	}
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		artifact.Tag = t.TagStr()
+	case name.Digest:
+		artifact.Digest = t.DigestStr()
+	}
+	return registry, artifact, nil
+}
+
+// GetSeverity maps the Grype severity to v1alpha1.Severity.
+func GetSeverity(severity string) v1alpha1.Severity {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return v1alpha1.SeverityCritical
+	case "high":
+		return v1alpha1.SeverityHigh
+	case "medium":
+		return v1alpha1.SeverityMedium
+	case "low", "negligible":
+		return v1alpha1.SeverityLow
+	}
+	return v1alpha1.SeverityUnknown
+}
+
+// GetFixedVersion returns a comma separated list of versions that fix a
+// vulnerability, or an empty string if the vulnerability is not fixed.
+func GetFixedVersion(fix Fix) string {
+	if fix.State != FixStateFixed {
+		return ""
+	}
+	return strings.Join(fix.Versions, ", ")
+}
+
+// GetScoreFromCVSS returns the highest CVSS v3 base score of the matched
+// vulnerability. Scores provided by the vendor take precedence over scores of
+// related vulnerabilities, e.g. the NVD record of the same CVE.
+func GetScoreFromCVSS(match Match) *float64 {
+	if score := maxV3Score(match.Vulnerability.Cvss); score != nil {
+		return score
+	}
+	for _, related := range match.RelatedVulnerabilities {
+		if score := maxV3Score(related.Cvss); score != nil {
+			return score
+		}
+	}
+	return nil
+}
+
+func maxV3Score(cvss []Cvss) *float64 {
+	var score *float64
+	for _, c := range cvss {
+		if !strings.HasPrefix(c.Version, "3") {
+			continue
+		}
+		if score == nil || c.Metrics.BaseScore > *score {
+			baseScore := c.Metrics.BaseScore
+			score = &baseScore
+		}
+	}
+	return score
+}
+
+// getTitle returns the first line of the description, because Grype does not
+// provide vulnerability titles.
+func getTitle(description string) string {
+	const maxLength = 100
+	title := strings.TrimSpace(description)
+	if i := strings.IndexByte(title, '\n'); i >= 0 {
+		title = strings.TrimSpace(title[:i])
+	}
+	if runes := []rune(title); len(runes) > maxLength {
+		title = string(runes[:maxLength-3]) + "..."
+	}
+	return title
+}
+
+func constructEnvVarSourceFromConfigMap(envName, configName, configKey string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: envName,
+		ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configName,
+				},
+				Key:      configKey,
+				Optional: pointer.BoolPtr(true),
+			},
+		},
+	}
+}
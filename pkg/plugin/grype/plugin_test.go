@@ -0,0 +1,496 @@
+package grype_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/grype"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	fixedTime  = time.Now()
+	fixedClock = ext.NewFixedClock(fixedTime)
+)
+
+func newPluginContext(config map[string]string) starboard.PluginContext {
+	var objects []corev1.ConfigMap
+	if config != nil {
+		objects = append(objects, corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-grype-config",
+				Namespace: "starboard-ns",
+			},
+			Data: config,
+		})
+	}
+	builder := fake.NewClientBuilder()
+	for i := range objects {
+		builder = builder.WithObjects(&objects[i])
+	}
+	return starboard.NewPluginContext().
+		WithName(grype.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(builder.Build()).
+		Get()
+}
+
+func configMapEnv(name, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "starboard-grype-config",
+				},
+				Key:      key,
+				Optional: pointer.BoolPtr(true),
+			},
+		},
+	}
+}
+
+func TestPlugin_Init(t *testing.T) {
+	testClient := fake.NewClientBuilder().Build()
+	pluginContext := starboard.NewPluginContext().
+		WithName(grype.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(testClient).
+		Get()
+
+	err := grype.NewPlugin(fixedClock, ext.NewSimpleIDGenerator()).Init(pluginContext)
+	require.NoError(t, err)
+
+	var cm corev1.ConfigMap
+	err = testClient.Get(context.Background(), types.NamespacedName{
+		Namespace: "starboard-ns",
+		Name:      "starboard-grype-config",
+	}, &cm)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"grype.imageRef": "docker.io/anchore/grype:v0.50.1",
+
+		"grype.resources.requests.cpu":    "100m",
+		"grype.resources.requests.memory": "100M",
+		"grype.resources.limits.cpu":      "500m",
+		"grype.resources.limits.memory":   "1G",
+	}, cm.Data)
+}
+
+func TestPlugin_GetScanJobSpec(t *testing.T) {
+	workload := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "nginx",
+							Image: "nginx:1.16",
+						},
+						{
+							Name:  "sidecar",
+							Image: "registry.example.com/sidecar:1.0",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Should return pod spec with registry credentials", func(t *testing.T) {
+		pluginContext := newPluginContext(map[string]string{
+			"grype.imageRef":                  "docker.io/anchore/grype:v0.50.1",
+			"grype.resources.requests.cpu":    "100m",
+			"grype.resources.requests.memory": "100M",
+			"grype.resources.limits.cpu":      "500m",
+			"grype.resources.limits.memory":   "1G",
+		})
+		credentials := map[string]docker.Auth{
+			"sidecar": {Username: "user", Password: "pass"},
+		}
+
+		spec, secrets, err := grype.NewPlugin(fixedClock, ext.NewSimpleIDGenerator()).
+			GetScanJobSpec(context.TODO(), pluginContext, workload, credentials)
+		require.NoError(t, err)
+
+		require.Len(t, secrets, 1)
+		assert.Equal(t, "scan-vulnerabilityreport-64d65c457-regcred", secrets[0].Name)
+		assert.Equal(t, map[string][]byte{
+			"sidecar.username": []byte("user"),
+			"sidecar.password": []byte("pass"),
+		}, secrets[0].Data)
+
+		requirements := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("100M"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1G"),
+			},
+		}
+		volumeMounts := []corev1.VolumeMount{
+			{
+				Name:      "tmp",
+				MountPath: "/tmp",
+				ReadOnly:  false,
+			},
+		}
+		securityContext := &corev1.SecurityContext{
+			Privileged:               pointer.BoolPtr(false),
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"all"},
+			},
+			ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+		}
+		scanEnv := []corev1.EnvVar{
+			{Name: "GRYPE_DB_CACHE_DIR", Value: "/tmp/grype/db"},
+			{Name: "GRYPE_DB_AUTO_UPDATE", Value: "false"},
+			{Name: "GRYPE_CHECK_FOR_APP_UPDATE", Value: "false"},
+			configMapEnv("GRYPE_ONLY_FIXED", "grype.onlyFixed"),
+			configMapEnv("GRYPE_ADD_CPES_IF_NONE", "grype.addCPEsIfNone"),
+			configMapEnv("HTTP_PROXY", "grype.httpProxy"),
+			configMapEnv("HTTPS_PROXY", "grype.httpsProxy"),
+			configMapEnv("NO_PROXY", "grype.noProxy"),
+		}
+
+		assert.Equal(t, corev1.PodSpec{
+			Affinity:                     starboard.LinuxNodeAffinity(),
+			RestartPolicy:                corev1.RestartPolicyNever,
+			ServiceAccountName:           "starboard-sa",
+			AutomountServiceAccountToken: pointer.BoolPtr(false),
+			Volumes: []corev1.Volume{
+				{
+					Name: "tmp",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{
+							Medium: corev1.StorageMediumDefault,
+						},
+					},
+				},
+			},
+			InitContainers: []corev1.Container{
+				{
+					Name:                     "00000000-0000-0000-0000-000000000001",
+					Image:                    "docker.io/anchore/grype:v0.50.1",
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					Env: []corev1.EnvVar{
+						{Name: "GRYPE_DB_CACHE_DIR", Value: "/tmp/grype/db"},
+						{Name: "GRYPE_CHECK_FOR_APP_UPDATE", Value: "false"},
+						configMapEnv("GRYPE_DB_UPDATE_URL", "grype.dbUpdateURL"),
+						configMapEnv("HTTP_PROXY", "grype.httpProxy"),
+						configMapEnv("HTTPS_PROXY", "grype.httpsProxy"),
+						configMapEnv("NO_PROXY", "grype.noProxy"),
+					},
+					Command:      []string{"/grype"},
+					Args:         []string{"db", "update"},
+					Resources:    requirements,
+					VolumeMounts: volumeMounts,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:                     "nginx",
+					Image:                    "docker.io/anchore/grype:v0.50.1",
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					Env:                      scanEnv,
+					Command:                  []string{"/grype"},
+					Args:                     []string{"registry:nginx:1.16", "--output", "json", "--quiet"},
+					Resources:                requirements,
+					VolumeMounts:             volumeMounts,
+					SecurityContext:          securityContext,
+				},
+				{
+					Name:                     "sidecar",
+					Image:                    "docker.io/anchore/grype:v0.50.1",
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					Env: append(append([]corev1.EnvVar{}, scanEnv...),
+						corev1.EnvVar{Name: "GRYPE_REGISTRY_AUTH_AUTHORITY", Value: "registry.example.com"},
+						corev1.EnvVar{
+							Name: "GRYPE_REGISTRY_AUTH_USERNAME",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "scan-vulnerabilityreport-64d65c457-regcred",
+									},
+									Key: "sidecar.username",
+								},
+							},
+						},
+						corev1.EnvVar{
+							Name: "GRYPE_REGISTRY_AUTH_PASSWORD",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "scan-vulnerabilityreport-64d65c457-regcred",
+									},
+									Key: "sidecar.password",
+								},
+							},
+						},
+					),
+					Command:         []string{"/grype"},
+					Args:            []string{"registry:registry.example.com/sidecar:1.0", "--output", "json", "--quiet"},
+					Resources:       requirements,
+					VolumeMounts:    volumeMounts,
+					SecurityContext: securityContext,
+				},
+			},
+			SecurityContext: &corev1.PodSecurityContext{},
+		}, spec)
+	})
+
+	t.Run("Should return pod spec with registry token", func(t *testing.T) {
+		pluginContext := newPluginContext(map[string]string{
+			"grype.imageRef": "docker.io/anchore/grype:v0.50.1",
+		})
+		credentials := map[string]docker.Auth{
+			"sidecar": {RegistryToken: "bearer-token"},
+		}
+
+		spec, secrets, err := grype.NewPlugin(fixedClock, ext.NewSimpleIDGenerator()).
+			GetScanJobSpec(context.TODO(), pluginContext, workload, credentials)
+		require.NoError(t, err)
+
+		require.Len(t, secrets, 1)
+		assert.Equal(t, map[string][]byte{
+			"sidecar.username":      []byte(""),
+			"sidecar.password":      []byte(""),
+			"sidecar.registryToken": []byte("bearer-token"),
+		}, secrets[0].Data)
+		require.Len(t, spec.Containers, 2)
+		assert.Contains(t, spec.Containers[1].Env, corev1.EnvVar{
+			Name: "GRYPE_REGISTRY_AUTH_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secrets[0].Name,
+					},
+					Key: "sidecar.registryToken",
+				},
+			},
+		})
+	})
+
+	t.Run("Should scan mirrored images from insecure and non-SSL registries", func(t *testing.T) {
+		pluginContext := newPluginContext(map[string]string{
+			"grype.imageRef":                        "docker.io/anchore/grype:v0.50.1",
+			"grype.registry.mirror.index.docker.io": "mirror.example.com",
+			"grype.insecureRegistry.mirror":         "mirror.example.com",
+			"grype.nonSslRegistry.sidecar":          "registry.example.com",
+		})
+
+		spec, secrets, err := grype.NewPlugin(fixedClock, ext.NewSimpleIDGenerator()).
+			GetScanJobSpec(context.TODO(), pluginContext, workload, nil)
+		require.NoError(t, err)
+		assert.Empty(t, secrets)
+		require.Len(t, spec.Containers, 2)
+
+		assert.Equal(t, "registry:mirror.example.com/library/nginx:1.16", spec.Containers[0].Args[0])
+		assert.Contains(t, spec.Containers[0].Env, corev1.EnvVar{Name: "GRYPE_REGISTRY_INSECURE_SKIP_TLS_VERIFY", Value: "true"})
+		assert.NotContains(t, spec.Containers[0].Env, corev1.EnvVar{Name: "GRYPE_REGISTRY_INSECURE_USE_HTTP", Value: "true"})
+
+		assert.Equal(t, "registry:registry.example.com/sidecar:1.0", spec.Containers[1].Args[0])
+		assert.Contains(t, spec.Containers[1].Env, corev1.EnvVar{Name: "GRYPE_REGISTRY_INSECURE_USE_HTTP", Value: "true"})
+		assert.NotContains(t, spec.Containers[1].Env, corev1.EnvVar{Name: "GRYPE_REGISTRY_INSECURE_SKIP_TLS_VERIFY", Value: "true"})
+	})
+
+	t.Run("Should return error when image reference is not set", func(t *testing.T) {
+		pluginContext := newPluginContext(map[string]string{})
+
+		_, _, err := grype.NewPlugin(fixedClock, ext.NewSimpleIDGenerator()).
+			GetScanJobSpec(context.TODO(), pluginContext, workload, nil)
+		assert.EqualError(t, err, "property grype.imageRef not set")
+	})
+}
+
+const sampleDocument = `{
+  "matches": [
+    {
+      "vulnerability": {
+        "id": "CVE-2020-1967",
+        "dataSource": "https://security-tracker.debian.org/tracker/CVE-2020-1967",
+        "namespace": "debian:10",
+        "severity": "High",
+        "urls": ["https://security-tracker.debian.org/tracker/CVE-2020-1967"],
+        "cvss": [],
+        "fix": {"versions": ["1.1.1d-0+deb10u3"], "state": "fixed"}
+      },
+      "relatedVulnerabilities": [
+        {
+          "id": "CVE-2020-1967",
+          "dataSource": "https://nvd.nist.gov/vuln/detail/CVE-2020-1967",
+          "namespace": "nvd:cpe",
+          "severity": "High",
+          "description": "Server or client applications that call the SSL_check_chain() function may crash.\nMore details.",
+          "cvss": [
+            {"version": "2.0", "metrics": {"baseScore": 5}},
+            {"version": "3.1", "metrics": {"baseScore": 7.5}}
+          ]
+        }
+      ],
+      "artifact": {"name": "libssl1.1", "version": "1.1.1d-0+deb10u2", "type": "deb"}
+    },
+    {
+      "vulnerability": {
+        "id": "CVE-2020-1967",
+        "dataSource": "https://security-tracker.debian.org/tracker/CVE-2020-1967",
+        "severity": "High",
+        "fix": {"versions": ["1.1.1d-0+deb10u3"], "state": "fixed"}
+      },
+      "artifact": {"name": "libssl1.1", "version": "1.1.1d-0+deb10u2", "type": "deb"}
+    },
+    {
+      "vulnerability": {
+        "id": "CVE-2019-18276",
+        "dataSource": "https://security-tracker.debian.org/tracker/CVE-2019-18276",
+        "severity": "Negligible",
+        "description": "An issue was discovered in disable_priv_mode in shell.c in GNU Bash.",
+        "cvss": [
+          {"version": "3.1", "metrics": {"baseScore": 7.8}}
+        ],
+        "fix": {"versions": [], "state": "wont-fix"}
+      },
+      "artifact": {"name": "bash", "version": "5.0-4", "type": "deb"}
+    },
+    {
+      "vulnerability": {
+        "id": "GHSA-jfh8-c2jp-5v3q",
+        "dataSource": "https://github.com/advisories/GHSA-jfh8-c2jp-5v3q",
+        "severity": "Critical",
+        "urls": ["https://github.com/advisories/GHSA-jfh8-c2jp-5v3q"],
+        "fix": {"versions": ["2.15.0", "2.12.2"], "state": "fixed"}
+      },
+      "artifact": {"name": "log4j-core", "version": "2.14.1", "type": "java-archive"}
+    }
+  ],
+  "descriptor": {
+    "name": "grype",
+    "version": "0.50.1"
+  }
+}`
+
+func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
+	pluginContext := newPluginContext(map[string]string{
+		"grype.imageRef": "docker.io/anchore/grype:v0.50.1",
+	})
+	instance := grype.NewPlugin(fixedClock, ext.NewSimpleIDGenerator())
+
+	t.Run("Should convert Grype JSON output", func(t *testing.T) {
+		report, err := instance.ParseVulnerabilityReportData(pluginContext, "nginx:1.16", io.NopCloser(strings.NewReader(sampleDocument)))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(fixedTime),
+			Scanner: v1alpha1.Scanner{
+				Name:    "Grype",
+				Vendor:  "Anchore",
+				Version: "0.50.1",
+			},
+			Registry: v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+			Summary: v1alpha1.VulnerabilitySummary{
+				CriticalCount: 1,
+				HighCount:     1,
+				LowCount:      1,
+			},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2020-1967",
+					Resource:         "libssl1.1",
+					InstalledVersion: "1.1.1d-0+deb10u2",
+					FixedVersion:     "1.1.1d-0+deb10u3",
+					Severity:         v1alpha1.SeverityHigh,
+					Title:            "Server or client applications that call the SSL_check_chain() function may crash.",
+					Description:      "Server or client applications that call the SSL_check_chain() function may crash.\nMore details.",
+					PrimaryLink:      "https://security-tracker.debian.org/tracker/CVE-2020-1967",
+					Links:            []string{"https://security-tracker.debian.org/tracker/CVE-2020-1967"},
+					Score:            pointer.Float64(7.5),
+				},
+				{
+					VulnerabilityID:  "CVE-2019-18276",
+					Resource:         "bash",
+					InstalledVersion: "5.0-4",
+					Severity:         v1alpha1.SeverityLow,
+					Title:            "An issue was discovered in disable_priv_mode in shell.c in GNU Bash.",
+					Description:      "An issue was discovered in disable_priv_mode in shell.c in GNU Bash.",
+					PrimaryLink:      "https://security-tracker.debian.org/tracker/CVE-2019-18276",
+					Links:            []string{},
+					Score:            pointer.Float64(7.8),
+				},
+				{
+					VulnerabilityID:  "GHSA-jfh8-c2jp-5v3q",
+					Resource:         "log4j-core",
+					InstalledVersion: "2.14.1",
+					FixedVersion:     "2.15.0, 2.12.2",
+					Severity:         v1alpha1.SeverityCritical,
+					PrimaryLink:      "https://github.com/advisories/GHSA-jfh8-c2jp-5v3q",
+					Links:            []string{"https://github.com/advisories/GHSA-jfh8-c2jp-5v3q"},
+				},
+			},
+		}, report)
+	})
+
+	t.Run("Should take scanner version from image reference when descriptor is missing", func(t *testing.T) {
+		report, err := instance.ParseVulnerabilityReportData(pluginContext, "nginx:1.16", io.NopCloser(strings.NewReader(`{"matches":[]}`)))
+		require.NoError(t, err)
+		assert.Equal(t, "v0.50.1", report.Scanner.Version)
+		assert.Equal(t, []v1alpha1.Vulnerability{}, report.Vulnerabilities)
+	})
+
+	t.Run("Should return error when output is not JSON", func(t *testing.T) {
+		_, err := instance.ParseVulnerabilityReportData(pluginContext, "nginx:1.16", io.NopCloser(strings.NewReader("not JSON")))
+		assert.Error(t, err)
+	})
+}
+
+func TestGetSeverity(t *testing.T) {
+	testCases := map[string]v1alpha1.Severity{
+		"Critical":   v1alpha1.SeverityCritical,
+		"High":       v1alpha1.SeverityHigh,
+		"Medium":     v1alpha1.SeverityMedium,
+		"Low":        v1alpha1.SeverityLow,
+		"Negligible": v1alpha1.SeverityLow,
+		"Unknown":    v1alpha1.SeverityUnknown,
+		"":           v1alpha1.SeverityUnknown,
+	}
+	for severity, expected := range testCases {
+		assert.Equal(t, expected, grype.GetSeverity(severity), severity)
+	}
+}
+
+func TestGetFixedVersion(t *testing.T) {
+	assert.Equal(t, "1.2.3", grype.GetFixedVersion(grype.Fix{Versions: []string{"1.2.3"}, State: grype.FixStateFixed}))
+	assert.Equal(t, "", grype.GetFixedVersion(grype.Fix{State: grype.FixStateNotFixed}))
+	assert.Equal(t, "", grype.GetFixedVersion(grype.Fix{Versions: []string{"1.2.3"}, State: grype.FixStateWontFix}))
+	assert.Equal(t, "", grype.GetFixedVersion(grype.Fix{State: grype.FixStateUnknown}))
+}
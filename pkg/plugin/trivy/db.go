@@ -0,0 +1,348 @@
+package trivy
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+)
+
+// DBSource is the source of the vulnerability database loaded into the
+// claim returned by Config.GetDBVolumeClaim.
+type DBSource string
+
+const (
+	// DBSourceRepository downloads the database from Config.GetDBRepository.
+	DBSourceRepository DBSource = "Repository"
+	// DBSourceBundle extracts the db.tar.gz bundle uploaded into the claim.
+	DBSourceBundle DBSource = "Bundle"
+)
+
+const (
+	// DBUpdateJobName is the name of the Job that loads the vulnerability
+	// database into the claim from the configured DBSource.
+	DBUpdateJobName = "starboard-trivy-db-update"
+
+	// DBImportPodName is the name of the Pod that mounts the claim while the
+	// database bundle is imported with the Starboard CLI.
+	DBImportPodName = "starboard-trivy-db-import"
+
+	// AnnotationDBUpdated is set on the claim to the time when the database
+	// was last loaded. Scan jobs are not submitted until it's set.
+	AnnotationDBUpdated = "starboard.trivy-db.updated"
+
+	// AnnotationDBUpdateFailed is set on the claim to the time when the last
+	// attempt to load the database failed. The next attempt is not made until
+	// the scan job retry interval has elapsed.
+	AnnotationDBUpdateFailed = "starboard.trivy-db.update-failed"
+
+	dbVolumeName             = "trivy-db"
+	dbVolumeMountPath        = "/var/lib/trivy"
+	dbBundleFileName         = "db.tar.gz"
+	defaultDBUpdateInterval  = 24 * time.Hour
+	dbVolumeSubPath          = "db"
+	dbJobActiveDeadlineInSec = 600
+)
+
+// GetDBVolumeClaim returns the name of the PersistentVolumeClaim that stores
+// the vulnerability database shared by scan jobs, or an empty string if each
+// scan job downloads the database.
+func (c Config) GetDBVolumeClaim() string {
+	return c.Data[keyTrivyDBVolumeClaim]
+}
+
+// IsDBVolumeEnabled returns true if scan jobs in the Standalone mode should
+// load the vulnerability database from the claim returned by GetDBVolumeClaim
+// instead of downloading it.
+func (c Config) IsDBVolumeEnabled() bool {
+	mode, err := c.GetMode()
+	if err != nil || mode != Standalone {
+		return false
+	}
+	return c.GetDBVolumeClaim() != ""
+}
+
+// GetDBSource returns the source of the vulnerability database loaded into
+// the claim returned by GetDBVolumeClaim. Defaults to DBSourceRepository.
+func (c Config) GetDBSource() (DBSource, error) {
+	value, ok := c.Data[keyTrivyDBSource]
+	if !ok {
+		return DBSourceRepository, nil
+	}
+	switch DBSource(value) {
+	case DBSourceRepository:
+		return DBSourceRepository, nil
+	case DBSourceBundle:
+		return DBSourceBundle, nil
+	}
+	return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+		value, keyTrivyDBSource, DBSourceRepository, DBSourceBundle)
+}
+
+// GetDBUpdateInterval returns how often the vulnerability database is loaded
+// into the claim returned by GetDBVolumeClaim. Defaults to 24 hours.
+func (c Config) GetDBUpdateInterval() (time.Duration, error) {
+	value, ok := c.Data[keyTrivyDBUpdateInterval]
+	if !ok {
+		return defaultDBUpdateInterval, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %s: %w", keyTrivyDBUpdateInterval, value, err)
+	}
+	return interval, nil
+}
+
+// newDBVolume returns the read-only volume of the claim returned by
+// Config.GetDBVolumeClaim.
+func newDBVolume(config Config) corev1.Volume {
+	return corev1.Volume{
+		Name: dbVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: config.GetDBVolumeClaim(),
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// newDBInitContainer returns the init container that copies the database from
+// the read-only volume returned by newDBVolume to the db subdirectory of the
+// specified Trivy cache directory. This is where Trivy run with the
+// --skip-update flag looks for the database. The database is copied, rather
+// than mounted, because Trivy opens it in read-write mode.
+func newDBInitContainer(name, trivyImageRef, cacheDir string, requirements corev1.ResourceRequirements, cacheVolumeMount corev1.VolumeMount) corev1.Container {
+	return corev1.Container{
+		Name:                     name,
+		Image:                    trivyImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Command: []string{
+			"/bin/sh",
+			"-c",
+		},
+		Args: []string{
+			fmt.Sprintf("mkdir -p %[2]s && cp -R %[1]s/%[3]s %[2]s/",
+				dbVolumeMountPath, cacheDir, dbVolumeSubPath),
+		},
+		Resources: requirements,
+		VolumeMounts: []corev1.VolumeMount{
+			cacheVolumeMount,
+			{
+				Name:      dbVolumeName,
+				MountPath: dbVolumeMountPath,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// NewDBUpdateJob returns the Job that loads the vulnerability database into
+// the claim returned by Config.GetDBVolumeClaim from the configured DBSource.
+//
+// With DBSourceRepository the Job runs the following Trivy command:
+//
+//	trivy --cache-dir /var/lib/trivy image --download-db-only
+//
+// With DBSourceBundle the Job extracts the db.tar.gz bundle uploaded into the
+// root directory of the claim, if any, and fails unless the database exists.
+func NewDBUpdateJob(config Config, namespace, serviceAccountName string) (*batchv1.Job, error) {
+	source, err := config.GetDBSource()
+	if err != nil {
+		return nil, err
+	}
+
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return nil, err
+	}
+
+	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
+
+	container := newDBJobContainer(trivyImageRef)
+	switch source {
+	case DBSourceRepository:
+		dbRepository, err := config.GetDBRepository()
+		if err != nil {
+			return nil, err
+		}
+		container.Env = []corev1.EnvVar{
+			constructEnvVarSourceFromConfigMap("HTTP_PROXY", trivyConfigName, keyTrivyHTTPProxy),
+			constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
+			constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
+			{
+				Name: "GITHUB_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: trivyConfigName,
+						},
+						Key:      keyTrivyGitHubToken,
+						Optional: pointer.BoolPtr(true),
+					},
+				},
+			},
+		}
+		container.Command = []string{
+			"trivy",
+		}
+		container.Args = []string{
+			"--cache-dir",
+			dbVolumeMountPath,
+			"image",
+			"--download-db-only",
+			"--db-repository",
+			dbRepository,
+		}
+	case DBSourceBundle:
+		bundle := dbVolumeMountPath + "/" + dbBundleFileName
+		dbDir := dbVolumeMountPath + "/" + dbVolumeSubPath
+		container.Command = []string{
+			"/bin/sh",
+			"-c",
+		}
+		container.Args = []string{
+			fmt.Sprintf("if [ -f %[1]s ]; then mkdir -p %[2]s && tar -xzf %[1]s -C %[2]s && rm %[1]s; fi; test -f %[2]s/trivy.db",
+				bundle, dbDir),
+		}
+	}
+
+	return newDBJob(DBUpdateJobName, namespace, serviceAccountName, config, container), nil
+}
+
+// NewDBImportPod returns the Pod that mounts the claim returned by
+// Config.GetDBVolumeClaim and waits until the database bundle is extracted
+// into the claim by the command returned by DBImportCommand, which is executed
+// in its container. The Pod terminates after dbJobActiveDeadlineInSec.
+func NewDBImportPod(config Config, namespace, serviceAccountName string) (*corev1.Pod, error) {
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return nil, err
+	}
+
+	container := newDBJobContainer(trivyImageRef)
+	container.Command = []string{
+		"sleep",
+		strconv.Itoa(dbJobActiveDeadlineInSec),
+	}
+
+	podLabels := labels.Set{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+		"app.kubernetes.io/name":       DBImportPodName,
+	}
+
+	spec := newDBPodSpec(serviceAccountName, config, container)
+	spec.ActiveDeadlineSeconds = pointer.Int64Ptr(dbJobActiveDeadlineInSec)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DBImportPodName,
+			Namespace: namespace,
+			Labels:    podLabels,
+		},
+		Spec: spec,
+	}, nil
+}
+
+// DBImportCommand returns the command that extracts the database bundle read
+// from the standard input into the claim mounted by the Pod returned by
+// NewDBImportPod. The database is replaced only if the bundle is extracted
+// successfully.
+func DBImportCommand() []string {
+	dbDir := dbVolumeMountPath + "/" + dbVolumeSubPath
+	return []string{
+		"/bin/sh",
+		"-c",
+		fmt.Sprintf("rm -rf %[1]s.tmp && mkdir -p %[1]s.tmp && tar -xzf - -C %[1]s.tmp && test -f %[1]s.tmp/trivy.db && rm -rf %[1]s && mv %[1]s.tmp %[1]s",
+			dbDir),
+	}
+}
+
+func newDBJobContainer(trivyImageRef string) corev1.Container {
+	return corev1.Container{
+		Name:                     "trivy-db",
+		Image:                    trivyImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      tmpVolumeName,
+				MountPath: "/tmp",
+			},
+			{
+				Name:      dbVolumeName,
+				MountPath: dbVolumeMountPath,
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:               pointer.BoolPtr(false),
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"all"},
+			},
+			ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+		},
+	}
+}
+
+func newDBJob(name, namespace, serviceAccountName string, config Config, container corev1.Container) *batchv1.Job {
+	jobLabels := labels.Set{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+		"app.kubernetes.io/name":       name,
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    jobLabels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32Ptr(0),
+			Completions:           pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds: pointer.Int64Ptr(dbJobActiveDeadlineInSec),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: jobLabels,
+				},
+				Spec: newDBPodSpec(serviceAccountName, config, container),
+			},
+		},
+	}
+}
+
+func newDBPodSpec(serviceAccountName string, config Config, container corev1.Container) corev1.PodSpec {
+	return corev1.PodSpec{
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           serviceAccountName,
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Containers:                   []corev1.Container{container},
+		Volumes: []corev1.Volume{
+			{
+				Name: tmpVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium: corev1.StorageMediumDefault,
+					},
+				},
+			},
+			{
+				Name: dbVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: config.GetDBVolumeClaim(),
+					},
+				},
+			},
+		},
+		SecurityContext: &corev1.PodSecurityContext{},
+	}
+}
@@ -0,0 +1,232 @@
+package trivy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfig_IsDBVolumeEnabled(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     map[string]string
+		expected bool
+	}{
+		{
+			name:     "Should return false by default",
+			data:     map[string]string{"trivy.mode": "Standalone"},
+			expected: false,
+		},
+		{
+			name:     "Should return true in Standalone mode",
+			data:     map[string]string{"trivy.mode": "Standalone", "trivy.dbVolumeClaim": "trivy-db"},
+			expected: true,
+		},
+		{
+			name:     "Should return false in ClientServer mode",
+			data:     map[string]string{"trivy.mode": "ClientServer", "trivy.dbVolumeClaim": "trivy-db"},
+			expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: tc.data}}
+			assert.Equal(t, tc.expected, config.IsDBVolumeEnabled())
+		})
+	}
+}
+
+func TestConfig_GetDBSource(t *testing.T) {
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: map[string]string{}}}
+	source, err := config.GetDBSource()
+	require.NoError(t, err)
+	assert.Equal(t, trivy.DBSourceRepository, source)
+
+	config.Data["trivy.dbSource"] = "Bundle"
+	source, err = config.GetDBSource()
+	require.NoError(t, err)
+	assert.Equal(t, trivy.DBSourceBundle, source)
+
+	config.Data["trivy.dbSource"] = "GitHub"
+	_, err = config.GetDBSource()
+	assert.EqualError(t, err, "invalid value (GitHub) of trivy.dbSource; allowed values (Repository, Bundle)")
+}
+
+func TestNewDBUpdateJob(t *testing.T) {
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: map[string]string{
+		"trivy.imageRef":      "docker.io/aquasec/trivy:0.25.2",
+		"trivy.dbRepository":  "mirror.example.com/trivy-db",
+		"trivy.dbVolumeClaim": "trivy-db",
+	}}}
+
+	job, err := trivy.NewDBUpdateJob(config, "starboard-ns", "starboard-sa")
+	require.NoError(t, err)
+	assert.Equal(t, "starboard-trivy-db-update", job.Name)
+	assert.Equal(t, "starboard-ns", job.Namespace)
+	assert.Equal(t, "starboard-sa", job.Spec.Template.Spec.ServiceAccountName)
+	require.Len(t, job.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, []string{
+		"--cache-dir", "/var/lib/trivy",
+		"image",
+		"--download-db-only",
+		"--db-repository", "mirror.example.com/trivy-db",
+	}, job.Spec.Template.Spec.Containers[0].Args)
+	assert.Contains(t, job.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "trivy-db",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "trivy-db",
+			},
+		},
+	})
+
+	config.Data["trivy.dbSource"] = "Bundle"
+	job, err = trivy.NewDBUpdateJob(config, "starboard-ns", "starboard-sa")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/bin/sh", "-c"}, job.Spec.Template.Spec.Containers[0].Command)
+	assert.Equal(t, []string{
+		"if [ -f /var/lib/trivy/db.tar.gz ]; then mkdir -p /var/lib/trivy/db && tar -xzf /var/lib/trivy/db.tar.gz -C /var/lib/trivy/db && rm /var/lib/trivy/db.tar.gz; fi; test -f /var/lib/trivy/db/trivy.db",
+	}, job.Spec.Template.Spec.Containers[0].Args)
+}
+
+func TestNewDBImportPod(t *testing.T) {
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: map[string]string{
+		"trivy.imageRef":      "docker.io/aquasec/trivy:0.25.2",
+		"trivy.dbVolumeClaim": "trivy-db",
+	}}}
+
+	pod, err := trivy.NewDBImportPod(config, "starboard-ns", "starboard-sa")
+	require.NoError(t, err)
+	assert.Equal(t, "starboard-trivy-db-import", pod.Name)
+	assert.Equal(t, "starboard-ns", pod.Namespace)
+	assert.Equal(t, "starboard-sa", pod.Spec.ServiceAccountName)
+	assert.Equal(t, pointer.Int64Ptr(600), pod.Spec.ActiveDeadlineSeconds)
+	require.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, []string{"sleep", "600"}, pod.Spec.Containers[0].Command)
+	assert.Contains(t, pod.Spec.Volumes, corev1.Volume{
+		Name: "trivy-db",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "trivy-db",
+			},
+		},
+	})
+
+	assert.Equal(t, []string{
+		"/bin/sh",
+		"-c",
+		"rm -rf /var/lib/trivy/db.tmp && mkdir -p /var/lib/trivy/db.tmp && tar -xzf - -C /var/lib/trivy/db.tmp && test -f /var/lib/trivy/db.tmp/trivy.db && rm -rf /var/lib/trivy/db && mv /var/lib/trivy/db.tmp /var/lib/trivy/db",
+	}, trivy.DBImportCommand())
+}
+
+func TestPlugin_GetScanJobSpec_DBVolume(t *testing.T) {
+	fakeclient := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-trivy-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"trivy.imageRef":      "docker.io/aquasec/trivy:0.25.2",
+				"trivy.mode":          string(trivy.Standalone),
+				"trivy.dbRepository":  defaultDBRepository,
+				"trivy.dbVolumeClaim": "trivy-db",
+			},
+		},
+	).Build()
+	pluginContext := starboard.NewPluginContext().
+		WithName(trivy.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeclient).
+		Get()
+	objectResolver := kube.NewObjectResolver(fakeclient, &kube.CompatibleObjectMapper{})
+	instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+	jobSpec, _, err := instance.GetScanJobSpec(context.TODO(), pluginContext, &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "prod-ns",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "nginx",
+					Image: "nginx:1.16",
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, jobSpec.InitContainers, 1)
+	assert.Equal(t, []string{"/bin/sh", "-c"}, jobSpec.InitContainers[0].Command)
+	assert.Equal(t, []string{"mkdir -p /tmp/trivy/.cache && cp -R /var/lib/trivy/db /tmp/trivy/.cache/"}, jobSpec.InitContainers[0].Args)
+	assert.Contains(t, jobSpec.Volumes, corev1.Volume{
+		Name: "trivy-db",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "trivy-db",
+				ReadOnly:  true,
+			},
+		},
+	})
+	require.Len(t, jobSpec.Containers, 1)
+	assert.Contains(t, jobSpec.Containers[0].Args, "--skip-update")
+}
+
+func TestPlugin_GetScanJobSpec_DBVolumeInWorkloadNamespace(t *testing.T) {
+	fakeclient := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-trivy-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"trivy.imageRef":      "docker.io/aquasec/trivy:0.25.2",
+				"trivy.mode":          string(trivy.Standalone),
+				"trivy.dbVolumeClaim": "trivy-db",
+			},
+		},
+	).Build()
+	pluginContext := starboard.NewPluginContext().
+		WithName(trivy.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeclient).
+		WithStarboardConfig(map[string]string{starboard.KeyVulnerabilityScansInSameNamespace: "true"}).
+		Get()
+	objectResolver := kube.NewObjectResolver(fakeclient, &kube.CompatibleObjectMapper{})
+	instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+	_, _, err := instance.GetScanJobSpec(context.TODO(), pluginContext, &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "prod-ns",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "nginx",
+					Image: "nginx:1.16",
+				},
+			},
+		},
+	}, nil)
+	assert.EqualError(t, err, "trivy.dbVolumeClaim is not supported when scan jobs run in namespaces of workloads, unset trivy.dbVolumeClaim or vulnerabilityReports.scanJobsInSameNamespace")
+}
@@ -6,11 +6,40 @@ import (
 
 type ScanResult struct {
 	Target          string          `json:"Target"`
+	Class           string          `json:"Class"`
+	Type            string          `json:"Type"`
+	Packages        []Package       `json:"Packages"`
 	Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
 }
 
 type ScanReport struct {
-	Results []ScanResult `json:"Results"`
+	ArtifactType string       `json:"ArtifactType"`
+	Metadata     Metadata     `json:"Metadata"`
+	Results      []ScanResult `json:"Results"`
+}
+
+type Metadata struct {
+	OS          *OS      `json:"OS"`
+	RepoDigests []string `json:"RepoDigests"`
+}
+
+type OS struct {
+	Family string `json:"Family"`
+	Name   string `json:"Name"`
+}
+
+// Package is an OS package or application dependency listed by Trivy when
+// the --list-all-pkgs flag is set.
+type Package struct {
+	Name       string   `json:"Name"`
+	Version    string   `json:"Version"`
+	Release    string   `json:"Release"`
+	Epoch      int      `json:"Epoch"`
+	Arch       string   `json:"Arch"`
+	SrcName    string   `json:"SrcName"`
+	SrcVersion string   `json:"SrcVersion"`
+	Licenses   []string `json:"Licenses"`
+	Layer      Layer    `json:"Layer"`
 }
 
 type Vulnerability struct {
@@ -49,6 +49,10 @@ const (
 	keyTrivySkipFiles              = "trivy.skipFiles"
 	keyTrivySkipDirs               = "trivy.skipDirs"
 	keyTrivyDBRepository           = "trivy.dbRepository"
+	keyTrivyDBVolumeClaim          = "trivy.dbVolumeClaim"
+	keyTrivyDBSource               = "trivy.dbSource"
+	keyTrivyDBUpdateInterval       = "trivy.dbUpdateInterval"
+	keyTrivyGenerateSBOM           = "trivy.generateSBOM"
 
 	keyTrivyServerURL           = "trivy.serverURL"
 	keyTrivyServerTokenHeader   = "trivy.serverTokenHeader"
@@ -56,6 +60,10 @@ const (
 	keyTrivyServerToken         = "trivy.serverToken"
 	keyTrivyServerCustomHeaders = "trivy.serverCustomHeaders"
 
+	keyTrivyServerManaged          = "trivy.serverManaged"
+	keyTrivyServerCacheSize        = "trivy.serverCacheSize"
+	keyTrivyServerStorageClassName = "trivy.serverStorageClassName"
+
 	keyResourcesRequestsCPU    = "trivy.resources.requests.cpu"
 	keyResourcesRequestsMemory = "trivy.resources.requests.memory"
 	keyResourcesLimitsCPU      = "trivy.resources.limits.cpu"
@@ -72,12 +80,13 @@ const (
 	ClientServer Mode = "ClientServer"
 )
 
-// Command to scan image or filesystem.
+// Command to scan image, filesystem, or stored SBOM.
 type Command string
 
 const (
 	Filesystem Command = "filesystem"
 	Image      Command = "image"
+	SBOM       Command = "sbom"
 )
 
 // Config defines configuration params for this plugin.
@@ -120,9 +129,11 @@ func (c Config) GetCommand() (Command, error) {
 		return Image, nil
 	case Filesystem:
 		return Filesystem, nil
+	case SBOM:
+		return SBOM, nil
 	}
-	return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
-		value, keyTrivyCommand, Image, Filesystem)
+	return "", fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s, %s)",
+		value, keyTrivyCommand, Image, Filesystem, SBOM)
 }
 
 func (c Config) GetServerURL() (string, error) {
@@ -144,6 +155,16 @@ func (c Config) IgnoreUnfixed() bool {
 	return ok
 }
 
+// GenerateSBOM returns true if SBOMs should be generated for scanned images.
+// SBOMs are always generated with the SBOM command, which rescans them.
+func (c Config) GenerateSBOM() bool {
+	if c.Data[keyTrivyCommand] == string(SBOM) {
+		return true
+	}
+	value, ok := c.Data[keyTrivyGenerateSBOM]
+	return ok && value == "true"
+}
+
 func (c Config) GetInsecureRegistries() map[string]bool {
 	insecureRegistries := make(map[string]bool)
 	for key, val := range c.Data {
@@ -232,9 +253,11 @@ type plugin struct {
 // NewPlugin constructs a new vulnerabilityreport.Plugin, which is using an
 // upstream Trivy container image to scan Kubernetes workloads.
 //
-// The plugin supports Image and Filesystem commands. The Filesystem command may
-// be used to scan workload images cached on cluster nodes by scheduling
-// scan jobs on a particular node.
+// The plugin supports Image, Filesystem, and SBOM commands. The Filesystem
+// command may be used to scan workload images cached on cluster nodes by
+// scheduling scan jobs on a particular node. The SBOM command scans SBOMs
+// stored as v1alpha1.SBOMReport objects instead of pulling images again, and
+// falls back to the Image command for images without SBOMs.
 //
 // The Image command supports both Standalone and ClientServer modes depending
 // on the settings returned by Config.GetMode. The ClientServer mode is usually
@@ -266,12 +289,20 @@ func (p *plugin) Init(ctx starboard.PluginContext) error {
 	})
 }
 
-func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
-	config, err := p.newConfigFrom(ctx)
+func (p *plugin) GetScanJobSpec(ctx context.Context, pluginContext starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	config, err := p.newConfigFrom(pluginContext)
 	if err != nil {
 		return corev1.PodSpec{}, nil, err
 	}
 
+	// The claim is accessible only to scan jobs running in the Starboard
+	// namespace. Rather than silently downloading the database, which might be
+	// impossible in air-gapped environments, such configuration is refused.
+	if config.IsDBVolumeEnabled() && pluginContext.GetStarboardConfig().VulnerabilityScanJobsInSameNamespace() {
+		return corev1.PodSpec{}, nil, fmt.Errorf("%s is not supported when scan jobs run in namespaces of workloads, unset %s or %s",
+			keyTrivyDBVolumeClaim, keyTrivyDBVolumeClaim, starboard.KeyVulnerabilityScansInSameNamespace)
+	}
+
 	mode, err := config.GetMode()
 	if err != nil {
 		return corev1.PodSpec{}, nil, err
@@ -282,9 +313,9 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Obj
 	if command == Image {
 		switch mode {
 		case Standalone:
-			return p.getPodSpecForStandaloneMode(ctx, config, workload, credentials)
+			return p.getPodSpecForStandaloneMode(pluginContext, config, workload, credentials)
 		case ClientServer:
-			return p.getPodSpecForClientServerMode(ctx, config, workload, credentials)
+			return p.getPodSpecForClientServerMode(pluginContext, config, workload, credentials)
 		default:
 			return corev1.PodSpec{}, nil, fmt.Errorf("unrecognized trivy mode %q for command %q", mode, command)
 		}
@@ -293,7 +324,30 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Obj
 	if command == Filesystem {
 		switch mode {
 		case Standalone:
-			return p.getPodSpecForStandaloneFSMode(ctx, config, workload)
+			return p.getPodSpecForStandaloneFSMode(pluginContext, config, workload)
+		default:
+			return corev1.PodSpec{}, nil, fmt.Errorf("unrecognized trivy mode %q for command %q", mode, command)
+		}
+	}
+
+	if command == SBOM {
+		switch mode {
+		case Standalone:
+			sboms, err := p.getStoredSBOMs(ctx, workload)
+			if err != nil {
+				return corev1.PodSpec{}, nil, err
+			}
+			// Images without stored SBOMs are pulled, therefore registry
+			// credentials, including registry tokens, are passed as in the
+			// Standalone mode.
+			if sboms == nil {
+				return p.getPodSpecForStandaloneMode(pluginContext, config, workload, credentials)
+			}
+			return p.getPodSpecForStandaloneSBOMMode(pluginContext, config, workload, sboms)
+		case ClientServer:
+			// Scanning stored SBOMs is not supported in the ClientServer mode,
+			// therefore images are scanned as with the Image command.
+			return p.getPodSpecForClientServerMode(pluginContext, config, workload, credentials)
 		default:
 			return corev1.PodSpec{}, nil, fmt.Errorf("unrecognized trivy mode %q for command %q", mode, command)
 		}
@@ -302,13 +356,13 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Obj
 	return corev1.PodSpec{}, nil, fmt.Errorf("unrecognized trivy command %q", command)
 }
 
-func (p *plugin) newSecretWithAggregateImagePullCredentials(obj client.Object, spec corev1.PodSpec, credentials map[string]docker.Auth) *corev1.Secret {
+func (p *plugin) newSecretWithAggregateImagePullCredentials(ctx starboard.PluginContext, obj client.Object, spec corev1.PodSpec, credentials map[string]docker.Auth) *corev1.Secret {
 	containerImages := kube.GetContainerImagesFromPodSpec(spec)
 	secretData := kube.AggregateImagePullSecretsData(containerImages, credentials)
 
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: vulnerabilityreport.RegistryCredentialsSecretName(obj),
+			Name: vulnerabilityreport.RegistryCredentialsSecretName(ctx, obj),
 		},
 		Data: secretData,
 	}
@@ -317,6 +371,7 @@ func (p *plugin) newSecretWithAggregateImagePullCredentials(obj client.Object, s
 const (
 	tmpVolumeName               = "tmp"
 	ignoreFileVolumeName        = "ignorefile"
+	sbomVolumeName              = "sbom"
 	FsSharedVolumeName          = "starboard"
 	SharedVolumeLocationOfTrivy = "/var/starboard/trivy"
 )
@@ -328,6 +383,9 @@ const (
 //
 //     trivy --cache-dir /tmp/trivy/.cache image --download-db-only
 //
+// If Config.GetDBVolumeClaim is set, the init container copies the database
+// from the read-only volume of the claim instead of downloading it.
+//
 // The number of main containers correspond to the number of containers
 // defined for the scanned workload. Each container runs the Trivy image scan
 // command and skips the database download:
@@ -344,7 +402,7 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 	}
 
 	if len(credentials) > 0 {
-		secret = p.newSecretWithAggregateImagePullCredentials(workload, spec, credentials)
+		secret = p.newSecretWithAggregateImagePullCredentials(ctx, workload, spec, credentials)
 		secrets = append(secrets, secret)
 	}
 
@@ -461,6 +519,11 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		},
 	}
 
+	if config.IsDBVolumeEnabled() {
+		initContainer = newDBInitContainer(initContainer.Name, trivyImageRef, "/tmp/trivy/.cache", requirements, volumeMounts[0])
+		volumes = append(volumes, newDBVolume(config))
+	}
+
 	if config.IgnoreFileExists() {
 		volumes = append(volumes, corev1.Volume{
 			Name: ignoreFileVolumeName,
@@ -486,7 +549,7 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		})
 	}
 
-	for _, c := range spec.Containers {
+	for _, c := range kube.GetContainers(spec) {
 
 		env := []corev1.EnvVar{
 			{
@@ -602,7 +665,7 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 			})
 		}
 
-		if _, ok := credentials[c.Name]; ok && secret != nil {
+		if auth, ok := credentials[c.Name]; ok && secret != nil {
 			registryUsernameKey := fmt.Sprintf("%s.username", c.Name)
 			registryPasswordKey := fmt.Sprintf("%s.password", c.Name)
 
@@ -627,6 +690,20 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 					},
 				},
 			})
+
+			if auth.RegistryToken != "" {
+				env = append(env, corev1.EnvVar{
+					Name: "TRIVY_REGISTRY_TOKEN",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: secret.Name,
+							},
+							Key: fmt.Sprintf("%s.registryToken", c.Name),
+						},
+					},
+				})
+			}
 		}
 
 		env, err = p.appendTrivyInsecureEnv(config, c.Image, env)
@@ -634,6 +711,8 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 			return corev1.PodSpec{}, nil, err
 		}
 
+		env = p.appendTrivySBOMEnv(config, env)
+
 		env, err = p.appendTrivyNonSSLEnv(config, c.Image, env)
 		if err != nil {
 			return corev1.PodSpec{}, nil, err
@@ -696,7 +775,8 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 // In the ClientServer mode the number of containers of the pod created by the
 // scan job equals the number of containers defined for the scanned workload.
 // Each container runs Trivy image scan command and refers to Trivy server URL
-// returned by Config.GetServerURL:
+// returned by Config.GetServerURL, or to the Trivy server managed by Starboard
+// Operator if Config.IsServerManaged:
 //
 //     trivy client --remote <server URL> \
 //       --format json <container image>
@@ -716,13 +796,16 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 		return corev1.PodSpec{}, nil, err
 	}
 
-	trivyServerURL, err := config.GetServerURL()
-	if err != nil {
-		return corev1.PodSpec{}, nil, err
+	trivyServerURL := GetManagedServerURL(ctx.GetNamespace())
+	if !config.IsServerManaged() {
+		trivyServerURL, err = config.GetServerURL()
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
 	}
 
 	if len(credentials) > 0 {
-		secret = p.newSecretWithAggregateImagePullCredentials(workload, spec, credentials)
+		secret = p.newSecretWithAggregateImagePullCredentials(ctx, workload, spec, credentials)
 		secrets = append(secrets, secret)
 	}
 
@@ -730,7 +813,7 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 
 	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
 
-	for _, container := range spec.Containers {
+	for _, container := range kube.GetContainers(spec) {
 
 		env := []corev1.EnvVar{
 			{
@@ -867,7 +950,7 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 			},
 		}
 
-		if _, ok := credentials[container.Name]; ok && secret != nil {
+		if auth, ok := credentials[container.Name]; ok && secret != nil {
 			registryUsernameKey := fmt.Sprintf("%s.username", container.Name)
 			registryPasswordKey := fmt.Sprintf("%s.password", container.Name)
 
@@ -892,6 +975,20 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 					},
 				},
 			})
+
+			if auth.RegistryToken != "" {
+				env = append(env, corev1.EnvVar{
+					Name: "TRIVY_REGISTRY_TOKEN",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: secret.Name,
+							},
+							Key: fmt.Sprintf("%s.registryToken", container.Name),
+						},
+					},
+				})
+			}
 		}
 
 		env, err = p.appendTrivyInsecureEnv(config, container.Image, env)
@@ -899,6 +996,8 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 			return corev1.PodSpec{}, nil, err
 		}
 
+		env = p.appendTrivySBOMEnv(config, env)
+
 		env, err = p.appendTrivyNonSSLEnv(config, container.Image, env)
 		if err != nil {
 			return corev1.PodSpec{}, nil, err
@@ -1103,6 +1202,11 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 		},
 	}
 
+	if config.IsDBVolumeEnabled() {
+		initContainerDB = newDBInitContainer(initContainerDB.Name, trivyImageRef, "/var/starboard/trivy-db", requirements, volumeMounts[0])
+		volumes = append(volumes, newDBVolume(config))
+	}
+
 	//TODO Move this to function and refactor the code to use it
 	if config.IgnoreFileExists() {
 		volumes = append(volumes, corev1.Volume{
@@ -1129,7 +1233,7 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 		})
 	}
 
-	for _, c := range spec.Containers {
+	for _, c := range kube.GetContainers(spec) {
 
 		env := []corev1.EnvVar{
 			constructEnvVarSourceFromConfigMap("TRIVY_SEVERITY", trivyConfigName, keyTrivySeverity),
@@ -1155,6 +1259,8 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 			return corev1.PodSpec{}, nil, err
 		}
 
+		env = p.appendTrivySBOMEnv(config, env)
+
 		resourceRequirements, err := config.GetResourceRequirements()
 		if err != nil {
 			return corev1.PodSpec{}, nil, err
@@ -1213,6 +1319,223 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 	return podSpec, secrets, nil
 }
 
+// In the SBOM mode the SBOMs of container images, which were generated by
+// previous scans and stored as SBOMReports, are passed to the pod created by
+// the scan job in a secret. Similarly to the Standalone mode the init
+// container downloads the Trivy DB, but each main container scans the stored
+// SBOM rather than the container image. This way images are not pulled and
+// registry credentials are not required:
+//
+//     trivy --cache-dir /tmp/trivy/.cache --quiet sbom --skip-update \
+//       --format json /sbom/<container name>.json
+func (p *plugin) getPodSpecForStandaloneSBOMMode(ctx starboard.PluginContext, config Config, workload client.Object,
+	sboms map[string][]byte) (corev1.PodSpec, []*corev1.Secret, error) {
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
+
+	dbRepository, err := config.GetDBRepository()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	requirements, err := config.GetResourceRequirements()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: sbomSecretName(ctx, workload),
+		},
+		Data: make(map[string][]byte),
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      tmpVolumeName,
+			ReadOnly:  false,
+			MountPath: "/tmp",
+		},
+		{
+			Name:      sbomVolumeName,
+			ReadOnly:  true,
+			MountPath: "/sbom",
+		},
+	}
+
+	initContainer := corev1.Container{
+		Name:                     p.idGenerator.GenerateID(),
+		Image:                    trivyImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Env: []corev1.EnvVar{
+			constructEnvVarSourceFromConfigMap("HTTP_PROXY", trivyConfigName, keyTrivyHTTPProxy),
+			constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
+			constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
+			{
+				Name: "GITHUB_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: trivyConfigName,
+						},
+						Key:      keyTrivyGitHubToken,
+						Optional: pointer.BoolPtr(true),
+					},
+				},
+			},
+		},
+		Command: []string{
+			"trivy",
+		},
+		Args: []string{
+			"--cache-dir",
+			"/tmp/trivy/.cache",
+			"image",
+			"--download-db-only",
+			"--db-repository",
+			dbRepository,
+		},
+		Resources: requirements,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      tmpVolumeName,
+				MountPath: "/tmp",
+				ReadOnly:  false,
+			},
+		},
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: tmpVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumDefault,
+				},
+			},
+		},
+	}
+
+	if config.IsDBVolumeEnabled() {
+		initContainer = newDBInitContainer(initContainer.Name, trivyImageRef, "/tmp/trivy/.cache", requirements, volumeMounts[0])
+		volumes = append(volumes, newDBVolume(config))
+	}
+
+	if config.IgnoreFileExists() {
+		volumes = append(volumes, corev1.Volume{
+			Name: ignoreFileVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: trivyConfigName,
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  keyTrivyIgnoreFile,
+							Path: ".trivyignore",
+						},
+					},
+				},
+			},
+		})
+
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      ignoreFileVolumeName,
+			MountPath: "/etc/trivy/.trivyignore",
+			SubPath:   ".trivyignore",
+		})
+	}
+
+	var sbomItems []corev1.KeyToPath
+	var containers []corev1.Container
+
+	for _, c := range kube.GetContainers(spec) {
+		sbomFileName := c.Name + ".json"
+		secret.Data[sbomFileName] = sboms[c.Name]
+		sbomItems = append(sbomItems, corev1.KeyToPath{
+			Key:  sbomFileName,
+			Path: sbomFileName,
+		})
+
+		env := []corev1.EnvVar{
+			constructEnvVarSourceFromConfigMap("TRIVY_SEVERITY", trivyConfigName, keyTrivySeverity),
+			constructEnvVarSourceFromConfigMap("TRIVY_IGNORE_UNFIXED", trivyConfigName, keyTrivyIgnoreUnfixed),
+			constructEnvVarSourceFromConfigMap("TRIVY_TIMEOUT", trivyConfigName, keyTrivyTimeout),
+			constructEnvVarSourceFromConfigMap("HTTP_PROXY", trivyConfigName, keyTrivyHTTPProxy),
+			constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
+			constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
+		}
+		if config.IgnoreFileExists() {
+			env = append(env, corev1.EnvVar{
+				Name:  "TRIVY_IGNOREFILE",
+				Value: "/etc/trivy/.trivyignore",
+			})
+		}
+
+		containers = append(containers, corev1.Container{
+			Name:                     c.Name,
+			Image:                    trivyImageRef,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			Env:                      env,
+			Command: []string{
+				"trivy",
+			},
+			Args: []string{
+				"--cache-dir",
+				"/tmp/trivy/.cache",
+				"--quiet",
+				"sbom",
+				"--skip-update",
+				"--format",
+				"json",
+				"/sbom/" + sbomFileName,
+			},
+			Resources:    requirements,
+			VolumeMounts: volumeMounts,
+			SecurityContext: &corev1.SecurityContext{
+				Privileged:               pointer.BoolPtr(false),
+				AllowPrivilegeEscalation: pointer.BoolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"all"},
+				},
+				ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+			},
+		})
+	}
+
+	volumes = append(volumes, corev1.Volume{
+		Name: sbomVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secret.Name,
+				Items:      sbomItems,
+			},
+		},
+	})
+
+	return corev1.PodSpec{
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           ctx.GetServiceAccountName(),
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Volumes:                      volumes,
+		InitContainers:               []corev1.Container{initContainer},
+		Containers:                   containers,
+		SecurityContext:              &corev1.PodSecurityContext{},
+	}, []*corev1.Secret{secret}, nil
+}
+
 func (p *plugin) appendTrivyInsecureEnv(config Config, image string, env []corev1.EnvVar) ([]corev1.EnvVar, error) {
 	ref, err := name.ParseReference(image)
 	if err != nil {
@@ -1247,6 +1570,19 @@ func (p *plugin) appendTrivyNonSSLEnv(config Config, image string, env []corev1.
 	return env, nil
 }
 
+// appendTrivySBOMEnv instructs Trivy to list all packages found in the scanned
+// image, not only the vulnerable ones, so that the SBOM can be generated from
+// the output of the same scan.
+func (p *plugin) appendTrivySBOMEnv(config Config, env []corev1.EnvVar) []corev1.EnvVar {
+	if !config.GenerateSBOM() {
+		return env
+	}
+	return append(env, corev1.EnvVar{
+		Name:  "TRIVY_LIST_ALL_PKGS",
+		Value: "true",
+	})
+}
+
 func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
 	config, err := p.newConfigFrom(ctx)
 	if err != nil {
@@ -1279,6 +1615,9 @@ func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, image
 	if err != nil {
 		return v1alpha1.VulnerabilityReportData{}, err
 	}
+	if artifact.Digest == "" {
+		artifact.Digest = repoDigest(reports.Metadata, registry, artifact)
+	}
 
 	trivyImageRef, err := config.GetImageRef()
 	if err != nil {
@@ -1351,6 +1690,21 @@ func (p *plugin) parseImageRef(imageRef string) (v1alpha1.Registry, v1alpha1.Art
 	return registry, artifact, nil
 }
 
+// repoDigest returns the digest of the scanned image that Trivy resolved for
+// the repository of the given artifact, or an empty string if it is unknown.
+func repoDigest(metadata Metadata, registry v1alpha1.Registry, artifact v1alpha1.Artifact) string {
+	for _, repoDigest := range metadata.RepoDigests {
+		ref, err := name.NewDigest(repoDigest)
+		if err != nil {
+			continue
+		}
+		if ref.Context().RegistryStr() == registry.Server && ref.Context().RepositoryStr() == artifact.Repository {
+			return ref.DigestStr()
+		}
+	}
+	return ""
+}
+
 func GetScoreFromCVSS(CVSSs map[string]*CVSS) *float64 {
 	var nvdScore, vendorScore *float64
 
@@ -153,6 +153,15 @@ func TestConfig_GetCommand(t *testing.T) {
 			}},
 			expectedCommand: trivy.Filesystem,
 		},
+		{
+			name: "Should return sbom",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.command": "sbom",
+				},
+			}},
+			expectedCommand: trivy.SBOM,
+		},
 		{
 			name: "Should return error when value is not allowed",
 			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
@@ -160,7 +169,7 @@ func TestConfig_GetCommand(t *testing.T) {
 					"trivy.command": "ls",
 				},
 			}},
-			expectedError: "invalid value (ls) of trivy.command; allowed values (image, filesystem)",
+			expectedError: "invalid value (ls) of trivy.command; allowed values (image, filesystem, sbom)",
 		},
 	}
 	for _, tc := range testCases {
@@ -3165,7 +3174,7 @@ CVE-2019-1543`,
 				Get()
 			objectResolver := kube.NewObjectResolver(fakeclient, &kube.CompatibleObjectMapper{})
 			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
-			jobSpec, secrets, err := instance.GetScanJobSpec(pluginContext, tc.workloadSpec, nil)
+			jobSpec, secrets, err := instance.GetScanJobSpec(context.TODO(), pluginContext, tc.workloadSpec, nil)
 			require.NoError(t, err)
 			assert.Empty(t, secrets)
 			assert.Equal(t, tc.expectedJobSpec, jobSpec)
@@ -3483,7 +3492,7 @@ CVE-2019-1543`,
 				Get()
 			objectResolver := kube.NewObjectResolver(fakeclient, &kube.CompatibleObjectMapper{})
 			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
-			jobSpec, secrets, err := instance.GetScanJobSpec(pluginContext, tc.workloadSpec, nil)
+			jobSpec, secrets, err := instance.GetScanJobSpec(context.TODO(), pluginContext, tc.workloadSpec, nil)
 			require.NoError(t, err)
 			assert.Empty(t, secrets)
 			assert.Equal(t, tc.expectedJobSpec, jobSpec)
@@ -3491,6 +3500,73 @@ CVE-2019-1543`,
 	}
 }
 
+func TestPlugin_GetScanJobSpec_AllContainerClasses(t *testing.T) {
+	fakeclient := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-trivy-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"trivy.imageRef":     "docker.io/aquasec/trivy:0.14.0",
+				"trivy.mode":         string(trivy.Standalone),
+				"trivy.dbRepository": defaultDBRepository,
+			},
+		},
+	).Build()
+	pluginContext := starboard.NewPluginContext().
+		WithName(trivy.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeclient).
+		Get()
+	objectResolver := kube.NewObjectResolver(fakeclient, &kube.CompatibleObjectMapper{})
+	instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+	jobSpec, _, err := instance.GetScanJobSpec(context.TODO(), pluginContext, &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "prod-ns",
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name:  "init",
+					Image: "busybox:1.34",
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  "nginx",
+					Image: "nginx:1.16",
+				},
+			},
+			EphemeralContainers: []corev1.EphemeralContainer{
+				{
+					EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+						Name:  "debugger",
+						Image: "alpine:3.15",
+					},
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	images := make(map[string]string)
+	for _, container := range jobSpec.Containers {
+		images[container.Name] = container.Args[len(container.Args)-1]
+	}
+	assert.Equal(t, map[string]string{
+		"init":     "busybox:1.34",
+		"nginx":    "nginx:1.16",
+		"debugger": "alpine:3.15",
+	}, images)
+}
+
 var (
 	sampleReportAsString = `{
 		"SchemaVersion": 2,
@@ -3627,6 +3703,29 @@ func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
 				Vulnerabilities: []v1alpha1.Vulnerability{},
 			},
 		},
+		{
+			name:          "Should set digest resolved by Trivy for the repository of the image",
+			imageRef:      "nginx:1.16",
+			input:         `{"Metadata":{"RepoDigests":["quay.io/nginx/nginx@sha256:1d4a39d8ea2a2d2b2c2a6f3ea51e37bd3f9a1bd7bd52f4a5e6ed1bf8b2a6e2c3","nginx@sha256:d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c"]},"Results":[]}`,
+			expectedError: nil,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner: v1alpha1.Scanner{
+					Name:    "Trivy",
+					Vendor:  "Aqua Security",
+					Version: "0.9.1",
+				},
+				Registry: v1alpha1.Registry{
+					Server: "index.docker.io",
+				},
+				Artifact: v1alpha1.Artifact{
+					Repository: "library/nginx",
+					Tag:        "1.16",
+					Digest:     "sha256:d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c",
+				},
+				Vulnerabilities: []v1alpha1.Vulnerability{},
+			},
+		},
 		{
 			name:          "Should return error when image reference cannot be parsed",
 			imageRef:      ":",
@@ -0,0 +1,420 @@
+package trivy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	classOSPackages = "os-pkgs"
+
+	// artifactTypeCycloneDX is the type of the artifact scanned by Trivy
+	// with the SBOM command.
+	artifactTypeCycloneDX = "cyclonedx"
+
+	propertyPkgType    = "aquasecurity:trivy:PkgType"
+	propertySrcName    = "aquasecurity:trivy:SrcName"
+	propertySrcVersion = "aquasecurity:trivy:SrcVersion"
+	propertyLayerDiff  = "aquasecurity:trivy:LayerDiffID"
+)
+
+// IsSBOMEnabled returns true if the trivy.generateSBOM config key is set to
+// true, false otherwise.
+func (p *plugin) IsSBOMEnabled(ctx starboard.PluginContext) (bool, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return false, err
+	}
+	return config.GenerateSBOM(), nil
+}
+
+// ParseSBOMReportData converts the list of all packages output by Trivy to
+// the SBOM in the CycloneDX format.
+func (p *plugin) ParseSBOMReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (v1alpha1.SBOMReportData, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+	var report ScanReport
+	err = json.NewDecoder(logsReader).Decode(&report)
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+
+	// The stored SBOM that was scanned with the SBOM command is kept as is.
+	if report.ArtifactType == artifactTypeCycloneDX {
+		return v1alpha1.SBOMReportData{}, vulnerabilityreport.ErrSBOMNotGenerated
+	}
+
+	registry, artifact, err := p.parseImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+	if artifact.Digest == "" {
+		artifact.Digest = repoDigest(report.Metadata, registry, artifact)
+	}
+
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+
+	version, err := starboard.GetVersionFromImageRef(trivyImageRef)
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+
+	now := p.clock.Now()
+	components := toComponents(report)
+
+	imageVersion := artifact.Tag
+	if imageVersion == "" {
+		imageVersion = artifact.Digest
+	}
+
+	return v1alpha1.SBOMReportData{
+		UpdateTimestamp: metav1.NewTime(now),
+		Scanner: v1alpha1.Scanner{
+			Name:    "Trivy",
+			Vendor:  "Aqua Security",
+			Version: version,
+		},
+		Registry: registry,
+		Artifact: artifact,
+		Summary: v1alpha1.SBOMSummary{
+			ComponentsCount: len(components),
+		},
+		Components: v1alpha1.BOM{
+			BOMFormat:   v1alpha1.BOMFormatCycloneDX,
+			SpecVersion: v1alpha1.BOMSpecVersion,
+			Version:     1,
+			Metadata: &v1alpha1.BOMMetadata{
+				Timestamp: now.UTC().Format(time.RFC3339),
+				Tools: []v1alpha1.Tool{
+					{
+						Vendor:  "Aqua Security",
+						Name:    "trivy",
+						Version: version,
+					},
+				},
+				Component: &v1alpha1.Component{
+					BOMRef:  imageRef,
+					Type:    v1alpha1.ComponentTypeContainer,
+					Name:    registry.Server + "/" + artifact.Repository,
+					Version: imageVersion,
+				},
+			},
+			Components: components,
+		},
+	}, nil
+}
+
+// getStoredSBOMs returns SBOMs of container images of the specified workload,
+// encoded as CycloneDX JSON documents and indexed by container name. It returns
+// nil if any container image does not have the SBOM stored as the
+// v1alpha1.SBOMReport, if the stored SBOM was generated for a different image
+// than the one running, or if SBOMs are too large to be passed to the scan job.
+func (p *plugin) getStoredSBOMs(ctx context.Context, workload client.Object) (map[string][]byte, error) {
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := sbomreport.NewReadWriter(p.objectResolver).FindByOwner(ctx, kube.ObjectRef{
+		Kind:      kube.Kind(workload.GetObjectKind().GroupVersionKind().Kind),
+		Name:      workload.GetName(),
+		Namespace: workload.GetNamespace(),
+	})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing sbom reports: %w", err)
+	}
+
+	reportsByContainer := make(map[string]v1alpha1.SBOMReport)
+	for _, report := range reports {
+		reportsByContainer[report.Labels[starboard.LabelContainerName]] = report
+	}
+
+	digests, err := p.getContainerImageDigests(ctx, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	sboms := make(map[string][]byte)
+	size := 0
+	for _, container := range kube.GetContainers(spec) {
+		report, ok := reportsByContainer[container.Name]
+		if !ok {
+			return nil, nil
+		}
+		bom := report.Report.Components
+		// The SBOM is stale if the container image has been changed since
+		// it was generated.
+		if bom.Metadata == nil || bom.Metadata.Component == nil || bom.Metadata.Component.BOMRef != container.Image {
+			return nil, nil
+		}
+		// The SBOM is also stale if the image reference is unchanged, but it
+		// refers to a different image than the one running, e.g. because a
+		// mutable tag has been moved.
+		if digest, ok := digests[container.Name]; ok && digest != report.Report.Artifact.Digest {
+			return nil, nil
+		}
+		data, err := json.Marshal(bom)
+		if err != nil {
+			return nil, err
+		}
+		size += len(data)
+		sboms[container.Name] = data
+	}
+	if size > corev1.MaxSecretSize {
+		return nil, nil
+	}
+	return sboms, nil
+}
+
+// getContainerImageDigests returns a map of container names to image digests
+// resolved by the container runtime for one of the active pods of the given
+// workload. An empty map is returned if there are no running pods.
+func (p *plugin) getContainerImageDigests(ctx context.Context, workload client.Object) (kube.ContainerImages, error) {
+	pods, err := p.objectResolver.GetActivePods(ctx, workload)
+	if err != nil {
+		if errors.Is(err, kube.ErrReplicaSetNotFound) || errors.Is(err, kube.ErrNoRunningPods) ||
+			errors.Is(err, kube.ErrUnSupportedKind) {
+			return kube.ContainerImages{}, nil
+		}
+		return nil, fmt.Errorf("getting active pods: %w", err)
+	}
+	return kube.GetContainerImageDigestsFromPod(pods[0]), nil
+}
+
+func sbomSecretName(ctx starboard.PluginContext, obj client.Object) string {
+	return fmt.Sprintf("%s-sbom", vulnerabilityreport.GetScanJobName(ctx, obj))
+}
+
+// toComponents converts the operating system and packages found by Trivy to
+// CycloneDX components sorted by their BOM references.
+func toComponents(report ScanReport) []v1alpha1.Component {
+	components := make(map[string]v1alpha1.Component)
+
+	var osFamily, osName string
+	if report.Metadata.OS != nil {
+		osFamily = report.Metadata.OS.Family
+		osName = report.Metadata.OS.Name
+		components[osFamily] = v1alpha1.Component{
+			BOMRef:  osFamily,
+			Type:    v1alpha1.ComponentTypeOperatingSystem,
+			Name:    osFamily,
+			Version: osName,
+		}
+	}
+
+	for _, result := range report.Results {
+		for _, pkg := range result.Packages {
+			component := v1alpha1.Component{
+				Type:    v1alpha1.ComponentTypeLibrary,
+				Name:    pkg.Name,
+				Version: formatVersion(pkg),
+			}
+			if result.Class == classOSPackages {
+				component.PackageURL = osPackageURL(result.Type, osName, pkg)
+			} else {
+				component.PackageURL = langPackageURL(result.Type, pkg)
+			}
+			if strings.Contains(pkg.Name, ":") && (result.Type == "jar" || result.Type == "pom") {
+				parts := strings.SplitN(pkg.Name, ":", 2)
+				component.Group = parts[0]
+				component.Name = parts[1]
+			}
+			for _, license := range pkg.Licenses {
+				component.Licenses = append(component.Licenses, v1alpha1.LicenseChoice{
+					License: v1alpha1.License{Name: license},
+				})
+			}
+			component.Properties = toProperties(result.Type, pkg)
+
+			component.BOMRef = component.PackageURL
+			if component.BOMRef == "" {
+				component.BOMRef = fmt.Sprintf("%s:%s@%s", result.Type, pkg.Name, component.Version)
+			}
+			if _, ok := components[component.BOMRef]; ok {
+				continue
+			}
+			components[component.BOMRef] = component
+		}
+	}
+
+	var sorted []v1alpha1.Component
+	for _, component := range components {
+		sorted = append(sorted, component)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].BOMRef < sorted[j].BOMRef
+	})
+	return sorted
+}
+
+func toProperties(pkgType string, pkg Package) []v1alpha1.Property {
+	properties := []v1alpha1.Property{
+		{Name: propertyPkgType, Value: pkgType},
+	}
+	if pkg.SrcName != "" {
+		properties = append(properties, v1alpha1.Property{Name: propertySrcName, Value: pkg.SrcName})
+	}
+	if pkg.SrcVersion != "" {
+		properties = append(properties, v1alpha1.Property{Name: propertySrcVersion, Value: pkg.SrcVersion})
+	}
+	if pkg.Layer.DiffID != "" {
+		properties = append(properties, v1alpha1.Property{Name: propertyLayerDiff, Value: pkg.Layer.DiffID})
+	}
+	return properties
+}
+
+// formatVersion returns the version of the given package in the same format as
+// the installed version of a vulnerable package reported by Trivy, i.e.
+// [epoch:]version[-release].
+func formatVersion(pkg Package) string {
+	version := pkg.Version
+	if pkg.Release != "" {
+		version = fmt.Sprintf("%s-%s", version, pkg.Release)
+	}
+	if pkg.Epoch != 0 {
+		version = fmt.Sprintf("%d:%s", pkg.Epoch, version)
+	}
+	return version
+}
+
+// osPackageURL returns the package URL of the given OS package, or an empty
+// string if the OS family is not supported.
+// @see https://github.com/package-url/purl-spec
+func osPackageURL(family, osName string, pkg Package) string {
+	var purlType string
+	switch family {
+	case "alpine":
+		purlType = "apk"
+	case "debian", "ubuntu":
+		purlType = "deb"
+	case "redhat", "centos", "rocky", "alma", "amazon", "oracle", "fedora", "photon",
+		"opensuse.leap", "opensuse.tumbleweed", "suse linux enterprise server", "cbl-mariner":
+		purlType = "rpm"
+	default:
+		return ""
+	}
+
+	version := pkg.Version
+	if pkg.Release != "" {
+		version = fmt.Sprintf("%s-%s", version, pkg.Release)
+	}
+
+	qualifiers := url.Values{}
+	if pkg.Arch != "" {
+		qualifiers.Set("arch", pkg.Arch)
+	}
+	if pkg.Epoch != 0 {
+		qualifiers.Set("epoch", fmt.Sprintf("%d", pkg.Epoch))
+	}
+	if osName != "" {
+		qualifiers.Set("distro", fmt.Sprintf("%s-%s", family, osName))
+	}
+
+	return formatPackageURL(purlType, url.PathEscape(family), pkg.Name, version, qualifiers)
+}
+
+// langPackageURL returns the package URL of the given application dependency,
+// or an empty string if the package type is not supported.
+// @see https://github.com/package-url/purl-spec
+func langPackageURL(pkgType string, pkg Package) string {
+	var purlType, namespace string
+	name := pkg.Name
+
+	switch pkgType {
+	case "npm", "yarn", "node-pkg":
+		purlType = "npm"
+		if strings.HasPrefix(name, "@") && strings.Contains(name, "/") {
+			parts := strings.SplitN(name, "/", 2)
+			// The @ sign of a scope must be percent-encoded.
+			namespace, name = "%40"+url.PathEscape(strings.TrimPrefix(parts[0], "@")), parts[1]
+		}
+	case "pip", "pipenv", "poetry", "python-pkg":
+		purlType = "pypi"
+		name = strings.ToLower(name)
+	case "bundler", "gemspec":
+		purlType = "gem"
+	case "jar", "pom":
+		purlType = "maven"
+		if strings.Contains(name, ":") {
+			parts := strings.SplitN(name, ":", 2)
+			namespace, name = url.PathEscape(parts[0]), parts[1]
+		}
+	case "gomod", "gobinary":
+		purlType = "golang"
+		if i := strings.LastIndex(name, "/"); i > 0 {
+			namespace = escapeSegments(name[:i])
+			name = name[i+1:]
+		}
+	case "composer":
+		purlType = "composer"
+		if strings.Contains(name, "/") {
+			parts := strings.SplitN(name, "/", 2)
+			namespace, name = url.PathEscape(parts[0]), parts[1]
+		}
+	case "cargo":
+		purlType = "cargo"
+	case "nuget":
+		purlType = "nuget"
+	case "conan":
+		purlType = "conan"
+	default:
+		return ""
+	}
+
+	return formatPackageURL(purlType, namespace, name, pkg.Version, nil)
+}
+
+func formatPackageURL(purlType, namespace, name, version string, qualifiers url.Values) string {
+	var b strings.Builder
+	b.WriteString("pkg:")
+	b.WriteString(purlType)
+	b.WriteString("/")
+	if namespace != "" {
+		b.WriteString(namespace)
+		b.WriteString("/")
+	}
+	b.WriteString(url.PathEscape(name))
+	if version != "" {
+		b.WriteString("@")
+		b.WriteString(url.PathEscape(version))
+	}
+	if len(qualifiers) > 0 {
+		// url.Values.Encode sorts qualifiers by key as required by the spec.
+		b.WriteString("?")
+		b.WriteString(qualifiers.Encode())
+	}
+	return b.String()
+}
+
+func escapeSegments(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,504 @@
+package trivy_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const sampleSBOMReportAsString = `{
+  "Metadata": {
+    "OS": {
+      "Family": "debian",
+      "Name": "10.3"
+    }
+  },
+  "Results": [
+    {
+      "Target": "nginx:1.16 (debian 10.3)",
+      "Class": "os-pkgs",
+      "Type": "debian",
+      "Packages": [
+        {
+          "Name": "libbsd0",
+          "Version": "0.9.1",
+          "Release": "2",
+          "Arch": "amd64",
+          "SrcName": "libbsd",
+          "SrcVersion": "0.9.1-2",
+          "Licenses": ["BSD-3-Clause"]
+        },
+        {
+          "Name": "libbsd0",
+          "Version": "0.9.1",
+          "Release": "2",
+          "Arch": "amd64",
+          "SrcName": "libbsd",
+          "SrcVersion": "0.9.1-2",
+          "Licenses": ["BSD-3-Clause"]
+        }
+      ]
+    },
+    {
+      "Target": "app/package-lock.json",
+      "Class": "lang-pkgs",
+      "Type": "npm",
+      "Packages": [
+        {
+          "Name": "@babel/core",
+          "Version": "7.12.3"
+        }
+      ]
+    },
+    {
+      "Target": "app/app.jar",
+      "Class": "lang-pkgs",
+      "Type": "jar",
+      "Packages": [
+        {
+          "Name": "org.apache.logging.log4j:log4j-core",
+          "Version": "2.14.1",
+          "Layer": {
+            "DiffID": "sha256:5d6b8ea0ef9b2b2f6c4f4ae3b7b4c1d4bc9c1f5e9e4a7c0e0b2b1d3c8a8e9f10"
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+func TestPlugin_IsSBOMEnabled(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     map[string]string
+		expected bool
+	}{
+		{
+			name: "Should return false by default",
+			data: map[string]string{
+				"trivy.imageRef": "aquasec/trivy:0.9.1",
+			},
+			expected: false,
+		},
+		{
+			name: "Should return true",
+			data: map[string]string{
+				"trivy.imageRef":     "aquasec/trivy:0.9.1",
+				"trivy.generateSBOM": "true",
+			},
+			expected: true,
+		},
+		{
+			name: "Should return false when set it as false",
+			data: map[string]string{
+				"trivy.imageRef":     "aquasec/trivy:0.9.1",
+				"trivy.generateSBOM": "false",
+			},
+			expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: tc.data,
+			}).Build()
+			ctx := starboard.NewPluginContext().
+				WithName("Trivy").
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				WithClient(fakeClient).
+				Get()
+			objectResolver := kube.NewObjectResolver(fakeClient, &kube.CompatibleObjectMapper{})
+			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+			enabled, err := instance.(vulnerabilityreport.SBOMPlugin).IsSBOMEnabled(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, enabled)
+		})
+	}
+}
+
+func TestPlugin_ParseSBOMReportData(t *testing.T) {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard-trivy-config",
+			Namespace: "starboard-ns",
+		},
+		Data: map[string]string{
+			"trivy.imageRef": "aquasec/trivy:0.9.1",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(config).Build()
+	ctx := starboard.NewPluginContext().
+		WithName("Trivy").
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeClient).
+		Get()
+	objectResolver := kube.NewObjectResolver(fakeClient, &kube.CompatibleObjectMapper{})
+	instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+
+	report, err := instance.(vulnerabilityreport.SBOMPlugin).
+		ParseSBOMReportData(ctx, "nginx:1.16", io.NopCloser(strings.NewReader(sampleSBOMReportAsString)))
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.SBOMReportData{
+		UpdateTimestamp: metav1.NewTime(fixedTime),
+		Scanner: v1alpha1.Scanner{
+			Name:    "Trivy",
+			Vendor:  "Aqua Security",
+			Version: "0.9.1",
+		},
+		Registry: v1alpha1.Registry{
+			Server: "index.docker.io",
+		},
+		Artifact: v1alpha1.Artifact{
+			Repository: "library/nginx",
+			Tag:        "1.16",
+		},
+		Summary: v1alpha1.SBOMSummary{
+			ComponentsCount: 4,
+		},
+		Components: v1alpha1.BOM{
+			BOMFormat:   "CycloneDX",
+			SpecVersion: "1.4",
+			Version:     1,
+			Metadata: &v1alpha1.BOMMetadata{
+				Timestamp: fixedTime.UTC().Format(time.RFC3339),
+				Tools: []v1alpha1.Tool{
+					{Vendor: "Aqua Security", Name: "trivy", Version: "0.9.1"},
+				},
+				Component: &v1alpha1.Component{
+					BOMRef:  "nginx:1.16",
+					Type:    v1alpha1.ComponentTypeContainer,
+					Name:    "index.docker.io/library/nginx",
+					Version: "1.16",
+				},
+			},
+			Components: []v1alpha1.Component{
+				{
+					BOMRef:  "debian",
+					Type:    v1alpha1.ComponentTypeOperatingSystem,
+					Name:    "debian",
+					Version: "10.3",
+				},
+				{
+					BOMRef:     "pkg:deb/debian/libbsd0@0.9.1-2?arch=amd64&distro=debian-10.3",
+					Type:       v1alpha1.ComponentTypeLibrary,
+					Name:       "libbsd0",
+					Version:    "0.9.1-2",
+					PackageURL: "pkg:deb/debian/libbsd0@0.9.1-2?arch=amd64&distro=debian-10.3",
+					Licenses: []v1alpha1.LicenseChoice{
+						{License: v1alpha1.License{Name: "BSD-3-Clause"}},
+					},
+					Properties: []v1alpha1.Property{
+						{Name: "aquasecurity:trivy:PkgType", Value: "debian"},
+						{Name: "aquasecurity:trivy:SrcName", Value: "libbsd"},
+						{Name: "aquasecurity:trivy:SrcVersion", Value: "0.9.1-2"},
+					},
+				},
+				{
+					BOMRef:     "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+					Type:       v1alpha1.ComponentTypeLibrary,
+					Group:      "org.apache.logging.log4j",
+					Name:       "log4j-core",
+					Version:    "2.14.1",
+					PackageURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+					Properties: []v1alpha1.Property{
+						{Name: "aquasecurity:trivy:PkgType", Value: "jar"},
+						{Name: "aquasecurity:trivy:LayerDiffID", Value: "sha256:5d6b8ea0ef9b2b2f6c4f4ae3b7b4c1d4bc9c1f5e9e4a7c0e0b2b1d3c8a8e9f10"},
+					},
+				},
+				{
+					BOMRef:     "pkg:npm/%40babel/core@7.12.3",
+					Type:       v1alpha1.ComponentTypeLibrary,
+					Name:       "@babel/core",
+					Version:    "7.12.3",
+					PackageURL: "pkg:npm/%40babel/core@7.12.3",
+					Properties: []v1alpha1.Property{
+						{Name: "aquasecurity:trivy:PkgType", Value: "npm"},
+					},
+				},
+			},
+		},
+	}, report)
+}
+
+func TestPlugin_GetScanJobSpec_SBOMCommand(t *testing.T) {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard-trivy-config",
+			Namespace: "starboard-ns",
+		},
+		Data: map[string]string{
+			"trivy.imageRef":     "aquasec/trivy:0.30.0",
+			"trivy.mode":         string(trivy.Standalone),
+			"trivy.command":      string(trivy.SBOM),
+			"trivy.dbRepository": defaultDBRepository,
+		},
+	}
+
+	clientServerConfig := config.DeepCopy()
+	clientServerConfig.Data["trivy.mode"] = string(trivy.ClientServer)
+	clientServerConfig.Data["trivy.serverURL"] = "http://trivy.trivy:4954"
+
+	workload := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "nginx"},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "nginx",
+							Image: "nginx:1.16",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8-qwx2p",
+			Namespace: "prod-ns",
+			Labels:    map[string]string{"app": "nginx"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:    "nginx",
+					Image:   "nginx:1.16",
+					ImageID: "docker-pullable://nginx@sha256:d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c",
+				},
+			},
+		},
+	}
+
+	storedSBOM := func(image string) *v1alpha1.SBOMReport {
+		return &v1alpha1.SBOMReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6799fc88d8-nginx",
+				Namespace: "prod-ns",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "nginx-6799fc88d8",
+					starboard.LabelResourceNamespace: "prod-ns",
+					starboard.LabelContainerName:     "nginx",
+				},
+			},
+			Report: v1alpha1.SBOMReportData{
+				Components: v1alpha1.BOM{
+					BOMFormat:   v1alpha1.BOMFormatCycloneDX,
+					SpecVersion: v1alpha1.BOMSpecVersion,
+					Version:     1,
+					Metadata: &v1alpha1.BOMMetadata{
+						Component: &v1alpha1.Component{
+							BOMRef: image,
+							Type:   v1alpha1.ComponentTypeContainer,
+							Name:   "index.docker.io/library/nginx",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	storedSBOMWithDigest := func(image, digest string) *v1alpha1.SBOMReport {
+		report := storedSBOM(image)
+		report.Report.Artifact.Digest = digest
+		return report
+	}
+
+	testCases := []struct {
+		name            string
+		objects         []client.Object
+		expectedArgs    []string
+		expectedSecrets int
+	}{
+		{
+			name:    "Should scan stored SBOM",
+			objects: []client.Object{config, storedSBOM("nginx:1.16")},
+			expectedArgs: []string{
+				"--cache-dir", "/tmp/trivy/.cache", "--quiet", "sbom", "--skip-update", "--format", "json",
+				"/sbom/nginx.json",
+			},
+			expectedSecrets: 1,
+		},
+		{
+			name:    "Should scan image when SBOM is not stored",
+			objects: []client.Object{config},
+			expectedArgs: []string{
+				"--cache-dir", "/tmp/trivy/.cache", "--quiet", "image", "--skip-update", "--format", "json",
+				"nginx:1.16",
+			},
+			expectedSecrets: 0,
+		},
+		{
+			name:    "Should scan image when stored SBOM is stale",
+			objects: []client.Object{config, storedSBOM("nginx:1.14")},
+			expectedArgs: []string{
+				"--cache-dir", "/tmp/trivy/.cache", "--quiet", "image", "--skip-update", "--format", "json",
+				"nginx:1.16",
+			},
+			expectedSecrets: 0,
+		},
+		{
+			name: "Should scan stored SBOM of running image",
+			objects: []client.Object{config, runningPod,
+				storedSBOMWithDigest("nginx:1.16", "sha256:d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c")},
+			expectedArgs: []string{
+				"--cache-dir", "/tmp/trivy/.cache", "--quiet", "sbom", "--skip-update", "--format", "json",
+				"/sbom/nginx.json",
+			},
+			expectedSecrets: 1,
+		},
+		{
+			name: "Should scan image when stored SBOM was generated for different digest",
+			objects: []client.Object{config, runningPod,
+				storedSBOMWithDigest("nginx:1.16", "sha256:6d75c99af15565a301e48297fa2d121e15d80ad526f8369c526324f0f7ccb750")},
+			expectedArgs: []string{
+				"--cache-dir", "/tmp/trivy/.cache", "--quiet", "image", "--skip-update", "--format", "json",
+				"nginx:1.16",
+			},
+			expectedSecrets: 0,
+		},
+		{
+			name:    "Should scan image with Trivy server in ClientServer mode",
+			objects: []client.Object{clientServerConfig, storedSBOM("nginx:1.16")},
+			expectedArgs: []string{
+				"--quiet", "client", "--format", "json", "--remote", "http://trivy.trivy:4954", "nginx:1.16",
+			},
+			expectedSecrets: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(starboard.NewScheme()).
+				WithObjects(tc.objects...).
+				Build()
+			ctx := starboard.NewPluginContext().
+				WithName(trivy.Plugin).
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				WithClient(fakeClient).
+				Get()
+			objectResolver := kube.NewObjectResolver(fakeClient, &kube.CompatibleObjectMapper{})
+			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+
+			jobSpec, secrets, err := instance.GetScanJobSpec(context.TODO(), ctx, workload, nil)
+			require.NoError(t, err)
+			require.Len(t, secrets, tc.expectedSecrets)
+			require.Len(t, jobSpec.Containers, 1)
+			assert.Equal(t, tc.expectedArgs, jobSpec.Containers[0].Args)
+			assert.Equal(t, "aquasec/trivy:0.30.0", jobSpec.Containers[0].Image)
+
+			if tc.expectedSecrets > 0 {
+				assert.Equal(t, "scan-vulnerabilityreport-"+kube.ComputeHash(kube.ObjectRef{
+					Kind:      kube.KindReplicaSet,
+					Name:      "nginx-6799fc88d8",
+					Namespace: "prod-ns",
+				})+"-sbom", secrets[0].Name)
+				assert.JSONEq(t, `{"bomFormat":"CycloneDX","specVersion":"1.4","version":1,"metadata":{"component":{"bom-ref":"nginx:1.16","type":"container","name":"index.docker.io/library/nginx"}}}`,
+					string(secrets[0].Data["nginx.json"]))
+				for _, env := range jobSpec.Containers[0].Env {
+					assert.NotEqual(t, "TRIVY_USERNAME", env.Name)
+					assert.NotEqual(t, "TRIVY_LIST_ALL_PKGS", env.Name)
+				}
+			}
+		})
+	}
+
+	t.Run("Should pass registry token when SBOM is not stored", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(starboard.NewScheme()).
+			WithObjects(config).
+			Build()
+		ctx := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fakeClient).
+			Get()
+		objectResolver := kube.NewObjectResolver(fakeClient, &kube.CompatibleObjectMapper{})
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+		credentials := map[string]docker.Auth{
+			"nginx": {RegistryToken: "bearer-token"},
+		}
+
+		jobSpec, secrets, err := instance.GetScanJobSpec(context.TODO(), ctx, workload, credentials)
+		require.NoError(t, err)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, []byte("bearer-token"), secrets[0].Data["nginx.registryToken"])
+		require.Len(t, jobSpec.Containers, 1)
+		assert.Contains(t, jobSpec.Containers[0].Env, corev1.EnvVar{
+			Name: "TRIVY_REGISTRY_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secrets[0].Name,
+					},
+					Key: "nginx.registryToken",
+				},
+			},
+		})
+	})
+}
+
+func TestPlugin_ParseSBOMReportData_StoredSBOM(t *testing.T) {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard-trivy-config",
+			Namespace: "starboard-ns",
+		},
+		Data: map[string]string{
+			"trivy.imageRef": "aquasec/trivy:0.30.0",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(config).Build()
+	ctx := starboard.NewPluginContext().
+		WithName("Trivy").
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeClient).
+		Get()
+	objectResolver := kube.NewObjectResolver(fakeClient, &kube.CompatibleObjectMapper{})
+	instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+
+	_, err := instance.(vulnerabilityreport.SBOMPlugin).
+		ParseSBOMReportData(ctx, "nginx:1.16", io.NopCloser(strings.NewReader(`{"ArtifactType":"cyclonedx","Results":[]}`)))
+	assert.ErrorIs(t, err, vulnerabilityreport.ErrSBOMNotGenerated)
+}
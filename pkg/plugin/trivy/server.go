@@ -0,0 +1,323 @@
+package trivy
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// ManagedServerName is the name of the Deployment, the Service, and the
+	// PersistentVolumeClaim of the Trivy server managed by Starboard Operator.
+	ManagedServerName = "starboard-trivy-server"
+
+	// AnnotationServerSecretChecksum is the annotation of the Pod template of
+	// the managed Trivy server that holds the hash of the plugin's Secret, so
+	// that the server is restarted whenever the token changes.
+	AnnotationServerSecretChecksum = "starboard.trivy-server.secret-checksum"
+
+	managedServerPort      = 4954
+	managedServerCacheDir  = "/var/lib/trivy"
+	cacheVolumeName        = "cache"
+	defaultServerCacheSize = "5Gi"
+)
+
+// IsServerManaged returns true if scan jobs in the ClientServer mode should
+// connect to the Trivy server managed by Starboard Operator instead of the
+// server at Config.GetServerURL.
+func (c Config) IsServerManaged() bool {
+	mode, err := c.GetMode()
+	if err != nil || mode != ClientServer {
+		return false
+	}
+	return c.Data[keyTrivyServerManaged] == "true"
+}
+
+// GetServerCacheSize returns the size of the PersistentVolumeClaim used to
+// cache the vulnerability database of the managed Trivy server.
+func (c Config) GetServerCacheSize() (resource.Quantity, error) {
+	value, ok := c.Data[keyTrivyServerCacheSize]
+	if !ok {
+		value = defaultServerCacheSize
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("parsing %s: %s: %w", keyTrivyServerCacheSize, value, err)
+	}
+	return quantity, nil
+}
+
+// GetServerStorageClassName returns the name of the StorageClass of the
+// PersistentVolumeClaim used by the managed Trivy server, or nil to use the
+// default StorageClass.
+func (c Config) GetServerStorageClassName() *string {
+	if value, ok := c.Data[keyTrivyServerStorageClassName]; ok && value != "" {
+		return pointer.StringPtr(value)
+	}
+	return nil
+}
+
+// GetManagedServerURL returns the URL of the Trivy server managed by Starboard
+// Operator in the specified namespace.
+func GetManagedServerURL(namespace string) string {
+	return fmt.Sprintf("http://%s.%s:%d", ManagedServerName, namespace, managedServerPort)
+}
+
+// EnsureServerToken generates a random token to authenticate Trivy clients with
+// the managed Trivy server unless the specified Secret already holds a token.
+// Returns true if the Secret has been modified.
+func EnsureServerToken(secret *corev1.Secret) (bool, error) {
+	if len(secret.Data[keyTrivyServerToken]) > 0 {
+		return false, nil
+	}
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return false, fmt.Errorf("generating server token: %w", err)
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[keyTrivyServerToken] = []byte(hex.EncodeToString(token))
+	return true, nil
+}
+
+func managedServerLabels() labels.Set {
+	return labels.Set{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+		"app.kubernetes.io/name":       ManagedServerName,
+	}
+}
+
+// NewManagedServerCache returns the PersistentVolumeClaim used by the managed
+// Trivy server to cache the vulnerability database across restarts.
+func NewManagedServerCache(config Config, namespace string) (*corev1.PersistentVolumeClaim, error) {
+	size, err := config.GetServerCacheSize()
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagedServerName,
+			Namespace: namespace,
+			Labels:    managedServerLabels(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			StorageClassName: config.GetServerStorageClassName(),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}, nil
+}
+
+// NewManagedServerService returns the Service that exposes the managed Trivy
+// server to scan jobs.
+func NewManagedServerService(namespace string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagedServerName,
+			Namespace: namespace,
+			Labels:    managedServerLabels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: managedServerLabels(),
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "trivy-http",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       managedServerPort,
+					TargetPort: intstr.FromInt(managedServerPort),
+				},
+			},
+		},
+	}
+}
+
+// NewManagedServerDeployment returns the Deployment of the managed Trivy
+// server. The server downloads the vulnerability database from the configured
+// repository once, caches it in the volume claimed by NewManagedServerCache,
+// and keeps it up to date. Clients authenticate with the token stored in the
+// specified plugin's Secret, which is shared with scan jobs.
+//
+// The Deployment is labeled with the hash of its spec so that it's updated
+// only when the configuration or the Secret has changed.
+func NewManagedServerDeployment(config Config, secret *corev1.Secret, namespace, serviceAccountName string) (*appsv1.Deployment, error) {
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return nil, err
+	}
+
+	dbRepository, err := config.GetDBRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
+
+	configMapEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: trivyConfigName,
+					},
+					Key:      key,
+					Optional: pointer.BoolPtr(true),
+				},
+			},
+		}
+	}
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: trivyConfigName,
+					},
+					Key:      key,
+					Optional: pointer.BoolPtr(true),
+				},
+			},
+		}
+	}
+
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/healthz",
+				Port: intstr.FromInt(managedServerPort),
+			},
+		},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+		FailureThreshold:    10,
+	}
+
+	spec := appsv1.DeploymentSpec{
+		Replicas: pointer.Int32Ptr(1),
+		// The cache volume can be mounted by a single node at a time.
+		Strategy: appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		},
+		Selector: &metav1.LabelSelector{
+			MatchLabels: managedServerLabels(),
+		},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: managedServerLabels(),
+				// Secrets referenced by environment variables are not reloaded,
+				// therefore the Pod is replaced whenever the Secret changes.
+				Annotations: map[string]string{
+					AnnotationServerSecretChecksum: kube.ComputeHash(secret.Data),
+				},
+			},
+			Spec: corev1.PodSpec{
+				Affinity:                     starboard.LinuxNodeAffinity(),
+				ServiceAccountName:           serviceAccountName,
+				AutomountServiceAccountToken: pointer.BoolPtr(false),
+				Containers: []corev1.Container{
+					{
+						Name:            "trivy-server",
+						Image:           trivyImageRef,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Env: []corev1.EnvVar{
+							configMapEnv("HTTP_PROXY", keyTrivyHTTPProxy),
+							configMapEnv("HTTPS_PROXY", keyTrivyHTTPSProxy),
+							configMapEnv("NO_PROXY", keyTrivyNoProxy),
+							configMapEnv("TRIVY_TOKEN_HEADER", keyTrivyServerTokenHeader),
+							secretEnv("TRIVY_TOKEN", keyTrivyServerToken),
+							secretEnv("GITHUB_TOKEN", keyTrivyGitHubToken),
+						},
+						Command: []string{
+							"trivy",
+						},
+						Args: []string{
+							"--cache-dir",
+							managedServerCacheDir,
+							"server",
+							"--listen",
+							fmt.Sprintf("0.0.0.0:%d", managedServerPort),
+							"--db-repository",
+							dbRepository,
+						},
+						Ports: []corev1.ContainerPort{
+							{
+								Name:          "trivy-http",
+								ContainerPort: managedServerPort,
+								Protocol:      corev1.ProtocolTCP,
+							},
+						},
+						ReadinessProbe: probe,
+						LivenessProbe:  probe,
+						SecurityContext: &corev1.SecurityContext{
+							Privileged:               pointer.BoolPtr(false),
+							AllowPrivilegeEscalation: pointer.BoolPtr(false),
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{"all"},
+							},
+							ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{
+								Name:      tmpVolumeName,
+								MountPath: "/tmp",
+							},
+							{
+								Name:      cacheVolumeName,
+								MountPath: managedServerCacheDir,
+							},
+						},
+					},
+				},
+				Volumes: []corev1.Volume{
+					{
+						Name: tmpVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							EmptyDir: &corev1.EmptyDirVolumeSource{
+								Medium: corev1.StorageMediumDefault,
+							},
+						},
+					},
+					{
+						Name: cacheVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: ManagedServerName,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	deploymentLabels := managedServerLabels()
+	deploymentLabels[starboard.LabelResourceSpecHash] = kube.ComputeHash(spec)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagedServerName,
+			Namespace: namespace,
+			Labels:    deploymentLabels,
+		},
+		Spec: spec,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package trivy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfig_IsServerManaged(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     map[string]string
+		expected bool
+	}{
+		{
+			name:     "Should return false by default",
+			data:     map[string]string{"trivy.mode": "ClientServer"},
+			expected: false,
+		},
+		{
+			name:     "Should return true in ClientServer mode",
+			data:     map[string]string{"trivy.mode": "ClientServer", "trivy.serverManaged": "true"},
+			expected: true,
+		},
+		{
+			name:     "Should return false in Standalone mode",
+			data:     map[string]string{"trivy.mode": "Standalone", "trivy.serverManaged": "true"},
+			expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: tc.data}}
+			assert.Equal(t, tc.expected, config.IsServerManaged())
+		})
+	}
+}
+
+func TestConfig_GetServerCacheSize(t *testing.T) {
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: map[string]string{}}}
+	size, err := config.GetServerCacheSize()
+	require.NoError(t, err)
+	assert.Equal(t, resource.MustParse("5Gi"), size)
+
+	config.Data["trivy.serverCacheSize"] = "10Gi"
+	size, err = config.GetServerCacheSize()
+	require.NoError(t, err)
+	assert.Equal(t, resource.MustParse("10Gi"), size)
+
+	config.Data["trivy.serverCacheSize"] = "ten"
+	_, err = config.GetServerCacheSize()
+	assert.Error(t, err)
+}
+
+func TestEnsureServerToken(t *testing.T) {
+	secret := &corev1.Secret{}
+	modified, err := trivy.EnsureServerToken(secret)
+	require.NoError(t, err)
+	assert.True(t, modified)
+	token := secret.Data["trivy.serverToken"]
+	assert.Len(t, token, 64)
+
+	modified, err = trivy.EnsureServerToken(secret)
+	require.NoError(t, err)
+	assert.False(t, modified)
+	assert.Equal(t, token, secret.Data["trivy.serverToken"])
+}
+
+func TestNewManagedServerDeployment(t *testing.T) {
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: map[string]string{
+		"trivy.imageRef":     "docker.io/aquasec/trivy:0.25.2",
+		"trivy.mode":         "ClientServer",
+		"trivy.dbRepository": "mirror.example.com/trivy-db",
+	}}}
+
+	secret := &corev1.Secret{Data: map[string][]byte{
+		"trivy.serverToken": []byte("s3cr3t"),
+	}}
+
+	deployment, err := trivy.NewManagedServerDeployment(config, secret, "starboard-ns", "starboard-sa")
+	require.NoError(t, err)
+	assert.Equal(t, "starboard-trivy-server", deployment.Name)
+	assert.Equal(t, "starboard-ns", deployment.Namespace)
+	assert.Equal(t, starboard.AppStarboard, deployment.Labels[starboard.LabelK8SAppManagedBy])
+	assert.NotEmpty(t, deployment.Labels[starboard.LabelResourceSpecHash])
+	assert.Equal(t, "starboard-sa", deployment.Spec.Template.Spec.ServiceAccountName)
+	require.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "docker.io/aquasec/trivy:0.25.2", container.Image)
+	assert.Equal(t, []string{
+		"--cache-dir", "/var/lib/trivy",
+		"server",
+		"--listen", "0.0.0.0:4954",
+		"--db-repository", "mirror.example.com/trivy-db",
+	}, container.Args)
+
+	// Changing the configuration must change the hash of the spec.
+	config.Data["trivy.imageRef"] = "docker.io/aquasec/trivy:0.26.0"
+	updated, err := trivy.NewManagedServerDeployment(config, secret, "starboard-ns", "starboard-sa")
+	require.NoError(t, err)
+	assert.NotEqual(t, deployment.Labels[starboard.LabelResourceSpecHash], updated.Labels[starboard.LabelResourceSpecHash])
+
+	// Changing the token must change the checksum of the Secret, and thus
+	// roll out the server.
+	secret.Data["trivy.serverToken"] = []byte("n3w-s3cr3t")
+	rotated, err := trivy.NewManagedServerDeployment(config, secret, "starboard-ns", "starboard-sa")
+	require.NoError(t, err)
+	assert.NotEqual(t, updated.Spec.Template.Annotations[trivy.AnnotationServerSecretChecksum], rotated.Spec.Template.Annotations[trivy.AnnotationServerSecretChecksum])
+	assert.NotEqual(t, updated.Labels[starboard.LabelResourceSpecHash], rotated.Labels[starboard.LabelResourceSpecHash])
+}
+
+func TestPlugin_GetScanJobSpec_ManagedServer(t *testing.T) {
+	fakeclient := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-trivy-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"trivy.imageRef":      "docker.io/aquasec/trivy:0.25.2",
+				"trivy.mode":          string(trivy.ClientServer),
+				"trivy.serverManaged": "true",
+				"trivy.serverURL":     "http://trivy.trivy:4954",
+				"trivy.dbRepository":  defaultDBRepository,
+			},
+		},
+	).Build()
+	pluginContext := starboard.NewPluginContext().
+		WithName(trivy.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fakeclient).
+		Get()
+	objectResolver := kube.NewObjectResolver(fakeclient, &kube.CompatibleObjectMapper{})
+	instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+	jobSpec, _, err := instance.GetScanJobSpec(context.TODO(), pluginContext, &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "prod-ns",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "nginx",
+					Image: "nginx:1.16",
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, jobSpec.Containers, 1)
+	assert.Contains(t, jobSpec.Containers[0].Args, "http://starboard-trivy-server.starboard-ns:4954")
+	assert.NotContains(t, jobSpec.Containers[0].Args, "http://trivy.trivy:4954")
+}
@@ -198,7 +198,7 @@ func (r *namespaceReporter) topNVulnerabilitiesByScore(reports []v1alpha1.Vulner
 				tempVuln.AffectedWorkloads++
 				vulnerabilityMap[vulnId] = tempVuln
 			} else {
-				if vulnerability.Score == nil {
+				if vulnerability.Score == nil && !vulnerability.KnownExploited && vulnerability.EPSSScore == nil {
 					continue
 				}
 
@@ -208,6 +208,9 @@ func (r *namespaceReporter) topNVulnerabilitiesByScore(reports []v1alpha1.Vulner
 						PrimaryLink:     vulnerability.PrimaryLink,
 						Severity:        vulnerability.Severity,
 						Score:           vulnerability.Score,
+						KnownExploited:  vulnerability.KnownExploited,
+						EPSSScore:       vulnerability.EPSSScore,
+						EPSSPercentile:  vulnerability.EPSSPercentile,
 					},
 					AffectedWorkloads: 1,
 				}
@@ -222,13 +225,29 @@ func (r *namespaceReporter) topNVulnerabilitiesByScore(reports []v1alpha1.Vulner
 		i++
 	}
 
+	// Known exploited vulnerabilities are the most urgent to fix, followed by
+	// vulnerabilities that are likely to be exploited according to EPSS.
 	sort.SliceStable(vulnerabilities, func(i, j int) bool {
-		return *vulnerabilities[i].Score > *vulnerabilities[j].Score
+		a, b := vulnerabilities[i], vulnerabilities[j]
+		if a.KnownExploited != b.KnownExploited {
+			return a.KnownExploited
+		}
+		if valueOrNegative(a.EPSSScore) != valueOrNegative(b.EPSSScore) {
+			return valueOrNegative(a.EPSSScore) > valueOrNegative(b.EPSSScore)
+		}
+		return valueOrNegative(a.Score) > valueOrNegative(b.Score)
 	})
 
 	return vulnerabilities[:ext.MinInt(N, len(vulnerabilities))]
 }
 
+func valueOrNegative(value *float64) float64 {
+	if value == nil {
+		return -1
+	}
+	return *value
+}
+
 func (r *namespaceReporter) Generate(namespace kube.ObjectRef, out io.Writer) error {
 	data, err := r.RetrieveData(namespace)
 	if err != nil {
@@ -206,6 +206,77 @@ func Test_topNVulnerabilitiesByScore(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Should order known exploited vulnerabilities and vulnerabilities with EPSS score first",
+			reports: []v1alpha1.VulnerabilityReport{
+				{
+					Report: v1alpha1.VulnerabilityReportData{
+						Vulnerabilities: []v1alpha1.Vulnerability{
+							{
+								VulnerabilityID: "CVE-2019-1548",
+								Severity:        v1alpha1.SeverityCritical,
+								Score:           pointer.Float64Ptr(9.1),
+							},
+							{
+								VulnerabilityID: "CVE-2020-27350",
+								Severity:        v1alpha1.SeverityMedium,
+								Score:           pointer.Float64Ptr(5.7),
+								EPSSScore:       pointer.Float64Ptr(0.0012),
+							},
+							{
+								VulnerabilityID: "CVE-2021-44228",
+								Severity:        v1alpha1.SeverityCritical,
+								KnownExploited:  true,
+								EPSSScore:       pointer.Float64Ptr(0.97565),
+							},
+							{
+								VulnerabilityID: "CVE-2011-3374",
+								Severity:        v1alpha1.SeverityLow,
+								Score:           pointer.Float64Ptr(3.7),
+								KnownExploited:  true,
+							},
+						},
+					},
+				},
+			},
+			expectedOutput: []templates.VulnerabilityWithCount{
+				{
+					Vulnerability: v1alpha1.Vulnerability{
+						VulnerabilityID: "CVE-2021-44228",
+						Severity:        v1alpha1.SeverityCritical,
+						KnownExploited:  true,
+						EPSSScore:       pointer.Float64Ptr(0.97565),
+					},
+					AffectedWorkloads: 1,
+				},
+				{
+					Vulnerability: v1alpha1.Vulnerability{
+						VulnerabilityID: "CVE-2011-3374",
+						Severity:        v1alpha1.SeverityLow,
+						Score:           pointer.Float64Ptr(3.7),
+						KnownExploited:  true,
+					},
+					AffectedWorkloads: 1,
+				},
+				{
+					Vulnerability: v1alpha1.Vulnerability{
+						VulnerabilityID: "CVE-2020-27350",
+						Severity:        v1alpha1.SeverityMedium,
+						Score:           pointer.Float64Ptr(5.7),
+						EPSSScore:       pointer.Float64Ptr(0.0012),
+					},
+					AffectedWorkloads: 1,
+				},
+				{
+					Vulnerability: v1alpha1.Vulnerability{
+						VulnerabilityID: "CVE-2019-1548",
+						Severity:        v1alpha1.SeverityCritical,
+						Score:           pointer.Float64Ptr(9.1),
+					},
+					AffectedWorkloads: 1,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -150,52 +150,76 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
   </div>
 
   <div class="row">
-    <h3>Top 5 vulnerabilities by score</h3>
+    <h3>Top 5 vulnerabilities by exploitability and score</h3>
     <table class="table table-sm table-bordered">
       <thead>
         <tr>
           <th scope="col">ID</th>
           <th scope="col">Severity</th>
+          <th scope="col">Known Exploited</th>
+          <th scope="col">EPSS</th>
           <th scope="col">Score</th>
           <th scope="col">Affected Workloads</th>
         </tr>
       </thead>
       <tbody>
       `)
-//line pkg/report/templates/namespace_report.qtpl:63
+//line pkg/report/templates/namespace_report.qtpl:65
 	for _, vulnerability := range p.Top5Vulnerability {
-//line pkg/report/templates/namespace_report.qtpl:63
+//line pkg/report/templates/namespace_report.qtpl:65
 		qw422016.N().S(`
       <tr>
         <td><a href="`)
-//line pkg/report/templates/namespace_report.qtpl:65
+//line pkg/report/templates/namespace_report.qtpl:67
 		qw422016.E().S(vulnerability.PrimaryLink)
-//line pkg/report/templates/namespace_report.qtpl:65
+//line pkg/report/templates/namespace_report.qtpl:67
 		qw422016.N().S(`">`)
-//line pkg/report/templates/namespace_report.qtpl:65
+//line pkg/report/templates/namespace_report.qtpl:67
 		qw422016.E().S(vulnerability.VulnerabilityID)
-//line pkg/report/templates/namespace_report.qtpl:65
+//line pkg/report/templates/namespace_report.qtpl:67
 		qw422016.N().S(`</a></td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:66
+//line pkg/report/templates/namespace_report.qtpl:68
 		qw422016.E().S(string(vulnerability.Severity))
-//line pkg/report/templates/namespace_report.qtpl:66
+//line pkg/report/templates/namespace_report.qtpl:68
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:67
-		qw422016.N().F(*vulnerability.Score)
-//line pkg/report/templates/namespace_report.qtpl:67
+//line pkg/report/templates/namespace_report.qtpl:69
+		if vulnerability.KnownExploited {
+//line pkg/report/templates/namespace_report.qtpl:69
+			qw422016.N().S(`Yes`)
+//line pkg/report/templates/namespace_report.qtpl:69
+		}
+//line pkg/report/templates/namespace_report.qtpl:69
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:68
+//line pkg/report/templates/namespace_report.qtpl:70
+		if vulnerability.EPSSScore != nil {
+//line pkg/report/templates/namespace_report.qtpl:70
+			qw422016.N().FPrec(*vulnerability.EPSSScore, 5)
+//line pkg/report/templates/namespace_report.qtpl:70
+		}
+//line pkg/report/templates/namespace_report.qtpl:70
+		qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/namespace_report.qtpl:71
+		if vulnerability.Score != nil {
+//line pkg/report/templates/namespace_report.qtpl:71
+			qw422016.N().F(*vulnerability.Score)
+//line pkg/report/templates/namespace_report.qtpl:71
+		}
+//line pkg/report/templates/namespace_report.qtpl:71
+		qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/namespace_report.qtpl:72
 		qw422016.N().D(vulnerability.AffectedWorkloads)
-//line pkg/report/templates/namespace_report.qtpl:68
+//line pkg/report/templates/namespace_report.qtpl:72
 		qw422016.N().S(`</td>
       </tr>
       `)
-//line pkg/report/templates/namespace_report.qtpl:70
+//line pkg/report/templates/namespace_report.qtpl:74
 	}
-//line pkg/report/templates/namespace_report.qtpl:70
+//line pkg/report/templates/namespace_report.qtpl:74
 	qw422016.N().S(`
       </tbody>
     </table>
@@ -214,36 +238,36 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
       </thead>
       <tbody>
       `)
-//line pkg/report/templates/namespace_report.qtpl:87
+//line pkg/report/templates/namespace_report.qtpl:91
 	for _, report := range p.Top5FailedChecks {
-//line pkg/report/templates/namespace_report.qtpl:87
+//line pkg/report/templates/namespace_report.qtpl:91
 		qw422016.N().S(`
       <tr>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:89
+//line pkg/report/templates/namespace_report.qtpl:93
 		qw422016.E().S(report.ID)
-//line pkg/report/templates/namespace_report.qtpl:89
+//line pkg/report/templates/namespace_report.qtpl:93
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:90
+//line pkg/report/templates/namespace_report.qtpl:94
 		qw422016.E().V(report.Severity)
-//line pkg/report/templates/namespace_report.qtpl:90
+//line pkg/report/templates/namespace_report.qtpl:94
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:91
+//line pkg/report/templates/namespace_report.qtpl:95
 		qw422016.E().S(report.Category)
-//line pkg/report/templates/namespace_report.qtpl:91
+//line pkg/report/templates/namespace_report.qtpl:95
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:92
+//line pkg/report/templates/namespace_report.qtpl:96
 		qw422016.N().D(report.AffectedWorkloads)
-//line pkg/report/templates/namespace_report.qtpl:92
+//line pkg/report/templates/namespace_report.qtpl:96
 		qw422016.N().S(`</td>
       </tr>
       `)
-//line pkg/report/templates/namespace_report.qtpl:94
+//line pkg/report/templates/namespace_report.qtpl:98
 	}
-//line pkg/report/templates/namespace_report.qtpl:94
+//line pkg/report/templates/namespace_report.qtpl:98
 	qw422016.N().S(`
       </tbody>
     </table>
@@ -251,160 +275,160 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
 
 </div>
 `)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 }
 
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 func (p *NamespaceReport) WriteBody(qq422016 qtio422016.Writer) {
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 	p.StreamBody(qw422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 }
 
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 func (p *NamespaceReport) Body() string {
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 	p.WriteBody(qb422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 	return qs422016
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:104
 }
 
-//line pkg/report/templates/namespace_report.qtpl:102
+//line pkg/report/templates/namespace_report.qtpl:106
 func streamimageReference(qw422016 *qt422016.Writer, registry v1alpha1.Registry, artifact v1alpha1.Artifact) {
-//line pkg/report/templates/namespace_report.qtpl:102
+//line pkg/report/templates/namespace_report.qtpl:106
 	qw422016.N().S(`
   `)
-//line pkg/report/templates/namespace_report.qtpl:103
+//line pkg/report/templates/namespace_report.qtpl:107
 	if artifact.Tag != "" && artifact.Digest != "" {
-//line pkg/report/templates/namespace_report.qtpl:103
+//line pkg/report/templates/namespace_report.qtpl:107
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:108
 		qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:108
 		qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:108
 		qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:108
 		qw422016.N().S(`:`)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:108
 		qw422016.E().S(artifact.Tag)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:108
 		qw422016.N().S(`@`)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:108
 		qw422016.E().S(artifact.Digest)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:108
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:105
+//line pkg/report/templates/namespace_report.qtpl:109
 		return
-//line pkg/report/templates/namespace_report.qtpl:106
+//line pkg/report/templates/namespace_report.qtpl:110
 	}
-//line pkg/report/templates/namespace_report.qtpl:106
+//line pkg/report/templates/namespace_report.qtpl:110
 	qw422016.N().S(`
 
   `)
-//line pkg/report/templates/namespace_report.qtpl:108
+//line pkg/report/templates/namespace_report.qtpl:112
 	if artifact.Tag == "" && artifact.Digest != "" {
-//line pkg/report/templates/namespace_report.qtpl:108
+//line pkg/report/templates/namespace_report.qtpl:112
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:113
 		qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:113
 		qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:113
 		qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:113
 		qw422016.N().S(`@`)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:113
 		qw422016.E().S(artifact.Digest)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:113
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:110
+//line pkg/report/templates/namespace_report.qtpl:114
 		return
-//line pkg/report/templates/namespace_report.qtpl:111
+//line pkg/report/templates/namespace_report.qtpl:115
 	}
-//line pkg/report/templates/namespace_report.qtpl:111
+//line pkg/report/templates/namespace_report.qtpl:115
 	qw422016.N().S(`
 
   `)
-//line pkg/report/templates/namespace_report.qtpl:113
+//line pkg/report/templates/namespace_report.qtpl:117
 	if artifact.Tag != "" && artifact.Digest == "" {
-//line pkg/report/templates/namespace_report.qtpl:113
+//line pkg/report/templates/namespace_report.qtpl:117
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:118
 		qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:118
 		qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:118
 		qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:118
 		qw422016.N().S(`:`)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:118
 		qw422016.E().S(artifact.Tag)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:118
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:115
+//line pkg/report/templates/namespace_report.qtpl:119
 		return
-//line pkg/report/templates/namespace_report.qtpl:116
+//line pkg/report/templates/namespace_report.qtpl:120
 	}
-//line pkg/report/templates/namespace_report.qtpl:116
+//line pkg/report/templates/namespace_report.qtpl:120
 	qw422016.N().S(`
 
   `)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:122
 	qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:122
 	qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:122
 	qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:122
 	qw422016.N().S(`:`)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:122
 	qw422016.E().S(artifact.Tag)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:122
 	qw422016.N().S(`
 `)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 }
 
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 func writeimageReference(qq422016 qtio422016.Writer, registry v1alpha1.Registry, artifact v1alpha1.Artifact) {
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 	streamimageReference(qw422016, registry, artifact)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 }
 
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 func imageReference(registry v1alpha1.Registry, artifact v1alpha1.Artifact) string {
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 	writeimageReference(qb422016, registry, artifact)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 	return qs422016
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:123
 }
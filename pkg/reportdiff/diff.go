@@ -0,0 +1,194 @@
+package reportdiff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// Finding is a vulnerability, a failed configuration check, or a failed or
+// warned CIS benchmark test.
+type Finding struct {
+	// ReportKind is the kind of the security report, e.g. VulnerabilityReport.
+	ReportKind string `json:"reportKind"`
+	Container  string `json:"container,omitempty"`
+
+	// ID is a vulnerability ID, a check ID, or a test number.
+	ID               string            `json:"id"`
+	Title            string            `json:"title,omitempty"`
+	Severity         v1alpha1.Severity `json:"severity,omitempty"`
+	Status           string            `json:"status,omitempty"`
+	Package          string            `json:"package,omitempty"`
+	InstalledVersion string            `json:"installedVersion,omitempty"`
+	FixedVersion     string            `json:"fixedVersion,omitempty"`
+}
+
+// key identifies the same finding in different report sets.
+func (f Finding) key() string {
+	return strings.Join([]string{f.ReportKind, f.Container, f.ID, f.Package}, "|")
+}
+
+// Change is a finding found in both report sets whose severity, status, or
+// versions differ.
+type Change struct {
+	From Finding `json:"from"`
+	To   Finding `json:"to"`
+}
+
+// Result is the difference between two sets of security reports.
+type Result struct {
+	// Added is the list of findings that are only in the second set.
+	Added []Finding `json:"added"`
+
+	// Removed is the list of findings that are only in the first set.
+	Removed []Finding `json:"removed"`
+
+	// Changed is the list of findings that are in both sets but differ.
+	Changed []Change `json:"changed"`
+}
+
+// IsEmpty returns true if the compared sets of reports have the same
+// findings.
+func (r Result) IsEmpty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// Compare returns Findings that were added, removed, or changed in the second
+// set of findings compared to the first one. Findings are sorted by report
+// kind, container, and ID.
+func Compare(from, to []Finding) Result {
+	fromByKey := byKey(from)
+	toByKey := byKey(to)
+
+	result := Result{
+		Added:   []Finding{},
+		Removed: []Finding{},
+		Changed: []Change{},
+	}
+	for key, finding := range toByKey {
+		previous, ok := fromByKey[key]
+		if !ok {
+			result.Added = append(result.Added, finding)
+			continue
+		}
+		if previous != finding {
+			result.Changed = append(result.Changed, Change{From: previous, To: finding})
+		}
+	}
+	for key, finding := range fromByKey {
+		if _, ok := toByKey[key]; !ok {
+			result.Removed = append(result.Removed, finding)
+		}
+	}
+
+	sortFindings(result.Added)
+	sortFindings(result.Removed)
+	sort.Slice(result.Changed, func(i, j int) bool {
+		return less(result.Changed[i].To, result.Changed[j].To)
+	})
+	return result
+}
+
+// byKey indexes findings by key. If the same finding is reported more than
+// once, e.g. for the same package installed in different paths, the first one
+// is used.
+func byKey(findings []Finding) map[string]Finding {
+	index := make(map[string]Finding)
+	for _, finding := range findings {
+		if _, ok := index[finding.key()]; !ok {
+			index[finding.key()] = finding
+		}
+	}
+	return index
+}
+
+func sortFindings(findings []Finding) {
+	sort.Slice(findings, func(i, j int) bool {
+		return less(findings[i], findings[j])
+	})
+}
+
+func less(a, b Finding) bool {
+	if a.ReportKind != b.ReportKind {
+		return a.ReportKind < b.ReportKind
+	}
+	if a.Container != b.Container {
+		return a.Container < b.Container
+	}
+	if a.ID != b.ID {
+		return a.ID < b.ID
+	}
+	return a.Package < b.Package
+}
+
+// FromVulnerabilityReports returns Findings of the specified vulnerability
+// reports. Vulnerabilities excluded by exceptions or VEX statements are
+// omitted. The reports are expected to be generated by the same scanner, see
+// vulnerabilityreport.FilterPrimaryScannerReports.
+func FromVulnerabilityReports(reports []v1alpha1.VulnerabilityReport) []Finding {
+	var findings []Finding
+	for _, report := range reports {
+		for _, v := range report.Report.Vulnerabilities {
+			if v.IsExcluded() {
+				continue
+			}
+			findings = append(findings, Finding{
+				ReportKind:       v1alpha1.VulnerabilityReportKind,
+				Container:        report.Labels[starboard.LabelContainerName],
+				ID:               v.VulnerabilityID,
+				Title:            v.Title,
+				Severity:         v.Severity,
+				Package:          v.Resource,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+			})
+		}
+	}
+	return findings
+}
+
+// FromConfigAuditReport returns Findings of failed checks of the specified
+// config audit report.
+func FromConfigAuditReport(report v1alpha1.ConfigAuditReport) []Finding {
+	var findings []Finding
+	for _, check := range report.Report.Checks {
+		if check.Success {
+			continue
+		}
+		finding := Finding{
+			ReportKind: v1alpha1.ConfigAuditReportKind,
+			ID:         check.ID,
+			Title:      check.Title,
+			Severity:   check.Severity,
+		}
+		if check.Scope != nil && check.Scope.Type == "Container" {
+			finding.Container = check.Scope.Value
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// FromCISKubeBenchReport returns Findings of tests of the specified CIS
+// Kubernetes Benchmark report that failed or ended with a warning.
+func FromCISKubeBenchReport(report v1alpha1.CISKubeBenchReport) []Finding {
+	var findings []Finding
+	for _, section := range report.Report.Sections {
+		for _, tests := range section.Tests {
+			for _, result := range tests.Results {
+				if result.Status != "FAIL" && result.Status != "WARN" {
+					continue
+				}
+				findings = append(findings, Finding{
+					ReportKind: v1alpha1.CISKubeBenchReportKind,
+					ID:         result.TestNumber,
+					Title:      result.TestDesc,
+					Status:     result.Status,
+				})
+			}
+		}
+	}
+	return findings
+}
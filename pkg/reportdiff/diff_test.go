@@ -0,0 +1,153 @@
+package reportdiff_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/reportdiff"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCompare(t *testing.T) {
+	openSSL := reportdiff.Finding{
+		ReportKind:       v1alpha1.VulnerabilityReportKind,
+		Container:        "nginx",
+		ID:               "CVE-2022-0778",
+		Severity:         v1alpha1.SeverityHigh,
+		Package:          "openssl",
+		InstalledVersion: "1.1.1k",
+		FixedVersion:     "1.1.1n",
+	}
+	apt := reportdiff.Finding{
+		ReportKind:       v1alpha1.VulnerabilityReportKind,
+		Container:        "nginx",
+		ID:               "CVE-2020-3810",
+		Severity:         v1alpha1.SeverityMedium,
+		Package:          "apt",
+		InstalledVersion: "1.8.2",
+		FixedVersion:     "1.8.2.1",
+	}
+	privileged := reportdiff.Finding{
+		ReportKind: v1alpha1.ConfigAuditReportKind,
+		Container:  "nginx",
+		ID:         "KSV017",
+		Severity:   v1alpha1.SeverityHigh,
+	}
+	upgradedOpenSSL := openSSL
+	upgradedOpenSSL.InstalledVersion = "1.1.1m"
+
+	t.Run("Should return added, removed, and changed findings", func(t *testing.T) {
+		result := reportdiff.Compare(
+			[]reportdiff.Finding{openSSL, apt},
+			[]reportdiff.Finding{privileged, upgradedOpenSSL},
+		)
+		assert.Equal(t, reportdiff.Result{
+			Added:   []reportdiff.Finding{privileged},
+			Removed: []reportdiff.Finding{apt},
+			Changed: []reportdiff.Change{{From: openSSL, To: upgradedOpenSSL}},
+		}, result)
+		assert.False(t, result.IsEmpty())
+	})
+
+	t.Run("Should return empty result for the same findings", func(t *testing.T) {
+		result := reportdiff.Compare(
+			[]reportdiff.Finding{openSSL, apt},
+			[]reportdiff.Finding{apt, openSSL, openSSL},
+		)
+		assert.True(t, result.IsEmpty())
+	})
+
+	t.Run("Should distinguish findings of different containers", func(t *testing.T) {
+		sidecar := openSSL
+		sidecar.Container = "sidecar"
+		result := reportdiff.Compare(
+			[]reportdiff.Finding{openSSL},
+			[]reportdiff.Finding{openSSL, sidecar},
+		)
+		assert.Equal(t, []reportdiff.Finding{sidecar}, result.Added)
+		assert.Empty(t, result.Removed)
+		assert.Empty(t, result.Changed)
+	})
+}
+
+func TestFromVulnerabilityReports(t *testing.T) {
+	findings := reportdiff.FromVulnerabilityReports([]v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{starboard.LabelContainerName: "nginx"},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2022-0778", Resource: "openssl", InstalledVersion: "1.1.1k", Severity: v1alpha1.SeverityHigh},
+					{VulnerabilityID: "CVE-2020-3810", Resource: "apt", Severity: v1alpha1.SeverityMedium, Suppressed: true},
+				},
+			},
+		},
+	})
+	assert.Equal(t, []reportdiff.Finding{
+		{
+			ReportKind:       v1alpha1.VulnerabilityReportKind,
+			Container:        "nginx",
+			ID:               "CVE-2022-0778",
+			Severity:         v1alpha1.SeverityHigh,
+			Package:          "openssl",
+			InstalledVersion: "1.1.1k",
+		},
+	}, findings)
+}
+
+func TestFromConfigAuditReport(t *testing.T) {
+	findings := reportdiff.FromConfigAuditReport(v1alpha1.ConfigAuditReport{
+		Report: v1alpha1.ConfigAuditReportData{
+			Checks: []v1alpha1.Check{
+				{ID: "KSV017", Title: "Privileged", Severity: v1alpha1.SeverityHigh, Scope: &v1alpha1.CheckScope{Type: "Container", Value: "nginx"}},
+				{ID: "KSV001", Severity: v1alpha1.SeverityMedium, Success: true},
+			},
+		},
+	})
+	assert.Equal(t, []reportdiff.Finding{
+		{
+			ReportKind: v1alpha1.ConfigAuditReportKind,
+			Container:  "nginx",
+			ID:         "KSV017",
+			Title:      "Privileged",
+			Severity:   v1alpha1.SeverityHigh,
+		},
+	}, findings)
+}
+
+func TestFromCISKubeBenchReport(t *testing.T) {
+	findings := reportdiff.FromCISKubeBenchReport(v1alpha1.CISKubeBenchReport{
+		Report: v1alpha1.CISKubeBenchReportData{
+			Sections: []v1alpha1.CISKubeBenchSection{
+				{
+					Tests: []v1alpha1.CISKubeBenchTests{
+						{
+							Results: []v1alpha1.CISKubeBenchResult{
+								{TestNumber: "1.1.1", TestDesc: "Ensure that the API server pod specification file permissions are set", Status: "PASS"},
+								{TestNumber: "1.1.12", TestDesc: "Ensure that the etcd data directory ownership is set", Status: "FAIL"},
+								{TestNumber: "1.2.6", TestDesc: "Ensure that the --kubelet-certificate-authority argument is set", Status: "WARN"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, []reportdiff.Finding{
+		{
+			ReportKind: v1alpha1.CISKubeBenchReportKind,
+			ID:         "1.1.12",
+			Title:      "Ensure that the etcd data directory ownership is set",
+			Status:     "FAIL",
+		},
+		{
+			ReportKind: v1alpha1.CISKubeBenchReportKind,
+			ID:         "1.2.6",
+			Title:      "Ensure that the --kubelet-certificate-authority argument is set",
+			Status:     "WARN",
+		},
+	}, findings)
+}
@@ -0,0 +1,3 @@
+// Package reportdiff provides primitives for comparing findings of two sets
+// of security reports.
+package reportdiff
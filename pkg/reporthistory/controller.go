@@ -0,0 +1,206 @@
+package reporthistory
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Controller watches v1alpha1.VulnerabilityReport and
+// v1alpha1.ConfigAuditReport instances and appends their summaries to the
+// v1alpha1.ReportHistory of the workload whenever they are updated. Reports
+// generated by different vulnerability scanners are recorded in separate
+// histories.
+type Controller struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Client
+	kube.ObjectResolver
+}
+
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.NewControllerManagedBy(mgr).
+		Named("reporthistory-vulnerabilityreport").
+		For(&v1alpha1.VulnerabilityReport{}, builder.WithPredicates(
+			Not(IsBeingTerminated),
+			installModePredicate)).
+		Complete(reconcile.Func(r.ReconcileVulnerabilityReport))
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("reporthistory-configauditreport").
+		For(&v1alpha1.ConfigAuditReport{}, builder.WithPredicates(
+			Not(IsBeingTerminated),
+			installModePredicate)).
+		Complete(reconcile.Func(r.ReconcileConfigAuditReport))
+}
+
+// ReconcileVulnerabilityReport appends the summary of the specified
+// vulnerability report to the history of the workload and the container.
+func (r *Controller) ReconcileVulnerabilityReport(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("report", req.NamespacedName)
+
+	var report v1alpha1.VulnerabilityReport
+	err := r.Client.Get(ctx, req.NamespacedName, &report)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Ignoring cached report that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting report from cache: %w", err)
+	}
+
+	summary := report.Report.Summary
+	entry := v1alpha1.ReportHistoryEntry{
+		UpdateTimestamp: report.Report.UpdateTimestamp,
+		CriticalCount:   summary.CriticalCount,
+		HighCount:       summary.HighCount,
+		MediumCount:     summary.MediumCount,
+		LowCount:        summary.LowCount,
+		UnknownCount:    summary.UnknownCount,
+	}
+	var scanner string
+	if !vulnerabilityreport.IsGeneratedByPrimaryScanner(report.ObjectMeta, r.ConfigData) {
+		scanner = report.Labels[starboard.LabelVulnerabilityReportScanner]
+	}
+	return ctrl.Result{}, r.appendEntry(ctx, log, v1alpha1.VulnerabilityReportKind, report.ObjectMeta, scanner,
+		entry, VulnerabilityFindings(report.Report))
+}
+
+// ReconcileConfigAuditReport appends the summary of the specified config
+// audit report to the history of the workload.
+func (r *Controller) ReconcileConfigAuditReport(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("report", req.NamespacedName)
+
+	var report v1alpha1.ConfigAuditReport
+	err := r.Client.Get(ctx, req.NamespacedName, &report)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Ignoring cached report that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting report from cache: %w", err)
+	}
+
+	summary := report.Report.Summary
+	entry := v1alpha1.ReportHistoryEntry{
+		UpdateTimestamp: report.Report.UpdateTimestamp,
+		CriticalCount:   summary.CriticalCount,
+		HighCount:       summary.HighCount,
+		MediumCount:     summary.MediumCount,
+		LowCount:        summary.LowCount,
+	}
+	return ctrl.Result{}, r.appendEntry(ctx, log, v1alpha1.ConfigAuditReportKind, report.ObjectMeta, "",
+		entry, ConfigAuditFindings(report.Report))
+}
+
+func (r *Controller) appendEntry(ctx context.Context, log logr.Logger, reportKind string, reportMeta metav1.ObjectMeta, scanner string,
+	entry v1alpha1.ReportHistoryEntry, findings []Finding) error {
+	ref, err := kube.ObjectRefFromObjectMeta(reportMeta)
+	if err != nil {
+		log.V(1).Info("Ignoring report without owner labels", "error", err.Error())
+		return nil
+	}
+
+	workload, err := ResolveWorkload(ctx, r.ObjectResolver, ref)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Ignoring report of workload that must have been deleted")
+			return nil
+		}
+		if errors.Is(err, ErrNotCurrentRevision) {
+			log.V(1).Info("Ignoring report of replicaset that is not the current revision")
+			return nil
+		}
+		return fmt.Errorf("resolving workload: %w", err)
+	}
+	workloadRef := kube.ObjectRefFromKindAndObjectKey(kube.Kind(workload.GetObjectKind().GroupVersionKind().Kind),
+		client.ObjectKeyFromObject(workload))
+	container := reportMeta.Labels[starboard.LabelContainerName]
+	key := types.NamespacedName{
+		Namespace: reportMeta.Namespace,
+		Name:      GetReportHistoryName(reportKind, workloadRef, container, scanner),
+	}
+	log = log.WithValues("history", key.Name)
+
+	limit := DefaultLimit
+	if r.Config.ReportHistoryLimit > 0 {
+		limit = r.Config.ReportHistoryLimit
+	}
+
+	var history v1alpha1.ReportHistory
+	err = r.Client.Get(ctx, key, &history)
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		return fmt.Errorf("getting report history: %w", err)
+	}
+
+	if k8sapierror.IsNotFound(err) {
+		labels := kube.ObjectRefToLabels(workloadRef)
+		labels[starboard.LabelK8SAppManagedBy] = starboard.AppStarboard
+		if container != "" {
+			labels[starboard.LabelContainerName] = container
+		}
+		if name, ok := reportMeta.Labels[starboard.LabelVulnerabilityReportScanner]; ok {
+			labels[starboard.LabelVulnerabilityReportScanner] = name
+		}
+		history = v1alpha1.ReportHistory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels:    labels,
+			},
+			History: v1alpha1.ReportHistoryData{
+				ReportKind: reportKind,
+				Entries:    []v1alpha1.ReportHistoryEntry{},
+			},
+		}
+		Append(&history.History, entry, findings, limit)
+		err = controllerutil.SetControllerReference(workload, &history, r.Client.Scheme())
+		if err != nil {
+			return fmt.Errorf("setting controller reference: %w", err)
+		}
+		log.V(1).Info("Creating report history")
+		err = r.Client.Create(ctx, &history)
+		if err != nil && !k8sapierror.IsAlreadyExists(err) {
+			return fmt.Errorf("creating report history: %w", err)
+		}
+		return nil
+	}
+
+	copied := history.DeepCopy()
+	if !Append(&copied.History, entry, findings, limit) {
+		log.V(1).Info("Report history is up to date")
+		return nil
+	}
+	log.V(1).Info("Updating report history")
+	err = r.Client.Update(ctx, copied)
+	if err != nil {
+		return fmt.Errorf("updating report history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package reporthistory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/reporthistory"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestController_ReconcileVulnerabilityReport(t *testing.T) {
+	scanTime := time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC)
+	key := types.NamespacedName{Namespace: "default", Name: "vulnerabilityreport-deployment-nginx-nginx"}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+			UID:       "734c1370-2281-4946-9b5f-940b33f3e4b8",
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "nginx",
+					UID:        "734c1370-2281-4946-9b5f-940b33f3e4b8",
+					Controller: pointer.BoolPtr(true),
+				},
+			},
+		},
+	}
+	newReport := func(updateTimestamp time.Time, vulnerabilities ...v1alpha1.Vulnerability) *v1alpha1.VulnerabilityReport {
+		return &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-nginx",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "nginx-6d4cf56db6",
+					starboard.LabelResourceNamespace: "default",
+					starboard.LabelContainerName:     "nginx",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(updateTimestamp),
+				Summary:         v1alpha1.VulnerabilitySummary{HighCount: len(vulnerabilities)},
+				Vulnerabilities: vulnerabilities,
+			},
+		}
+	}
+	openSSL := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-0778", Severity: v1alpha1.SeverityHigh}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "replicaset-nginx-6d4cf56db6-nginx"}}
+
+	newController := func(c client.Client) *reporthistory.Controller {
+		return &reporthistory.Controller{
+			Logger:         logr.Discard(),
+			ConfigData:     starboard.ConfigData{"vulnerabilityReports.scanner": "Trivy,Grype"},
+			Client:         c,
+			ObjectResolver: kube.NewObjectResolver(c, &kube.CompatibleObjectMapper{}),
+		}
+	}
+
+	t.Run("Should create history of deployment", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(deployment, replicaSet, newReport(scanTime, openSSL)).
+			Build()
+
+		_, err := newController(testClient).ReconcileVulnerabilityReport(context.TODO(), request)
+		require.NoError(t, err)
+
+		var history v1alpha1.ReportHistory
+		require.NoError(t, testClient.Get(context.TODO(), key, &history))
+		assert.Equal(t, map[string]string{
+			starboard.LabelK8SAppManagedBy:   starboard.AppStarboard,
+			starboard.LabelResourceKind:      "Deployment",
+			starboard.LabelResourceName:      "nginx",
+			starboard.LabelResourceNamespace: "default",
+			starboard.LabelContainerName:     "nginx",
+		}, history.Labels)
+		require.Len(t, history.OwnerReferences, 1)
+		assert.Equal(t, "Deployment", history.OwnerReferences[0].Kind)
+		assert.Equal(t, v1alpha1.VulnerabilityReportKind, history.History.ReportKind)
+		require.Len(t, history.History.Entries, 1)
+		assert.Equal(t, 1, history.History.Entries[0].HighCount)
+		assert.Equal(t, 1, history.History.Entries[0].NewCount)
+		require.Len(t, history.History.Findings, 1)
+		assert.Equal(t, "CVE-2022-0778", history.History.Findings[0].ID)
+	})
+
+	t.Run("Should append entry of updated report", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(deployment, replicaSet, newReport(scanTime, openSSL)).
+			Build()
+		controller := newController(testClient)
+
+		_, err := controller.ReconcileVulnerabilityReport(context.TODO(), request)
+		require.NoError(t, err)
+		// Reconciling the same report again must not add an entry.
+		_, err = controller.ReconcileVulnerabilityReport(context.TODO(), request)
+		require.NoError(t, err)
+
+		var report v1alpha1.VulnerabilityReport
+		require.NoError(t, testClient.Get(context.TODO(), request.NamespacedName, &report))
+		report.Report = newReport(scanTime.Add(24 * time.Hour)).Report
+		require.NoError(t, testClient.Update(context.TODO(), &report))
+
+		_, err = controller.ReconcileVulnerabilityReport(context.TODO(), request)
+		require.NoError(t, err)
+
+		var history v1alpha1.ReportHistory
+		require.NoError(t, testClient.Get(context.TODO(), key, &history))
+		require.Len(t, history.History.Entries, 2)
+		assert.Equal(t, 0, history.History.Entries[1].HighCount)
+		assert.Equal(t, 1, history.History.Entries[1].ResolvedCount)
+		require.Len(t, history.History.Findings, 1)
+		require.NotNil(t, history.History.Findings[0].Resolved)
+		assert.True(t, scanTime.Add(24*time.Hour).Equal(history.History.Findings[0].Resolved.Time))
+	})
+
+	t.Run("Should create separate history of secondary scanner", func(t *testing.T) {
+		grypeReport := newReport(scanTime, openSSL)
+		grypeReport.Name = "replicaset-nginx-6d4cf56db6-nginx-grype"
+		grypeReport.Labels[starboard.LabelVulnerabilityReportScanner] = "Grype"
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(deployment, replicaSet, newReport(scanTime), grypeReport).
+			Build()
+		controller := newController(testClient)
+
+		_, err := controller.ReconcileVulnerabilityReport(context.TODO(), request)
+		require.NoError(t, err)
+		_, err = controller.ReconcileVulnerabilityReport(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(grypeReport)})
+		require.NoError(t, err)
+
+		var history v1alpha1.ReportHistory
+		require.NoError(t, testClient.Get(context.TODO(), key, &history))
+		require.Len(t, history.History.Entries, 1)
+		assert.Empty(t, history.History.Findings)
+
+		var grypeHistory v1alpha1.ReportHistory
+		require.NoError(t, testClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: "default",
+			Name:      "vulnerabilityreport-deployment-nginx-nginx-grype",
+		}, &grypeHistory))
+		assert.Equal(t, "Grype", grypeHistory.Labels[starboard.LabelVulnerabilityReportScanner])
+		require.Len(t, grypeHistory.History.Findings, 1)
+		assert.Equal(t, "CVE-2022-0778", grypeHistory.History.Findings[0].ID)
+	})
+
+	t.Run("Should ignore report of replicaset that is not the current revision", func(t *testing.T) {
+		currentDeployment := deployment.DeepCopy()
+		currentDeployment.Annotations = map[string]string{"deployment.kubernetes.io/revision": "2"}
+		oldReplicaSet := replicaSet.DeepCopy()
+		oldReplicaSet.Annotations = map[string]string{"deployment.kubernetes.io/revision": "1"}
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(currentDeployment, oldReplicaSet, newReport(scanTime, openSSL)).
+			Build()
+
+		_, err := newController(testClient).ReconcileVulnerabilityReport(context.TODO(), request)
+		require.NoError(t, err)
+
+		var list v1alpha1.ReportHistoryList
+		require.NoError(t, testClient.List(context.TODO(), &list))
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("Should ignore report of deleted workload", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newReport(scanTime, openSSL)).
+			Build()
+
+		_, err := newController(testClient).ReconcileVulnerabilityReport(context.TODO(), request)
+		require.NoError(t, err)
+
+		var list v1alpha1.ReportHistoryList
+		require.NoError(t, testClient.List(context.TODO(), &list))
+		assert.Empty(t, list.Items)
+	})
+}
@@ -0,0 +1,3 @@
+// Package reporthistory provides primitives for recording how security
+// reports of a workload change over time in a v1alpha1.ReportHistory.
+package reporthistory
@@ -0,0 +1,230 @@
+package reporthistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultLimit is the default maximum number of entries and resolved
+// findings kept in a v1alpha1.ReportHistory.
+const DefaultLimit = 50
+
+// ErrNotCurrentRevision is returned by ResolveWorkload for ReplicaSets that
+// are not the current revision of their Deployment.
+var ErrNotCurrentRevision = errors.New("replicaset is not the current revision of deployment")
+
+// Finding is a vulnerability or a failed check of a security report.
+type Finding struct {
+	ID       string
+	Severity v1alpha1.Severity
+}
+
+// VulnerabilityFindings returns Findings of the specified vulnerability
+// report. Vulnerabilities found in multiple packages are returned once.
+// Vulnerabilities excluded from the summary by exceptions or VEX statements
+// are omitted.
+func VulnerabilityFindings(data v1alpha1.VulnerabilityReportData) []Finding {
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, v := range data.Vulnerabilities {
+		if v.IsExcluded() || seen[v.VulnerabilityID] {
+			continue
+		}
+		seen[v.VulnerabilityID] = true
+		findings = append(findings, Finding{ID: v.VulnerabilityID, Severity: v.Severity})
+	}
+	return findings
+}
+
+// ConfigAuditFindings returns Findings of failed checks of the specified
+// config audit report.
+func ConfigAuditFindings(data v1alpha1.ConfigAuditReportData) []Finding {
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, check := range data.Checks {
+		if check.Success || seen[check.ID] {
+			continue
+		}
+		seen[check.ID] = true
+		findings = append(findings, Finding{ID: check.ID, Severity: check.Severity})
+	}
+	return findings
+}
+
+// Append adds the entry of a security report with the given findings to the
+// history. It sets the numbers of new and resolved findings of the entry and
+// updates first-seen, last-seen, and resolved timestamps of findings.
+//
+// Entries must be appended in the order of report updates. Append returns
+// false and leaves the history unchanged if the history already has an entry
+// of the same or a more recent report. Only the limit most recent entries and
+// resolved findings are kept, whereas open findings are always kept.
+func Append(history *v1alpha1.ReportHistoryData, entry v1alpha1.ReportHistoryEntry, findings []Finding, limit int) bool {
+	timestamp := entry.UpdateTimestamp
+	if n := len(history.Entries); n > 0 && !history.Entries[n-1].UpdateTimestamp.Before(&timestamp) {
+		return false
+	}
+
+	current := make(map[string]Finding)
+	for _, finding := range findings {
+		current[finding.ID] = finding
+	}
+
+	entry.NewCount = 0
+	entry.ResolvedCount = 0
+
+	tracked := make(map[string]bool)
+	for i := range history.Findings {
+		finding := &history.Findings[i]
+		tracked[finding.ID] = true
+		if found, ok := current[finding.ID]; ok {
+			if finding.Resolved != nil {
+				// The finding was resolved and found again.
+				finding.Resolved = nil
+				entry.NewCount++
+			}
+			finding.Severity = found.Severity
+			finding.LastSeen = timestamp
+			continue
+		}
+		if finding.Resolved == nil {
+			resolved := timestamp
+			finding.Resolved = &resolved
+			entry.ResolvedCount++
+		}
+	}
+
+	for _, finding := range findings {
+		if tracked[finding.ID] {
+			continue
+		}
+		tracked[finding.ID] = true
+		history.Findings = append(history.Findings, v1alpha1.ReportHistoryFinding{
+			ID:        finding.ID,
+			Severity:  finding.Severity,
+			FirstSeen: timestamp,
+			LastSeen:  timestamp,
+		})
+		entry.NewCount++
+	}
+
+	history.Entries = append(history.Entries, entry)
+	if len(history.Entries) > limit {
+		history.Entries = history.Entries[len(history.Entries)-limit:]
+	}
+	history.Findings = pruneResolved(history.Findings, limit)
+	return true
+}
+
+// pruneResolved drops findings resolved least recently so that at most limit
+// resolved findings are kept.
+func pruneResolved(findings []v1alpha1.ReportHistoryFinding, limit int) []v1alpha1.ReportHistoryFinding {
+	var resolved []time.Time
+	for _, finding := range findings {
+		if finding.Resolved != nil {
+			resolved = append(resolved, finding.Resolved.Time)
+		}
+	}
+	if len(resolved) <= limit {
+		return findings
+	}
+	sort.Slice(resolved, func(i, j int) bool {
+		return resolved[i].After(resolved[j])
+	})
+	// Findings resolved at the same time as the oldest kept one are kept as
+	// well, which may exceed the limit slightly.
+	oldest := resolved[limit-1]
+	var pruned []v1alpha1.ReportHistoryFinding
+	for _, finding := range findings {
+		if finding.Resolved != nil && finding.Resolved.Time.Before(oldest) {
+			continue
+		}
+		pruned = append(pruned, finding)
+	}
+	return pruned
+}
+
+// MeanTimeToRemediate returns the mean time between the first-seen and the
+// resolved timestamps of resolved findings, and the number of resolved
+// findings.
+func MeanTimeToRemediate(findings []v1alpha1.ReportHistoryFinding) (time.Duration, int) {
+	var total time.Duration
+	var count int
+	for _, finding := range findings {
+		if finding.Resolved == nil {
+			continue
+		}
+		total += finding.Resolved.Sub(finding.FirstSeen.Time)
+		count++
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return total / time.Duration(count), count
+}
+
+// GetReportHistoryName returns the name of the v1alpha1.ReportHistory of
+// security reports of the given kind generated for the specified workload
+// and container by the given scanner. The container is empty for reports that
+// are not generated per container. The scanner is empty for reports of the
+// primary vulnerability scanner and reports of other kinds.
+func GetReportHistoryName(reportKind string, workload kube.ObjectRef, container, scanner string) string {
+	prefix := fmt.Sprintf("%s-%s", strings.ToLower(reportKind), strings.ToLower(string(workload.Kind)))
+	name := workload.Name
+	if container != "" {
+		name = name + "-" + container
+	}
+	if scanner != "" {
+		name = name + "-" + strings.ToLower(scanner)
+	}
+	historyName := fmt.Sprintf("%s-%s", prefix, name)
+	if len(validation.IsValidLabelValue(historyName)) == 0 {
+		return historyName
+	}
+	return fmt.Sprintf("%s-%s", prefix, kube.ComputeHash(name))
+}
+
+// ResolveWorkload returns the workload whose history is recorded for security
+// reports of the specified resource. Reports of ReplicaSets managed by a
+// Deployment and Jobs managed by a CronJob are recorded in the history of the
+// managing workload, so that the history spans across rollouts. Reports of
+// ReplicaSets that are not the current revision of their Deployment are not
+// recorded, and ErrNotCurrentRevision is returned for such ReplicaSets.
+func ResolveWorkload(ctx context.Context, resolver kube.ObjectResolver, ref kube.ObjectRef) (client.Object, error) {
+	obj, err := resolver.ObjectFromObjectRef(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	controller := metav1.GetControllerOf(obj)
+	if controller == nil {
+		return obj, nil
+	}
+	switch {
+	case ref.Kind == kube.KindReplicaSet && controller.Kind == string(kube.KindDeployment):
+		active, err := resolver.IsActiveReplicaSet(ctx, obj, controller)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			return nil, ErrNotCurrentRevision
+		}
+		fallthrough
+	case ref.Kind == kube.KindJob && controller.Kind == string(kube.KindCronJob):
+		return resolver.ObjectFromObjectRef(ctx, kube.ObjectRef{
+			Kind:      kube.Kind(controller.Kind),
+			Name:      controller.Name,
+			Namespace: ref.Namespace,
+		})
+	}
+	return obj, nil
+}
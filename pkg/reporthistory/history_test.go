@@ -0,0 +1,176 @@
+package reporthistory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/reporthistory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAppend(t *testing.T) {
+	day1 := metav1.NewTime(time.Date(2022, time.August, 1, 10, 0, 0, 0, time.UTC))
+	day2 := metav1.NewTime(day1.Add(24 * time.Hour))
+	day3 := metav1.NewTime(day1.Add(48 * time.Hour))
+
+	log4Shell := reporthistory.Finding{ID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical}
+	openSSL := reporthistory.Finding{ID: "CVE-2022-0778", Severity: v1alpha1.SeverityHigh}
+
+	history := v1alpha1.ReportHistoryData{ReportKind: v1alpha1.VulnerabilityReportKind}
+
+	appended := reporthistory.Append(&history, v1alpha1.ReportHistoryEntry{
+		UpdateTimestamp: day1, CriticalCount: 1, HighCount: 1,
+	}, []reporthistory.Finding{log4Shell, openSSL}, reporthistory.DefaultLimit)
+	require.True(t, appended)
+
+	appended = reporthistory.Append(&history, v1alpha1.ReportHistoryEntry{
+		UpdateTimestamp: day2, HighCount: 1,
+	}, []reporthistory.Finding{openSSL}, reporthistory.DefaultLimit)
+	require.True(t, appended)
+
+	t.Run("Should ignore entry of report that is not more recent", func(t *testing.T) {
+		copied := history.DeepCopy()
+		appended := reporthistory.Append(copied, v1alpha1.ReportHistoryEntry{
+			UpdateTimestamp: day2,
+		}, nil, reporthistory.DefaultLimit)
+		assert.False(t, appended)
+		assert.Equal(t, history, *copied)
+	})
+
+	assert.Equal(t, []v1alpha1.ReportHistoryEntry{
+		{UpdateTimestamp: day1, CriticalCount: 1, HighCount: 1, NewCount: 2},
+		{UpdateTimestamp: day2, HighCount: 1, ResolvedCount: 1},
+	}, history.Entries)
+	assert.Equal(t, []v1alpha1.ReportHistoryFinding{
+		{ID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical, FirstSeen: day1, LastSeen: day1, Resolved: &day2},
+		{ID: "CVE-2022-0778", Severity: v1alpha1.SeverityHigh, FirstSeen: day1, LastSeen: day2},
+	}, history.Findings)
+
+	t.Run("Should reopen finding that is found again", func(t *testing.T) {
+		copied := history.DeepCopy()
+		appended := reporthistory.Append(copied, v1alpha1.ReportHistoryEntry{
+			UpdateTimestamp: day3, CriticalCount: 1, HighCount: 1,
+		}, []reporthistory.Finding{log4Shell, openSSL}, reporthistory.DefaultLimit)
+		require.True(t, appended)
+		assert.Equal(t, 1, copied.Entries[2].NewCount)
+		assert.Equal(t, 0, copied.Entries[2].ResolvedCount)
+		assert.Nil(t, copied.Findings[0].Resolved)
+		assert.Equal(t, day1, copied.Findings[0].FirstSeen)
+		assert.Equal(t, day3, copied.Findings[0].LastSeen)
+	})
+
+	t.Run("Should keep limited number of entries and resolved findings", func(t *testing.T) {
+		copied := history.DeepCopy()
+		appended := reporthistory.Append(copied, v1alpha1.ReportHistoryEntry{
+			UpdateTimestamp: day3,
+		}, nil, 1)
+		require.True(t, appended)
+		assert.Equal(t, []v1alpha1.ReportHistoryEntry{
+			{UpdateTimestamp: day3, ResolvedCount: 1},
+		}, copied.Entries)
+		assert.Equal(t, []v1alpha1.ReportHistoryFinding{
+			{ID: "CVE-2022-0778", Severity: v1alpha1.SeverityHigh, FirstSeen: day1, LastSeen: day2, Resolved: &day3},
+		}, copied.Findings)
+	})
+}
+
+func TestVulnerabilityFindings(t *testing.T) {
+	findings := reporthistory.VulnerabilityFindings(v1alpha1.VulnerabilityReportData{
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2022-0778", Resource: "libssl1.1", Severity: v1alpha1.SeverityHigh},
+			{VulnerabilityID: "CVE-2022-0778", Resource: "openssl", Severity: v1alpha1.SeverityHigh},
+			{VulnerabilityID: "CVE-2020-3810", Resource: "apt", Severity: v1alpha1.SeverityMedium, Suppressed: true},
+			{VulnerabilityID: "CVE-2019-18276", Resource: "bash", Severity: v1alpha1.SeverityLow, VEXStatus: "not_affected"},
+		},
+	})
+	assert.Equal(t, []reporthistory.Finding{
+		{ID: "CVE-2022-0778", Severity: v1alpha1.SeverityHigh},
+	}, findings)
+}
+
+func TestConfigAuditFindings(t *testing.T) {
+	findings := reporthistory.ConfigAuditFindings(v1alpha1.ConfigAuditReportData{
+		Checks: []v1alpha1.Check{
+			{ID: "KSV001", Severity: v1alpha1.SeverityMedium, Success: false},
+			{ID: "KSV003", Severity: v1alpha1.SeverityLow, Success: true},
+		},
+	})
+	assert.Equal(t, []reporthistory.Finding{
+		{ID: "KSV001", Severity: v1alpha1.SeverityMedium},
+	}, findings)
+}
+
+func TestMeanTimeToRemediate(t *testing.T) {
+	firstSeen := metav1.NewTime(time.Date(2022, time.August, 1, 10, 0, 0, 0, time.UTC))
+	resolvedAfterDay := metav1.NewTime(firstSeen.Add(24 * time.Hour))
+	resolvedAfterThreeDays := metav1.NewTime(firstSeen.Add(72 * time.Hour))
+
+	mttr, count := reporthistory.MeanTimeToRemediate([]v1alpha1.ReportHistoryFinding{
+		{ID: "CVE-2021-44228", FirstSeen: firstSeen, Resolved: &resolvedAfterDay},
+		{ID: "CVE-2022-0778", FirstSeen: firstSeen, Resolved: &resolvedAfterThreeDays},
+		{ID: "CVE-2020-3810", FirstSeen: firstSeen},
+	})
+	assert.Equal(t, 48*time.Hour, mttr)
+	assert.Equal(t, 2, count)
+
+	mttr, count = reporthistory.MeanTimeToRemediate(nil)
+	assert.Equal(t, time.Duration(0), mttr)
+	assert.Equal(t, 0, count)
+}
+
+func TestGetReportHistoryName(t *testing.T) {
+	testCases := []struct {
+		name       string
+		reportKind string
+		workload   kube.ObjectRef
+		container  string
+		scanner    string
+		expected   string
+	}{
+		{
+			name:       "Should return name of vulnerability report history",
+			reportKind: v1alpha1.VulnerabilityReportKind,
+			workload:   kube.ObjectRef{Kind: kube.KindDeployment, Name: "nginx", Namespace: "default"},
+			container:  "nginx",
+			expected:   "vulnerabilityreport-deployment-nginx-nginx",
+		},
+		{
+			name:       "Should return name of vulnerability report history of secondary scanner",
+			reportKind: v1alpha1.VulnerabilityReportKind,
+			workload:   kube.ObjectRef{Kind: kube.KindDeployment, Name: "nginx", Namespace: "default"},
+			container:  "nginx",
+			scanner:    "Grype",
+			expected:   "vulnerabilityreport-deployment-nginx-nginx-grype",
+		},
+		{
+			name:       "Should return name of config audit report history",
+			reportKind: v1alpha1.ConfigAuditReportKind,
+			workload:   kube.ObjectRef{Kind: kube.KindDeployment, Name: "nginx", Namespace: "default"},
+			expected:   "configauditreport-deployment-nginx",
+		},
+		{
+			name:       "Should return name with hash of long workload name",
+			reportKind: v1alpha1.VulnerabilityReportKind,
+			workload:   kube.ObjectRef{Kind: kube.KindDeployment, Name: "a-very-long-name-of-deployment-exceeding-label-value-limit", Namespace: "default"},
+			container:  "nginx",
+			expected:   "vulnerabilityreport-deployment-" + kube.ComputeHash("a-very-long-name-of-deployment-exceeding-label-value-limit-nginx"),
+		},
+		{
+			name:       "Should return name with hash of long workload name and scanner",
+			reportKind: v1alpha1.VulnerabilityReportKind,
+			workload:   kube.ObjectRef{Kind: kube.KindDeployment, Name: "a-very-long-name-of-deployment-exceeding-label-value-limit", Namespace: "default"},
+			container:  "nginx",
+			scanner:    "Grype",
+			expected:   "vulnerabilityreport-deployment-" + kube.ComputeHash("a-very-long-name-of-deployment-exceeding-label-value-limit-nginx-grype"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, reporthistory.GetReportHistoryName(tc.reportKind, tc.workload, tc.container, tc.scanner))
+		})
+	}
+}
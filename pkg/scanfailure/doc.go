@@ -0,0 +1,3 @@
+// Package scanfailure provides primitives for recording failed scan jobs as
+// v1alpha1.ScanFailure instances and retrying them with exponential backoff.
+package scanfailure
@@ -0,0 +1,292 @@
+package scanfailure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// EventReasonScanFailed is the reason of the Warning event posted on a
+	// Kubernetes resource whose scan job has failed.
+	EventReasonScanFailed = "ScanFailed"
+
+	defaultReason = "Error"
+)
+
+// Failure describes a failed scan job.
+type Failure struct {
+	// ReportKind is the kind of the security report that the scan job was
+	// supposed to generate.
+	ReportKind string
+	// Scanner is the name of the scanner that ran the scan job.
+	Scanner string
+	// ResourceSpecHash is the hash of the scanned resource's spec. A change of
+	// the spec resets the backoff.
+	ResourceSpecHash string
+	Reason           string
+	Message          string
+}
+
+// NewFailure constructs a Failure of the given scan job. Reason and message
+// are taken from the first container terminated with non-zero exit code, or
+// from the Failed condition of the job if there is no such container, e.g.
+// when the job exceeded its active deadline.
+func NewFailure(reportKind, scanner, resourceSpecHash string, job *batchv1.Job, statuses map[string]*corev1.ContainerStateTerminated) Failure {
+	failure := Failure{
+		ReportKind:       reportKind,
+		Scanner:          scanner,
+		ResourceSpecHash: resourceSpecHash,
+		Reason:           defaultReason,
+	}
+
+	containers := make([]string, 0, len(statuses))
+	for container := range statuses {
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+
+	for _, container := range containers {
+		status := statuses[container]
+		if status == nil || status.ExitCode == 0 {
+			continue
+		}
+		if status.Reason != "" {
+			failure.Reason = status.Reason
+		}
+		failure.Message = fmt.Sprintf("container %s terminated with exit code %d", container, status.ExitCode)
+		if message := strings.TrimSpace(status.Message); message != "" {
+			failure.Message = fmt.Sprintf("%s: %s", failure.Message, message)
+		}
+		return failure
+	}
+
+	if job == nil {
+		return failure
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type != batchv1.JobFailed || condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Reason != "" {
+			failure.Reason = condition.Reason
+		}
+		failure.Message = condition.Message
+		break
+	}
+	return failure
+}
+
+// Recorder is the interface that wraps methods for recording failed scans of
+// Kubernetes resources.
+//
+// RecordFailure creates or updates the v1alpha1.ScanFailure of the given
+// owner, report kind, and scanner, posts a Warning event on the owner, and
+// returns the duration after which the scan should be retried.
+//
+// RetryAfter returns the remaining backoff duration of the given owner, report
+// kind, and scanner, or zero if the scan can be retried now. A recorded
+// failure of a different resource spec hash does not delay the scan.
+//
+// ClearFailure deletes the v1alpha1.ScanFailure of the given owner, report
+// kind, and scanner, if any.
+type Recorder interface {
+	RecordFailure(ctx context.Context, owner client.Object, failure Failure) (time.Duration, error)
+	RetryAfter(ctx context.Context, owner client.Object, reportKind, scanner, resourceSpecHash string) (time.Duration, error)
+	ClearFailure(ctx context.Context, owner client.Object, reportKind, scanner string) error
+}
+
+type recorder struct {
+	client.Client
+	ext.Clock
+	record.EventRecorder
+	namespace  string
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// NewRecorder constructs a new Recorder. Failures of cluster-scoped resources
+// are recorded in the specified namespace. The backoff duration is doubled
+// with each consecutive failure up to the specified maximum.
+func NewRecorder(c client.Client, clock ext.Clock, eventRecorder record.EventRecorder, namespace string, backoff, maxBackoff time.Duration) Recorder {
+	return &recorder{
+		Client:        c,
+		Clock:         clock,
+		EventRecorder: eventRecorder,
+		namespace:     namespace,
+		backoff:       backoff,
+		maxBackoff:    maxBackoff,
+	}
+}
+
+// Backoff returns the duration to wait before retrying a scan that has failed
+// the given number of times in a row.
+func Backoff(initial, max time.Duration, attempts int) time.Duration {
+	if initial <= 0 || attempts <= 0 {
+		return 0
+	}
+	backoff := initial
+	for i := 1; i < attempts; i++ {
+		if backoff >= max/2 {
+			return max
+		}
+		backoff *= 2
+	}
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
+
+// GetScanFailureName returns the name of the v1alpha1.ScanFailure which
+// records failed scans of the given resource for the given report kind and
+// scanner. Scanners that generate reports of the same kind side by side back
+// off independently of each other.
+func GetScanFailureName(reportKind, scanner string, ref kube.ObjectRef) string {
+	prefix := strings.ToLower(reportKind)
+	if scanner != "" {
+		prefix = fmt.Sprintf("%s-%s", prefix, strings.ToLower(scanner))
+	}
+	name := fmt.Sprintf("%s-%s-%s", prefix, strings.ToLower(string(ref.Kind)), ref.Name)
+	if len(validation.IsValidLabelValue(name)) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%s-%s", prefix, strings.ToLower(string(ref.Kind)), kube.ComputeHash(ref.Name))
+}
+
+func (r *recorder) RecordFailure(ctx context.Context, owner client.Object, failure Failure) (time.Duration, error) {
+	key, ref, err := r.objectKey(owner, failure.ReportKind, failure.Scanner)
+	if err != nil {
+		return 0, err
+	}
+
+	attempts := 1
+	scanFailure := &v1alpha1.ScanFailure{}
+	err = r.Client.Get(ctx, key, scanFailure)
+	switch {
+	case errors.IsNotFound(err):
+		scanFailure = &v1alpha1.ScanFailure{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+	case err != nil:
+		return 0, fmt.Errorf("getting scan failure: %w", err)
+	case scanFailure.Labels[starboard.LabelResourceSpecHash] == failure.ResourceSpecHash:
+		attempts = scanFailure.Status.Attempts + 1
+	}
+
+	now := r.Clock.Now()
+	backoff := Backoff(r.backoff, r.maxBackoff, attempts)
+
+	labels := kube.ObjectRefToLabels(ref)
+	labels[starboard.LabelResourceSpecHash] = failure.ResourceSpecHash
+	labels[starboard.LabelK8SAppManagedBy] = starboard.AppStarboard
+	scanFailure.Labels = labels
+	scanFailure.Status = v1alpha1.ScanFailureStatus{
+		ReportKind:      failure.ReportKind,
+		Scanner:         failure.Scanner,
+		Reason:          failure.Reason,
+		Message:         failure.Message,
+		Attempts:        attempts,
+		LastFailureTime: metav1.NewTime(now),
+		NextRetryTime:   metav1.NewTime(now.Add(backoff)),
+	}
+	err = controllerutil.SetControllerReference(owner, scanFailure, r.Client.Scheme())
+	if err != nil {
+		return 0, fmt.Errorf("setting controller reference: %w", err)
+	}
+
+	if scanFailure.ResourceVersion == "" {
+		err = r.Client.Create(ctx, scanFailure)
+	} else {
+		err = r.Client.Update(ctx, scanFailure)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("writing scan failure: %w", err)
+	}
+
+	message := fmt.Sprintf("%s scan failed (attempt %d): %s", failure.ReportKind, attempts, failure.Reason)
+	if failure.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, failure.Message)
+	}
+	r.EventRecorder.Eventf(owner, corev1.EventTypeWarning, EventReasonScanFailed, "%s; retrying after %s", message, backoff)
+
+	return backoff, nil
+}
+
+func (r *recorder) RetryAfter(ctx context.Context, owner client.Object, reportKind, scanner, resourceSpecHash string) (time.Duration, error) {
+	key, _, err := r.objectKey(owner, reportKind, scanner)
+	if err != nil {
+		return 0, err
+	}
+	scanFailure := &v1alpha1.ScanFailure{}
+	err = r.Client.Get(ctx, key, scanFailure)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("getting scan failure: %w", err)
+	}
+	if scanFailure.Labels[starboard.LabelResourceSpecHash] != resourceSpecHash {
+		return 0, nil
+	}
+	if retryAfter := scanFailure.Status.NextRetryTime.Sub(r.Clock.Now()); retryAfter > 0 {
+		return retryAfter, nil
+	}
+	return 0, nil
+}
+
+func (r *recorder) ClearFailure(ctx context.Context, owner client.Object, reportKind, scanner string) error {
+	key, _, err := r.objectKey(owner, reportKind, scanner)
+	if err != nil {
+		return err
+	}
+	err = r.Client.Delete(ctx, &v1alpha1.ScanFailure{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("deleting scan failure: %w", err)
+	}
+	return nil
+}
+
+func (r *recorder) objectKey(owner client.Object, reportKind, scanner string) (types.NamespacedName, kube.ObjectRef, error) {
+	kind, err := kube.KindForObject(owner, r.Client.Scheme())
+	if err != nil {
+		return types.NamespacedName{}, kube.ObjectRef{}, fmt.Errorf("getting kind of %s: %w", owner.GetName(), err)
+	}
+	ref := kube.ObjectRef{
+		Kind:      kube.Kind(kind),
+		Name:      owner.GetName(),
+		Namespace: owner.GetNamespace(),
+	}
+	namespace := owner.GetNamespace()
+	if namespace == "" {
+		namespace = r.namespace
+	}
+	return types.NamespacedName{
+		Name:      GetScanFailureName(reportKind, scanner, ref),
+		Namespace: namespace,
+	}, ref, nil
+}
@@ -0,0 +1,232 @@
+package scanfailure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/scanfailure"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBackoff(t *testing.T) {
+	testCases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: 0},
+		{attempts: 1, expected: time.Minute},
+		{attempts: 2, expected: 2 * time.Minute},
+		{attempts: 3, expected: 4 * time.Minute},
+		{attempts: 6, expected: 32 * time.Minute},
+		{attempts: 7, expected: time.Hour},
+		{attempts: 100, expected: time.Hour},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, scanfailure.Backoff(time.Minute, time.Hour, tc.attempts), "attempts: %d", tc.attempts)
+	}
+}
+
+func TestNewFailure(t *testing.T) {
+	t.Run("Should use status of container terminated with error", func(t *testing.T) {
+		failure := scanfailure.NewFailure(v1alpha1.VulnerabilityReportKind, "Trivy", "h1", &batchv1.Job{},
+			map[string]*corev1.ContainerStateTerminated{
+				"nginx": {ExitCode: 0},
+				"redis": {ExitCode: 1, Reason: "Error", Message: "unable to pull image\n"},
+			})
+		assert.Equal(t, scanfailure.Failure{
+			ReportKind:       v1alpha1.VulnerabilityReportKind,
+			Scanner:          "Trivy",
+			ResourceSpecHash: "h1",
+			Reason:           "Error",
+			Message:          "container redis terminated with exit code 1: unable to pull image",
+		}, failure)
+	})
+
+	t.Run("Should fall back to failed job condition", func(t *testing.T) {
+		failure := scanfailure.NewFailure(v1alpha1.CISKubeBenchReportKind, "kube-bench", "", &batchv1.Job{
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{
+						Type:    batchv1.JobFailed,
+						Status:  corev1.ConditionTrue,
+						Reason:  "DeadlineExceeded",
+						Message: "Job was active longer than specified deadline",
+					},
+				},
+			},
+		}, nil)
+		assert.Equal(t, "DeadlineExceeded", failure.Reason)
+		assert.Equal(t, "Job was active longer than specified deadline", failure.Message)
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	now := time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC)
+
+	owner := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "default",
+			UID:       "7a3dc4a5-9ac0-4e32-9dd5-d9b9ddf0d1c4",
+		},
+	}
+
+	failure := scanfailure.Failure{
+		ReportKind:       v1alpha1.VulnerabilityReportKind,
+		Scanner:          "Trivy",
+		ResourceSpecHash: "h1",
+		Reason:           "Error",
+		Message:          "unable to pull image",
+	}
+
+	key := types.NamespacedName{
+		Namespace: "default",
+		Name:      "vulnerabilityreport-trivy-replicaset-nginx-6d4cf56db6",
+	}
+
+	t.Run("Should record consecutive failures with exponential backoff", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(owner).Build()
+		eventRecorder := record.NewFakeRecorder(10)
+		recorder := scanfailure.NewRecorder(testClient, ext.NewFixedClock(now), eventRecorder, "starboard-system", time.Minute, time.Hour)
+
+		backoff, err := recorder.RecordFailure(context.TODO(), owner, failure)
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, backoff)
+
+		backoff, err = recorder.RecordFailure(context.TODO(), owner, failure)
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, backoff)
+
+		var found v1alpha1.ScanFailure
+		err = testClient.Get(context.TODO(), key, &found)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			starboard.LabelResourceKind:      "ReplicaSet",
+			starboard.LabelResourceName:      "nginx-6d4cf56db6",
+			starboard.LabelResourceNamespace: "default",
+			starboard.LabelResourceSpecHash:  "h1",
+			starboard.LabelK8SAppManagedBy:   starboard.AppStarboard,
+		}, found.Labels)
+		require.Len(t, found.OwnerReferences, 1)
+		assert.Equal(t, owner.UID, found.OwnerReferences[0].UID)
+		assert.Equal(t, 2, found.Status.Attempts)
+		assert.Equal(t, "Error", found.Status.Reason)
+		assert.Equal(t, now.Add(2*time.Minute), found.Status.NextRetryTime.Time.UTC())
+
+		require.Len(t, eventRecorder.Events, 2)
+		<-eventRecorder.Events
+		assert.Equal(t, "Warning ScanFailed VulnerabilityReport scan failed (attempt 2): Error: unable to pull image; retrying after 2m0s", <-eventRecorder.Events)
+
+		retryAfter, err := recorder.RetryAfter(context.TODO(), owner, v1alpha1.VulnerabilityReportKind, "Trivy", "h1")
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, retryAfter)
+	})
+
+	t.Run("Should reset attempts when resource spec changes", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(owner).Build()
+		recorder := scanfailure.NewRecorder(testClient, ext.NewFixedClock(now), record.NewFakeRecorder(10), "starboard-system", time.Minute, time.Hour)
+
+		_, err := recorder.RecordFailure(context.TODO(), owner, failure)
+		require.NoError(t, err)
+
+		retryAfter, err := recorder.RetryAfter(context.TODO(), owner, v1alpha1.VulnerabilityReportKind, "Trivy", "h2")
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), retryAfter)
+
+		changed := failure
+		changed.ResourceSpecHash = "h2"
+		backoff, err := recorder.RecordFailure(context.TODO(), owner, changed)
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, backoff)
+	})
+
+	t.Run("Should not delay scan after backoff has elapsed", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(owner).Build()
+		_, err := scanfailure.NewRecorder(testClient, ext.NewFixedClock(now), record.NewFakeRecorder(10), "starboard-system", time.Minute, time.Hour).
+			RecordFailure(context.TODO(), owner, failure)
+		require.NoError(t, err)
+
+		recorder := scanfailure.NewRecorder(testClient, ext.NewFixedClock(now.Add(time.Minute)), record.NewFakeRecorder(10), "starboard-system", time.Minute, time.Hour)
+		retryAfter, err := recorder.RetryAfter(context.TODO(), owner, v1alpha1.VulnerabilityReportKind, "Trivy", "h1")
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), retryAfter)
+	})
+
+	t.Run("Should back off each scanner independently", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(owner).Build()
+		recorder := scanfailure.NewRecorder(testClient, ext.NewFixedClock(now), record.NewFakeRecorder(10), "starboard-system", time.Minute, time.Hour)
+
+		_, err := recorder.RecordFailure(context.TODO(), owner, failure)
+		require.NoError(t, err)
+
+		retryAfter, err := recorder.RetryAfter(context.TODO(), owner, v1alpha1.VulnerabilityReportKind, "Grype", "h1")
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), retryAfter)
+
+		err = recorder.ClearFailure(context.TODO(), owner, v1alpha1.VulnerabilityReportKind, "Grype")
+		require.NoError(t, err)
+
+		retryAfter, err = recorder.RetryAfter(context.TODO(), owner, v1alpha1.VulnerabilityReportKind, "Trivy", "h1")
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, retryAfter)
+	})
+
+	t.Run("Should clear recorded failure", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(owner).Build()
+		recorder := scanfailure.NewRecorder(testClient, ext.NewFixedClock(now), record.NewFakeRecorder(10), "starboard-system", time.Minute, time.Hour)
+
+		_, err := recorder.RecordFailure(context.TODO(), owner, failure)
+		require.NoError(t, err)
+
+		err = recorder.ClearFailure(context.TODO(), owner, v1alpha1.VulnerabilityReportKind, "Trivy")
+		require.NoError(t, err)
+		err = recorder.ClearFailure(context.TODO(), owner, v1alpha1.VulnerabilityReportKind, "Trivy")
+		require.NoError(t, err)
+
+		var list v1alpha1.ScanFailureList
+		require.NoError(t, testClient.List(context.TODO(), &list))
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("Should record failure of cluster-scoped resource in operator namespace", func(t *testing.T) {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kind-control-plane",
+			},
+		}
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(node).Build()
+		recorder := scanfailure.NewRecorder(testClient, ext.NewFixedClock(now), record.NewFakeRecorder(10), "starboard-system", time.Minute, time.Hour)
+
+		_, err := recorder.RecordFailure(context.TODO(), node, scanfailure.Failure{
+			ReportKind: v1alpha1.CISKubeBenchReportKind,
+			Scanner:    "kube-bench",
+			Reason:     "DeadlineExceeded",
+		})
+		require.NoError(t, err)
+
+		var found v1alpha1.ScanFailure
+		err = testClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: "starboard-system",
+			Name:      scanfailure.GetScanFailureName(v1alpha1.CISKubeBenchReportKind, "kube-bench", kube.ObjectRef{Kind: kube.KindNode, Name: "kind-control-plane"}),
+		}, &found)
+		require.NoError(t, err)
+		assert.Equal(t, "ciskubebenchreport-kube-bench-node-kind-control-plane", found.Name)
+	})
+}
@@ -0,0 +1,64 @@
+package scanjobtemplate
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Apply merges the specified ScanJobTemplates, in the given order, into the
+// pod template of a scan job as described by v1alpha1.ScanJobPodTemplate.
+func Apply(podTemplate *corev1.PodTemplateSpec, templates ...v1alpha1.ScanJobTemplate) {
+	for _, template := range templates {
+		apply(podTemplate, template.Spec.Template)
+	}
+}
+
+func apply(podTemplate *corev1.PodTemplateSpec, template v1alpha1.ScanJobPodTemplate) {
+	podTemplate.Labels = mergeMaps(podTemplate.Labels, template.Labels)
+	podTemplate.Annotations = mergeMaps(podTemplate.Annotations, template.Annotations)
+
+	spec := &podTemplate.Spec
+	spec.NodeSelector = mergeMaps(spec.NodeSelector, template.NodeSelector)
+	if template.Affinity != nil {
+		spec.Affinity = template.Affinity.DeepCopy()
+	}
+	spec.Tolerations = append(spec.Tolerations, template.Tolerations...)
+	if template.PriorityClassName != "" {
+		spec.PriorityClassName = template.PriorityClassName
+	}
+	if template.RuntimeClassName != nil {
+		runtimeClassName := *template.RuntimeClassName
+		spec.RuntimeClassName = &runtimeClassName
+	}
+	if template.SecurityContext != nil {
+		spec.SecurityContext = template.SecurityContext.DeepCopy()
+	}
+	for _, volume := range template.Volumes {
+		spec.Volumes = append(spec.Volumes, *volume.DeepCopy())
+	}
+
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			if template.Resources != nil {
+				containers[i].Resources = *template.Resources.DeepCopy()
+			}
+			containers[i].VolumeMounts = append(containers[i].VolumeMounts, template.VolumeMounts...)
+		}
+	}
+}
+
+// mergeMaps returns the union of the specified maps. Values of the target map
+// take precedence.
+func mergeMaps(target, source map[string]string) map[string]string {
+	if len(source) == 0 {
+		return target
+	}
+	merged := make(map[string]string, len(target)+len(source))
+	for key, value := range source {
+		merged[key] = value
+	}
+	for key, value := range target {
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,3 @@
+// Package scanjobtemplate provides primitives for customizing pods created by
+// scan jobs with ScanJobTemplate resources.
+package scanjobtemplate
@@ -0,0 +1,98 @@
+package scanjobtemplate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reader is the interface that wraps the FindTemplates method.
+//
+// FindTemplates returns ScanJobTemplates that apply to scan jobs of the given
+// scanner for workloads in the given namespace, sorted by name. The namespace
+// is empty for cluster-scoped resources, such as nodes. An empty slice is
+// returned if the ScanJobTemplate CRD is not installed.
+type Reader interface {
+	FindTemplates(ctx context.Context, scanner, namespace string) ([]v1alpha1.ScanJobTemplate, error)
+}
+
+type reader struct {
+	client.Client
+}
+
+// NewReader constructs a new Reader which is using the client package
+// provided by the controller-runtime libraries for interacting with the
+// Kubernetes API server.
+func NewReader(c client.Client) Reader {
+	return &reader{
+		Client: c,
+	}
+}
+
+func (r *reader) FindTemplates(ctx context.Context, scanner, namespace string) ([]v1alpha1.ScanJobTemplate, error) {
+	var list v1alpha1.ScanJobTemplateList
+	err := r.List(ctx, &list)
+	if meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing scan job templates: %w", err)
+	}
+
+	var namespaceLabels labels.Set
+	var templates []v1alpha1.ScanJobTemplate
+	for _, template := range list.Items {
+		if !matchesScanner(template.Spec, scanner) {
+			continue
+		}
+		if template.Spec.NamespaceSelector != nil {
+			if namespace == "" {
+				continue
+			}
+			if namespaceLabels == nil {
+				ns := &corev1.Namespace{}
+				err = r.Get(ctx, client.ObjectKey{Name: namespace}, ns)
+				if err != nil {
+					return nil, fmt.Errorf("getting namespace: %w", err)
+				}
+				namespaceLabels = ns.Labels
+				if namespaceLabels == nil {
+					namespaceLabels = labels.Set{}
+				}
+			}
+			selector, err := metav1.LabelSelectorAsSelector(template.Spec.NamespaceSelector)
+			if err != nil {
+				return nil, fmt.Errorf("parsing namespace selector of scan job template %s: %w", template.Name, err)
+			}
+			if !selector.Matches(namespaceLabels) {
+				continue
+			}
+		}
+		templates = append(templates, template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+func matchesScanner(spec v1alpha1.ScanJobTemplateSpec, scanner string) bool {
+	if len(spec.Scanners) == 0 {
+		return true
+	}
+	for _, name := range spec.Scanners {
+		if strings.EqualFold(name, scanner) {
+			return true
+		}
+	}
+	return false
+}
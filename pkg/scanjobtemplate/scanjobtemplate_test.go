@@ -0,0 +1,159 @@
+package scanjobtemplate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTemplate(name string, spec v1alpha1.ScanJobTemplateSpec) *v1alpha1.ScanJobTemplate {
+	return &v1alpha1.ScanJobTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: spec,
+	}
+}
+
+func TestReader_FindTemplates(t *testing.T) {
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "prod-ns",
+				Labels: map[string]string{"env": "prod"},
+			},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "dev-ns",
+			},
+		},
+		newTemplate("z-all", v1alpha1.ScanJobTemplateSpec{}),
+		newTemplate("a-trivy", v1alpha1.ScanJobTemplateSpec{
+			Scanners: []string{"Trivy"},
+		}),
+		newTemplate("polaris", v1alpha1.ScanJobTemplateSpec{
+			Scanners: []string{"Polaris"},
+		}),
+		newTemplate("prod", v1alpha1.ScanJobTemplateSpec{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+		}),
+	).Build()
+
+	testCases := []struct {
+		name      string
+		scanner   string
+		namespace string
+		expected  []string
+	}{
+		{
+			name:      "Should return templates of scanner sorted by name",
+			scanner:   "trivy",
+			namespace: "dev-ns",
+			expected:  []string{"a-trivy", "z-all"},
+		},
+		{
+			name:      "Should return templates selecting namespace",
+			scanner:   "Polaris",
+			namespace: "prod-ns",
+			expected:  []string{"polaris", "prod", "z-all"},
+		},
+		{
+			name:      "Should skip templates with namespace selector for cluster-scoped resources",
+			scanner:   "kube-bench",
+			namespace: "",
+			expected:  []string{"z-all"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			templates, err := scanjobtemplate.NewReader(testClient).FindTemplates(context.TODO(), tc.scanner, tc.namespace)
+			require.NoError(t, err)
+			var names []string
+			for _, template := range templates {
+				names = append(names, template.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	podTemplate := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"starboard.resource.kind": "Pod"},
+		},
+		Spec: corev1.PodSpec{
+			Tolerations: []corev1.Toleration{
+				{Key: "key1", Operator: corev1.TolerationOpExists},
+			},
+			PriorityClassName: "default",
+			InitContainers: []corev1.Container{
+				{Name: "init"},
+			},
+			Containers: []corev1.Container{
+				{Name: "scan"},
+			},
+		},
+	}
+	resources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("500m"),
+		},
+	}
+	mount := corev1.VolumeMount{Name: "certs", MountPath: "/etc/ssl/certs"}
+
+	scanjobtemplate.Apply(&podTemplate,
+		*newTemplate("a", v1alpha1.ScanJobTemplateSpec{
+			Template: v1alpha1.ScanJobPodTemplate{
+				Labels: map[string]string{
+					"starboard.resource.kind": "Deployment",
+					"team":                    "security",
+				},
+				PriorityClassName: "low-priority",
+				Tolerations: []corev1.Toleration{
+					{Key: "key2", Operator: corev1.TolerationOpExists},
+				},
+			},
+		}),
+		*newTemplate("b", v1alpha1.ScanJobTemplateSpec{
+			Template: v1alpha1.ScanJobPodTemplate{
+				PriorityClassName: "high-priority",
+				RuntimeClassName:  pointer.String("gvisor"),
+				Resources:         &resources,
+				Volumes: []corev1.Volume{
+					{Name: "certs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				},
+				VolumeMounts: []corev1.VolumeMount{mount},
+			},
+		}),
+	)
+
+	assert.Equal(t, map[string]string{
+		"starboard.resource.kind": "Pod",
+		"team":                    "security",
+	}, podTemplate.Labels)
+	assert.Equal(t, "high-priority", podTemplate.Spec.PriorityClassName)
+	assert.Equal(t, pointer.String("gvisor"), podTemplate.Spec.RuntimeClassName)
+	assert.Equal(t, []corev1.Toleration{
+		{Key: "key1", Operator: corev1.TolerationOpExists},
+		{Key: "key2", Operator: corev1.TolerationOpExists},
+	}, podTemplate.Spec.Tolerations)
+	require.Len(t, podTemplate.Spec.Volumes, 1)
+	for _, container := range append(podTemplate.Spec.InitContainers, podTemplate.Spec.Containers...) {
+		assert.Equal(t, resources, container.Resources)
+		assert.Equal(t, []corev1.VolumeMount{mount}, container.VolumeMounts)
+	}
+}
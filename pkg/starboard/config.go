@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	embedded "github.com/aquasecurity/starboard"
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -68,6 +69,28 @@ const (
 	keyScanJobAnnotations                = "scanJob.annotations"
 	keyScanJobPodTemplateLabels          = "scanJob.podTemplateLabels"
 	keyComplianceFailEntriesLimit        = "compliance.failEntriesLimit"
+	keyNotificationWebhookURL            = "notification.webhook.url"
+	keyNotificationWebhookCloudEvents    = "notification.webhook.cloudEvents"
+	keyNotificationWebhookTimeout        = "notification.webhook.timeout"
+	keyNotificationSeverity              = "notification.severity"
+	keyAdmissionMode                     = "admission.mode"
+	keyAdmissionVulnerabilitiesSeverity  = "admission.vulnerabilities.severity"
+	keyAdmissionVulnerabilitiesFixable   = "admission.vulnerabilities.fixableOnly"
+	keyAdmissionConfigAuditSeverity      = "admission.configAuditChecks.severity"
+	keyExploitabilityKEVFile             = "exploitability.kevFile"
+	keyExploitabilityEPSSFile            = "exploitability.epssFile"
+)
+
+// AdmissionMode describes how the admission webhook handles workloads that
+// violate the admission policy.
+type AdmissionMode string
+
+const (
+	// AdmissionModeWarn admits violating workloads and returns warnings to
+	// the API client. Use it to evaluate the policy before enforcing it.
+	AdmissionModeWarn AdmissionMode = "Warn"
+	// AdmissionModeDeny rejects violating workloads.
+	AdmissionModeDeny AdmissionMode = "Deny"
 )
 
 // ConfigData holds Starboard configuration settings as a set of key-value
@@ -94,13 +117,51 @@ func GetDefaultConfig() ConfigData {
 	}
 }
 
+// GetVulnerabilityReportsScanner returns the primary vulnerability scanner,
+// i.e. the first of the configured scanners.
 func (c ConfigData) GetVulnerabilityReportsScanner() (Scanner, error) {
+	scanners, err := c.GetVulnerabilityReportsScanners()
+	if err != nil {
+		return "", err
+	}
+	return scanners[0], nil
+}
+
+// GetVulnerabilityReportsScanners returns the comma separated list of
+// vulnerability scanners that run side by side. Duplicates are ignored.
+func (c ConfigData) GetVulnerabilityReportsScanners() ([]Scanner, error) {
 	var ok bool
 	var value string
 	if value, ok = c[keyVulnerabilityReportsScanner]; !ok {
-		return "", fmt.Errorf("property %s not set", keyVulnerabilityReportsScanner)
+		return nil, fmt.Errorf("property %s not set", keyVulnerabilityReportsScanner)
 	}
-	return Scanner(value), nil
+	var scanners []Scanner
+	seen := make(map[Scanner]bool)
+	for _, name := range strings.Split(value, ",") {
+		scanner := Scanner(strings.TrimSpace(name))
+		if scanner == "" || seen[scanner] {
+			continue
+		}
+		seen[scanner] = true
+		scanners = append(scanners, scanner)
+	}
+	if len(scanners) == 0 {
+		return nil, fmt.Errorf("property %s not set", keyVulnerabilityReportsScanner)
+	}
+	return scanners, nil
+}
+
+// IsPrimaryVulnerabilityReportsScanner checks if the given scanner is the
+// primary vulnerability scanner. Scan jobs and reports of the primary scanner
+// are named as if it was the only scanner, whereas names of the others are
+// suffixed with the scanner name. If scanners are not configured, any scanner
+// is considered primary.
+func (c ConfigData) IsPrimaryVulnerabilityReportsScanner(scanner string) bool {
+	primary, err := c.GetVulnerabilityReportsScanner()
+	if err != nil {
+		return true
+	}
+	return string(primary) == scanner
 }
 
 func (c ConfigData) VulnerabilityScanJobsInSameNamespace() bool {
@@ -355,3 +416,101 @@ func LinuxNodeAffinity() *corev1.Affinity {
 					},
 				}}}}
 }
+
+// GetNotificationWebhookURL returns the URL of the webhook notified about new
+// findings in security reports, or an empty string if notifications are
+// disabled.
+func (c ConfigData) GetNotificationWebhookURL() string {
+	return strings.TrimSpace(c[keyNotificationWebhookURL])
+}
+
+// NotificationWebhookCloudEventsEnabled returns true if notifications should
+// be wrapped in the CloudEvents envelope.
+func (c ConfigData) NotificationWebhookCloudEventsEnabled() bool {
+	return c[keyNotificationWebhookCloudEvents] == "true"
+}
+
+// GetNotificationWebhookTimeout returns the timeout of a single webhook call.
+func (c ConfigData) GetNotificationWebhookTimeout() (time.Duration, error) {
+	const defaultValue = 10 * time.Second
+	value, ok := c[keyNotificationWebhookTimeout]
+	if !ok || value == "" {
+		return defaultValue, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", keyNotificationWebhookTimeout, err)
+	}
+	return timeout, nil
+}
+
+// GetNotificationSeverity returns the minimum severity of findings that
+// trigger a notification. Defaults to v1alpha1.SeverityCritical.
+func (c ConfigData) GetNotificationSeverity() (v1alpha1.Severity, error) {
+	severity, err := c.getSeverity(keyNotificationSeverity)
+	if err != nil || severity != "" {
+		return severity, err
+	}
+	return v1alpha1.SeverityCritical, nil
+}
+
+// GetAdmissionMode returns the mode of the admission webhook. Defaults to
+// AdmissionModeWarn.
+func (c ConfigData) GetAdmissionMode() (AdmissionMode, error) {
+	value, ok := c[keyAdmissionMode]
+	if !ok || value == "" {
+		return AdmissionModeWarn, nil
+	}
+	switch AdmissionMode(value) {
+	case AdmissionModeWarn, AdmissionModeDeny:
+		return AdmissionMode(value), nil
+	}
+	return "", fmt.Errorf("property %s must be either %q or %q, got %q", keyAdmissionMode,
+		AdmissionModeWarn, AdmissionModeDeny, value)
+}
+
+// GetAdmissionVulnerabilitiesSeverity returns the minimum severity of
+// vulnerabilities that violate the admission policy, or an empty string if
+// vulnerabilities are not checked on admission.
+func (c ConfigData) GetAdmissionVulnerabilitiesSeverity() (v1alpha1.Severity, error) {
+	return c.getSeverity(keyAdmissionVulnerabilitiesSeverity)
+}
+
+// AdmissionFixableVulnerabilitiesOnly returns true if only vulnerabilities
+// with a fixed version available violate the admission policy.
+func (c ConfigData) AdmissionFixableVulnerabilitiesOnly() bool {
+	return c[keyAdmissionVulnerabilitiesFixable] == "true"
+}
+
+// GetAdmissionConfigAuditChecksSeverity returns the minimum severity of
+// failed configuration audit checks that violate the admission policy, or an
+// empty string if configuration audit checks are not evaluated on admission.
+func (c ConfigData) GetAdmissionConfigAuditChecksSeverity() (v1alpha1.Severity, error) {
+	return c.getSeverity(keyAdmissionConfigAuditSeverity)
+}
+
+// GetExploitabilityKEVFile returns the path to a catalog of known exploited
+// vulnerabilities in the CISA KEV JSON format, or an empty string if
+// vulnerabilities are not checked against such a catalog.
+func (c ConfigData) GetExploitabilityKEVFile() string {
+	return strings.TrimSpace(c[keyExploitabilityKEVFile])
+}
+
+// GetExploitabilityEPSSFile returns the path to a CSV file, optionally gzip
+// compressed, with Exploit Prediction Scoring System (EPSS) scores, or an
+// empty string if vulnerabilities are not scored with EPSS.
+func (c ConfigData) GetExploitabilityEPSSFile() string {
+	return strings.TrimSpace(c[keyExploitabilityEPSSFile])
+}
+
+func (c ConfigData) getSeverity(key string) (v1alpha1.Severity, error) {
+	value, ok := c[key]
+	if !ok || value == "" {
+		return "", nil
+	}
+	severity, err := v1alpha1.StringToSeverity(value)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s: %w", key, err)
+	}
+	return severity, nil
+}
@@ -3,7 +3,9 @@ package starboard_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
@@ -36,6 +38,13 @@ func TestConfigData_GetVulnerabilityReportsScanner(t *testing.T) {
 			},
 			expectedScanner: "Aqua",
 		},
+		{
+			name: "Should return primary scanner",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Aqua, Trivy",
+			},
+			expectedScanner: "Aqua",
+		},
 		{
 			name:          "Should return error when value is not set",
 			configData:    starboard.ConfigData{},
@@ -55,6 +64,57 @@ func TestConfigData_GetVulnerabilityReportsScanner(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetVulnerabilityReportsScanners(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configData       starboard.ConfigData
+		expectedError    string
+		expectedScanners []starboard.Scanner
+	}{
+		{
+			name: "Should return single scanner",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Trivy",
+			},
+			expectedScanners: []starboard.Scanner{"Trivy"},
+		},
+		{
+			name: "Should return scanners in order and skip duplicates",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": "Trivy, Aqua,,Trivy,Grype",
+			},
+			expectedScanners: []starboard.Scanner{"Trivy", "Aqua", "Grype"},
+		},
+		{
+			name: "Should return error when value is blank",
+			configData: starboard.ConfigData{
+				"vulnerabilityReports.scanner": " , ",
+			},
+			expectedError: "property vulnerabilityReports.scanner not set",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scanners, err := tc.configData.GetVulnerabilityReportsScanners()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedScanners, scanners)
+			}
+		})
+	}
+}
+
+func TestConfigData_IsPrimaryVulnerabilityReportsScanner(t *testing.T) {
+	config := starboard.ConfigData{
+		"vulnerabilityReports.scanner": "Trivy,Grype",
+	}
+	assert.True(t, config.IsPrimaryVulnerabilityReportsScanner("Trivy"))
+	assert.False(t, config.IsPrimaryVulnerabilityReportsScanner("Grype"))
+	assert.True(t, starboard.ConfigData{}.IsPrimaryVulnerabilityReportsScanner("Grype"))
+}
+
 func TestConfigData_GetConfigAuditReportsScanner(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -410,6 +470,146 @@ func TestConfigData_GetKubeHunterQuick(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetNotificationWebhookTimeout(t *testing.T) {
+	testCases := []struct {
+		name            string
+		configData      starboard.ConfigData
+		expectedError   string
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "Should return default value when parameter is not set",
+			configData:      starboard.ConfigData{},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name: "Should return timeout from config data",
+			configData: starboard.ConfigData{
+				"notification.webhook.timeout": "30s",
+			},
+			expectedTimeout: 30 * time.Second,
+		},
+		{
+			name: "Should return error when timeout is not a valid duration",
+			configData: starboard.ConfigData{
+				"notification.webhook.timeout": "thirty",
+			},
+			expectedError: "parsing notification.webhook.timeout: time: invalid duration \"thirty\"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeout, err := tc.configData.GetNotificationWebhookTimeout()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedTimeout, timeout)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetNotificationSeverity(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configData       starboard.ConfigData
+		expectedError    string
+		expectedSeverity v1alpha1.Severity
+	}{
+		{
+			name:             "Should return CRITICAL when parameter is not set",
+			configData:       starboard.ConfigData{},
+			expectedSeverity: v1alpha1.SeverityCritical,
+		},
+		{
+			name: "Should return severity from config data",
+			configData: starboard.ConfigData{
+				"notification.severity": "high",
+			},
+			expectedSeverity: v1alpha1.SeverityHigh,
+		},
+		{
+			name: "Should return error when severity is not recognized",
+			configData: starboard.ConfigData{
+				"notification.severity": "SEVERE",
+			},
+			expectedError: "parsing notification.severity: unrecognized name literal: SEVERE",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			severity, err := tc.configData.GetNotificationSeverity()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedSeverity, severity)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetAdmissionMode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configData    starboard.ConfigData
+		expectedError string
+		expectedMode  starboard.AdmissionMode
+	}{
+		{
+			name:         "Should return Warn when parameter is not set",
+			configData:   starboard.ConfigData{},
+			expectedMode: starboard.AdmissionModeWarn,
+		},
+		{
+			name: "Should return Deny",
+			configData: starboard.ConfigData{
+				"admission.mode": "Deny",
+			},
+			expectedMode: starboard.AdmissionModeDeny,
+		},
+		{
+			name: "Should return error when mode is not recognized",
+			configData: starboard.ConfigData{
+				"admission.mode": "Block",
+			},
+			expectedError: "property admission.mode must be either \"Warn\" or \"Deny\", got \"Block\"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := tc.configData.GetAdmissionMode()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedMode, mode)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetAdmissionVulnerabilitiesSeverity(t *testing.T) {
+	severity, err := starboard.ConfigData{}.GetAdmissionVulnerabilitiesSeverity()
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.Severity(""), severity)
+
+	severity, err = starboard.ConfigData{
+		"admission.vulnerabilities.severity": "CRITICAL",
+	}.GetAdmissionVulnerabilitiesSeverity()
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.SeverityCritical, severity)
+
+	_, err = starboard.ConfigData{
+		"admission.vulnerabilities.severity": "SEVERE",
+	}.GetAdmissionVulnerabilitiesSeverity()
+	require.EqualError(t, err, "parsing admission.vulnerabilities.severity: unrecognized name literal: SEVERE")
+}
+
 func TestGetVersionFromImageRef(t *testing.T) {
 	testCases := []struct {
 		imageRef        string
@@ -34,6 +34,7 @@ const (
 	LabelResourceNameHash  = "starboard.resource.name-hash"
 	LabelResourceNamespace = "starboard.resource.namespace"
 	LabelContainerName     = "starboard.container.name"
+	LabelContainerClass    = "starboard.container.class"
 	LabelResourceSpecHash  = "resource-spec-hash"
 	LabelPluginConfigHash  = "plugin-config-hash"
 
@@ -41,10 +42,26 @@ const (
 	LabelVulnerabilityReportScanner = "vulnerabilityReport.scanner"
 	LabelKubeBenchReportScanner     = "kubeBenchReport.scanner"
 
+	// LabelVEX marks ConfigMaps that store VEX documents.
+	LabelVEX = "starboard.vex"
+
+	// LabelVulnerabilityReportCache marks ClusterVulnerabilityReports that
+	// cache scan results of container images by image digest rather than
+	// describe a workload.
+	LabelVulnerabilityReportCache = "starboard.vulnerability-report-cache"
+
 	LabelK8SAppManagedBy = "app.kubernetes.io/managed-by"
 	AppStarboard         = "starboard"
 )
 
 const (
 	AnnotationContainerImages = "starboard.container-images"
+
+	// AnnotationScanPriority sets the priority of scan jobs of a workload or
+	// a node. Scan jobs with higher priority are admitted first.
+	AnnotationScanPriority = "starboard.scan-priority"
+
+	// LabelScanPriority sets the default priority of scan jobs of workloads in
+	// a namespace.
+	LabelScanPriority = "starboard.scan-priority"
 )
@@ -0,0 +1,225 @@
+package trivydb
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Controller watches the ConfigMap of the Trivy plugin and periodically loads
+// the vulnerability database into the PersistentVolumeClaim configured with
+// trivy.dbVolumeClaim. The database is loaded by the Job returned by
+// trivy.NewDBUpdateJob, which is owned by the ConfigMap. Once the Job is
+// complete the claim is annotated with the time of the update, and the next
+// update is scheduled after trivy.dbUpdateInterval. If the Job fails the claim
+// is annotated with the time of the failure, and the Job is retried after
+// etc.Config.ScanJobRetryAfter.
+type Controller struct {
+	logr.Logger
+	etc.Config
+	ext.Clock
+	client.Client
+}
+
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	operatorNamespace, err := r.Config.GetOperatorNamespace()
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("trivydb").
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			InNamespace(operatorNamespace),
+			HasName(starboard.GetPluginConfigMapName(trivy.Plugin)),
+		)).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("configMap", req.NamespacedName)
+
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, req.NamespacedName, cm)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
+	}
+
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: cm.Data}}
+	if !config.IsDBVolumeEnabled() {
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("claim", req.Namespace+"/"+config.GetDBVolumeClaim())
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err = r.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: config.GetDBVolumeClaim()}, pvc)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.Info("Waiting for persistent volume claim of vulnerability database", "retryAfter", r.Config.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting persistent volume claim: %w", err)
+	}
+
+	job := &batchv1.Job{}
+	err = r.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: trivy.DBUpdateJobName}, job)
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("getting job: %w", err)
+	}
+	if err == nil {
+		return r.processUpdateJob(ctx, log, job, pvc)
+	}
+
+	// Do not run the update concurrently with the import from the Starboard CLI.
+	err = r.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: trivy.DBImportPodName}, &corev1.Pod{})
+	if err == nil {
+		log.V(1).Info("Pushing back update of vulnerability database until import is complete", "retryAfter", r.Config.ScanJobRetryAfter)
+		return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+	}
+	if !k8sapierror.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("getting pod: %w", err)
+	}
+
+	interval, err := config.GetDBUpdateInterval()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if failed, ok := getTimeAnnotation(pvc, trivy.AnnotationDBUpdateFailed); ok {
+		if next := failed.Add(r.Config.ScanJobRetryAfter); r.Clock.Now().Before(next) {
+			log.V(1).Info("Backing off failed update of vulnerability database", "failed", failed, "nextUpdate", next)
+			return ctrl.Result{RequeueAfter: next.Sub(r.Clock.Now())}, nil
+		}
+	}
+	if updated, ok := GetUpdateTime(pvc); ok {
+		if next := updated.Add(interval); r.Clock.Now().Before(next) {
+			log.V(1).Info("Vulnerability database is up to date", "updated", updated, "nextUpdate", next)
+			return ctrl.Result{RequeueAfter: next.Sub(r.Clock.Now())}, nil
+		}
+	}
+
+	job, err = trivy.NewDBUpdateJob(config, req.Namespace, r.Config.ServiceAccount)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	err = controllerutil.SetControllerReference(cm, job, r.Client.Scheme())
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("setting controller reference: %w", err)
+	}
+
+	log.V(1).Info("Updating vulnerability database", "job", req.Namespace+"/"+job.Name)
+	err = r.Client.Create(ctx, job)
+	if err != nil && !k8sapierror.IsAlreadyExists(err) {
+		return ctrl.Result{}, fmt.Errorf("creating job: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *Controller) processUpdateJob(ctx context.Context, log logr.Logger, job *batchv1.Job, pvc *corev1.PersistentVolumeClaim) (ctrl.Result, error) {
+	log = log.WithValues("job", job.Namespace+"/"+job.Name)
+
+	if len(job.Status.Conditions) == 0 {
+		log.V(1).Info("Ignoring Job without conditions")
+		return ctrl.Result{}, nil
+	}
+
+	switch jobCondition := job.Status.Conditions[0].Type; jobCondition {
+	case batchv1.JobComplete:
+		err := SetUpdateTime(ctx, r.Client, pvc, r.Clock.Now())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Updated vulnerability database")
+		// The next update is scheduled on reconciliation triggered by the
+		// deletion of the job.
+	case batchv1.JobFailed:
+		err := setUpdateFailedTime(ctx, r.Client, pvc, r.Clock.Now())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Failed to update vulnerability database",
+			"reason", job.Status.Conditions[0].Reason,
+			"message", job.Status.Conditions[0].Message,
+			"retryAfter", r.Config.ScanJobRetryAfter)
+		// The retry is scheduled on reconciliation triggered by the deletion
+		// of the job.
+	default:
+		return ctrl.Result{}, fmt.Errorf("unrecognized job condition: %v", jobCondition)
+	}
+
+	err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("deleting job: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// GetUpdateTime returns the time when the vulnerability database was last
+// loaded into the specified claim.
+func GetUpdateTime(pvc *corev1.PersistentVolumeClaim) (time.Time, bool) {
+	return getTimeAnnotation(pvc, trivy.AnnotationDBUpdated)
+}
+
+// SetUpdateTime annotates the specified claim with the time when the
+// vulnerability database was loaded, and clears the time of the last failed
+// update.
+func SetUpdateTime(ctx context.Context, c client.Client, pvc *corev1.PersistentVolumeClaim, updated time.Time) error {
+	return annotateClaim(ctx, c, pvc, func(annotations map[string]string) {
+		annotations[trivy.AnnotationDBUpdated] = updated.UTC().Format(time.RFC3339)
+		delete(annotations, trivy.AnnotationDBUpdateFailed)
+	})
+}
+
+// setUpdateFailedTime annotates the specified claim with the time when the
+// update of the vulnerability database failed.
+func setUpdateFailedTime(ctx context.Context, c client.Client, pvc *corev1.PersistentVolumeClaim, failed time.Time) error {
+	return annotateClaim(ctx, c, pvc, func(annotations map[string]string) {
+		annotations[trivy.AnnotationDBUpdateFailed] = failed.UTC().Format(time.RFC3339)
+	})
+}
+
+func getTimeAnnotation(pvc *corev1.PersistentVolumeClaim, key string) (time.Time, bool) {
+	value, ok := pvc.Annotations[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func annotateClaim(ctx context.Context, c client.Client, pvc *corev1.PersistentVolumeClaim, mutate func(map[string]string)) error {
+	patch := client.MergeFrom(pvc.DeepCopy())
+	if pvc.Annotations == nil {
+		pvc.Annotations = make(map[string]string)
+	}
+	mutate(pvc.Annotations)
+	err := c.Patch(ctx, pvc, patch)
+	if err != nil {
+		return fmt.Errorf("annotating persistent volume claim: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package trivydb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/trivydb"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var now = time.Date(2022, time.May, 4, 10, 30, 0, 0, time.UTC)
+
+var dbConfig = map[string]string{
+	"trivy.imageRef":      "docker.io/aquasec/trivy:0.25.2",
+	"trivy.mode":          "Standalone",
+	"trivy.dbRepository":  "mirror.example.com/trivy-db",
+	"trivy.dbVolumeClaim": "trivy-db",
+}
+
+func newTrivyConfig(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard-trivy-config",
+			Namespace: "starboard-system",
+			UID:       "b1a2f4a8-5a1c-4d9e-9a47-39f0b3a5c1d2",
+		},
+		Data: data,
+	}
+}
+
+func newClaim(annotations map[string]string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "trivy-db",
+			Namespace:   "starboard-system",
+			Annotations: annotations,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+		},
+	}
+}
+
+func newJob(name string, conditionType batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "starboard-system",
+		},
+	}
+	if conditionType != "" {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+	}
+	return job
+}
+
+func TestController_Reconcile(t *testing.T) {
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "starboard-system", Name: "starboard-trivy-config"}}
+	jobKey := types.NamespacedName{Namespace: "starboard-system", Name: trivy.DBUpdateJobName}
+	claimKey := types.NamespacedName{Namespace: "starboard-system", Name: "trivy-db"}
+
+	newController := func(c client.Client) *trivydb.Controller {
+		return &trivydb.Controller{
+			Logger: logr.Discard(),
+			Config: etc.Config{
+				Namespace:         "starboard-system",
+				ServiceAccount:    "starboard-operator",
+				ScanJobRetryAfter: 30 * time.Second,
+			},
+			Clock:  ext.NewFixedClock(now),
+			Client: c,
+		}
+	}
+
+	t.Run("Should do nothing if claim is not configured", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(map[string]string{"trivy.mode": "Standalone"})).
+			Build()
+
+		result, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		err = testClient.Get(context.TODO(), jobKey, &batchv1.Job{})
+		assert.True(t, k8sapierror.IsNotFound(err))
+	})
+
+	t.Run("Should wait for claim", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(dbConfig)).
+			Build()
+
+		result, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, result)
+
+		err = testClient.Get(context.TODO(), jobKey, &batchv1.Job{})
+		assert.True(t, k8sapierror.IsNotFound(err))
+	})
+
+	t.Run("Should create update job if database was never loaded", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(dbConfig), newClaim(nil)).
+			Build()
+
+		_, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		var job batchv1.Job
+		require.NoError(t, testClient.Get(context.TODO(), jobKey, &job))
+		require.Len(t, job.OwnerReferences, 1)
+		assert.Equal(t, "ConfigMap", job.OwnerReferences[0].Kind)
+		assert.Equal(t, "starboard-operator", job.Spec.Template.Spec.ServiceAccountName)
+	})
+
+	t.Run("Should not create update job while database is being imported", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(dbConfig), newClaim(nil), &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: trivy.DBImportPodName, Namespace: "starboard-system"},
+			}).
+			Build()
+
+		result, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, result)
+
+		err = testClient.Get(context.TODO(), jobKey, &batchv1.Job{})
+		assert.True(t, k8sapierror.IsNotFound(err))
+	})
+
+	t.Run("Should schedule next update if database is up to date", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(dbConfig), newClaim(map[string]string{
+				trivy.AnnotationDBUpdated: now.Add(-20 * time.Hour).Format(time.RFC3339),
+			})).
+			Build()
+
+		result, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: 4 * time.Hour}, result)
+
+		err = testClient.Get(context.TODO(), jobKey, &batchv1.Job{})
+		assert.True(t, k8sapierror.IsNotFound(err))
+	})
+
+	t.Run("Should annotate claim when update job is complete", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(dbConfig), newClaim(nil), newJob(trivy.DBUpdateJobName, batchv1.JobComplete)).
+			Build()
+
+		result, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		var pvc corev1.PersistentVolumeClaim
+		require.NoError(t, testClient.Get(context.TODO(), claimKey, &pvc))
+		assert.Equal(t, "2022-05-04T10:30:00Z", pvc.Annotations[trivy.AnnotationDBUpdated])
+
+		err = testClient.Get(context.TODO(), jobKey, &batchv1.Job{})
+		assert.True(t, k8sapierror.IsNotFound(err))
+	})
+
+	t.Run("Should back off failed update job", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(dbConfig), newClaim(nil), newJob(trivy.DBUpdateJobName, batchv1.JobFailed)).
+			Build()
+
+		result, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		var pvc corev1.PersistentVolumeClaim
+		require.NoError(t, testClient.Get(context.TODO(), claimKey, &pvc))
+		assert.NotContains(t, pvc.Annotations, trivy.AnnotationDBUpdated)
+		assert.Equal(t, "2022-05-04T10:30:00Z", pvc.Annotations[trivy.AnnotationDBUpdateFailed])
+
+		err = testClient.Get(context.TODO(), jobKey, &batchv1.Job{})
+		assert.True(t, k8sapierror.IsNotFound(err))
+
+		// The deletion of the failed job triggers another reconciliation.
+		result, err = newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, result)
+
+		err = testClient.Get(context.TODO(), jobKey, &batchv1.Job{})
+		assert.True(t, k8sapierror.IsNotFound(err))
+	})
+
+	t.Run("Should retry failed update job after retry interval", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(dbConfig), newClaim(map[string]string{
+				trivy.AnnotationDBUpdateFailed: now.Add(-time.Minute).Format(time.RFC3339),
+			})).
+			Build()
+
+		result, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		require.NoError(t, testClient.Get(context.TODO(), jobKey, &batchv1.Job{}))
+	})
+
+	t.Run("Should clear failed update when update job is complete", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(dbConfig), newClaim(map[string]string{
+				trivy.AnnotationDBUpdateFailed: now.Add(-time.Minute).Format(time.RFC3339),
+			}), newJob(trivy.DBUpdateJobName, batchv1.JobComplete)).
+			Build()
+
+		_, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		var pvc corev1.PersistentVolumeClaim
+		require.NoError(t, testClient.Get(context.TODO(), claimKey, &pvc))
+		assert.Equal(t, "2022-05-04T10:30:00Z", pvc.Annotations[trivy.AnnotationDBUpdated])
+		assert.NotContains(t, pvc.Annotations, trivy.AnnotationDBUpdateFailed)
+	})
+}
+
+func TestReadinessChecker_IsReady(t *testing.T) {
+	newPluginContext := func(c client.Client) starboard.PluginContext {
+		return starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-system").
+			WithClient(c).
+			Get()
+	}
+	updated := map[string]string{trivy.AnnotationDBUpdated: now.Format(time.RFC3339)}
+
+	testCases := []struct {
+		name     string
+		objects  []client.Object
+		expected bool
+	}{
+		{
+			name:     "Should be ready if claim is not configured",
+			objects:  []client.Object{newTrivyConfig(map[string]string{"trivy.mode": "Standalone"})},
+			expected: true,
+		},
+		{
+			name:     "Should not be ready if claim does not exist",
+			objects:  []client.Object{newTrivyConfig(dbConfig)},
+			expected: false,
+		},
+		{
+			name:     "Should not be ready if database was never loaded",
+			objects:  []client.Object{newTrivyConfig(dbConfig), newClaim(nil)},
+			expected: false,
+		},
+		{
+			name:     "Should not be ready while database is being updated",
+			objects:  []client.Object{newTrivyConfig(dbConfig), newClaim(updated), newJob(trivy.DBUpdateJobName, "")},
+			expected: false,
+		},
+		{
+			name: "Should not be ready while database is being imported",
+			objects: []client.Object{newTrivyConfig(dbConfig), newClaim(updated), &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: trivy.DBImportPodName, Namespace: "starboard-system"},
+			}},
+			expected: false,
+		},
+		{
+			name:     "Should be ready if database was loaded",
+			objects:  []client.Object{newTrivyConfig(dbConfig), newClaim(updated)},
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+				WithObjects(tc.objects...).
+				Build()
+
+			ready, err := trivydb.NewReadinessChecker(testClient, newPluginContext(testClient)).IsReady(context.TODO())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ready)
+		})
+	}
+	t.Run("Should return error if claim cannot be shared by nodes", func(t *testing.T) {
+		pvc := newClaim(updated)
+		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(dbConfig), pvc).
+			Build()
+
+		_, err := trivydb.NewReadinessChecker(testClient, newPluginContext(testClient)).IsReady(context.TODO())
+		assert.EqualError(t, err, `persistent volume claim "trivy-db" must have ReadOnlyMany or ReadWriteMany access mode`)
+	})
+}
@@ -0,0 +1,6 @@
+// Package trivydb provides primitives for distributing the Trivy
+// vulnerability database to scan jobs in disconnected clusters. The database
+// is loaded once into a PersistentVolumeClaim, either by Starboard Operator or
+// with the Starboard CLI, and then copied from the read-only volume by each
+// scan job running in the Standalone mode.
+package trivydb
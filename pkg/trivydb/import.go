@@ -0,0 +1,191 @@
+package trivydb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+)
+
+// Importer loads the vulnerability database bundle, i.e. the db.tar.gz file
+// published as the trivy-db OCI artifact, into the claim configured with
+// trivy.dbVolumeClaim.
+type Importer struct {
+	clientset     kubernetes.Interface
+	restConfig    *rest.Config
+	clock         ext.Clock
+	pluginContext starboard.PluginContext
+}
+
+func NewImporter(
+	clientset kubernetes.Interface,
+	restConfig *rest.Config,
+	clock ext.Clock,
+	pluginContext starboard.PluginContext,
+) *Importer {
+	return &Importer{
+		clientset:     clientset,
+		restConfig:    restConfig,
+		clock:         clock,
+		pluginContext: pluginContext,
+	}
+}
+
+// Import runs the Pod returned by trivy.NewDBImportPod and streams the bundle
+// read from the specified reader to the standard input of the command returned
+// by trivy.DBImportCommand, which extracts it into the claim. Once the bundle
+// is extracted the claim is annotated with the time of the import. The Pod is
+// deleted even if the import fails or the context is cancelled.
+func (i *Importer) Import(ctx context.Context, bundle io.Reader) error {
+	pluginConfig, err := i.pluginContext.GetConfig()
+	if err != nil {
+		return fmt.Errorf("getting plugin config: %w", err)
+	}
+	config := trivy.Config{PluginConfig: pluginConfig}
+	if config.GetDBVolumeClaim() == "" {
+		return errors.New("persistent volume claim of vulnerability database is not configured, set trivy.dbVolumeClaim")
+	}
+
+	namespace := i.pluginContext.GetNamespace()
+	pvc, err := i.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, config.GetDBVolumeClaim(), metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("getting persistent volume claim: %w", err)
+	}
+
+	_, err = i.clientset.BatchV1().Jobs(namespace).Get(ctx, trivy.DBUpdateJobName, metav1.GetOptions{})
+	if err == nil {
+		return fmt.Errorf("vulnerability database is being updated by job %q, try again later", namespace+"/"+trivy.DBUpdateJobName)
+	}
+	if !k8sapierror.IsNotFound(err) {
+		return fmt.Errorf("getting job: %w", err)
+	}
+
+	pod, err := trivy.NewDBImportPod(config, namespace, i.pluginContext.GetServiceAccountName())
+	if err != nil {
+		return err
+	}
+
+	klog.V(3).Infof("Creating pod %q", pod.Namespace+"/"+pod.Name)
+	_, err = i.clientset.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("creating import pod: %w", err)
+	}
+
+	defer func() {
+		// The context might have been cancelled, e.g. by an interrupt signal,
+		// therefore the pod is deleted with a fresh one.
+		klog.V(3).Infof("Deleting pod %q", pod.Namespace+"/"+pod.Name)
+		_ = i.clientset.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{
+			GracePeriodSeconds: pointer.Int64Ptr(0),
+		})
+	}()
+
+	err = i.waitForPodRunning(ctx, pod)
+	if err != nil {
+		return err
+	}
+
+	err = i.streamBundle(ctx, pod, bundle)
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				trivy.AnnotationDBUpdated:      i.clock.Now().UTC().Format(time.RFC3339),
+				trivy.AnnotationDBUpdateFailed: nil,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = i.clientset.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx, pvc.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("annotating persistent volume claim: %w", err)
+	}
+	return nil
+}
+
+func (i *Importer) waitForPodRunning(ctx context.Context, pod *corev1.Pod) error {
+	klog.V(3).Infof("Waiting for pod %q to be running", pod.Namespace+"/"+pod.Name)
+	return wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		current, err := i.clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("getting import pod: %w", err)
+		}
+		switch current.Status.Phase {
+		case corev1.PodRunning:
+			return true, nil
+		case corev1.PodSucceeded, corev1.PodFailed:
+			return false, fmt.Errorf("import pod terminated: %s", current.Status.Message)
+		}
+		return false, nil
+	})
+}
+
+// streamBundle executes the command returned by trivy.DBImportCommand in the
+// container of the specified pod with the bundle attached to its standard
+// input. Streaming cannot be cancelled, therefore it's abandoned when the
+// context is done, and terminated once the pod is deleted.
+func (i *Importer) streamBundle(ctx context.Context, pod *corev1.Pod, bundle io.Reader) error {
+	req := i.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: pod.Spec.Containers[0].Name,
+			Command:   trivy.DBImportCommand(),
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(i.restConfig, http.MethodPost, req.URL())
+	if err != nil {
+		return fmt.Errorf("creating executor: %w", err)
+	}
+
+	klog.V(3).Infof("Streaming database bundle to pod %q", pod.Namespace+"/"+pod.Name)
+	var output bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.Stream(remotecommand.StreamOptions{
+			Stdin:  bundle,
+			Stdout: &output,
+			Stderr: &output,
+		})
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err = <-done:
+		if err != nil {
+			return fmt.Errorf("extracting database bundle: %w: %s", err, strings.TrimSpace(output.String()))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package trivydb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type readinessChecker struct {
+	client        client.Client
+	pluginContext starboard.PluginContext
+}
+
+// NewReadinessChecker constructs a vulnerabilityreport.ReadinessChecker which
+// holds back scan jobs until the vulnerability database is loaded into the
+// claim configured with trivy.dbVolumeClaim, and while it's being updated. It
+// always reports ready if the claim is not configured, and returns an error if
+// the claim cannot be mounted by scan jobs running on different nodes.
+func NewReadinessChecker(client client.Client, pluginContext starboard.PluginContext) vulnerabilityreport.ReadinessChecker {
+	return &readinessChecker{
+		client:        client,
+		pluginContext: pluginContext,
+	}
+}
+
+func (c *readinessChecker) IsReady(ctx context.Context) (bool, error) {
+	pluginConfig, err := c.pluginContext.GetConfig()
+	if err != nil {
+		return false, fmt.Errorf("getting plugin config: %w", err)
+	}
+	config := trivy.Config{PluginConfig: pluginConfig}
+	if !config.IsDBVolumeEnabled() {
+		return true, nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err = c.client.Get(ctx, client.ObjectKey{Namespace: c.pluginContext.GetNamespace(), Name: config.GetDBVolumeClaim()}, pvc)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting persistent volume claim: %w", err)
+	}
+	if !hasSharedAccessMode(pvc) {
+		return false, fmt.Errorf("persistent volume claim %q must have %s or %s access mode",
+			pvc.Name, corev1.ReadOnlyMany, corev1.ReadWriteMany)
+	}
+	if _, ok := GetUpdateTime(pvc); !ok {
+		return false, nil
+	}
+
+	err = c.client.Get(ctx, client.ObjectKey{Namespace: c.pluginContext.GetNamespace(), Name: trivy.DBUpdateJobName}, &batchv1.Job{})
+	if err == nil {
+		return false, nil
+	}
+	if !k8sapierror.IsNotFound(err) {
+		return false, fmt.Errorf("getting job: %w", err)
+	}
+	err = c.client.Get(ctx, client.ObjectKey{Namespace: c.pluginContext.GetNamespace(), Name: trivy.DBImportPodName}, &corev1.Pod{})
+	if err == nil {
+		return false, nil
+	}
+	if !k8sapierror.IsNotFound(err) {
+		return false, fmt.Errorf("getting pod: %w", err)
+	}
+	return true, nil
+}
+
+// hasSharedAccessMode returns true if the specified claim can be mounted by
+// many nodes. Access modes of the bound volume take precedence over the
+// requested ones.
+func hasSharedAccessMode(pvc *corev1.PersistentVolumeClaim) bool {
+	accessModes := pvc.Spec.AccessModes
+	if len(pvc.Status.AccessModes) > 0 {
+		accessModes = pvc.Status.AccessModes
+	}
+	for _, mode := range accessModes {
+		if mode == corev1.ReadOnlyMany || mode == corev1.ReadWriteMany {
+			return true
+		}
+	}
+	return false
+}
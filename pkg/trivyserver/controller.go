@@ -0,0 +1,273 @@
+package trivyserver
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Controller watches the ConfigMap of the Trivy plugin and runs the Trivy
+// server in the operator namespace if trivy.serverManaged is enabled. The
+// Deployment, the Service, and the PersistentVolumeClaim of the server are
+// owned by the ConfigMap, whereas the token shared by the server and scan jobs
+// is generated and stored in the plugin's Secret. The server is deleted once
+// trivy.serverManaged is disabled.
+type Controller struct {
+	logr.Logger
+	etc.Config
+	client.Client
+}
+
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	operatorNamespace, err := r.Config.GetOperatorNamespace()
+	if err != nil {
+		return err
+	}
+	// The ConfigMap and the Secret of the plugin have the same name.
+	trivyConfig := builder.WithPredicates(
+		InNamespace(operatorNamespace),
+		HasName(starboard.GetPluginConfigMapName(trivy.Plugin)),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("trivyserver").
+		For(&corev1.ConfigMap{}, trivyConfig).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForObject{}, trivyConfig).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Complete(r)
+}
+
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("configMap", req.NamespacedName)
+
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, req.NamespacedName, cm)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
+	}
+
+	config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: cm.Data}}
+	if !config.IsServerManaged() {
+		return ctrl.Result{}, r.deleteServer(ctx, req.Namespace)
+	}
+
+	secret, err := r.ensureToken(ctx, req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	pvc, err := trivy.NewManagedServerCache(config, req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	err = r.ensureCache(ctx, cm, pvc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	deployment, err := trivy.NewManagedServerDeployment(config, secret, req.Namespace, r.Config.ServiceAccount)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	err = r.ensureDeployment(ctx, cm, deployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.ensureService(ctx, cm, trivy.NewManagedServerService(req.Namespace))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.V(1).Info("Reconciled managed Trivy server", "url", trivy.GetManagedServerURL(req.Namespace))
+	return ctrl.Result{}, nil
+}
+
+// ensureToken makes sure that the plugin's Secret holds the token used by scan
+// jobs to authenticate with the managed Trivy server, and returns the Secret.
+func (r *Controller) ensureToken(ctx context.Context, namespace string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: starboard.GetPluginConfigMapName(trivy.Plugin)}, secret)
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		return nil, fmt.Errorf("getting secret: %w", err)
+	}
+	if k8sapierror.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      starboard.GetPluginConfigMapName(trivy.Plugin),
+				Namespace: namespace,
+				Labels: labels.Set{
+					starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+				},
+			},
+		}
+		_, err = trivy.EnsureServerToken(secret)
+		if err != nil {
+			return nil, err
+		}
+		err = r.Client.Create(ctx, secret)
+		if err != nil {
+			return nil, fmt.Errorf("creating secret: %w", err)
+		}
+		return secret, nil
+	}
+
+	secret = secret.DeepCopy()
+	modified, err := trivy.EnsureServerToken(secret)
+	if err != nil {
+		return nil, err
+	}
+	if !modified {
+		return secret, nil
+	}
+	r.Logger.V(1).Info("Generating Trivy server token", "secret", namespace+"/"+secret.Name)
+	err = r.Client.Update(ctx, secret)
+	if err != nil {
+		return nil, fmt.Errorf("updating secret: %w", err)
+	}
+	return secret, nil
+}
+
+// ensureCache creates the PersistentVolumeClaim of the managed Trivy server.
+// The claim is never updated, because most of its spec is immutable.
+func (r *Controller) ensureCache(ctx context.Context, owner *corev1.ConfigMap, pvc *corev1.PersistentVolumeClaim) error {
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{})
+	if err == nil {
+		return nil
+	}
+	if !k8sapierror.IsNotFound(err) {
+		return fmt.Errorf("getting persistent volume claim: %w", err)
+	}
+	err = controllerutil.SetControllerReference(owner, pvc, r.Client.Scheme())
+	if err != nil {
+		return fmt.Errorf("setting controller reference: %w", err)
+	}
+	err = r.Client.Create(ctx, pvc)
+	if err != nil && !k8sapierror.IsAlreadyExists(err) {
+		return fmt.Errorf("creating persistent volume claim: %w", err)
+	}
+	return nil
+}
+
+// ensureDeployment creates the Deployment of the managed Trivy server, or
+// updates it if the hash of the desired spec has changed.
+func (r *Controller) ensureDeployment(ctx context.Context, owner *corev1.ConfigMap, desired *appsv1.Deployment) error {
+	err := controllerutil.SetControllerReference(owner, desired, r.Client.Scheme())
+	if err != nil {
+		return fmt.Errorf("setting controller reference: %w", err)
+	}
+
+	existing := &appsv1.Deployment{}
+	err = r.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil {
+		if !k8sapierror.IsNotFound(err) {
+			return fmt.Errorf("getting deployment: %w", err)
+		}
+		err = r.Client.Create(ctx, desired)
+		if err != nil {
+			return fmt.Errorf("creating deployment: %w", err)
+		}
+		return nil
+	}
+
+	if existing.Labels[starboard.LabelResourceSpecHash] == desired.Labels[starboard.LabelResourceSpecHash] {
+		return nil
+	}
+	existing = existing.DeepCopy()
+	existing.Labels = desired.Labels
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec = desired.Spec
+	err = r.Client.Update(ctx, existing)
+	if err != nil {
+		return fmt.Errorf("updating deployment: %w", err)
+	}
+	return nil
+}
+
+// ensureService creates the Service of the managed Trivy server, or updates
+// its selector and ports. Other fields, such as the allocated cluster IP, are
+// left intact.
+func (r *Controller) ensureService(ctx context.Context, owner *corev1.ConfigMap, desired *corev1.Service) error {
+	err := controllerutil.SetControllerReference(owner, desired, r.Client.Scheme())
+	if err != nil {
+		return fmt.Errorf("setting controller reference: %w", err)
+	}
+
+	existing := &corev1.Service{}
+	err = r.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil {
+		if !k8sapierror.IsNotFound(err) {
+			return fmt.Errorf("getting service: %w", err)
+		}
+		err = r.Client.Create(ctx, desired)
+		if err != nil {
+			return fmt.Errorf("creating service: %w", err)
+		}
+		return nil
+	}
+
+	if reflect.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) && reflect.DeepEqual(existing.Spec.Ports, desired.Spec.Ports) {
+		return nil
+	}
+	existing = existing.DeepCopy()
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.Ports = desired.Spec.Ports
+	err = r.Client.Update(ctx, existing)
+	if err != nil {
+		return fmt.Errorf("updating service: %w", err)
+	}
+	return nil
+}
+
+// deleteServer deletes the Deployment, the Service, and the
+// PersistentVolumeClaim of the managed Trivy server if they exist. The token
+// is kept in the plugin's Secret.
+func (r *Controller) deleteServer(ctx context.Context, namespace string) error {
+	objects := []client.Object{
+		&appsv1.Deployment{},
+		&corev1.Service{},
+		&corev1.PersistentVolumeClaim{},
+	}
+	for _, obj := range objects {
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: trivy.ManagedServerName}, obj)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("getting %T: %w", obj, err)
+		}
+		if obj.GetLabels()[starboard.LabelK8SAppManagedBy] != starboard.AppStarboard {
+			continue
+		}
+		r.Logger.V(1).Info("Deleting managed Trivy server object", "kind", fmt.Sprintf("%T", obj), "name", namespace+"/"+obj.GetName())
+		err = r.Client.Delete(ctx, obj)
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return fmt.Errorf("deleting %T: %w", obj, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package trivyserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/trivyserver"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTrivyConfig(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard-trivy-config",
+			Namespace: "starboard-system",
+			UID:       "b1a2f4a8-5a1c-4d9e-9a47-39f0b3a5c1d2",
+		},
+		Data: data,
+	}
+}
+
+func TestController_Reconcile(t *testing.T) {
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "starboard-system", Name: "starboard-trivy-config"}}
+	serverKey := types.NamespacedName{Namespace: "starboard-system", Name: trivy.ManagedServerName}
+	managedConfig := map[string]string{
+		"trivy.imageRef":      "docker.io/aquasec/trivy:0.25.2",
+		"trivy.mode":          "ClientServer",
+		"trivy.serverManaged": "true",
+		"trivy.dbRepository":  "ghcr.io/aquasecurity/trivy-db",
+	}
+
+	newController := func(c client.Client) *trivyserver.Controller {
+		return &trivyserver.Controller{
+			Logger: logr.Discard(),
+			Config: etc.Config{Namespace: "starboard-system", ServiceAccount: "starboard-operator"},
+			Client: c,
+		}
+	}
+
+	t.Run("Should create managed server", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(managedConfig)).
+			Build()
+
+		_, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		var secret corev1.Secret
+		require.NoError(t, testClient.Get(context.TODO(), request.NamespacedName, &secret))
+		assert.NotEmpty(t, secret.Data["trivy.serverToken"])
+
+		var pvc corev1.PersistentVolumeClaim
+		require.NoError(t, testClient.Get(context.TODO(), serverKey, &pvc))
+		require.Len(t, pvc.OwnerReferences, 1)
+		assert.Equal(t, "ConfigMap", pvc.OwnerReferences[0].Kind)
+
+		var deployment appsv1.Deployment
+		require.NoError(t, testClient.Get(context.TODO(), serverKey, &deployment))
+		assert.Equal(t, "starboard-operator", deployment.Spec.Template.Spec.ServiceAccountName)
+		require.Len(t, deployment.OwnerReferences, 1)
+
+		var service corev1.Service
+		require.NoError(t, testClient.Get(context.TODO(), serverKey, &service))
+		require.Len(t, service.Spec.Ports, 1)
+		assert.Equal(t, int32(4954), service.Spec.Ports[0].Port)
+	})
+
+	t.Run("Should keep existing token", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(managedConfig), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-system",
+				},
+				Data: map[string][]byte{
+					"trivy.serverToken": []byte("s3cret"),
+				},
+			}).
+			Build()
+
+		_, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		var secret corev1.Secret
+		require.NoError(t, testClient.Get(context.TODO(), request.NamespacedName, &secret))
+		assert.Equal(t, []byte("s3cret"), secret.Data["trivy.serverToken"])
+	})
+
+	t.Run("Should update deployment when config changes", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(managedConfig)).
+			Build()
+		controller := newController(testClient)
+
+		_, err := controller.Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		var cm corev1.ConfigMap
+		require.NoError(t, testClient.Get(context.TODO(), request.NamespacedName, &cm))
+		cm.Data["trivy.imageRef"] = "docker.io/aquasec/trivy:0.26.0"
+		require.NoError(t, testClient.Update(context.TODO(), &cm))
+
+		_, err = controller.Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		var deployment appsv1.Deployment
+		require.NoError(t, testClient.Get(context.TODO(), serverKey, &deployment))
+		assert.Equal(t, "docker.io/aquasec/trivy:0.26.0", deployment.Spec.Template.Spec.Containers[0].Image)
+	})
+
+	t.Run("Should roll out deployment when token changes", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(managedConfig)).
+			Build()
+		controller := newController(testClient)
+
+		_, err := controller.Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		var deployment appsv1.Deployment
+		require.NoError(t, testClient.Get(context.TODO(), serverKey, &deployment))
+		checksum := deployment.Spec.Template.Annotations[trivy.AnnotationServerSecretChecksum]
+		assert.NotEmpty(t, checksum)
+
+		var secret corev1.Secret
+		require.NoError(t, testClient.Get(context.TODO(), request.NamespacedName, &secret))
+		secret.Data["trivy.serverToken"] = []byte("r0tated")
+		require.NoError(t, testClient.Update(context.TODO(), &secret))
+
+		_, err = controller.Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		require.NoError(t, testClient.Get(context.TODO(), serverKey, &deployment))
+		assert.NotEqual(t, checksum, deployment.Spec.Template.Annotations[trivy.AnnotationServerSecretChecksum])
+	})
+
+	t.Run("Should delete managed server when disabled", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(managedConfig)).
+			Build()
+		controller := newController(testClient)
+
+		_, err := controller.Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		var cm corev1.ConfigMap
+		require.NoError(t, testClient.Get(context.TODO(), request.NamespacedName, &cm))
+		cm.Data["trivy.serverManaged"] = "false"
+		require.NoError(t, testClient.Update(context.TODO(), &cm))
+
+		_, err = controller.Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.PersistentVolumeClaim{}} {
+			err = testClient.Get(context.TODO(), serverKey, obj)
+			assert.True(t, k8sapierror.IsNotFound(err), "expected %T to be deleted", obj)
+		}
+	})
+
+	t.Run("Should not delete objects unmanaged by Starboard", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newTrivyConfig(map[string]string{"trivy.mode": "ClientServer"}), &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      trivy.ManagedServerName,
+					Namespace: "starboard-system",
+				},
+			}).
+			Build()
+
+		_, err := newController(testClient).Reconcile(context.TODO(), request)
+		require.NoError(t, err)
+
+		require.NoError(t, testClient.Get(context.TODO(), serverKey, &corev1.Service{}))
+	})
+}
+
+func TestReadinessChecker_IsReady(t *testing.T) {
+	newPluginContext := func(c client.Client) starboard.PluginContext {
+		return starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-system").
+			WithClient(c).
+			Get()
+	}
+	newDeployment := func(availableReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      trivy.ManagedServerName,
+				Namespace: "starboard-system",
+			},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: availableReplicas,
+			},
+		}
+	}
+	managedConfig := map[string]string{"trivy.mode": "ClientServer", "trivy.serverManaged": "true"}
+
+	testCases := []struct {
+		name     string
+		objects  []client.Object
+		expected bool
+	}{
+		{
+			name:     "Should be ready if server is not managed",
+			objects:  []client.Object{newTrivyConfig(map[string]string{"trivy.mode": "ClientServer"})},
+			expected: true,
+		},
+		{
+			name:     "Should not be ready if deployment does not exist",
+			objects:  []client.Object{newTrivyConfig(managedConfig)},
+			expected: false,
+		},
+		{
+			name:     "Should not be ready if deployment is not available",
+			objects:  []client.Object{newTrivyConfig(managedConfig), newDeployment(0)},
+			expected: false,
+		},
+		{
+			name:     "Should be ready if deployment is available",
+			objects:  []client.Object{newTrivyConfig(managedConfig), newDeployment(1)},
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+				WithObjects(tc.objects...).
+				Build()
+
+			ready, err := trivyserver.NewReadinessChecker(testClient, newPluginContext(testClient)).IsReady(context.TODO())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ready)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package trivyserver provides primitives for running the Trivy server managed
+// by Starboard Operator, which caches the vulnerability database once for all
+// scan jobs running in the ClientServer mode.
+package trivyserver
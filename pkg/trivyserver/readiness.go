@@ -0,0 +1,49 @@
+package trivyserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	appsv1 "k8s.io/api/apps/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type readinessChecker struct {
+	client        client.Client
+	pluginContext starboard.PluginContext
+}
+
+// NewReadinessChecker constructs a vulnerabilityreport.ReadinessChecker which
+// holds back scan jobs until the managed Trivy server is available. It always
+// reports ready if the server is not managed by Starboard Operator.
+func NewReadinessChecker(client client.Client, pluginContext starboard.PluginContext) vulnerabilityreport.ReadinessChecker {
+	return &readinessChecker{
+		client:        client,
+		pluginContext: pluginContext,
+	}
+}
+
+func (c *readinessChecker) IsReady(ctx context.Context) (bool, error) {
+	pluginConfig, err := c.pluginContext.GetConfig()
+	if err != nil {
+		return false, fmt.Errorf("getting plugin config: %w", err)
+	}
+	config := trivy.Config{PluginConfig: pluginConfig}
+	if !config.IsServerManaged() {
+		return true, nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	err = c.client.Get(ctx, client.ObjectKey{Namespace: c.pluginContext.GetNamespace(), Name: trivy.ManagedServerName}, deployment)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting deployment: %w", err)
+	}
+	return deployment.Status.AvailableReplicas > 0, nil
+}
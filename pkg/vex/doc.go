@@ -0,0 +1,5 @@
+// Package vex provides primitives for reading Vulnerability Exploitability
+// eXchange (VEX) documents in the OpenVEX and CycloneDX formats, and for
+// matching their statements against vulnerabilities found in container images.
+// VEX documents are stored in ConfigMaps labeled with starboard.LabelVEX.
+package vex
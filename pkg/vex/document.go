@@ -0,0 +1,281 @@
+package vex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Status is the exploitability status of a vulnerability in a product.
+type Status string
+
+const (
+	StatusNotAffected        Status = "not_affected"
+	StatusAffected           Status = "affected"
+	StatusFixed              Status = "fixed"
+	StatusUnderInvestigation Status = "under_investigation"
+)
+
+// IsResolved returns true if vulnerabilities with this status do not pose a
+// risk, i.e. the product is not affected or the vulnerability is fixed.
+func (s Status) IsResolved() bool {
+	return s == StatusNotAffected || s == StatusFixed
+}
+
+// Statement asserts the Status of a vulnerability in products. A statement
+// without Products does not apply to any product.
+type Statement struct {
+	// VulnerabilityID is the identifier of the vulnerability, e.g. CVE-2022-1234.
+	VulnerabilityID string
+
+	// Aliases are other identifiers of the same vulnerability, e.g. GHSA IDs.
+	Aliases []string
+
+	// Products are package URLs or image references of the products, such as
+	// container images or packages, the statement applies to.
+	Products []string
+
+	// Subcomponents are package URLs of the packages of the products the
+	// statement applies to.
+	Subcomponents []string
+
+	Status Status
+
+	// Justification explains the Status, e.g. vulnerable_code_not_present.
+	Justification string
+
+	// Timestamp is the time the statement was made. When multiple statements
+	// apply to the same vulnerability, the most recent one wins.
+	Timestamp time.Time
+}
+
+// Parse returns Statements of the specified VEX document. OpenVEX and
+// CycloneDX JSON documents are supported.
+func Parse(data []byte) ([]Statement, error) {
+	var header struct {
+		Context   string `json:"@context"`
+		BOMFormat string `json:"bomFormat"`
+	}
+	err := json.Unmarshal(data, &header)
+	if err != nil {
+		return nil, fmt.Errorf("decoding vex document: %w", err)
+	}
+	switch {
+	case strings.Contains(header.Context, "openvex"):
+		return parseOpenVEX(data)
+	case header.BOMFormat == "CycloneDX":
+		return parseCycloneDX(data)
+	default:
+		return nil, errors.New("unrecognized vex document format, expected OpenVEX or CycloneDX")
+	}
+}
+
+type openVEXDocument struct {
+	Timestamp  *time.Time         `json:"timestamp"`
+	Statements []openVEXStatement `json:"statements"`
+}
+
+type openVEXStatement struct {
+	// Vulnerability is a string in OpenVEX v0.0.1 and an object since v0.2.0.
+	Vulnerability   json.RawMessage   `json:"vulnerability"`
+	Products        []json.RawMessage `json:"products"`
+	Status          Status            `json:"status"`
+	Justification   string            `json:"justification"`
+	ImpactStatement string            `json:"impact_statement"`
+	Timestamp       *time.Time        `json:"timestamp"`
+}
+
+type openVEXVulnerability struct {
+	Name    string   `json:"name"`
+	ID      string   `json:"@id"`
+	Aliases []string `json:"aliases"`
+}
+
+// openVEXProduct is an object since OpenVEX v0.2.0, which replaced strings
+// and the separate list of subcomponents of the statement.
+type openVEXProduct struct {
+	ID          string `json:"@id"`
+	Identifiers struct {
+		PURL string `json:"purl"`
+	} `json:"identifiers"`
+	Subcomponents []openVEXProduct `json:"subcomponents"`
+}
+
+func (p openVEXProduct) identifier() string {
+	if p.Identifiers.PURL != "" {
+		return p.Identifiers.PURL
+	}
+	return p.ID
+}
+
+func parseOpenVEX(data []byte) ([]Statement, error) {
+	var doc openVEXDocument
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("decoding openvex document: %w", err)
+	}
+	var statements []Statement
+	for _, s := range doc.Statements {
+		statement := Statement{
+			Status:        s.Status,
+			Justification: s.Justification,
+		}
+		if statement.Justification == "" {
+			statement.Justification = s.ImpactStatement
+		}
+		switch {
+		case s.Timestamp != nil:
+			statement.Timestamp = *s.Timestamp
+		case doc.Timestamp != nil:
+			statement.Timestamp = *doc.Timestamp
+		}
+
+		var id string
+		if err := json.Unmarshal(s.Vulnerability, &id); err == nil {
+			statement.VulnerabilityID = id
+		} else {
+			var vulnerability openVEXVulnerability
+			if err := json.Unmarshal(s.Vulnerability, &vulnerability); err != nil {
+				return nil, fmt.Errorf("decoding openvex vulnerability: %w", err)
+			}
+			statement.VulnerabilityID = vulnerability.Name
+			if statement.VulnerabilityID == "" {
+				statement.VulnerabilityID = vulnerability.ID
+			}
+			statement.Aliases = vulnerability.Aliases
+		}
+
+		for _, raw := range s.Products {
+			var product string
+			if err := json.Unmarshal(raw, &product); err == nil {
+				statement.Products = append(statement.Products, product)
+				continue
+			}
+			var p openVEXProduct
+			if err := json.Unmarshal(raw, &p); err != nil {
+				return nil, fmt.Errorf("decoding openvex product: %w", err)
+			}
+			statement.Products = append(statement.Products, p.identifier())
+			for _, subcomponent := range p.Subcomponents {
+				statement.Subcomponents = append(statement.Subcomponents, subcomponent.identifier())
+			}
+		}
+
+		if statement.VulnerabilityID == "" || statement.Status == "" {
+			return nil, errors.New("openvex statement must specify vulnerability and status")
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+type cycloneDXDocument struct {
+	Metadata struct {
+		Timestamp *time.Time          `json:"timestamp"`
+		Component *cycloneDXComponent `json:"component"`
+	} `json:"metadata"`
+	Components      []cycloneDXComponent     `json:"components"`
+	Vulnerabilities []cycloneDXVulnerability `json:"vulnerabilities"`
+}
+
+type cycloneDXComponent struct {
+	BOMRef     string               `json:"bom-ref"`
+	PURL       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXVulnerability struct {
+	ID         string `json:"id"`
+	References []struct {
+		ID string `json:"id"`
+	} `json:"references"`
+	Analysis struct {
+		State         string `json:"state"`
+		Justification string `json:"justification"`
+		Detail        string `json:"detail"`
+	} `json:"analysis"`
+	Affects []struct {
+		Ref string `json:"ref"`
+	} `json:"affects"`
+	Updated   *time.Time `json:"updated"`
+	Published *time.Time `json:"published"`
+}
+
+// cycloneDXStates maps CycloneDX impact analysis states to VEX statuses.
+var cycloneDXStates = map[string]Status{
+	"resolved":               StatusFixed,
+	"resolved_with_pedigree": StatusFixed,
+	"exploitable":            StatusAffected,
+	"in_triage":              StatusUnderInvestigation,
+	"false_positive":         StatusNotAffected,
+	"not_affected":           StatusNotAffected,
+}
+
+func parseCycloneDX(data []byte) ([]Statement, error) {
+	var doc cycloneDXDocument
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("decoding cyclonedx document: %w", err)
+	}
+
+	// Affected components are referenced by bom-ref, which is resolved to the
+	// package URL of the component if it is defined in the same document.
+	purls := make(map[string]string)
+	var index func(components []cycloneDXComponent)
+	index = func(components []cycloneDXComponent) {
+		for _, component := range components {
+			if component.BOMRef != "" && component.PURL != "" {
+				purls[component.BOMRef] = component.PURL
+			}
+			index(component.Components)
+		}
+	}
+	if doc.Metadata.Component != nil {
+		index([]cycloneDXComponent{*doc.Metadata.Component})
+	}
+	index(doc.Components)
+
+	var statements []Statement
+	for _, v := range doc.Vulnerabilities {
+		if v.Analysis.State == "" {
+			continue
+		}
+		status, ok := cycloneDXStates[v.Analysis.State]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized cyclonedx analysis state: %s", v.Analysis.State)
+		}
+		statement := Statement{
+			VulnerabilityID: v.ID,
+			Status:          status,
+			Justification:   v.Analysis.Justification,
+		}
+		if statement.Justification == "" {
+			statement.Justification = v.Analysis.Detail
+		}
+		for _, reference := range v.References {
+			statement.Aliases = append(statement.Aliases, reference.ID)
+		}
+		for _, affects := range v.Affects {
+			product := affects.Ref
+			if purl, ok := purls[product]; ok {
+				product = purl
+			}
+			statement.Products = append(statement.Products, product)
+		}
+		switch {
+		case v.Updated != nil:
+			statement.Timestamp = *v.Updated
+		case v.Published != nil:
+			statement.Timestamp = *v.Published
+		case doc.Metadata.Timestamp != nil:
+			statement.Timestamp = *doc.Metadata.Timestamp
+		}
+		if statement.VulnerabilityID == "" {
+			return nil, errors.New("cyclonedx vulnerability must specify id")
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
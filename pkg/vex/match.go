@@ -0,0 +1,190 @@
+package vex
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// Find returns the most recent Statement that applies to the specified
+// vulnerability found in the image with the given registry and artifact, or
+// nil if there is no such statement.
+func Find(statements []Statement, registry v1alpha1.Registry, artifact v1alpha1.Artifact, vulnerability v1alpha1.Vulnerability) *Statement {
+	var found *Statement
+	for i, statement := range statements {
+		if !statement.Matches(registry, artifact, vulnerability) {
+			continue
+		}
+		if found == nil || !statement.Timestamp.Before(found.Timestamp) {
+			found = &statements[i]
+		}
+	}
+	return found
+}
+
+// Matches returns true if the statement applies to the specified vulnerability
+// found in the image with the given registry and artifact, false otherwise.
+// Products of the statement are matched against the image and the vulnerable
+// package, whereas subcomponents are matched against the vulnerable package.
+// A statement without products does not match any image.
+func (s Statement) Matches(registry v1alpha1.Registry, artifact v1alpha1.Artifact, vulnerability v1alpha1.Vulnerability) bool {
+	if !s.matchesID(vulnerability.VulnerabilityID) {
+		return false
+	}
+	matches := false
+	for _, product := range s.Products {
+		if matchesImage(product, registry, artifact) || matchesPackage(product, vulnerability) {
+			matches = true
+			break
+		}
+	}
+	if !matches {
+		return false
+	}
+	if len(s.Subcomponents) > 0 {
+		for _, subcomponent := range s.Subcomponents {
+			if matchesPackage(subcomponent, vulnerability) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (s Statement) matchesID(id string) bool {
+	if strings.EqualFold(s.VulnerabilityID, id) {
+		return true
+	}
+	for _, alias := range s.Aliases {
+		if strings.EqualFold(alias, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesImage returns true if the specified product identifies the image
+// with the given registry and artifact. The product is either an OCI package
+// URL, e.g. pkg:oci/nginx@sha256:...?repository_url=index.docker.io/library/nginx,
+// or an image reference, e.g. nginx:1.16. An OCI package URL without the
+// repository URL only identifies the image by the last path segment of its
+// repository, which is ambiguous, therefore the digest must also match.
+func matchesImage(product string, registry v1alpha1.Registry, artifact v1alpha1.Artifact) bool {
+	image := artifact.Repository
+	if registry.Server != "" {
+		image = registry.Server + "/" + image
+	}
+	imageRef, err := name.ParseReference(image)
+	if err != nil {
+		return false
+	}
+
+	if purl, ok := parsePackageURL(product); ok {
+		if purl.Type != "oci" {
+			return false
+		}
+		if repositoryURL := purl.Qualifiers.Get("repository_url"); repositoryURL != "" {
+			ref, err := name.ParseReference(repositoryURL)
+			if err != nil || ref.Context().Name() != imageRef.Context().Name() {
+				return false
+			}
+		} else if purl.Version == "" || !strings.EqualFold(purl.Name, path.Base(artifact.Repository)) {
+			return false
+		}
+		if purl.Version != "" && purl.Version != artifact.Digest {
+			return false
+		}
+		if tag := purl.Qualifiers.Get("tag"); tag != "" && tag != artifact.Tag {
+			return false
+		}
+		return true
+	}
+
+	ref, err := name.ParseReference(product)
+	if err != nil || ref.Context().Name() != imageRef.Context().Name() {
+		return false
+	}
+	switch r := ref.(type) {
+	case name.Digest:
+		return r.DigestStr() == artifact.Digest
+	case name.Tag:
+		// Unlike a missing digest, a missing tag defaults to latest, hence the
+		// tag is only compared if it is specified explicitly.
+		if strings.Contains(path.Base(product), ":") {
+			return r.TagStr() == artifact.Tag
+		}
+	}
+	return true
+}
+
+// matchesPackage returns true if the specified product is a package URL of the
+// vulnerable package. The version is optional.
+func matchesPackage(product string, vulnerability v1alpha1.Vulnerability) bool {
+	purl, ok := parsePackageURL(product)
+	if !ok || purl.Type == "oci" {
+		return false
+	}
+	if vulnerability.Resource != purl.Name && vulnerability.Resource != purl.Namespace+"/"+purl.Name {
+		return false
+	}
+	return purl.Version == "" || purl.Version == vulnerability.InstalledVersion
+}
+
+type packageURL struct {
+	Type       string
+	Namespace  string
+	Name       string
+	Version    string
+	Qualifiers url.Values
+}
+
+// parsePackageURL parses the specified package URL, which has the format
+// pkg:type/namespace/name@version?qualifiers#subpath. Returns false if the
+// string is not a package URL.
+func parsePackageURL(s string) (packageURL, bool) {
+	if !strings.HasPrefix(s, "pkg:") {
+		return packageURL{}, false
+	}
+	s = strings.TrimPrefix(s, "pkg:")
+	if i := strings.Index(s, "#"); i >= 0 {
+		s = s[:i]
+	}
+
+	var purl packageURL
+	if i := strings.Index(s, "?"); i >= 0 {
+		qualifiers, err := url.ParseQuery(s[i+1:])
+		if err != nil {
+			return packageURL{}, false
+		}
+		purl.Qualifiers = qualifiers
+		s = s[:i]
+	}
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		version, err := url.PathUnescape(s[i+1:])
+		if err != nil {
+			return packageURL{}, false
+		}
+		purl.Version = version
+		s = s[:i]
+	}
+
+	segments := strings.Split(strings.Trim(s, "/"), "/")
+	if len(segments) < 2 {
+		return packageURL{}, false
+	}
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return packageURL{}, false
+		}
+		segments[i] = unescaped
+	}
+	purl.Type = strings.ToLower(segments[0])
+	purl.Name = segments[len(segments)-1]
+	purl.Namespace = strings.Join(segments[1:len(segments)-1], "/")
+	return purl, true
+}
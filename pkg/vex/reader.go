@@ -0,0 +1,84 @@
+package vex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StatementsReader is the interface that wraps the FindStatements method.
+//
+// FindStatements returns Statements of VEX documents that apply to workloads
+// in the given namespace, i.e. documents stored in that namespace and in the
+// Starboard namespace. Each key of a ConfigMap labeled with
+// starboard.LabelVEX holds one document. Invalid documents are logged and
+// skipped, so that a malformed vendor document does not fail scans. Statements
+// without products are logged and skipped too, because they would otherwise
+// apply to every image.
+type StatementsReader interface {
+	FindStatements(ctx context.Context, namespace string) ([]Statement, error)
+}
+
+type statementsReader struct {
+	logr.Logger
+	client.Client
+	starboardNamespace string
+}
+
+// NewStatementsReader constructs a new StatementsReader which is using the
+// client package provided by the controller-runtime libraries for interacting
+// with the Kubernetes API server. Documents stored in the specified Starboard
+// namespace apply to workloads in all namespaces.
+func NewStatementsReader(logger logr.Logger, c client.Client, starboardNamespace string) StatementsReader {
+	return &statementsReader{
+		Logger:             logger,
+		Client:             c,
+		starboardNamespace: starboardNamespace,
+	}
+}
+
+func (r *statementsReader) FindStatements(ctx context.Context, namespace string) ([]Statement, error) {
+	namespaces := []string{r.starboardNamespace}
+	if namespace != "" && namespace != r.starboardNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+
+	var statements []Statement
+	for _, ns := range namespaces {
+		var list corev1.ConfigMapList
+		err := r.List(ctx, &list, client.InNamespace(ns), client.MatchingLabels{
+			starboard.LabelVEX: "true",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing vex configmaps: %w", err)
+		}
+		for _, cm := range list.Items {
+			keys := make([]string, 0, len(cm.Data))
+			for key := range cm.Data {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				parsed, err := Parse([]byte(cm.Data[key]))
+				if err != nil {
+					r.Logger.Error(err, "Skipping invalid vex document", "configMap", cm.Namespace+"/"+cm.Name, "key", key)
+					continue
+				}
+				for _, statement := range parsed {
+					if len(statement.Products) == 0 {
+						r.Logger.Info("Skipping vex statement without products", "configMap", cm.Namespace+"/"+cm.Name,
+							"key", key, "vulnerabilityID", statement.VulnerabilityID)
+						continue
+					}
+					statements = append(statements, statement)
+				}
+			}
+		}
+	}
+	return statements, nil
+}
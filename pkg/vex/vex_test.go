@@ -0,0 +1,371 @@
+package vex_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vex"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const openVEXDocument = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://example.com/vex/nginx-2022-08",
+  "author": "Example Security Team",
+  "timestamp": "2022-08-01T10:00:00Z",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {
+        "name": "CVE-2022-0778",
+        "aliases": ["GHSA-x3xh-3ph6-9mjm"]
+      },
+      "products": [
+        {
+          "@id": "pkg:oci/nginx?repository_url=index.docker.io/library/nginx",
+          "subcomponents": [
+            {"@id": "pkg:deb/debian/openssl@1.1.1d-0+deb10u2"}
+          ]
+        }
+      ],
+      "status": "not_affected",
+      "justification": "vulnerable_code_not_in_execute_path"
+    },
+    {
+      "vulnerability": {"name": "CVE-2020-3810"},
+      "timestamp": "2022-08-02T10:00:00Z",
+      "status": "affected"
+    }
+  ]
+}`
+
+const openVEXDocumentV001 = `{
+  "@context": "https://openvex.dev/ns",
+  "@id": "https://example.com/vex/legacy",
+  "timestamp": "2022-07-01T10:00:00Z",
+  "statements": [
+    {
+      "vulnerability": "CVE-2020-3810",
+      "products": ["nginx:1.16"],
+      "status": "fixed",
+      "impact_statement": "Backported fix"
+    }
+  ]
+}`
+
+const cycloneDXDocument = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "version": 1,
+  "metadata": {
+    "timestamp": "2022-08-03T10:00:00Z",
+    "component": {
+      "bom-ref": "nginx",
+      "type": "container",
+      "name": "nginx",
+      "purl": "pkg:oci/nginx@sha256%3A2e87d9ff130deb0c2d63600390c3f2370e71e71841573990d54579bc35046203"
+    }
+  },
+  "vulnerabilities": [
+    {
+      "id": "CVE-2020-1967",
+      "references": [{"id": "GHSA-jq65-29v4-4x35"}],
+      "analysis": {
+        "state": "false_positive",
+        "detail": "OpenSSL is not used"
+      },
+      "affects": [{"ref": "nginx"}]
+    },
+    {
+      "id": "CVE-2019-1551",
+      "analysis": {
+        "state": "resolved"
+      },
+      "affects": [{"ref": "pkg:deb/debian/openssl"}]
+    },
+    {
+      "id": "CVE-2019-1563"
+    }
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	t.Run("Should parse OpenVEX document", func(t *testing.T) {
+		statements, err := vex.Parse([]byte(openVEXDocument))
+		require.NoError(t, err)
+		assert.Equal(t, []vex.Statement{
+			{
+				VulnerabilityID: "CVE-2022-0778",
+				Aliases:         []string{"GHSA-x3xh-3ph6-9mjm"},
+				Products:        []string{"pkg:oci/nginx?repository_url=index.docker.io/library/nginx"},
+				Subcomponents:   []string{"pkg:deb/debian/openssl@1.1.1d-0+deb10u2"},
+				Status:          vex.StatusNotAffected,
+				Justification:   "vulnerable_code_not_in_execute_path",
+				Timestamp:       time.Date(2022, time.August, 1, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				VulnerabilityID: "CVE-2020-3810",
+				Status:          vex.StatusAffected,
+				Timestamp:       time.Date(2022, time.August, 2, 10, 0, 0, 0, time.UTC),
+			},
+		}, statements)
+	})
+
+	t.Run("Should parse OpenVEX v0.0.1 document", func(t *testing.T) {
+		statements, err := vex.Parse([]byte(openVEXDocumentV001))
+		require.NoError(t, err)
+		assert.Equal(t, []vex.Statement{
+			{
+				VulnerabilityID: "CVE-2020-3810",
+				Products:        []string{"nginx:1.16"},
+				Status:          vex.StatusFixed,
+				Justification:   "Backported fix",
+				Timestamp:       time.Date(2022, time.July, 1, 10, 0, 0, 0, time.UTC),
+			},
+		}, statements)
+	})
+
+	t.Run("Should parse CycloneDX document", func(t *testing.T) {
+		statements, err := vex.Parse([]byte(cycloneDXDocument))
+		require.NoError(t, err)
+		assert.Equal(t, []vex.Statement{
+			{
+				VulnerabilityID: "CVE-2020-1967",
+				Aliases:         []string{"GHSA-jq65-29v4-4x35"},
+				Products:        []string{"pkg:oci/nginx@sha256%3A2e87d9ff130deb0c2d63600390c3f2370e71e71841573990d54579bc35046203"},
+				Status:          vex.StatusNotAffected,
+				Justification:   "OpenSSL is not used",
+				Timestamp:       time.Date(2022, time.August, 3, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				VulnerabilityID: "CVE-2019-1551",
+				Products:        []string{"pkg:deb/debian/openssl"},
+				Status:          vex.StatusFixed,
+				Timestamp:       time.Date(2022, time.August, 3, 10, 0, 0, 0, time.UTC),
+			},
+		}, statements)
+	})
+
+	t.Run("Should return error for unrecognized document", func(t *testing.T) {
+		_, err := vex.Parse([]byte(`{"spdxVersion": "SPDX-2.3"}`))
+		assert.EqualError(t, err, "unrecognized vex document format, expected OpenVEX or CycloneDX")
+	})
+}
+
+func TestStatement_Matches(t *testing.T) {
+	registry := v1alpha1.Registry{Server: "index.docker.io"}
+	artifact := v1alpha1.Artifact{
+		Repository: "library/nginx",
+		Tag:        "1.16",
+		Digest:     "sha256:2e87d9ff130deb0c2d63600390c3f2370e71e71841573990d54579bc35046203",
+	}
+	openssl := v1alpha1.Vulnerability{
+		VulnerabilityID:  "CVE-2022-0778",
+		Resource:         "openssl",
+		InstalledVersion: "1.1.1d-0+deb10u2",
+	}
+
+	testCases := []struct {
+		name      string
+		statement vex.Statement
+		expected  bool
+	}{
+		{
+			name:      "Should not match statement without products",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778"},
+			expected:  false,
+		},
+		{
+			name:      "Should match alias",
+			statement: vex.Statement{VulnerabilityID: "GHSA-x3xh-3ph6-9mjm", Aliases: []string{"cve-2022-0778"}, Products: []string{"nginx"}},
+			expected:  true,
+		},
+		{
+			name:      "Should not match other vulnerability",
+			statement: vex.Statement{VulnerabilityID: "CVE-2020-3810", Products: []string{"nginx"}},
+			expected:  false,
+		},
+		{
+			name:      "Should match image reference",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{"nginx"}},
+			expected:  true,
+		},
+		{
+			name:      "Should match image reference with tag",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{"docker.io/library/nginx:1.16"}},
+			expected:  true,
+		},
+		{
+			name:      "Should not match image reference with other tag",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{"nginx:1.17"}},
+			expected:  false,
+		},
+		{
+			name: "Should match image reference with digest",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{
+				"nginx@sha256:2e87d9ff130deb0c2d63600390c3f2370e71e71841573990d54579bc35046203",
+			}},
+			expected: true,
+		},
+		{
+			name:      "Should not match other image",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{"quay.io/nginx/nginx"}},
+			expected:  false,
+		},
+		{
+			name: "Should match OCI package URL with digest",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{
+				"pkg:oci/nginx@sha256%3A2e87d9ff130deb0c2d63600390c3f2370e71e71841573990d54579bc35046203",
+			}},
+			expected: true,
+		},
+		{
+			name: "Should not match OCI package URL without digest and repository URL",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{
+				"pkg:oci/nginx",
+			}},
+			expected: false,
+		},
+		{
+			name: "Should not match OCI package URL with other digest",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{
+				"pkg:oci/nginx@sha256%3A0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+			}},
+			expected: false,
+		},
+		{
+			name: "Should match OCI package URL with repository URL",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{
+				"pkg:oci/nginx?repository_url=index.docker.io/library/nginx",
+			}},
+			expected: true,
+		},
+		{
+			name: "Should not match OCI package URL with other repository URL",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{
+				"pkg:oci/nginx?repository_url=ghcr.io/example/nginx",
+			}},
+			expected: false,
+		},
+		{
+			name:      "Should match package URL of vulnerable package",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{"pkg:deb/debian/openssl@1.1.1d-0+deb10u2"}},
+			expected:  true,
+		},
+		{
+			name:      "Should not match package URL of other version",
+			statement: vex.Statement{VulnerabilityID: "CVE-2022-0778", Products: []string{"pkg:deb/debian/openssl@1.1.1n-0+deb10u1"}},
+			expected:  false,
+		},
+		{
+			name: "Should match image with vulnerable subcomponent",
+			statement: vex.Statement{
+				VulnerabilityID: "CVE-2022-0778",
+				Products:        []string{"nginx"},
+				Subcomponents:   []string{"pkg:deb/debian/openssl"},
+			},
+			expected: true,
+		},
+		{
+			name: "Should not match image with other subcomponent",
+			statement: vex.Statement{
+				VulnerabilityID: "CVE-2022-0778",
+				Products:        []string{"nginx"},
+				Subcomponents:   []string{"pkg:deb/debian/libssl1.1"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.statement.Matches(registry, artifact, openssl))
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	registry := v1alpha1.Registry{Server: "index.docker.io"}
+	artifact := v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"}
+	vulnerability := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-0778", Resource: "openssl"}
+
+	statements := []vex.Statement{
+		{VulnerabilityID: "CVE-2022-0778", Products: []string{"nginx"}, Status: vex.StatusUnderInvestigation, Timestamp: time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)},
+		{VulnerabilityID: "CVE-2022-0778", Products: []string{"nginx"}, Status: vex.StatusNotAffected, Timestamp: time.Date(2022, time.August, 3, 0, 0, 0, 0, time.UTC)},
+		{VulnerabilityID: "CVE-2022-0778", Products: []string{"nginx"}, Status: vex.StatusAffected, Timestamp: time.Date(2022, time.August, 2, 0, 0, 0, 0, time.UTC)},
+		{VulnerabilityID: "CVE-2020-3810", Products: []string{"nginx"}, Status: vex.StatusFixed, Timestamp: time.Date(2022, time.August, 4, 0, 0, 0, 0, time.UTC)},
+		{VulnerabilityID: "CVE-2022-0778", Status: vex.StatusAffected, Timestamp: time.Date(2022, time.August, 5, 0, 0, 0, 0, time.UTC)},
+	}
+
+	statement := vex.Find(statements, registry, artifact, vulnerability)
+	require.NotNil(t, statement)
+	assert.Equal(t, vex.StatusNotAffected, statement.Status)
+
+	assert.Nil(t, vex.Find(statements, registry, artifact, v1alpha1.Vulnerability{VulnerabilityID: "CVE-2019-1551"}))
+}
+
+func TestStatementsReader_FindStatements(t *testing.T) {
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vendor-vex",
+				Namespace: "starboard-system",
+				Labels:    map[string]string{starboard.LabelVEX: "true"},
+			},
+			Data: map[string]string{
+				"nginx.openvex.json": openVEXDocumentV001,
+				"invalid.json":       `{"spdxVersion": "SPDX-2.3"}`,
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team-vex",
+				Namespace: "default",
+				Labels:    map[string]string{starboard.LabelVEX: "true"},
+			},
+			Data: map[string]string{
+				"nginx.cdx.json":     cycloneDXDocument,
+				"nginx.openvex.json": openVEXDocument,
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "not-vex",
+				Namespace: "default",
+			},
+			Data: map[string]string{
+				"nginx.openvex.json": openVEXDocument,
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-namespace-vex",
+				Namespace: "kube-system",
+				Labels:    map[string]string{starboard.LabelVEX: "true"},
+			},
+			Data: map[string]string{
+				"nginx.openvex.json": openVEXDocument,
+			},
+		},
+	).Build()
+
+	statements, err := vex.NewStatementsReader(logr.Discard(), testClient, "starboard-system").
+		FindStatements(context.TODO(), "default")
+	require.NoError(t, err)
+
+	var ids []string
+	for _, statement := range statements {
+		ids = append(ids, statement.VulnerabilityID)
+	}
+	// The CVE-2020-3810 statement of the team document has no products and
+	// is skipped.
+	assert.Equal(t, []string{"CVE-2020-3810", "CVE-2020-1967", "CVE-2019-1551", "CVE-2022-0778"}, ids)
+}
@@ -1,6 +1,7 @@
 package vulnerabilityreport
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/docker"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +31,7 @@ type ScanJobBuilder struct {
 	tolerations       []corev1.Toleration
 	annotations       map[string]string
 	podTemplateLabels labels.Set
+	templates         []v1alpha1.ScanJobTemplate
 }
 
 func NewScanJobBuilder() *ScanJobBuilder {
@@ -70,18 +73,25 @@ func (s *ScanJobBuilder) WithPodTemplateLabels(podTemplateLabels labels.Set) *Sc
 	return s
 }
 
+// WithScanJobTemplates sets ScanJobTemplates merged into the pod template of
+// the scan job.
+func (s *ScanJobBuilder) WithScanJobTemplates(templates []v1alpha1.ScanJobTemplate) *ScanJobBuilder {
+	s.templates = templates
+	return s
+}
+
 func (s *ScanJobBuilder) WithCredentials(credentials map[string]docker.Auth) *ScanJobBuilder {
 	s.credentials = credentials
 	return s
 }
 
-func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
+func (s *ScanJobBuilder) Get(ctx context.Context) (*batchv1.Job, []*corev1.Secret, error) {
 	spec, err := kube.GetPodSpec(s.object)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	templateSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, s.object, s.credentials)
+	templateSpec, secrets, err := s.plugin.GetScanJobSpec(ctx, s.pluginContext, s.object, s.credentials)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -109,7 +119,7 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      GetScanJobName(s.object),
+			Name:      GetScanJobName(s.pluginContext, s.object),
 			Namespace: s.pluginContext.GetNamespace(),
 			Labels:    labelsSet,
 			Annotations: map[string]string{
@@ -145,6 +155,8 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		return nil, nil, err
 	}
 
+	scanjobtemplate.Apply(&job.Spec.Template, s.templates...)
+
 	return job, secrets, nil
 }
 
@@ -165,25 +177,84 @@ func (s *ScanJobBuilder) updateScanJobForWorkloadNamespace(job *batchv1.Job, pod
 	}
 }
 
-func GetScanJobName(obj client.Object) string {
-	return fmt.Sprintf("scan-vulnerabilityreport-%s", kube.ComputeHash(kube.ObjectRef{
+// GetScanJobName returns the name of the scan job that scans the given object
+// with the scanner of the given plugin context.
+func GetScanJobName(pluginContext starboard.PluginContext, obj client.Object) string {
+	return fmt.Sprintf("scan-vulnerabilityreport-%s%s", kube.ComputeHash(kube.ObjectRef{
 		Kind:      kube.Kind(obj.GetObjectKind().GroupVersionKind().Kind),
 		Namespace: obj.GetNamespace(),
 		Name:      obj.GetName(),
-	}))
+	}), scannerSuffix(pluginContext))
+}
+
+func RegistryCredentialsSecretName(pluginContext starboard.PluginContext, obj client.Object) string {
+	return fmt.Sprintf("%s-regcred", GetScanJobName(pluginContext, obj))
 }
 
-func RegistryCredentialsSecretName(obj client.Object) string {
-	return fmt.Sprintf("%s-regcred", GetScanJobName(obj))
+// scannerSuffix returns the suffix of names of scan jobs and reports generated
+// by the scanner of the given plugin context. Names are suffixed unless the
+// scanner is the primary one, so that multiple scanners can run side by side.
+func scannerSuffix(pluginContext starboard.PluginContext) string {
+	if pluginContext == nil {
+		return ""
+	}
+	scanner := pluginContext.GetName()
+	if pluginContext.GetStarboardConfig().IsPrimaryVulnerabilityReportsScanner(scanner) {
+		return ""
+	}
+	return "-" + strings.ToLower(scanner)
+}
+
+// IsGeneratedBy checks if the report with the given metadata and data was
+// generated by the specified scanner. The scanner label is preferred, but
+// reports that were created before it was introduced are matched by the
+// scanner name recorded in the report data.
+func IsGeneratedBy(meta metav1.ObjectMeta, report v1alpha1.VulnerabilityReportData, scanner string) bool {
+	if name, ok := meta.Labels[starboard.LabelVulnerabilityReportScanner]; ok {
+		return strings.EqualFold(name, scanner)
+	}
+	return strings.EqualFold(report.Scanner.Name, scanner)
+}
+
+// IsGeneratedByPrimaryScanner checks if the report with the given metadata
+// was generated by the primary vulnerability scanner. Reports without the
+// scanner label were created before several scanners could run side by
+// side, hence they are attributed to the primary one.
+func IsGeneratedByPrimaryScanner(meta metav1.ObjectMeta, config starboard.ConfigData) bool {
+	name, ok := meta.Labels[starboard.LabelVulnerabilityReportScanner]
+	if !ok {
+		return true
+	}
+	primary, err := config.GetVulnerabilityReportsScanner()
+	if err != nil {
+		return true
+	}
+	return strings.EqualFold(name, string(primary))
+}
+
+// FilterPrimaryScannerReports returns the given reports that were generated
+// by the primary vulnerability scanner.
+func FilterPrimaryScannerReports(reports []v1alpha1.VulnerabilityReport, config starboard.ConfigData) []v1alpha1.VulnerabilityReport {
+	var filtered []v1alpha1.VulnerabilityReport
+	for _, report := range reports {
+		if IsGeneratedByPrimaryScanner(report.ObjectMeta, config) {
+			filtered = append(filtered, report)
+		}
+	}
+	return filtered
 }
 
 type ReportBuilder struct {
-	scheme     *runtime.Scheme
-	controller client.Object
-	container  string
-	hash       string
-	data       v1alpha1.VulnerabilityReportData
-	reportTTL  *time.Duration
+	scheme        *runtime.Scheme
+	controller    client.Object
+	owner         client.Object
+	container     string
+	class         kube.ContainerClass
+	hash          string
+	pluginContext starboard.PluginContext
+	data          v1alpha1.VulnerabilityReportData
+	reportTTL     *time.Duration
+	annotations   map[string]string
 }
 
 func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
@@ -197,16 +268,39 @@ func (b *ReportBuilder) Controller(controller client.Object) *ReportBuilder {
 	return b
 }
 
+// Owner sets the cluster-scoped object that owns a ClusterVulnerabilityReport
+// generated for a namespaced controller, e.g. the Node of a static Pod. If not
+// set, the controller itself is the owner.
+func (b *ReportBuilder) Owner(owner client.Object) *ReportBuilder {
+	b.owner = owner
+	return b
+}
+
 func (b *ReportBuilder) Container(name string) *ReportBuilder {
 	b.container = name
 	return b
 }
 
+// ContainerClass sets the class of the container, i.e. whether it is a regular
+// container, an init container, or an ephemeral container.
+func (b *ReportBuilder) ContainerClass(class kube.ContainerClass) *ReportBuilder {
+	b.class = class
+	return b
+}
+
 func (b *ReportBuilder) PodSpecHash(hash string) *ReportBuilder {
 	b.hash = hash
 	return b
 }
 
+// PluginContext sets the context of the plugin that generated the report. The
+// report is labeled with the scanner name, which is also appended to the name
+// of the report unless the scanner is the primary one.
+func (b *ReportBuilder) PluginContext(pluginContext starboard.PluginContext) *ReportBuilder {
+	b.pluginContext = pluginContext
+	return b
+}
+
 func (b *ReportBuilder) Data(data v1alpha1.VulnerabilityReportData) *ReportBuilder {
 	b.data = data
 	return b
@@ -217,26 +311,112 @@ func (b *ReportBuilder) ReportTTL(ttl *time.Duration) *ReportBuilder {
 	return b
 }
 
+func (b *ReportBuilder) Annotations(annotations map[string]string) *ReportBuilder {
+	b.annotations = annotations
+	return b
+}
+
+func (b *ReportBuilder) reportAnnotations() map[string]string {
+	if len(b.annotations) == 0 && b.reportTTL == nil {
+		return nil
+	}
+	annotations := make(map[string]string)
+	for key, value := range b.annotations {
+		annotations[key] = value
+	}
+	if b.reportTTL != nil {
+		annotations[v1alpha1.TTLReportAnnotation] = b.reportTTL.String()
+	}
+	return annotations
+}
+
 func (b *ReportBuilder) reportName() string {
 	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
 	name := b.controller.GetName()
-	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), name, b.container)
+	suffix := scannerSuffix(b.pluginContext)
+	reportName := fmt.Sprintf("%s-%s-%s%s", strings.ToLower(kind), name, b.container, suffix)
 	if len(validation.IsValidLabelValue(reportName)) == 0 {
 		return reportName
 	}
 
-	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+b.container))
+	return fmt.Sprintf("%s-%s%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+b.container), suffix)
 }
 
-func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
+func (b *ReportBuilder) clusterReportName() string {
+	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
+	name := b.controller.GetName()
+	if namespace := b.controller.GetNamespace(); namespace != "" {
+		name = namespace + "-" + name
+	}
+	suffix := scannerSuffix(b.pluginContext)
+	reportName := fmt.Sprintf("%s-%s-%s%s", strings.ToLower(kind), name, b.container, suffix)
+	if len(validation.IsValidLabelValue(reportName)) == 0 {
+		return reportName
+	}
+
+	return fmt.Sprintf("%s-%s%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+b.container), suffix)
+}
+
+// reportLabels returns labels common to VulnerabilityReports and
+// ClusterVulnerabilityReports.
+func (b *ReportBuilder) reportLabels() map[string]string {
 	labels := map[string]string{
 		starboard.LabelContainerName: b.container,
 	}
 
+	if b.class != "" {
+		labels[starboard.LabelContainerClass] = string(b.class)
+	}
+
 	if b.hash != "" {
 		labels[starboard.LabelResourceSpecHash] = b.hash
 	}
 
+	if b.pluginContext != nil {
+		labels[starboard.LabelVulnerabilityReportScanner] = b.pluginContext.GetName()
+	}
+	return labels
+}
+
+func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterVulnerabilityReport, error) {
+	labels := b.reportLabels()
+
+	report := v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.clusterReportName(),
+			Labels:      labels,
+			Annotations: b.annotations,
+		},
+		Report: b.data,
+	}
+
+	err := kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
+	if err != nil {
+		return v1alpha1.ClusterVulnerabilityReport{}, err
+	}
+	owner := b.owner
+	if owner == nil {
+		owner = b.controller
+	}
+	err = controllerutil.SetControllerReference(owner, &report, b.scheme)
+	if err != nil {
+		return v1alpha1.ClusterVulnerabilityReport{}, fmt.Errorf("setting controller reference: %w", err)
+	}
+	// The OwnerReferencesPermissionsEnforcement admission controller protects the
+	// access to metadata.ownerReferences[x].blockOwnerDeletion of an object, so
+	// that only users with "update" permission to the finalizers subresource of the
+	// referenced owner can change it.
+	// We set metadata.ownerReferences[x].blockOwnerDeletion to false so that
+	// additional RBAC permissions are not required when the OwnerReferencesPermissionsEnforcement
+	// is enabled.
+	// See https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#ownerreferencespermissionenforcement
+	report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
+	return report, nil
+}
+
+func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
+	labels := b.reportLabels()
+
 	report := v1alpha1.VulnerabilityReport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      b.reportName(),
@@ -246,11 +426,7 @@ func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
 		Report: b.data,
 	}
 
-	if b.reportTTL != nil {
-		report.Annotations = map[string]string{
-			v1alpha1.TTLReportAnnotation: b.reportTTL.String(),
-		}
-	}
+	report.Annotations = b.reportAnnotations()
 	err := kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
 	if err != nil {
 		return v1alpha1.VulnerabilityReport{}, err
@@ -1,12 +1,14 @@
 package vulnerabilityreport_test
 
 import (
+	"context"
 	"io"
 	"testing"
 	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/onsi/gomega"
@@ -33,6 +35,7 @@ func TestReportBuilder(t *testing.T) {
 			},
 		}).
 		Container("my-container").
+		ContainerClass(kube.ContainerClassInitContainer).
 		PodSpecHash("xyz").
 		Data(v1alpha1.VulnerabilityReportData{}).
 		Get()
@@ -56,6 +59,135 @@ func TestReportBuilder(t *testing.T) {
 				starboard.LabelResourceName:      "some-owner",
 				starboard.LabelResourceNamespace: "qa",
 				starboard.LabelContainerName:     "my-container",
+				starboard.LabelContainerClass:    "InitContainer",
+				starboard.LabelResourceSpecHash:  "xyz",
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{},
+	}))
+}
+
+func TestReportBuilder_PluginContext(t *testing.T) {
+	config := starboard.ConfigData{"vulnerabilityReports.scanner": "Trivy,Grype"}
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ReplicaSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-owner",
+			Namespace: "qa",
+		},
+	}
+
+	testCases := []struct {
+		scanner      string
+		expectedName string
+	}{
+		{
+			scanner:      "Trivy",
+			expectedName: "replicaset-some-owner-my-container",
+		},
+		{
+			scanner:      "Grype",
+			expectedName: "replicaset-some-owner-my-container-grype",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scanner, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			pluginContext := starboard.NewPluginContext().
+				WithName(tc.scanner).
+				WithStarboardConfig(config).
+				Get()
+			report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+				Controller(replicaSet).
+				Container("my-container").
+				PodSpecHash("xyz").
+				PluginContext(pluginContext).
+				Data(v1alpha1.VulnerabilityReportData{}).
+				Get()
+
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(report.Name).To(gomega.Equal(tc.expectedName))
+			g.Expect(report.Labels).To(gomega.HaveKeyWithValue(starboard.LabelVulnerabilityReportScanner, tc.scanner))
+		})
+	}
+}
+
+func TestGetScanJobName(t *testing.T) {
+	config := starboard.ConfigData{"vulnerabilityReports.scanner": "Trivy,Grype"}
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+		},
+	}
+	g := gomega.NewGomegaWithT(t)
+
+	trivyName := vulnerabilityreport.GetScanJobName(starboard.NewPluginContext().
+		WithName("Trivy").
+		WithStarboardConfig(config).
+		Get(), pod)
+	grypeName := vulnerabilityreport.GetScanJobName(starboard.NewPluginContext().
+		WithName("Grype").
+		WithStarboardConfig(config).
+		Get(), pod)
+
+	g.Expect(trivyName).To(gomega.HavePrefix("scan-vulnerabilityreport-"))
+	g.Expect(grypeName).To(gomega.Equal(trivyName + "-grype"))
+}
+
+func TestReportBuilder_GetClusterReport(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&corev1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pod",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-master",
+				Namespace: "kube-system",
+			},
+		}).
+		Owner(&corev1.Node{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Node",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "master",
+			},
+		}).
+		Container("etcd").
+		PodSpecHash("xyz").
+		Data(v1alpha1.VulnerabilityReportData{}).
+		GetClusterReport()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report).To(gomega.Equal(v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod-kube-system-etcd-master-etcd",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "v1",
+					Kind:               "Node",
+					Name:               "master",
+					Controller:         pointer.BoolPtr(true),
+					BlockOwnerDeletion: pointer.BoolPtr(false),
+				},
+			},
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "Pod",
+				starboard.LabelResourceName:      "etcd-master",
+				starboard.LabelResourceNamespace: "kube-system",
+				starboard.LabelContainerName:     "etcd",
 				starboard.LabelResourceSpecHash:  "xyz",
 			},
 		},
@@ -97,7 +229,7 @@ func TestScanJobBuilder(t *testing.T) {
 					Selector: &metav1.LabelSelector{},
 				},
 			}).
-			Get()
+			Get(context.TODO())
 		g.Expect(err).ToNot(gomega.HaveOccurred())
 		g.Expect(job).ToNot(gomega.BeNil())
 		g.Expect(job).To(gomega.Equal(&batchv1.Job{
@@ -173,7 +305,7 @@ func TestScanJobBuilder(t *testing.T) {
 					Selector: &metav1.LabelSelector{},
 				},
 			}).
-			Get()
+			Get(context.TODO())
 		g.Expect(err).ToNot(gomega.HaveOccurred())
 		g.Expect(job).ToNot(gomega.BeNil())
 		g.Expect(job).To(gomega.Equal(&batchv1.Job{
@@ -221,7 +353,7 @@ func (p *testPlugin) Init(_ starboard.PluginContext) error {
 	return nil
 }
 
-func (p *testPlugin) GetScanJobSpec(_ starboard.PluginContext, _ client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+func (p *testPlugin) GetScanJobSpec(_ context.Context, _ starboard.PluginContext, _ client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
 	return corev1.PodSpec{}, nil, nil
 }
 
@@ -0,0 +1,187 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReportCache is the interface that wraps methods for caching scan results
+// of container images by image digest.
+//
+// GetByDigest returns the v1alpha1.ClusterVulnerabilityReport which caches
+// scan results for the given image digest, or nil if there is no such report
+// or the cached report is stale.
+//
+// PutByDigest creates or updates the v1alpha1.ClusterVulnerabilityReport
+// which caches scan results for the given image digest.
+type ReportCache interface {
+	GetByDigest(ctx context.Context, digest string) (*v1alpha1.ClusterVulnerabilityReport, error)
+	PutByDigest(ctx context.Context, digest string, data v1alpha1.VulnerabilityReportData) error
+}
+
+type reportCache struct {
+	client.Client
+	ext.Clock
+	pluginContext starboard.PluginContext
+	ttl           time.Duration
+}
+
+// NewReportCache constructs a new ReportCache which stores scan results of the
+// scanner of the given plugin context as instances of
+// v1alpha1.ClusterVulnerabilityReport named after a hash of the image digest.
+// Cached reports older than the specified TTL are considered stale.
+func NewReportCache(c client.Client, clock ext.Clock, pluginContext starboard.PluginContext, ttl time.Duration) ReportCache {
+	return &reportCache{
+		Client:        c,
+		Clock:         clock,
+		pluginContext: pluginContext,
+		ttl:           ttl,
+	}
+}
+
+// GetCachedReportName returns the name of the v1alpha1.ClusterVulnerabilityReport
+// which caches scan results of the scanner of the given plugin context for the
+// given image digest.
+func GetCachedReportName(pluginContext starboard.PluginContext, digest string) string {
+	return kube.ComputeHash(digest) + scannerSuffix(pluginContext)
+}
+
+// IsCachedReport returns true if the v1alpha1.ClusterVulnerabilityReport with
+// the given metadata caches scan results of a container image rather than
+// describes a workload, i.e. a static Pod. Reports cached before the
+// starboard.LabelVulnerabilityReportCache label was introduced are recognized
+// by the image digest annotation.
+func IsCachedReport(meta metav1.ObjectMeta) bool {
+	if meta.Labels[starboard.LabelVulnerabilityReportCache] == "true" {
+		return true
+	}
+	_, hasDigest := meta.Annotations[v1alpha1.ImageDigestAnnotation]
+	_, hasOwner := meta.Labels[starboard.LabelResourceKind]
+	return hasDigest && !hasOwner
+}
+
+func (c *reportCache) GetByDigest(ctx context.Context, digest string) (*v1alpha1.ClusterVulnerabilityReport, error) {
+	var report v1alpha1.ClusterVulnerabilityReport
+	err := c.Get(ctx, types.NamespacedName{Name: GetCachedReportName(c.pluginContext, digest)}, &report)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// Guard against hash collisions and reports which are not cache entries.
+	if report.Annotations[v1alpha1.ImageDigestAnnotation] != digest {
+		return nil, nil
+	}
+	// Reports cached before scanners were labeled belong to the primary scanner.
+	if scanner, ok := report.Labels[starboard.LabelVulnerabilityReportScanner]; ok && scanner != c.pluginContext.GetName() {
+		return nil, nil
+	}
+	if c.Now().Sub(report.Report.UpdateTimestamp.Time) > c.ttl {
+		return nil, nil
+	}
+	return &report, nil
+}
+
+func (c *reportCache) PutByDigest(ctx context.Context, digest string, data v1alpha1.VulnerabilityReportData) error {
+	report := v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: GetCachedReportName(c.pluginContext, digest),
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy:            starboard.AppStarboard,
+				starboard.LabelVulnerabilityReportScanner: c.pluginContext.GetName(),
+				starboard.LabelVulnerabilityReportCache:   "true",
+			},
+			Annotations: map[string]string{
+				v1alpha1.ImageDigestAnnotation: digest,
+				v1alpha1.TTLReportAnnotation:   c.ttl.String(),
+			},
+		},
+		Report: data,
+	}
+
+	var existing v1alpha1.ClusterVulnerabilityReport
+	err := c.Get(ctx, types.NamespacedName{Name: report.Name}, &existing)
+
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = report.Labels
+		copied.Annotations = report.Annotations
+		copied.Report = report.Report
+
+		return c.Update(ctx, copied)
+	}
+
+	if errors.IsNotFound(err) {
+		return c.Create(ctx, &report)
+	}
+
+	return err
+}
+
+// CopyCachedReportData returns a copy of the specified scan results with the
+// Registry and Artifact set to match the given image reference, which might
+// differ from the image reference that was originally scanned, but still
+// resolves to the same image digest.
+func CopyCachedReportData(data v1alpha1.VulnerabilityReportData, imageRef, digest string) (v1alpha1.VulnerabilityReportData, error) {
+	copied := *data.DeepCopy()
+
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	copied.Registry = v1alpha1.Registry{
+		Server: ref.Context().RegistryStr(),
+	}
+	copied.Artifact = v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+		Digest:     digest,
+		MimeType:   data.Artifact.MimeType,
+	}
+	if tag, ok := ref.(name.Tag); ok {
+		copied.Artifact.Tag = tag.TagStr()
+	}
+	return copied, nil
+}
+
+// CopySBOMReportData returns a copy of the specified SBOM with the Registry,
+// Artifact, and the container image component set to match the given image
+// reference, which might differ from the image reference that was originally
+// scanned, but still resolves to the same image digest.
+func CopySBOMReportData(data v1alpha1.SBOMReportData, imageRef, digest string) (v1alpha1.SBOMReportData, error) {
+	copied := *data.DeepCopy()
+
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+	copied.Registry = v1alpha1.Registry{
+		Server: ref.Context().RegistryStr(),
+	}
+	copied.Artifact = v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+		Digest:     digest,
+		MimeType:   data.Artifact.MimeType,
+	}
+	imageVersion := digest
+	if tag, ok := ref.(name.Tag); ok {
+		copied.Artifact.Tag = tag.TagStr()
+		imageVersion = tag.TagStr()
+	}
+	if metadata := copied.Components.Metadata; metadata != nil && metadata.Component != nil {
+		metadata.Component.BOMRef = imageRef
+		metadata.Component.Name = copied.Registry.Server + "/" + copied.Artifact.Repository
+		metadata.Component.Version = imageVersion
+	}
+	return copied, nil
+}
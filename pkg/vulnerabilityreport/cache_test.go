@@ -0,0 +1,270 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReportCache(t *testing.T) {
+	const digest = "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+
+	kubernetesScheme := starboard.NewScheme()
+	now := time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC)
+	config := starboard.ConfigData{
+		"vulnerabilityReports.scanner": "Trivy,Grype",
+	}
+	trivyContext := starboard.NewPluginContext().WithName("Trivy").WithStarboardConfig(config).Get()
+	grypeContext := starboard.NewPluginContext().WithName("Grype").WithStarboardConfig(config).Get()
+
+	t.Run("Should cache report data by image digest", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		cache := vulnerabilityreport.NewReportCache(testClient, ext.NewFixedClock(now), trivyContext, 24*time.Hour)
+
+		err := cache.PutByDigest(context.TODO(), digest, v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		})
+		require.NoError(t, err)
+
+		var found v1alpha1.ClusterVulnerabilityReport
+		err = testClient.Get(context.TODO(), types.NamespacedName{
+			Name: vulnerabilityreport.GetCachedReportName(trivyContext, digest),
+		}, &found)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			v1alpha1.ImageDigestAnnotation: digest,
+			v1alpha1.TTLReportAnnotation:   "24h0m0s",
+		}, found.Annotations)
+		assert.Equal(t, "Trivy", found.Labels[starboard.LabelVulnerabilityReportScanner])
+		assert.True(t, vulnerabilityreport.IsCachedReport(found.ObjectMeta))
+
+		cached, err := cache.GetByDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		require.NotNil(t, cached)
+		assert.Equal(t, vulnerabilityreport.GetCachedReportName(trivyContext, digest), cached.Name)
+	})
+
+	t.Run("Should return nil when report data is not cached", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		cache := vulnerabilityreport.NewReportCache(testClient, ext.NewFixedClock(now), trivyContext, 24*time.Hour)
+
+		cached, err := cache.GetByDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+	})
+
+	t.Run("Should return nil when cached report data is stale", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: vulnerabilityreport.GetCachedReportName(trivyContext, digest),
+				Annotations: map[string]string{
+					v1alpha1.ImageDigestAnnotation: digest,
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(now.Add(-25 * time.Hour)),
+			},
+		}).Build()
+		cache := vulnerabilityreport.NewReportCache(testClient, ext.NewFixedClock(now), trivyContext, 24*time.Hour)
+
+		cached, err := cache.GetByDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+	})
+
+	t.Run("Should cache report data of each scanner separately", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		trivyCache := vulnerabilityreport.NewReportCache(testClient, ext.NewFixedClock(now), trivyContext, 24*time.Hour)
+		grypeCache := vulnerabilityreport.NewReportCache(testClient, ext.NewFixedClock(now), grypeContext, 24*time.Hour)
+
+		err := trivyCache.PutByDigest(context.TODO(), digest, v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(now),
+		})
+		require.NoError(t, err)
+
+		cached, err := grypeCache.GetByDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+
+		err = grypeCache.PutByDigest(context.TODO(), digest, v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(now),
+		})
+		require.NoError(t, err)
+
+		cached, err = grypeCache.GetByDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		require.NotNil(t, cached)
+		assert.Equal(t, vulnerabilityreport.GetCachedReportName(trivyContext, digest)+"-grype", cached.Name)
+	})
+
+	t.Run("Should return nil when cached report belongs to another scanner", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: vulnerabilityreport.GetCachedReportName(trivyContext, digest),
+				Labels: map[string]string{
+					starboard.LabelVulnerabilityReportScanner: "Aqua",
+				},
+				Annotations: map[string]string{
+					v1alpha1.ImageDigestAnnotation: digest,
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(now),
+			},
+		}).Build()
+		cache := vulnerabilityreport.NewReportCache(testClient, ext.NewFixedClock(now), trivyContext, 24*time.Hour)
+
+		cached, err := cache.GetByDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+	})
+
+	t.Run("Should return nil when cached report has different digest", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: vulnerabilityreport.GetCachedReportName(trivyContext, digest),
+				Annotations: map[string]string{
+					v1alpha1.ImageDigestAnnotation: "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(now),
+			},
+		}).Build()
+		cache := vulnerabilityreport.NewReportCache(testClient, ext.NewFixedClock(now), trivyContext, 24*time.Hour)
+
+		cached, err := cache.GetByDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+	})
+}
+
+func TestCopyCachedReportData(t *testing.T) {
+	const digest = "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+
+	data, err := vulnerabilityreport.CopyCachedReportData(v1alpha1.VulnerabilityReportData{
+		Scanner: v1alpha1.Scanner{Name: "Trivy"},
+		Registry: v1alpha1.Registry{
+			Server: "index.docker.io",
+		},
+		Artifact: v1alpha1.Artifact{
+			Repository: "library/nginx",
+			Tag:        "1.16",
+		},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2020-1967"},
+		},
+	}, "quay.io/mirror/nginx:stable", digest)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.VulnerabilityReportData{
+		Scanner: v1alpha1.Scanner{Name: "Trivy"},
+		Registry: v1alpha1.Registry{
+			Server: "quay.io",
+		},
+		Artifact: v1alpha1.Artifact{
+			Repository: "mirror/nginx",
+			Tag:        "stable",
+			Digest:     digest,
+		},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2020-1967"},
+		},
+	}, data)
+}
+
+func TestIsCachedReport(t *testing.T) {
+	testCases := []struct {
+		name     string
+		meta     metav1.ObjectMeta
+		expected bool
+	}{
+		{
+			name: "Should return true for labeled cache entry",
+			meta: metav1.ObjectMeta{
+				Labels: map[string]string{starboard.LabelVulnerabilityReportCache: "true"},
+			},
+			expected: true,
+		},
+		{
+			name: "Should return true for cache entry without label",
+			meta: metav1.ObjectMeta{
+				Annotations: map[string]string{v1alpha1.ImageDigestAnnotation: "sha256:0d17b565"},
+			},
+			expected: true,
+		},
+		{
+			name: "Should return false for report of static Pod",
+			meta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Pod",
+					starboard.LabelResourceName:      "etcd-master",
+					starboard.LabelResourceNamespace: "kube-system",
+				},
+			},
+			expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, vulnerabilityreport.IsCachedReport(tc.meta))
+		})
+	}
+}
+
+func TestCopySBOMReportData(t *testing.T) {
+	const digest = "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+
+	data, err := vulnerabilityreport.CopySBOMReportData(v1alpha1.SBOMReportData{
+		Scanner: v1alpha1.Scanner{Name: "Trivy"},
+		Registry: v1alpha1.Registry{
+			Server: "index.docker.io",
+		},
+		Artifact: v1alpha1.Artifact{
+			Repository: "library/nginx",
+			Tag:        "1.16",
+			Digest:     digest,
+		},
+		Components: v1alpha1.BOM{
+			Metadata: &v1alpha1.BOMMetadata{
+				Component: &v1alpha1.Component{
+					BOMRef:  "nginx:1.16",
+					Type:    v1alpha1.ComponentTypeContainer,
+					Name:    "index.docker.io/library/nginx",
+					Version: "1.16",
+				},
+			},
+		},
+	}, "quay.io/mirror/nginx:stable", digest)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.SBOMReportData{
+		Scanner: v1alpha1.Scanner{Name: "Trivy"},
+		Registry: v1alpha1.Registry{
+			Server: "quay.io",
+		},
+		Artifact: v1alpha1.Artifact{
+			Repository: "mirror/nginx",
+			Tag:        "stable",
+			Digest:     digest,
+		},
+		Components: v1alpha1.BOM{
+			Metadata: &v1alpha1.BOMMetadata{
+				Component: &v1alpha1.Component{
+					BOMRef:  "quay.io/mirror/nginx:stable",
+					Type:    v1alpha1.ComponentTypeContainer,
+					Name:    "quay.io/mirror/nginx",
+					Version: "stable",
+				},
+			},
+		},
+	}, data)
+}
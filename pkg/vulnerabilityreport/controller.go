@@ -7,12 +7,21 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exploitability"
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/metrics"
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/scanfailure"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/utils"
+	"github.com/aquasecurity/starboard/pkg/vex"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -23,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -30,17 +40,29 @@ import (
 // WorkloadController watches Kubernetes workloads and generates
 // v1alpha1.VulnerabilityReport instances using vulnerability scanner that that
 // implements the Plugin interface.
+//
+// Multiple scanners run side by side with one WorkloadController per scanner.
+// Each controller only considers scan jobs and reports of its own scanner.
 type WorkloadController struct {
 	logr.Logger
 	etc.Config
+	ext.Clock
 	client.Client
 	kube.ObjectResolver
-	controller.LimitChecker
+	controller.ScanQueue
 	kube.LogsReader
 	kube.SecretsReader
 	Plugin
 	starboard.PluginContext
 	ReadWriter
+	SBOMReadWriter sbomreport.ReadWriter
+	ReportCache
+	ExceptionsReader
+	vex.StatementsReader
+	scanfailure.Recorder
+	exploitability.Enricher
+	ReadinessChecker
+	ScanJobTemplates scanjobtemplate.Reader
 	starboard.ConfigData
 }
 
@@ -72,6 +94,8 @@ func (r *WorkloadController) SetupWithManager(mgr ctrl.Manager) error {
 				installModePredicate,
 			)).
 			Owns(workload.ownsObject).
+			Owns(&v1alpha1.ScanFailure{}).
+			Watches(r.ScanQueue.Source(r.scanQueueConsumer(workload.kind)), &handler.EnqueueRequestForObject{}).
 			Complete(r.reconcileWorkload(workload.kind))
 		if err != nil {
 			return err
@@ -81,16 +105,30 @@ func (r *WorkloadController) SetupWithManager(mgr ctrl.Manager) error {
 	if !r.ConfigData.VulnerabilityScanJobsInSameNamespace() {
 		predicates = append(predicates, InNamespace(r.Config.Namespace))
 	}
-	predicates = append(predicates, ManagedByStarboardOperator, IsVulnerabilityReportScan, JobHasAnyCondition)
+	predicates = append(predicates, ManagedByStarboardOperator, IsVulnerabilityReportScanBy(r.PluginContext.GetName()), JobHasAnyCondition)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&batchv1.Job{}, builder.WithPredicates(predicates...)).
 		Complete(r.reconcileJobs())
 }
 
 func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile.Func {
-	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return func(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 		log := r.Logger.WithValues("kind", workloadKind, "name", req.NamespacedName)
 
+		// The workload might have been queued or admitted by a previous
+		// reconciliation, therefore it's forgotten by the scan queue unless it's
+		// admitted again below. Otherwise, it would hold a reserved slot or its
+		// position in the queue, e.g. after an old ReplicaSet has been scaled down.
+		admitting := false
+		defer func() {
+			if admitting {
+				return
+			}
+			if forgetErr := r.ScanQueue.Forget(ctx, r.scanQueueConsumer(workloadKind), req.NamespacedName); forgetErr != nil && err == nil {
+				err = forgetErr
+			}
+		}()
+
 		workloadRef := kube.ObjectRefFromKindAndObjectKey(workloadKind, req.NamespacedName)
 
 		log.V(1).Info("Getting workload from cache")
@@ -143,15 +181,25 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 
 		log = log.WithValues("podSpecHash", hash)
 
-		// Check if containers of the Pod have corresponding VulnerabilityReports.
-		hasReports, err := r.hasReports(ctx, workloadRef, hash, containerImages)
+		// Check if containers of the Pod have corresponding VulnerabilityReports,
+		// or ClusterVulnerabilityReports in case of static Pods.
+		hasReports, updateTimestamp, err := r.hasReports(ctx, workloadRef, kube.IsStaticPod(workloadObj), hash, containerImages)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("getting vulnerability reports: %w", err)
 		}
 
 		if hasReports {
-			log.V(1).Info("VulnerabilityReports already exist")
-			return ctrl.Result{}, nil
+			rescanDue, durationToRescan := r.isRescanDue(updateTimestamp)
+			if !rescanDue {
+				log.V(1).Info("VulnerabilityReports already exist")
+				if r.Config.VulnerabilityScannerRescanInterval != nil {
+					log.V(1).Info("RequeueAfter", "durationToRescan", durationToRescan)
+					return ctrl.Result{RequeueAfter: durationToRescan}, nil
+				}
+				return ctrl.Result{}, nil
+			}
+			log.V(1).Info("Rescanning VulnerabilityReports older than rescan interval",
+				"updateTimestamp", updateTimestamp, "rescanInterval", r.Config.VulnerabilityScannerRescanInterval)
 		}
 
 		_, job, err := r.hasActiveScanJob(ctx, workloadRef, hash)
@@ -165,33 +213,104 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 			return ctrl.Result{}, nil
 		}
 
-		limitExceeded, scanJobsCount, err := r.LimitChecker.Check(ctx)
+		if r.Config.VulnerabilityScannerCacheTTL != nil {
+			copied, err := r.copyCachedReports(ctx, workloadObj, hash, containerImages)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("copying cached vulnerability reports: %w", err)
+			}
+			if copied {
+				log.V(1).Info("Copied VulnerabilityReports from cache")
+				return ctrl.Result{}, nil
+			}
+		}
+
+		retryAfter, err := r.RetryAfter(ctx, workloadObj, v1alpha1.VulnerabilityReportKind, r.PluginContext.GetName(), hash)
 		if err != nil {
-			return ctrl.Result{}, err
+			return ctrl.Result{}, fmt.Errorf("checking scan failures: %w", err)
+		}
+		if retryAfter > 0 {
+			log.V(1).Info("Backing off failed scan", "retryAfter", retryAfter)
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
 		}
-		log.V(1).Info("Checking scan jobs limit", "count", scanJobsCount, "limit", r.ConcurrentScanJobsLimit)
 
-		if limitExceeded {
-			log.V(1).Info("Pushing back scan job", "count", scanJobsCount, "retryAfter", r.ScanJobRetryAfter)
-			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		if r.ReadinessChecker != nil {
+			ready, err := r.ReadinessChecker.IsReady(ctx)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("checking scanner readiness: %w", err)
+			}
+			if !ready {
+				log.V(1).Info("Pushing back scan job until scanner is ready", "retryAfter", r.ScanJobRetryAfter)
+				return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+			}
+		}
+
+		scanned := hasReports
+		if !scanned {
+			scanned, err = r.isScanned(ctx, workloadRef, kube.IsStaticPod(workloadObj))
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting vulnerability reports: %w", err)
+			}
+		}
+
+		admitting = true
+		admitted, err := r.ScanQueue.Admit(ctx, controller.ScanRequest{
+			Consumer:     r.scanQueueConsumer(workloadKind),
+			Kind:         workloadKind,
+			Object:       workloadObj,
+			NeverScanned: !scanned,
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("admitting scan job: %w", err)
+		}
+		if !admitted {
+			log.V(1).Info("Queueing scan job")
+			return ctrl.Result{}, nil
 		}
 
 		return ctrl.Result{}, r.submitScanJob(ctx, workloadObj)
 	}
 }
 
-func (r *WorkloadController) hasReports(ctx context.Context, owner kube.ObjectRef, hash string, images kube.ContainerImages) (bool, error) {
-	// TODO FindByOwner should accept optional label selector to further narrow down search results
-	list, err := r.FindByOwner(ctx, owner)
-	if err != nil {
-		return false, err
+// hasReports checks if all containers of the given owner have corresponding
+// reports generated by the scanner of this controller for the pod spec with
+// the specified hash. It also returns the update timestamp of the least
+// recently updated report.
+func (r *WorkloadController) hasReports(ctx context.Context, owner kube.ObjectRef, clusterScoped bool, hash string, images kube.ContainerImages) (bool, time.Time, error) {
+	var reportsMeta []metav1.ObjectMeta
+	var reportsData []v1alpha1.VulnerabilityReportData
+	if clusterScoped {
+		list, err := r.FindClusterByOwner(ctx, owner)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		for _, report := range list {
+			reportsMeta = append(reportsMeta, report.ObjectMeta)
+			reportsData = append(reportsData, report.Report)
+		}
+	} else {
+		// TODO FindByOwner should accept optional label selector to further narrow down search results
+		list, err := r.FindByOwner(ctx, owner)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		for _, report := range list {
+			reportsMeta = append(reportsMeta, report.ObjectMeta)
+			reportsData = append(reportsData, report.Report)
+		}
 	}
 
+	var updateTimestamp time.Time
 	actual := map[string]bool{}
-	for _, report := range list {
-		if containerName, ok := report.Labels[starboard.LabelContainerName]; ok {
-			if hash == report.Labels[starboard.LabelResourceSpecHash] {
+	for i, meta := range reportsMeta {
+		if !r.isScannedBy(meta) {
+			continue
+		}
+		if containerName, ok := meta.Labels[starboard.LabelContainerName]; ok {
+			if hash == meta.Labels[starboard.LabelResourceSpecHash] {
 				actual[containerName] = true
+				if updateTimestamp.IsZero() || reportsData[i].UpdateTimestamp.Time.Before(updateTimestamp) {
+					updateTimestamp = reportsData[i].UpdateTimestamp.Time
+				}
 			}
 		}
 	}
@@ -201,11 +320,66 @@ func (r *WorkloadController) hasReports(ctx context.Context, owner kube.ObjectRe
 		expected[containerName] = true
 	}
 
-	return reflect.DeepEqual(actual, expected), nil
+	return reflect.DeepEqual(actual, expected), updateTimestamp, nil
+}
+
+// isScanned checks if the given owner has any reports generated by the
+// scanner of this controller, regardless of the pod spec they were generated
+// for.
+func (r *WorkloadController) isScanned(ctx context.Context, owner kube.ObjectRef, clusterScoped bool) (bool, error) {
+	if clusterScoped {
+		list, err := r.FindClusterByOwner(ctx, owner)
+		if err != nil {
+			return false, err
+		}
+		for _, report := range list {
+			if r.isScannedBy(report.ObjectMeta) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	list, err := r.FindByOwner(ctx, owner)
+	if err != nil {
+		return false, err
+	}
+	for _, report := range list {
+		if r.isScannedBy(report.ObjectMeta) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// scanQueueConsumer returns the name of the ScanQueue source of the controller
+// of the given workload kind.
+func (r *WorkloadController) scanQueueConsumer(kind kube.Kind) string {
+	return controller.VulnerabilityReportScanQueueConsumer(r.PluginContext.GetName(), kind)
+}
+
+// isScannedBy checks if the report with the given metadata was generated by
+// the scanner of this controller. Reports generated before reports were
+// labeled with scanner names belong to the primary scanner.
+func (r *WorkloadController) isScannedBy(meta metav1.ObjectMeta) bool {
+	scanner, ok := meta.Labels[starboard.LabelVulnerabilityReportScanner]
+	if !ok {
+		return r.ConfigData.IsPrimaryVulnerabilityReportsScanner(r.PluginContext.GetName())
+	}
+	return scanner == r.PluginContext.GetName()
+}
+
+// isRescanDue checks if reports updated at the specified time are older than
+// the configured rescan interval. It also returns the duration until the next
+// rescan is due. If periodic rescans are disabled it always returns false.
+func (r *WorkloadController) isRescanDue(updateTimestamp time.Time) (bool, time.Duration) {
+	if r.Config.VulnerabilityScannerRescanInterval == nil {
+		return false, 0
+	}
+	return utils.IsTTLExpired(*r.Config.VulnerabilityScannerRescanInterval, updateTimestamp, r.Clock)
 }
 
 func (r *WorkloadController) hasActiveScanJob(ctx context.Context, owner kube.ObjectRef, hash string) (bool, *batchv1.Job, error) {
-	jobName := fmt.Sprintf("scan-vulnerabilityreport-%s", kube.ComputeHash(owner))
+	jobName := fmt.Sprintf("scan-vulnerabilityreport-%s%s", kube.ComputeHash(owner), scannerSuffix(r.PluginContext))
 	job := &batchv1.Job{}
 	err := r.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: jobName}, job)
 	if err != nil {
@@ -243,6 +417,11 @@ func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Obj
 		return fmt.Errorf("getting scan job template labels: %w", err)
 	}
 
+	scanJobTemplates, err := r.ScanJobTemplates.FindTemplates(ctx, r.PluginContext.GetName(), owner.GetNamespace())
+	if err != nil {
+		return fmt.Errorf("getting scan job templates: %w", err)
+	}
+
 	scanJob, secrets, err := NewScanJobBuilder().
 		WithPlugin(r.Plugin).
 		WithPluginContext(r.PluginContext).
@@ -251,8 +430,9 @@ func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Obj
 		WithTolerations(scanJobTolerations).
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
+		WithScanJobTemplates(scanJobTemplates).
 		WithCredentials(credentials).
-		Get()
+		Get(ctx)
 
 	if err != nil {
 		if errors.Is(err, kube.ErrReplicaSetNotFound) || errors.Is(err, kube.ErrNoRunningPods) ||
@@ -323,7 +503,6 @@ func (r *WorkloadController) reconcileJobs() reconcile.Func {
 		default:
 			err = fmt.Errorf("unrecognized scan job condition: %v", jobCondition)
 		}
-
 		return ctrl.Result{}, err
 	}
 
@@ -356,18 +535,26 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 		return fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
 	}
 
-	hasReports, err := r.hasReports(ctx, ownerRef, podSpecHash, containerImages)
+	hasReports, updateTimestamp, err := r.hasReports(ctx, ownerRef, kube.IsStaticPod(owner), podSpecHash, containerImages)
 	if err != nil {
 		return err
 	}
 
-	if hasReports {
+	// Reports older than the rescan interval are replaced with the results of
+	// this scan job.
+	if rescanDue, _ := r.isRescanDue(updateTimestamp); hasReports && !rescanDue {
 		log.V(1).Info("VulnerabilityReports already exist", "owner", owner)
 		log.V(1).Info("Deleting complete scan job", "owner", owner)
 		return r.deleteJob(ctx, job)
 	}
 
-	var vulnerabilityReports []v1alpha1.VulnerabilityReport
+	sbomPlugin, err := GetSBOMPlugin(r.PluginContext, r.Plugin)
+	if err != nil {
+		return err
+	}
+
+	reportsData := make(map[string]v1alpha1.VulnerabilityReportData)
+	sbomReportsData := make(map[string]v1alpha1.SBOMReportData)
 
 	for containerName, containerImage := range containerImages {
 		logsStream, err := r.LogsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
@@ -388,12 +575,131 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 		}
 		_ = logsStream.Close()
 
+		reportsData[containerName] = reportData
+
+		if sbomPlugin == nil {
+			continue
+		}
+
+		logsStream, err = r.LogsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
+		if err != nil {
+			return fmt.Errorf("getting logs for pod %q: %w", job.Namespace+"/"+job.Name, err)
+		}
+		sbomReportData, err := sbomPlugin.ParseSBOMReportData(r.PluginContext, containerImage, logsStream)
+		_ = logsStream.Close()
+		if errors.Is(err, ErrSBOMNotGenerated) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		sbomReportsData[containerName] = sbomReportData
+	}
+
+	if r.Config.VulnerabilityScannerCacheTTL != nil {
+		err = r.cacheReports(ctx, reportsData)
+		if err != nil {
+			return fmt.Errorf("caching vulnerability reports: %w", err)
+		}
+	}
+
+	err = r.writeReports(ctx, owner, podSpecHash, reportsData, nil)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Report owner must have been deleted", "owner", owner)
+			return r.deleteJob(ctx, job)
+		}
+		return err
+	}
+
+	err = r.writeSBOMReports(ctx, owner, podSpecHash, sbomReportsData)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			log.V(1).Info("Report owner must have been deleted", "owner", owner)
+			return r.deleteJob(ctx, job)
+		}
+		return err
+	}
+
+	log.V(1).Info("Deleting complete scan job", "owner", owner)
+	return r.deleteJob(ctx, job)
+}
+
+// writeReports creates or updates vulnerability reports with the given scan
+// results for containers of the specified owner. Optionally, the names of
+// cached ClusterVulnerabilityReports the scan results were copied from are
+// recorded as annotations.
+func (r *WorkloadController) writeReports(ctx context.Context, owner client.Object, podSpecHash string,
+	reportsData map[string]v1alpha1.VulnerabilityReportData, cachedReportNames map[string]string) error {
+	// Reports for static Pods are cluster-scoped and owned by the Node, so that
+	// they are garbage collected together with the Node rather than the mirror Pod.
+	staticPod := kube.IsStaticPod(owner)
+	var node *corev1.Node
+	if staticPod {
+		node = &corev1.Node{}
+		err := r.Client.Get(ctx, client.ObjectKey{Name: metav1.GetControllerOf(owner).Name}, node)
+		if err != nil {
+			return fmt.Errorf("getting node from cache: %w", err)
+		}
+	}
+
+	exceptions, err := r.FindActiveExceptions(ctx, owner.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	statements, err := r.FindStatements(ctx, owner.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	spec, err := kube.GetPodSpec(owner)
+	if err != nil {
+		return err
+	}
+	containerClasses := kube.GetContainerClasses(spec)
+
+	var vulnerabilityReports []v1alpha1.VulnerabilityReport
+	var clusterVulnerabilityReports []v1alpha1.ClusterVulnerabilityReport
+
+	for containerName, reportData := range reportsData {
+		enriched, err := r.Enrich(reportData)
+		if err != nil {
+			r.Logger.Error(err, "Skipping exploitability enrichment", "container", containerName)
+		} else {
+			reportData = enriched
+		}
+
+		reportData, err = ApplyExceptions(reportData, exceptions, owner.GetLabels())
+		if err != nil {
+			return err
+		}
+		reportData = ApplyVEX(reportData, statements)
+
 		reportBuilder := NewReportBuilder(r.Client.Scheme()).
 			Controller(owner).
 			Container(containerName).
+			ContainerClass(containerClasses[containerName]).
+			PluginContext(r.PluginContext).
 			Data(reportData).
 			PodSpecHash(podSpecHash)
 
+		if cachedReportName, ok := cachedReportNames[containerName]; ok {
+			reportBuilder.Annotations(map[string]string{
+				v1alpha1.ClusterVulnerabilityReportAnnotation: cachedReportName,
+			})
+		}
+
+		if staticPod {
+			report, err := reportBuilder.Owner(node).GetClusterReport()
+			if err != nil {
+				return err
+			}
+			clusterVulnerabilityReports = append(clusterVulnerabilityReports, report)
+			continue
+		}
+
 		if r.Config.VulnerabilityScannerReportTTL != nil {
 			reportBuilder.ReportTTL(r.Config.VulnerabilityScannerReportTTL)
 		}
@@ -412,8 +718,142 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 		return err
 	}
 
-	log.V(1).Info("Deleting complete scan job", "owner", owner)
-	return r.deleteJob(ctx, job)
+	err = r.ReadWriter.WriteCluster(ctx, clusterVulnerabilityReports)
+	if err != nil {
+		return err
+	}
+
+	return r.ClearFailure(ctx, owner, v1alpha1.VulnerabilityReportKind, r.PluginContext.GetName())
+}
+
+// writeSBOMReports creates or updates SBOM reports for containers of the
+// specified owner. SBOM reports are not generated for static Pods, whose
+// vulnerability reports are cluster-scoped.
+func (r *WorkloadController) writeSBOMReports(ctx context.Context, owner client.Object, podSpecHash string,
+	reportsData map[string]v1alpha1.SBOMReportData) error {
+	if len(reportsData) == 0 || kube.IsStaticPod(owner) {
+		return nil
+	}
+
+	var reports []v1alpha1.SBOMReport
+	for containerName, reportData := range reportsData {
+		report, err := sbomreport.NewReportBuilder(r.Client.Scheme()).
+			Controller(owner).
+			Container(containerName).
+			Data(reportData).
+			PodSpecHash(podSpecHash).
+			Get()
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	return r.SBOMReadWriter.Write(ctx, reports)
+}
+
+// getContainerImageDigests returns a map of container names to image digests
+// resolved by the container runtime for one of the active pods of the given
+// workload. An empty map is returned if there are no running pods.
+func (r *WorkloadController) getContainerImageDigests(ctx context.Context, workload client.Object) (kube.ContainerImages, error) {
+	pods, err := r.GetActivePods(ctx, workload)
+	if err != nil {
+		if errors.Is(err, kube.ErrReplicaSetNotFound) || errors.Is(err, kube.ErrNoRunningPods) ||
+			errors.Is(err, kube.ErrUnSupportedKind) {
+			return kube.ContainerImages{}, nil
+		}
+		return nil, err
+	}
+	return kube.GetContainerImageDigestsFromPod(pods[0]), nil
+}
+
+// cacheReports stores the given scan results by digests of scanned container
+// images as reported by the scanner. Scan results are not cached if the digest
+// is unknown, because the digest resolved for a running Pod might refer to a
+// different image than the one pulled by the scanner, e.g. if a tag was moved.
+func (r *WorkloadController) cacheReports(ctx context.Context, reportsData map[string]v1alpha1.VulnerabilityReportData) error {
+	for _, reportData := range reportsData {
+		digest := reportData.Artifact.Digest
+		if digest == "" {
+			continue
+		}
+		err := r.PutByDigest(ctx, digest, reportData)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyCachedReports creates vulnerability reports for containers of the given
+// workload by copying scan results cached by image digests. If SBOM generation
+// is enabled, SBOM reports are copied from SBOM reports of the same image
+// digests. It returns false if scan results or SBOMs of any container image
+// are not cached, in which case no reports are created.
+func (r *WorkloadController) copyCachedReports(ctx context.Context, workload client.Object, podSpecHash string, containerImages kube.ContainerImages) (bool, error) {
+	digests, err := r.getContainerImageDigests(ctx, workload)
+	if err != nil {
+		return false, err
+	}
+
+	sbomPlugin, err := GetSBOMPlugin(r.PluginContext, r.Plugin)
+	if err != nil {
+		return false, err
+	}
+
+	reportsData := make(map[string]v1alpha1.VulnerabilityReportData)
+	sbomReportsData := make(map[string]v1alpha1.SBOMReportData)
+	cachedReportNames := make(map[string]string)
+
+	for containerName, containerImage := range containerImages {
+		digest, ok := digests[containerName]
+		if !ok {
+			return false, nil
+		}
+		cachedReport, err := r.GetByDigest(ctx, digest)
+		if err != nil {
+			return false, err
+		}
+		if cachedReport == nil {
+			return false, nil
+		}
+		// Do not copy cached scan results which are due for a rescan anyway.
+		if rescanDue, _ := r.isRescanDue(cachedReport.Report.UpdateTimestamp.Time); rescanDue {
+			return false, nil
+		}
+		reportData, err := CopyCachedReportData(cachedReport.Report, containerImage, digest)
+		if err != nil {
+			return false, err
+		}
+		reportsData[containerName] = reportData
+		cachedReportNames[containerName] = cachedReport.Name
+
+		if sbomPlugin == nil || kube.IsStaticPod(workload) {
+			continue
+		}
+		sbomReport, err := r.SBOMReadWriter.FindByDigest(ctx, digest)
+		if err != nil {
+			return false, fmt.Errorf("finding sbom report by digest: %w", err)
+		}
+		if sbomReport == nil {
+			return false, nil
+		}
+		sbomReportData, err := CopySBOMReportData(sbomReport.Report, containerImage, digest)
+		if err != nil {
+			return false, err
+		}
+		sbomReportsData[containerName] = sbomReportData
+	}
+
+	err = r.writeReports(ctx, workload, podSpecHash, reportsData, cachedReportNames)
+	if err != nil {
+		return false, err
+	}
+	err = r.writeSBOMReports(ctx, workload, podSpecHash, sbomReportsData)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (r *WorkloadController) processFailedScanJob(ctx context.Context, scanJob *batchv1.Job) error {
@@ -425,11 +865,10 @@ func (r *WorkloadController) processFailedScanJob(ctx context.Context, scanJob *
 			log.V(1).Info("Cached job must have been deleted")
 			return nil
 		}
-		if kube.IsPodControlledByJobNotFound(err) {
-			log.V(1).Info("Pod must have been deleted")
-			return r.deleteJob(ctx, scanJob)
+		if !kube.IsPodControlledByJobNotFound(err) {
+			return err
 		}
-		return err
+		log.V(1).Info("Pod must have been deleted")
 	}
 	for container, status := range statuses {
 		if status.ExitCode == 0 {
@@ -437,10 +876,42 @@ func (r *WorkloadController) processFailedScanJob(ctx context.Context, scanJob *
 		}
 		log.Error(nil, "Scan job container", "container", container, "status.reason", status.Reason, "status.message", status.Message)
 	}
+
+	err = r.recordFailure(ctx, scanJob, statuses)
+	if err != nil {
+		return err
+	}
+
 	log.V(1).Info("Deleting failed scan job")
 	return r.deleteJob(ctx, scanJob)
 }
 
+// recordFailure records the failure of the given scan job so that scanning
+// its owner is retried with exponential backoff.
+func (r *WorkloadController) recordFailure(ctx context.Context, scanJob *batchv1.Job, statuses map[string]*corev1.ContainerStateTerminated) error {
+	ownerRef, err := kube.ObjectRefFromObjectMeta(scanJob.ObjectMeta)
+	if err != nil {
+		return fmt.Errorf("getting owner ref from scan job metadata: %w", err)
+	}
+	owner, err := r.ObjectFromObjectRef(ctx, ownerRef)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("getting object from object ref: %w", err)
+	}
+	failure := scanfailure.NewFailure(v1alpha1.VulnerabilityReportKind, r.PluginContext.GetName(),
+		scanJob.Labels[starboard.LabelResourceSpecHash], scanJob, statuses)
+	retryAfter, err := r.RecordFailure(ctx, owner, failure)
+	if err != nil {
+		return fmt.Errorf("recording scan failure: %w", err)
+	}
+	r.Logger.V(1).Info("Recorded scan failure", "owner", ownerRef, "reason", failure.Reason, "retryAfter", retryAfter)
+	return nil
+}
+
+// deleteJob deletes the specified scan job and records its metrics, unless the
+// job has already been deleted by a previous reconciliation.
 func (r *WorkloadController) deleteJob(ctx context.Context, job *batchv1.Job) error {
 	err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
 	if err != nil {
@@ -449,5 +920,6 @@ func (r *WorkloadController) deleteJob(ctx context.Context, job *batchv1.Job) er
 		}
 		return fmt.Errorf("deleting job: %w", err)
 	}
+	metrics.RecordScanJob(v1alpha1.VulnerabilityReportKind, r.PluginContext.GetName(), job)
 	return nil
 }
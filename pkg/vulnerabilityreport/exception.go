@@ -0,0 +1,183 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExceptionsReader is the interface that wraps the FindActiveExceptions method.
+//
+// FindActiveExceptions returns the slice of v1alpha1.VulnerabilityException
+// instances in the given namespace, which have not expired yet. An empty slice
+// is returned if the VulnerabilityException CRD is not installed.
+type ExceptionsReader interface {
+	FindActiveExceptions(ctx context.Context, namespace string) ([]v1alpha1.VulnerabilityException, error)
+}
+
+type exceptionsReader struct {
+	client.Client
+	ext.Clock
+}
+
+// NewExceptionsReader constructs a new ExceptionsReader which is using the
+// client package provided by the controller-runtime libraries for interacting
+// with the Kubernetes API server.
+func NewExceptionsReader(c client.Client, clock ext.Clock) ExceptionsReader {
+	return &exceptionsReader{
+		Client: c,
+		Clock:  clock,
+	}
+}
+
+func (r *exceptionsReader) FindActiveExceptions(ctx context.Context, namespace string) ([]v1alpha1.VulnerabilityException, error) {
+	var list v1alpha1.VulnerabilityExceptionList
+	err := r.List(ctx, &list, client.InNamespace(namespace))
+	if meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing vulnerability exceptions: %w", err)
+	}
+	var exceptions []v1alpha1.VulnerabilityException
+	for _, exception := range list.Items {
+		if exception.IsExpired(r.Now()) {
+			continue
+		}
+		exceptions = append(exceptions, exception)
+	}
+	return exceptions, nil
+}
+
+// ValidateException checks that the given exception sets vulnerability
+// identifiers or resources. Without them the exception would suppress all
+// vulnerabilities of workloads in its namespace.
+func ValidateException(exception v1alpha1.VulnerabilityException) error {
+	if len(exception.Spec.VulnerabilityIDs) == 0 && len(exception.Spec.Resources) == 0 {
+		return errors.New("at least one of vulnerabilityIDs or resources must be set")
+	}
+	return nil
+}
+
+// ApplyExceptions returns a copy of the given report data where vulnerabilities
+// that match any of the specified exceptions are marked as suppressed and
+// excluded from the summary. Vulnerabilities that were suppressed before, but
+// do not match any exception anymore, are restored and included in the summary
+// unless a VEX statement excludes them.
+// The workloadLabels are matched against the label selectors of exceptions.
+// Exceptions that are not valid according to ValidateException are ignored.
+func ApplyExceptions(data v1alpha1.VulnerabilityReportData, exceptions []v1alpha1.VulnerabilityException, workloadLabels labels.Set) (v1alpha1.VulnerabilityReportData, error) {
+	copied := *data.DeepCopy()
+
+	var applicable []v1alpha1.VulnerabilityException
+	for _, exception := range exceptions {
+		if ValidateException(exception) != nil {
+			continue
+		}
+		ok, err := matchesWorkload(exception.Spec, copied, workloadLabels)
+		if err != nil {
+			return v1alpha1.VulnerabilityReportData{}, fmt.Errorf("matching vulnerability exception %s: %w", exception.Name, err)
+		}
+		if ok {
+			applicable = append(applicable, exception)
+		}
+	}
+
+	for i, vulnerability := range copied.Vulnerabilities {
+		suppressedBy := ""
+		for _, exception := range applicable {
+			if matchesVulnerability(exception.Spec, vulnerability) {
+				suppressedBy = exception.Name
+				break
+			}
+		}
+
+		updated := vulnerability
+		updated.Suppressed = suppressedBy != ""
+		updated.SuppressedBy = suppressedBy
+		updateVulnerability(&copied, i, updated)
+	}
+
+	return copied, nil
+}
+
+// NextExceptionExpiration returns the duration until the earliest expiration
+// of the given exceptions, or zero if none of them expires.
+func NextExceptionExpiration(exceptions []v1alpha1.VulnerabilityException, now time.Time) time.Duration {
+	var next time.Duration
+	for _, exception := range exceptions {
+		if exception.Spec.ExpiresAt == nil || exception.IsExpired(now) {
+			continue
+		}
+		d := exception.Spec.ExpiresAt.Sub(now)
+		if next == 0 || d < next {
+			next = d
+		}
+	}
+	return next
+}
+
+func matchesWorkload(spec v1alpha1.VulnerabilityExceptionSpec, data v1alpha1.VulnerabilityReportData, workloadLabels labels.Set) (bool, error) {
+	if spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(workloadLabels) {
+			return false, nil
+		}
+	}
+	if len(spec.Repositories) == 0 {
+		return true, nil
+	}
+	repositories := []string{data.Artifact.Repository}
+	if data.Registry.Server != "" {
+		repositories = append(repositories, data.Registry.Server+"/"+data.Artifact.Repository)
+	}
+	for _, pattern := range spec.Repositories {
+		for _, repository := range repositories {
+			matches, err := path.Match(pattern, repository)
+			if err != nil {
+				return false, err
+			}
+			if matches {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func matchesVulnerability(spec v1alpha1.VulnerabilityExceptionSpec, vulnerability v1alpha1.Vulnerability) bool {
+	if len(spec.VulnerabilityIDs) > 0 && !ext.SliceContainsString(spec.VulnerabilityIDs, vulnerability.VulnerabilityID) {
+		return false
+	}
+	if len(spec.Resources) > 0 && !ext.SliceContainsString(spec.Resources, vulnerability.Resource) {
+		return false
+	}
+	return true
+}
+
+func updateSummary(summary *v1alpha1.VulnerabilitySummary, severity v1alpha1.Severity, delta int) {
+	switch severity {
+	case v1alpha1.SeverityCritical:
+		summary.CriticalCount += delta
+	case v1alpha1.SeverityHigh:
+		summary.HighCount += delta
+	case v1alpha1.SeverityMedium:
+		summary.MediumCount += delta
+	case v1alpha1.SeverityLow:
+		summary.LowCount += delta
+	default:
+		summary.UnknownCount += delta
+	}
+}
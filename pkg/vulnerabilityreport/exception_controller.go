@@ -0,0 +1,143 @@
+package vulnerabilityreport
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExceptionController watches v1alpha1.VulnerabilityException instances and
+// reapplies exceptions to vulnerability reports in the same namespace whenever
+// an exception is created, updated, deleted, or expires. Reports are written
+// with the ReadWriter, therefore vulnerabilities that are no longer suppressed
+// are announced like newly discovered ones.
+type ExceptionController struct {
+	logr.Logger
+	etc.Config
+	ext.Clock
+	client.Client
+	kube.ObjectResolver
+	ExceptionsReader
+	ReadWriter
+}
+
+func (r *ExceptionController) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.VulnerabilityException{}, builder.WithPredicates(installModePredicate)).
+		Complete(r)
+}
+
+func (r *ExceptionController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("namespace", req.Namespace)
+
+	var exception v1alpha1.VulnerabilityException
+	err := r.Client.Get(ctx, req.NamespacedName, &exception)
+	if err != nil && !k8sapierror.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("getting vulnerability exception from cache: %w", err)
+	}
+	if err == nil {
+		if err := ValidateException(exception); err != nil {
+			log.Error(err, "Ignoring invalid vulnerability exception", "name", req.Name)
+		}
+	}
+
+	exceptions, err := r.FindActiveExceptions(ctx, req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var reportList v1alpha1.VulnerabilityReportList
+	err = r.Client.List(ctx, &reportList, client.InNamespace(req.Namespace))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	for _, report := range reportList.Items {
+		data, err := r.applyExceptions(ctx, report.ObjectMeta, report.Report, exceptions)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if reflect.DeepEqual(data, report.Report) {
+			continue
+		}
+		log.V(1).Info("Updating suppressed vulnerabilities", "report", report.Name)
+		copied := report.DeepCopy()
+		copied.Report = data
+		err = r.ReadWriter.Write(ctx, []v1alpha1.VulnerabilityReport{*copied})
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("updating vulnerability report: %w", err)
+		}
+	}
+
+	// Reports for static Pods are cluster-scoped, but still subject to exceptions
+	// defined in the namespace of the Pod.
+	var clusterReportList v1alpha1.ClusterVulnerabilityReportList
+	err = r.Client.List(ctx, &clusterReportList, client.MatchingLabels{
+		starboard.LabelResourceNamespace: req.Namespace,
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing cluster vulnerability reports: %w", err)
+	}
+	for _, report := range clusterReportList.Items {
+		if IsCachedReport(report.ObjectMeta) {
+			continue
+		}
+		data, err := r.applyExceptions(ctx, report.ObjectMeta, report.Report, exceptions)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if reflect.DeepEqual(data, report.Report) {
+			continue
+		}
+		log.V(1).Info("Updating suppressed vulnerabilities", "report", report.Name)
+		copied := report.DeepCopy()
+		copied.Report = data
+		err = r.ReadWriter.WriteCluster(ctx, []v1alpha1.ClusterVulnerabilityReport{*copied})
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("updating cluster vulnerability report: %w", err)
+		}
+	}
+
+	if next := NextExceptionExpiration(exceptions, r.Now()); next > 0 {
+		log.V(1).Info("RequeueAfter", "durationToExceptionExpiration", next)
+		return ctrl.Result{RequeueAfter: next}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// applyExceptions resolves labels of the workload that owns the report with
+// the given metadata and applies exceptions to the report data.
+func (r *ExceptionController) applyExceptions(ctx context.Context, reportMeta metav1.ObjectMeta,
+	data v1alpha1.VulnerabilityReportData, exceptions []v1alpha1.VulnerabilityException) (v1alpha1.VulnerabilityReportData, error) {
+	var workloadLabels labels.Set
+	ref, err := kube.ObjectRefFromObjectMeta(reportMeta)
+	if err == nil {
+		workload, err := r.ObjectFromObjectRef(ctx, ref)
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return v1alpha1.VulnerabilityReportData{}, fmt.Errorf("getting workload from cache: %w", err)
+		}
+		if err == nil {
+			workloadLabels = workload.GetLabels()
+		}
+	}
+	return ApplyExceptions(data, exceptions, workloadLabels)
+}
@@ -0,0 +1,222 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExceptionsReader_FindActiveExceptions(t *testing.T) {
+	now := time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC)
+
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.VulnerabilityException{
+			ObjectMeta: metav1.ObjectMeta{Name: "active", Namespace: "default"},
+			Spec: v1alpha1.VulnerabilityExceptionSpec{
+				ExpiresAt: &metav1.Time{Time: now.Add(time.Hour)},
+			},
+		},
+		&v1alpha1.VulnerabilityException{
+			ObjectMeta: metav1.ObjectMeta{Name: "expired", Namespace: "default"},
+			Spec: v1alpha1.VulnerabilityExceptionSpec{
+				ExpiresAt: &metav1.Time{Time: now.Add(-time.Hour)},
+			},
+		},
+		&v1alpha1.VulnerabilityException{
+			ObjectMeta: metav1.ObjectMeta{Name: "never-expires", Namespace: "default"},
+		},
+		&v1alpha1.VulnerabilityException{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "kube-system"},
+		},
+	).Build()
+
+	exceptions, err := vulnerabilityreport.NewExceptionsReader(testClient, ext.NewFixedClock(now)).
+		FindActiveExceptions(context.TODO(), "default")
+	require.NoError(t, err)
+
+	var names []string
+	for _, exception := range exceptions {
+		names = append(names, exception.Name)
+	}
+	assert.ElementsMatch(t, []string{"active", "never-expires"}, names)
+}
+
+func TestApplyExceptions(t *testing.T) {
+	data := v1alpha1.VulnerabilityReportData{
+		Registry: v1alpha1.Registry{Server: "index.docker.io"},
+		Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+		Summary: v1alpha1.VulnerabilitySummary{
+			CriticalCount: 1,
+			HighCount:     2,
+		},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2020-1967", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2020-1971", Resource: "openssl", Severity: v1alpha1.SeverityHigh},
+			{VulnerabilityID: "CVE-2021-3449", Resource: "libssl", Severity: v1alpha1.SeverityHigh},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		exceptions     []v1alpha1.VulnerabilityException
+		workloadLabels labels.Set
+		expectedIDs    map[string]string
+		expectedCounts v1alpha1.VulnerabilitySummary
+	}{
+		{
+			name: "Should suppress vulnerability by ID",
+			exceptions: []v1alpha1.VulnerabilityException{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "accept-cve-2020-1967"},
+					Spec: v1alpha1.VulnerabilityExceptionSpec{
+						VulnerabilityIDs: []string{"CVE-2020-1967"},
+					},
+				},
+			},
+			expectedIDs: map[string]string{
+				"CVE-2020-1967": "accept-cve-2020-1967",
+			},
+			expectedCounts: v1alpha1.VulnerabilitySummary{HighCount: 2},
+		},
+		{
+			name: "Should suppress vulnerabilities by resource and repository pattern",
+			exceptions: []v1alpha1.VulnerabilityException{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "accept-openssl"},
+					Spec: v1alpha1.VulnerabilityExceptionSpec{
+						Resources:    []string{"openssl"},
+						Repositories: []string{"index.docker.io/library/*"},
+					},
+				},
+			},
+			expectedIDs: map[string]string{
+				"CVE-2020-1967": "accept-openssl",
+				"CVE-2020-1971": "accept-openssl",
+			},
+			expectedCounts: v1alpha1.VulnerabilitySummary{HighCount: 1},
+		},
+		{
+			name: "Should not suppress vulnerabilities when repository does not match",
+			exceptions: []v1alpha1.VulnerabilityException{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "accept-openssl"},
+					Spec: v1alpha1.VulnerabilityExceptionSpec{
+						Resources:    []string{"openssl"},
+						Repositories: []string{"quay.io/*"},
+					},
+				},
+			},
+			expectedIDs:    map[string]string{},
+			expectedCounts: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2},
+		},
+		{
+			name: "Should suppress vulnerabilities when workload labels match selector",
+			exceptions: []v1alpha1.VulnerabilityException{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "accept-libssl"},
+					Spec: v1alpha1.VulnerabilityExceptionSpec{
+						Resources: []string{"libssl"},
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "nginx"},
+						},
+					},
+				},
+			},
+			workloadLabels: labels.Set{"app": "nginx"},
+			expectedIDs: map[string]string{
+				"CVE-2021-3449": "accept-libssl",
+			},
+			expectedCounts: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 1},
+		},
+		{
+			name: "Should not suppress vulnerabilities when workload labels do not match selector",
+			exceptions: []v1alpha1.VulnerabilityException{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "accept-libssl"},
+					Spec: v1alpha1.VulnerabilityExceptionSpec{
+						Resources: []string{"libssl"},
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "nginx"},
+						},
+					},
+				},
+			},
+			workloadLabels: labels.Set{"app": "wordpress"},
+			expectedIDs:    map[string]string{},
+			expectedCounts: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2},
+		},
+		{
+			name: "Should not suppress vulnerabilities by exception without vulnerability IDs and resources",
+			exceptions: []v1alpha1.VulnerabilityException{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "accept-everything"},
+					Spec: v1alpha1.VulnerabilityExceptionSpec{
+						Repositories:  []string{"index.docker.io/library/*"},
+						Justification: "Accepted by security team",
+					},
+				},
+			},
+			expectedIDs:    map[string]string{},
+			expectedCounts: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := vulnerabilityreport.ApplyExceptions(data, tc.exceptions, tc.workloadLabels)
+			require.NoError(t, err)
+
+			suppressed := map[string]string{}
+			for _, vulnerability := range result.Vulnerabilities {
+				if vulnerability.Suppressed {
+					suppressed[vulnerability.VulnerabilityID] = vulnerability.SuppressedBy
+				}
+			}
+			assert.Equal(t, tc.expectedIDs, suppressed)
+			assert.Equal(t, tc.expectedCounts, result.Summary)
+
+			// Applying the same exceptions again must not change the result.
+			again, err := vulnerabilityreport.ApplyExceptions(result, tc.exceptions, tc.workloadLabels)
+			require.NoError(t, err)
+			assert.Equal(t, result, again)
+
+			// Removing exceptions must restore the original report data.
+			restored, err := vulnerabilityreport.ApplyExceptions(result, nil, tc.workloadLabels)
+			require.NoError(t, err)
+			assert.Equal(t, data, restored)
+		})
+	}
+}
+
+func TestValidateException(t *testing.T) {
+	assert.NoError(t, vulnerabilityreport.ValidateException(v1alpha1.VulnerabilityException{
+		Spec: v1alpha1.VulnerabilityExceptionSpec{VulnerabilityIDs: []string{"CVE-2020-1967"}},
+	}))
+	assert.NoError(t, vulnerabilityreport.ValidateException(v1alpha1.VulnerabilityException{
+		Spec: v1alpha1.VulnerabilityExceptionSpec{Resources: []string{"openssl"}},
+	}))
+	assert.EqualError(t, vulnerabilityreport.ValidateException(v1alpha1.VulnerabilityException{
+		Spec: v1alpha1.VulnerabilityExceptionSpec{Justification: "Accepted by security team"},
+	}), "at least one of vulnerabilityIDs or resources must be set")
+}
+
+func TestNextExceptionExpiration(t *testing.T) {
+	now := time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Duration(0), vulnerabilityreport.NextExceptionExpiration(nil, now))
+	assert.Equal(t, 30*time.Minute, vulnerabilityreport.NextExceptionExpiration([]v1alpha1.VulnerabilityException{
+		{Spec: v1alpha1.VulnerabilityExceptionSpec{ExpiresAt: &metav1.Time{Time: now.Add(time.Hour)}}},
+		{Spec: v1alpha1.VulnerabilityExceptionSpec{ExpiresAt: &metav1.Time{Time: now.Add(30 * time.Minute)}}},
+		{Spec: v1alpha1.VulnerabilityExceptionSpec{}},
+	}, now))
+}
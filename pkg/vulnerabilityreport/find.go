@@ -0,0 +1,119 @@
+package vulnerabilityreport
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// Finding is a vulnerability found in the image of a container of a
+// Kubernetes workload.
+type Finding struct {
+	// Workload is the Kubernetes workload that the vulnerability report was
+	// generated for.
+	Workload      kube.ObjectRef         `json:"workload"`
+	Container     string                 `json:"container"`
+	Image         string                 `json:"image"`
+	Vulnerability v1alpha1.Vulnerability `json:"vulnerability"`
+}
+
+// Matcher decides whether the given vulnerability is a match of a search.
+type Matcher func(vulnerability v1alpha1.Vulnerability) bool
+
+// MatchVulnerabilityID returns the Matcher that matches vulnerabilities with
+// the specified identifier, e.g. CVE-2021-44228. Identifiers are compared
+// case-insensitively.
+func MatchVulnerabilityID(id string) Matcher {
+	return func(vulnerability v1alpha1.Vulnerability) bool {
+		return strings.EqualFold(vulnerability.VulnerabilityID, id)
+	}
+}
+
+// MatchPackage returns the Matcher that matches vulnerabilities of the
+// specified package. The package is given as name or name@version, in which
+// case only vulnerabilities of the specified installed version are matched.
+func MatchPackage(pkg string) Matcher {
+	name, version := pkg, ""
+	// Use the last separator and ignore the leading one so that scoped npm
+	// packages such as @babel/core@7.0.0 are parsed correctly.
+	if i := strings.LastIndex(pkg, "@"); i > 0 {
+		name, version = pkg[:i], pkg[i+1:]
+	}
+	return func(vulnerability v1alpha1.Vulnerability) bool {
+		if vulnerability.Resource != name {
+			return false
+		}
+		return version == "" || vulnerability.InstalledVersion == version
+	}
+}
+
+// Find returns Findings of vulnerabilities in the specified reports that
+// match the given Matcher. Each Finding is resolved back to the workload
+// that owns the report. Cluster reports are taken into account for static
+// Pods, whereas reports cached by image digest are ignored. Reports that
+// cannot be resolved to a workload are skipped. Vulnerabilities excluded by
+// exceptions or VEX statements are omitted. Findings are sorted by namespace,
+// workload, and container.
+func Find(reports []v1alpha1.VulnerabilityReport, clusterReports []v1alpha1.ClusterVulnerabilityReport, matcher Matcher) []Finding {
+	var findings []Finding
+	for _, report := range reports {
+		findings = append(findings, find(report.ObjectMeta, report.Report, matcher)...)
+	}
+	for _, report := range clusterReports {
+		if IsCachedReport(report.ObjectMeta) {
+			continue
+		}
+		findings = append(findings, find(report.ObjectMeta, report.Report, matcher)...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Workload.Namespace != b.Workload.Namespace {
+			return a.Workload.Namespace < b.Workload.Namespace
+		}
+		if a.Workload.Kind != b.Workload.Kind {
+			return a.Workload.Kind < b.Workload.Kind
+		}
+		if a.Workload.Name != b.Workload.Name {
+			return a.Workload.Name < b.Workload.Name
+		}
+		return a.Container < b.Container
+	})
+	return findings
+}
+
+func find(meta metav1.ObjectMeta, data v1alpha1.VulnerabilityReportData, matcher Matcher) []Finding {
+	var findings []Finding
+	var workload *kube.ObjectRef
+	for _, vulnerability := range data.Vulnerabilities {
+		if vulnerability.IsExcluded() || !matcher(vulnerability) {
+			continue
+		}
+		if workload == nil {
+			ref, err := kube.ObjectRefFromObjectMeta(meta)
+			if err != nil {
+				klog.Warningf("Skipping report %s: resolving workload: %v", reportName(meta), err)
+				return nil
+			}
+			workload = &ref
+		}
+		findings = append(findings, Finding{
+			Workload:      *workload,
+			Container:     meta.Labels[starboard.LabelContainerName],
+			Image:         v1alpha1.ImageRef(data.Registry, data.Artifact),
+			Vulnerability: vulnerability,
+		})
+	}
+	return findings
+}
+
+func reportName(meta metav1.ObjectMeta) string {
+	if meta.Namespace == "" {
+		return meta.Name
+	}
+	return meta.Namespace + "/" + meta.Name
+}
@@ -0,0 +1,190 @@
+package vulnerabilityreport_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFind(t *testing.T) {
+	log4Shell := v1alpha1.Vulnerability{
+		VulnerabilityID:  "CVE-2021-44228",
+		Resource:         "org.apache.logging.log4j:log4j-core",
+		InstalledVersion: "2.14.1",
+		FixedVersion:     "2.15.0",
+		Severity:         v1alpha1.SeverityCritical,
+	}
+	newReport := func(namespace, kind, name, container string, data v1alpha1.VulnerabilityReportData) v1alpha1.VulnerabilityReport {
+		return v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      strings.ToLower(kind) + "-" + name + "-" + container,
+				Namespace: namespace,
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      kind,
+					starboard.LabelResourceName:      name,
+					starboard.LabelResourceNamespace: namespace,
+					starboard.LabelContainerName:     container,
+				},
+			},
+			Report: data,
+		}
+	}
+	reports := []v1alpha1.VulnerabilityReport{
+		newReport("staging", "StatefulSet", "solr", "solr", v1alpha1.VulnerabilityReportData{
+			Registry: v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact: v1alpha1.Artifact{Repository: "library/solr", Tag: "8.11.0"},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				log4Shell,
+				{
+					VulnerabilityID:  "CVE-2022-0778",
+					Resource:         "openssl",
+					InstalledVersion: "1.1.1k",
+					FixedVersion:     "1.1.1n",
+					Severity:         v1alpha1.SeverityHigh,
+				},
+			},
+		}),
+		newReport("default", "ReplicaSet", "app-6d4cf56db6", "app", v1alpha1.VulnerabilityReportData{
+			Registry: v1alpha1.Registry{Server: "quay.io"},
+			Artifact: v1alpha1.Artifact{Repository: "acme/app", Digest: "sha256:2e2f"},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2021-44228",
+					Resource:         "org.apache.logging.log4j:log4j-core",
+					InstalledVersion: "2.13.0",
+					FixedVersion:     "2.15.0",
+					Severity:         v1alpha1.SeverityCritical,
+				},
+			},
+		}),
+		newReport("default", "ReplicaSet", "legacy-7f8d9c", "legacy", v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{Repository: "legacy", Tag: "1.0"},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2021-44228",
+					Resource:         "org.apache.logging.log4j:log4j-core",
+					InstalledVersion: "2.14.1",
+					Suppressed:       true,
+				},
+			},
+		}),
+	}
+
+	t.Run("Should find workloads affected by vulnerability", func(t *testing.T) {
+		findings := vulnerabilityreport.Find(reports, nil, vulnerabilityreport.MatchVulnerabilityID("cve-2021-44228"))
+		assert.Equal(t, []vulnerabilityreport.Finding{
+			{
+				Workload:      kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "app-6d4cf56db6", Namespace: "default"},
+				Container:     "app",
+				Image:         "quay.io/acme/app@sha256:2e2f",
+				Vulnerability: reports[1].Report.Vulnerabilities[0],
+			},
+			{
+				Workload:      kube.ObjectRef{Kind: kube.KindStatefulSet, Name: "solr", Namespace: "staging"},
+				Container:     "solr",
+				Image:         "index.docker.io/library/solr:8.11.0",
+				Vulnerability: log4Shell,
+			},
+		}, findings)
+	})
+
+	t.Run("Should find workloads affected by vulnerable package", func(t *testing.T) {
+		findings := vulnerabilityreport.Find(reports, nil, vulnerabilityreport.MatchPackage("openssl"))
+		require.Len(t, findings, 1)
+		assert.Equal(t, "CVE-2022-0778", findings[0].Vulnerability.VulnerabilityID)
+		assert.Equal(t, kube.ObjectRef{Kind: kube.KindStatefulSet, Name: "solr", Namespace: "staging"}, findings[0].Workload)
+	})
+
+	t.Run("Should find workloads affected by vulnerable package version", func(t *testing.T) {
+		findings := vulnerabilityreport.Find(reports, nil, vulnerabilityreport.MatchPackage("org.apache.logging.log4j:log4j-core@2.13.0"))
+		require.Len(t, findings, 1)
+		assert.Equal(t, "app-6d4cf56db6", findings[0].Workload.Name)
+	})
+
+	t.Run("Should skip report that is not labeled with workload", func(t *testing.T) {
+		findings := vulnerabilityreport.Find(append([]v1alpha1.VulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: "default"},
+				Report: v1alpha1.VulnerabilityReportData{
+					Vulnerabilities: []v1alpha1.Vulnerability{log4Shell},
+				},
+			},
+		}, reports...), nil, vulnerabilityreport.MatchVulnerabilityID("CVE-2021-44228"))
+		require.Len(t, findings, 2)
+		assert.Equal(t, "app-6d4cf56db6", findings[0].Workload.Name)
+		assert.Equal(t, "solr", findings[1].Workload.Name)
+	})
+
+	t.Run("Should find static pods affected by vulnerability", func(t *testing.T) {
+		clusterReports := []v1alpha1.ClusterVulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod-kube-apiserver-kind-control-plane-kube-apiserver",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Pod",
+						starboard.LabelResourceName:      "kube-apiserver-kind-control-plane",
+						starboard.LabelResourceNamespace: "kube-system",
+						starboard.LabelContainerName:     "kube-apiserver",
+					},
+				},
+				Report: v1alpha1.VulnerabilityReportData{
+					Registry:        v1alpha1.Registry{Server: "k8s.gcr.io"},
+					Artifact:        v1alpha1.Artifact{Repository: "kube-apiserver", Tag: "v1.21.1"},
+					Vulnerabilities: []v1alpha1.Vulnerability{log4Shell},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sha256-2e2f",
+					Labels: map[string]string{
+						starboard.LabelVulnerabilityReportCache: "true",
+					},
+					Annotations: map[string]string{
+						v1alpha1.ImageDigestAnnotation: "sha256:2e2f",
+					},
+				},
+				Report: v1alpha1.VulnerabilityReportData{
+					Vulnerabilities: []v1alpha1.Vulnerability{log4Shell},
+				},
+			},
+		}
+		findings := vulnerabilityreport.Find(nil, clusterReports, vulnerabilityreport.MatchVulnerabilityID("CVE-2021-44228"))
+		assert.Equal(t, []vulnerabilityreport.Finding{
+			{
+				Workload:      kube.ObjectRef{Kind: kube.KindPod, Name: "kube-apiserver-kind-control-plane", Namespace: "kube-system"},
+				Container:     "kube-apiserver",
+				Image:         "k8s.gcr.io/kube-apiserver:v1.21.1",
+				Vulnerability: log4Shell,
+			},
+		}, findings)
+	})
+}
+
+func TestMatchPackage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pkg      string
+		expected bool
+	}{
+		{name: "Should match name", pkg: "@babel/core", expected: true},
+		{name: "Should match name and version", pkg: "@babel/core@7.0.0", expected: true},
+		{name: "Should not match other version", pkg: "@babel/core@7.1.0", expected: false},
+		{name: "Should not match other name", pkg: "core", expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := vulnerabilityreport.MatchPackage(tc.pkg)
+			assert.Equal(t, tc.expected, matcher(v1alpha1.Vulnerability{
+				Resource:         "@babel/core",
+				InstalledVersion: "7.0.0",
+			}))
+		})
+	}
+}
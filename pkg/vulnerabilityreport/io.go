@@ -6,17 +6,25 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/notification"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Writer is the interface that wraps the basic Write method.
+// Writer is the interface that wraps the basic Write and WriteCluster methods.
 //
 // Write creates or updates the given slice of v1alpha1.VulnerabilityReport
 // instances.
+//
+// WriteCluster creates or updates the given slice of
+// v1alpha1.ClusterVulnerabilityReport instances.
 type Writer interface {
 	Write(context.Context, []v1alpha1.VulnerabilityReport) error
+	WriteCluster(context.Context, []v1alpha1.ClusterVulnerabilityReport) error
 }
 
 // Reader is the interface that wraps methods for finding v1alpha1.VulnerabilityReport objects.
@@ -28,9 +36,14 @@ type Writer interface {
 // v1alpha1.VulnerabilityReport objects owned by related Kubernetes objects.
 // For example, if the given owner is a Deployment, but reports are owned by the
 // active ReplicaSet (current revision) this method will return the reports.
+//
+// FindClusterByOwner returns the slice of v1alpha1.ClusterVulnerabilityReport
+// instances owned by the given kube.ObjectRef or an empty slice if the reports
+// are not found.
 type Reader interface {
 	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
 	FindByOwnerInHierarchy(ctx context.Context, object kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
+	FindClusterByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.ClusterVulnerabilityReport, error)
 }
 
 type ReadWriter interface {
@@ -40,14 +53,23 @@ type ReadWriter interface {
 
 type readWriter struct {
 	*kube.ObjectResolver
+	notifier notification.Notifier
 }
 
 // NewReadWriter constructs a new ReadWriter which is using the client package
 // provided by the controller-runtime libraries for interacting with the
 // Kubernetes API server.
 func NewReadWriter(resolver *kube.ObjectResolver) ReadWriter {
+	return NewNotifyingReadWriter(resolver, notification.NewNopNotifier())
+}
+
+// NewNotifyingReadWriter constructs a new ReadWriter which, in addition to
+// writing reports, passes vulnerabilities that were not present in the
+// previous revision of a report to the given notification.Notifier.
+func NewNotifyingReadWriter(resolver *kube.ObjectResolver, notifier notification.Notifier) ReadWriter {
 	return &readWriter{
 		ObjectResolver: resolver,
+		notifier:       notifier,
 	}
 }
 
@@ -73,16 +95,142 @@ func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.Vulnera
 		copied.Labels = report.Labels
 		copied.Report = report.Report
 
-		return r.Update(ctx, copied)
+		err = r.Update(ctx, copied)
+		if err != nil {
+			return err
+		}
+		r.notifier.Notify(ctx, notification.NewVulnerabilityReportEvent(&existing.Report, report))
+		return nil
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		previous, err := r.findPreviousRevision(ctx, report)
+		if err != nil {
+			return fmt.Errorf("finding report of previous revision: %w", err)
+		}
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		r.notifier.Notify(ctx, notification.NewVulnerabilityReportEvent(previous, report))
+		return nil
 	}
 
 	return err
 }
 
+// findPreviousRevision returns data of the most recent report generated by the
+// same scanner for the same container of another ReplicaSet controlled by the
+// same Deployment as the ReplicaSet that owns the given report. Thus, a rollout
+// of a Deployment does not announce vulnerabilities known from the previous
+// revision again. Returns nil if there is no such report.
+func (r *readWriter) findPreviousRevision(ctx context.Context, report v1alpha1.VulnerabilityReport) (*v1alpha1.VulnerabilityReportData, error) {
+	owner, err := kube.ObjectRefFromObjectMeta(report.ObjectMeta)
+	if err != nil || owner.Kind != kube.KindReplicaSet {
+		return nil, nil
+	}
+	var rs appsv1.ReplicaSet
+	err = r.Get(ctx, types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}, &rs)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	controller := metav1.GetControllerOf(&rs)
+	if controller == nil || controller.Kind != string(kube.KindDeployment) {
+		return nil, nil
+	}
+
+	var rsList appsv1.ReplicaSetList
+	err = r.List(ctx, &rsList, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	revisions := make(map[string]bool)
+	for _, item := range rsList.Items {
+		if item.Name == rs.Name {
+			continue
+		}
+		if ref := metav1.GetControllerOf(&item); ref != nil && ref.UID == controller.UID {
+			revisions[item.Name] = true
+		}
+	}
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+
+	var reportList v1alpha1.VulnerabilityReportList
+	err = r.List(ctx, &reportList, client.InNamespace(owner.Namespace), client.MatchingLabels{
+		starboard.LabelResourceKind:  string(kube.KindReplicaSet),
+		starboard.LabelContainerName: report.Labels[starboard.LabelContainerName],
+	})
+	if err != nil {
+		return nil, err
+	}
+	var previous *v1alpha1.VulnerabilityReportData
+	for i, item := range reportList.Items {
+		if item.Labels[starboard.LabelVulnerabilityReportScanner] != report.Labels[starboard.LabelVulnerabilityReportScanner] {
+			continue
+		}
+		ref, err := kube.ObjectRefFromObjectMeta(item.ObjectMeta)
+		if err != nil || !revisions[ref.Name] {
+			continue
+		}
+		if previous == nil || item.Report.UpdateTimestamp.After(previous.UpdateTimestamp.Time) {
+			previous = &reportList.Items[i].Report
+		}
+	}
+	return previous, nil
+}
+
+func (r *readWriter) WriteCluster(ctx context.Context, reports []v1alpha1.ClusterVulnerabilityReport) error {
+	for _, report := range reports {
+		err := r.createOrUpdateCluster(ctx, report)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *readWriter) createOrUpdateCluster(ctx context.Context, report v1alpha1.ClusterVulnerabilityReport) error {
+	var existing v1alpha1.ClusterVulnerabilityReport
+	err := r.Get(ctx, types.NamespacedName{
+		Name: report.Name,
+	}, &existing)
+
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = report.Labels
+		copied.Report = report.Report
+
+		err = r.Update(ctx, copied)
+		if err != nil {
+			return err
+		}
+		r.notifyCluster(ctx, &existing.Report, report)
+		return nil
+	}
+
+	if errors.IsNotFound(err) {
+		err = r.Create(ctx, &report)
+		if err != nil {
+			return err
+		}
+		r.notifyCluster(ctx, nil, report)
+		return nil
+	}
+
+	return err
+}
+
+// notifyCluster skips ClusterVulnerabilityReports which cache scan results
+// of container images rather than describe a workload, i.e. static Pod.
+func (r *readWriter) notifyCluster(ctx context.Context, previous *v1alpha1.VulnerabilityReportData, report v1alpha1.ClusterVulnerabilityReport) {
+	if IsCachedReport(report.ObjectMeta) {
+		return
+	}
+	r.notifier.Notify(ctx, notification.NewClusterVulnerabilityReportEvent(previous, report))
+}
+
 func (r *readWriter) FindByOwner(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error) {
 	var list v1alpha1.VulnerabilityReportList
 
@@ -120,3 +268,16 @@ func (r *readWriter) FindByOwnerInHierarchy(ctx context.Context, owner kube.Obje
 
 	return reports, nil
 }
+
+func (r *readWriter) FindClusterByOwner(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.ClusterVulnerabilityReport, error) {
+	var list v1alpha1.ClusterVulnerabilityReportList
+
+	labels := client.MatchingLabels(kube.ObjectRefToLabels(owner))
+
+	err := r.List(ctx, &list, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.DeepCopy().Items, nil
+}
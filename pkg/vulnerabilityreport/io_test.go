@@ -6,10 +6,12 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/notification"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -259,4 +261,225 @@ func TestNewReadWriter(t *testing.T) {
 		}, reports)
 	})
 
+	t.Run("Should create ClusterVulnerabilityReports", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := vulnerabilityreport.NewReadWriter(&resolver)
+		err := readWriter.WriteCluster(context.TODO(), []v1alpha1.ClusterVulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod-kube-system-etcd-master-etcd",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Pod",
+						starboard.LabelResourceName:      "etcd-master",
+						starboard.LabelResourceNamespace: "kube-system",
+						starboard.LabelContainerName:     "etcd",
+						starboard.LabelResourceSpecHash:  "h1",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var found v1alpha1.ClusterVulnerabilityReport
+		err = testClient.Get(context.TODO(), types.NamespacedName{
+			Name: "pod-kube-system-etcd-master-etcd",
+		}, &found)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.ClusterVulnerabilityReport{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "aquasecurity.github.io/v1alpha1",
+				Kind:       "ClusterVulnerabilityReport",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				ResourceVersion: "1",
+				Name:            "pod-kube-system-etcd-master-etcd",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Pod",
+					starboard.LabelResourceName:      "etcd-master",
+					starboard.LabelResourceNamespace: "kube-system",
+					starboard.LabelContainerName:     "etcd",
+					starboard.LabelResourceSpecHash:  "h1",
+				},
+			},
+		}, found)
+	})
+
+	t.Run("Should update ClusterVulnerabilityReports", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			&v1alpha1.ClusterVulnerabilityReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "pod-kube-system-etcd-master-etcd",
+					ResourceVersion: "0",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Pod",
+						starboard.LabelResourceName:      "etcd-master",
+						starboard.LabelResourceNamespace: "kube-system",
+						starboard.LabelContainerName:     "etcd",
+						starboard.LabelResourceSpecHash:  "h1",
+					},
+				},
+			}).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := vulnerabilityreport.NewReadWriter(&resolver)
+		err := readWriter.WriteCluster(context.TODO(), []v1alpha1.ClusterVulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod-kube-system-etcd-master-etcd",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Pod",
+						starboard.LabelResourceName:      "etcd-master",
+						starboard.LabelResourceNamespace: "kube-system",
+						starboard.LabelContainerName:     "etcd",
+						starboard.LabelResourceSpecHash:  "h2",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var found v1alpha1.ClusterVulnerabilityReport
+		err = testClient.Get(context.TODO(), types.NamespacedName{
+			Name: "pod-kube-system-etcd-master-etcd",
+		}, &found)
+		require.NoError(t, err)
+		assert.Equal(t, "h2", found.Labels[starboard.LabelResourceSpecHash])
+		assert.Equal(t, "1", found.ResourceVersion)
+	})
+
+	t.Run("Should find ClusterVulnerabilityReports", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-kube-system-etcd-master-etcd",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      string(kube.KindPod),
+					starboard.LabelResourceName:      "etcd-master",
+					starboard.LabelResourceNamespace: "kube-system",
+					starboard.LabelContainerName:     "etcd",
+				},
+			},
+		}, &v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-kube-system-kube-apiserver-master-kube-apiserver",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      string(kube.KindPod),
+					starboard.LabelResourceName:      "kube-apiserver-master",
+					starboard.LabelResourceNamespace: "kube-system",
+					starboard.LabelContainerName:     "kube-apiserver",
+				},
+			},
+		}).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := vulnerabilityreport.NewReadWriter(&resolver)
+		list, err := readWriter.FindClusterByOwner(context.TODO(), kube.ObjectRef{
+			Kind:      kube.KindPod,
+			Name:      "etcd-master",
+			Namespace: "kube-system",
+		})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "pod-kube-system-etcd-master-etcd", list[0].Name)
+	})
+
+	t.Run("Should notify about vulnerabilities not present in previous report", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			&v1alpha1.VulnerabilityReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+				},
+				Report: v1alpha1.VulnerabilityReportData{
+					Vulnerabilities: []v1alpha1.Vulnerability{
+						{VulnerabilityID: "CVE-2019-1551", Resource: "openssl", Severity: v1alpha1.SeverityMedium},
+					},
+				},
+			}).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		notifier := &recordingNotifier{}
+		readWriter := vulnerabilityreport.NewNotifyingReadWriter(&resolver, notifier)
+		err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+				},
+				Report: v1alpha1.VulnerabilityReportData{
+					Vulnerabilities: []v1alpha1.Vulnerability{
+						{VulnerabilityID: "CVE-2019-1551", Resource: "openssl", Severity: v1alpha1.SeverityMedium},
+						{VulnerabilityID: "CVE-2020-1967", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, notifier.events, 1)
+		assert.Equal(t, []notification.Finding{
+			{ID: "CVE-2020-1967", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+		}, notifier.events[0].Findings)
+	})
+
+	t.Run("Should notify about vulnerabilities not present in report of previous revision", func(t *testing.T) {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "app1", Namespace: "qa", UID: "deployment-uid"},
+		}
+		newReplicaSet := func(name string) *appsv1.ReplicaSet {
+			return &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "qa",
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+					},
+				},
+			}
+		}
+		newReport := func(rsName string, vulnerabilities ...v1alpha1.Vulnerability) v1alpha1.VulnerabilityReport {
+			return v1alpha1.VulnerabilityReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "replicaset-" + rsName + "-container1",
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "ReplicaSet",
+						starboard.LabelResourceName:      rsName,
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "container1",
+					},
+				},
+				Report: v1alpha1.VulnerabilityReportData{
+					Vulnerabilities: vulnerabilities,
+				},
+			}
+		}
+		oldReport := newReport("app1-6b8f4c5d9",
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2019-1551", Resource: "openssl", Severity: v1alpha1.SeverityCritical})
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			deployment,
+			newReplicaSet("app1-6b8f4c5d9"),
+			newReplicaSet("app1-7d9c6f8b4"),
+			&oldReport,
+		).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		notifier := &recordingNotifier{}
+		readWriter := vulnerabilityreport.NewNotifyingReadWriter(&resolver, notifier)
+		err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			newReport("app1-7d9c6f8b4",
+				v1alpha1.Vulnerability{VulnerabilityID: "CVE-2019-1551", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+				v1alpha1.Vulnerability{VulnerabilityID: "CVE-2020-1967", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			),
+		})
+		require.NoError(t, err)
+		require.Len(t, notifier.events, 1)
+		assert.Equal(t, []notification.Finding{
+			{ID: "CVE-2020-1967", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+		}, notifier.events[0].Findings)
+	})
+
+}
+
+type recordingNotifier struct {
+	events []notification.Event
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, event notification.Event) {
+	n.events = append(n.events, event)
 }
@@ -1,6 +1,8 @@
 package vulnerabilityreport
 
 import (
+	"context"
+	"errors"
 	"io"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -21,10 +23,11 @@ type Plugin interface {
 	// GetScanJobSpec describes the pod that will be created by Starboard when
 	// it schedules a Kubernetes job to scan the workload with the specified
 	// descriptor.
-	// The second argument maps container names to Docker registry credentials,
-	// which can be passed to the scanner as environment variables with values
-	// set from returned secrets.
-	GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (
+	// The credentials argument maps container names to Docker registry
+	// credentials, which can be passed to the scanner as environment variables
+	// with values set from returned secrets. The context is used for reading
+	// Kubernetes objects that the pod spec depends on, e.g. stored SBOMs.
+	GetScanJobSpec(ctx context.Context, pluginContext starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (
 		corev1.PodSpec, []*corev1.Secret, error)
 
 	// ParseVulnerabilityReportData is a callback to parse and convert logs of
@@ -32,3 +35,66 @@ type Plugin interface {
 	ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (
 		v1alpha1.VulnerabilityReportData, error)
 }
+
+// SBOMPlugin is the interface implemented by vulnerability scanner plugins
+// which can also generate software bill of materials (SBOM) of container
+// images scanned by the same scan job.
+type SBOMPlugin interface {
+
+	// IsSBOMEnabled returns true if scan jobs described by this plugin output
+	// the inventory of packages required to generate SBOMs.
+	IsSBOMEnabled(ctx starboard.PluginContext) (bool, error)
+
+	// ParseSBOMReportData is a callback to parse and convert logs of the pod
+	// controlled by the scan job to v1alpha1.SBOMReportData. It returns
+	// ErrSBOMNotGenerated if the scan job did not output the inventory of
+	// packages, e.g. because it scanned the previously stored SBOM.
+	ParseSBOMReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (
+		v1alpha1.SBOMReportData, error)
+}
+
+// ReadinessChecker tells whether a vulnerability scanner is ready to accept
+// scan jobs, e.g. whether the server of a scanner running in the client/server
+// mode is available.
+type ReadinessChecker interface {
+	IsReady(ctx context.Context) (bool, error)
+}
+
+type readinessCheckers []ReadinessChecker
+
+// AllReady returns a ReadinessChecker which reports ready only if all the
+// specified checkers are ready.
+func AllReady(checkers ...ReadinessChecker) ReadinessChecker {
+	return readinessCheckers(checkers)
+}
+
+func (c readinessCheckers) IsReady(ctx context.Context) (bool, error) {
+	for _, checker := range c {
+		ready, err := checker.IsReady(ctx)
+		if err != nil || !ready {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ErrSBOMNotGenerated is returned by SBOMPlugin.ParseSBOMReportData when the
+// SBOM cannot be generated from logs of the scan job.
+var ErrSBOMNotGenerated = errors.New("sbom not generated")
+
+// GetSBOMPlugin returns the specified Plugin as SBOMPlugin if it implements
+// the SBOMPlugin interface and SBOM generation is enabled, or nil otherwise.
+func GetSBOMPlugin(ctx starboard.PluginContext, plugin Plugin) (SBOMPlugin, error) {
+	sbomPlugin, ok := plugin.(SBOMPlugin)
+	if !ok {
+		return nil, nil
+	}
+	enabled, err := sbomPlugin.IsSBOMEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, nil
+	}
+	return sbomPlugin, nil
+}
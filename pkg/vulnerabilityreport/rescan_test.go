@@ -0,0 +1,202 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/scanfailure"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// recordingScanQueue is a controller.ScanQueue that never admits scan jobs
+// and records requests to admit them.
+type recordingScanQueue struct {
+	requests []controller.ScanRequest
+}
+
+func (q *recordingScanQueue) Source(_ string) source.Source {
+	return nil
+}
+
+func (q *recordingScanQueue) Admit(_ context.Context, request controller.ScanRequest) (bool, error) {
+	q.requests = append(q.requests, request)
+	return false, nil
+}
+
+func (q *recordingScanQueue) Forget(_ context.Context, _ string, _ client.ObjectKey) error {
+	return nil
+}
+
+func TestWorkloadController_isRescanDue(t *testing.T) {
+	now := time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC)
+	interval := 24 * time.Hour
+
+	testCases := []struct {
+		name             string
+		rescanInterval   *time.Duration
+		updateTimestamp  time.Time
+		expectedDue      bool
+		expectedDuration time.Duration
+	}{
+		{
+			name:             "Should not rescan when rescan interval is disabled",
+			rescanInterval:   nil,
+			updateTimestamp:  now.Add(-30 * 24 * time.Hour),
+			expectedDue:      false,
+			expectedDuration: 0,
+		},
+		{
+			name:             "Should not rescan report that is not yet due",
+			rescanInterval:   &interval,
+			updateTimestamp:  now.Add(-20 * time.Hour),
+			expectedDue:      false,
+			expectedDuration: 4 * time.Hour,
+		},
+		{
+			name:             "Should rescan report that is overdue",
+			rescanInterval:   &interval,
+			updateTimestamp:  now.Add(-25 * time.Hour),
+			expectedDue:      true,
+			expectedDuration: -time.Hour,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &WorkloadController{
+				Config: etc.Config{VulnerabilityScannerRescanInterval: tc.rescanInterval},
+				Clock:  ext.NewFixedClock(now),
+			}
+			due, duration := r.isRescanDue(tc.updateTimestamp)
+			assert.Equal(t, tc.expectedDue, due)
+			assert.Equal(t, tc.expectedDuration, duration)
+		})
+	}
+}
+
+func TestWorkloadController_reconcileWorkload_Rescan(t *testing.T) {
+	now := time.Date(2022, time.August, 10, 10, 0, 0, 0, time.UTC)
+	interval := 24 * time.Hour
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "nginx", Image: "nginx:1.16"},
+			},
+		},
+	}
+	newReport := func(updateTimestamp time.Time) *v1alpha1.VulnerabilityReport {
+		return &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-nginx-nginx",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:               "Pod",
+					starboard.LabelResourceName:               "nginx",
+					starboard.LabelResourceNamespace:          "default",
+					starboard.LabelContainerName:              "nginx",
+					starboard.LabelResourceSpecHash:           kube.ComputeHash(pod.Spec),
+					starboard.LabelVulnerabilityReportScanner: "Trivy",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(updateTimestamp),
+			},
+		}
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "nginx"}}
+
+	testCases := []struct {
+		name            string
+		rescanInterval  *time.Duration
+		updateTimestamp time.Time
+		expectedResult  ctrl.Result
+		expectedRescan  bool
+	}{
+		{
+			name:            "Should not requeue when rescan interval is disabled",
+			rescanInterval:  nil,
+			updateTimestamp: now.Add(-30 * 24 * time.Hour),
+			expectedResult:  ctrl.Result{},
+			expectedRescan:  false,
+		},
+		{
+			name:            "Should requeue report that is not yet due",
+			rescanInterval:  &interval,
+			updateTimestamp: now.Add(-20 * time.Hour),
+			expectedResult:  ctrl.Result{RequeueAfter: 4 * time.Hour},
+			expectedRescan:  false,
+		},
+		{
+			name:            "Should rescan report that is overdue",
+			rescanInterval:  &interval,
+			updateTimestamp: now.Add(-25 * time.Hour),
+			expectedResult:  ctrl.Result{},
+			expectedRescan:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+				WithObjects(pod, newReport(tc.updateTimestamp)).
+				Build()
+			resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+			config := starboard.GetDefaultConfig()
+			clock := ext.NewFixedClock(now)
+			queue := &recordingScanQueue{}
+
+			r := &WorkloadController{
+				Logger: logr.Discard(),
+				Config: etc.Config{
+					Namespace:                          "starboard-operator",
+					VulnerabilityScannerRescanInterval: tc.rescanInterval,
+				},
+				Clock:          clock,
+				Client:         testClient,
+				ObjectResolver: resolver,
+				ScanQueue:      queue,
+				PluginContext: starboard.NewPluginContext().
+					WithName("Trivy").
+					WithNamespace("starboard-operator").
+					WithClient(testClient).
+					WithStarboardConfig(config).
+					Get(),
+				ReadWriter: NewReadWriter(&resolver),
+				Recorder: scanfailure.NewRecorder(testClient, clock, record.NewFakeRecorder(10),
+					"starboard-operator", time.Minute, time.Hour),
+				ConfigData: config,
+			}
+
+			result, err := r.reconcileWorkload(kube.KindPod)(context.TODO(), request)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+			if tc.expectedRescan {
+				require.Len(t, queue.requests, 1)
+				assert.Equal(t, "nginx", queue.requests[0].Object.GetName())
+				assert.False(t, queue.requests[0].NeverScanned)
+			} else {
+				assert.Empty(t, queue.requests)
+			}
+		})
+	}
+}
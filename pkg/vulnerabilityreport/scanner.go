@@ -2,17 +2,24 @@ package vulnerabilityreport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exploitability"
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/runner"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/scanjobtemplate"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vex"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/klogr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -28,6 +35,10 @@ type Scanner struct {
 	config         starboard.ConfigData
 	opts           kube.ScannerOpts
 	secretsReader  kube.SecretsReader
+	exceptions     ExceptionsReader
+	exploitability exploitability.Enricher
+	vex            vex.StatementsReader
+	templates      scanjobtemplate.Reader
 }
 
 // NewScanner constructs a new static vulnerability Scanner with the specified
@@ -54,6 +65,10 @@ func NewScanner(
 		logsReader:     kube.NewLogsReader(clientset),
 		config:         config,
 		secretsReader:  kube.NewSecretsReader(client),
+		exceptions:     NewExceptionsReader(client, ext.NewSystemClock()),
+		exploitability: exploitability.NewEnricher(config),
+		vex:            vex.NewStatementsReader(klogr.New(), client, starboard.NamespaceName),
+		templates:      scanjobtemplate.NewReader(client),
 	}
 }
 
@@ -62,40 +77,47 @@ func NewScanner(
 // It is a blocking method that watches the status of the job until it succeeds
 // or fails. When succeeded it parses container logs and coverts the output
 // to instances of v1alpha1.VulnerabilityReport by delegating such transformation
-// logic also to the Plugin.
-func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error) {
+// logic also to the Plugin. If the Plugin implements the SBOMPlugin interface
+// and SBOM generation is enabled, it also returns instances of
+// v1alpha1.SBOMReport.
+func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, []v1alpha1.SBOMReport, error) {
 	klog.V(3).Infof("Getting Pod template for workload: %v", workload)
 
 	workloadObj, err := s.objectResolver.ObjectFromObjectRef(ctx, workload)
 	if err != nil {
-		return nil, fmt.Errorf("resolving object: %w", err)
+		return nil, nil, fmt.Errorf("resolving object: %w", err)
 	}
 
 	owner, err := s.objectResolver.ReportOwner(ctx, workloadObj)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	scanJobTolerations, err := s.config.GetScanJobTolerations()
 	if err != nil {
-		return nil, fmt.Errorf("getting scan job tolerations: %w", err)
+		return nil, nil, fmt.Errorf("getting scan job tolerations: %w", err)
 	}
 
 	scanJobAnnotations, err := s.config.GetScanJobAnnotations()
 	if err != nil {
-		return nil, fmt.Errorf("getting scan job annotations: %w", err)
+		return nil, nil, fmt.Errorf("getting scan job annotations: %w", err)
 	}
 
 	scanJobPodTemplateLabels, err := s.config.GetScanJobPodTemplateLabels()
 	if err != nil {
-		return nil, fmt.Errorf("getting scan job template labels: %w", err)
+		return nil, nil, fmt.Errorf("getting scan job template labels: %w", err)
+	}
+
+	scanJobTemplates, err := s.templates.FindTemplates(ctx, s.pluginContext.GetName(), owner.GetNamespace())
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting scan job templates: %w", err)
 	}
 
 	klog.V(3).Infof("Scanning with options: %+v", s.opts)
 
 	credentials, err := s.secretsReader.CredentialsByWorkload(ctx, owner)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	job, secrets, err := NewScanJobBuilder().
@@ -107,15 +129,16 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 		WithTolerations(scanJobTolerations).
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
-		Get()
+		WithScanJobTemplates(scanJobTemplates).
+		Get(ctx)
 
 	if err != nil {
-		return nil, fmt.Errorf("constructing scan job: %w", err)
+		return nil, nil, fmt.Errorf("constructing scan job: %w", err)
 	}
 
 	err = runner.New().Run(ctx, kube.NewRunnableJob(s.scheme, s.clientset, job, secrets...))
 	if err != nil {
-		return nil, fmt.Errorf("running scan job: %w", err)
+		return nil, nil, fmt.Errorf("running scan job: %w", err)
 	}
 
 	defer func() {
@@ -140,44 +163,109 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 // passing owner directly. The goal is for CLI and operator to create jobs
 // with the same struct and set of labels to reuse code responsible for parsing
 // v1alpha1.VulnerabilityReport instances.
-func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *batchv1.Job, owner client.Object) ([]v1alpha1.VulnerabilityReport, error) {
+func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *batchv1.Job, owner client.Object) ([]v1alpha1.VulnerabilityReport, []v1alpha1.SBOMReport, error) {
 	var reports []v1alpha1.VulnerabilityReport
+	var sbomReports []v1alpha1.SBOMReport
 
 	containerImages, err := kube.GetContainerImagesFromJob(job)
 	if err != nil {
-		return nil, fmt.Errorf("getting container images: %w", err)
+		return nil, nil, fmt.Errorf("getting container images: %w", err)
 	}
 
 	podSpecHash, ok := job.Labels[starboard.LabelResourceSpecHash]
 	if !ok {
-		return nil, fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
+		return nil, nil, fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
+	}
+
+	spec, err := kube.GetPodSpec(owner)
+	if err != nil {
+		return nil, nil, err
+	}
+	containerClasses := kube.GetContainerClasses(spec)
+
+	exceptions, err := s.exceptions.FindActiveExceptions(ctx, owner.GetNamespace())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statements, err := s.vex.FindStatements(ctx, owner.GetNamespace())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sbomPlugin, err := GetSBOMPlugin(s.pluginContext, s.plugin)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	for containerName, containerImage := range containerImages {
 		klog.V(3).Infof("Getting logs for %s container in job: %s/%s", containerName, job.Namespace, job.Name)
 		logsStream, err := s.logsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		result, err := s.plugin.ParseVulnerabilityReportData(s.pluginContext, containerImage, logsStream)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		_ = logsStream.Close()
 
+		enriched, err := s.exploitability.Enrich(result)
+		if err != nil {
+			klog.Warningf("Skipping exploitability enrichment of %s container: %v", containerName, err)
+		} else {
+			result = enriched
+		}
+
+		result, err = ApplyExceptions(result, exceptions, owner.GetLabels())
+		if err != nil {
+			return nil, nil, err
+		}
+		result = ApplyVEX(result, statements)
+
 		report, err := NewReportBuilder(s.scheme).
 			Controller(owner).
 			Container(containerName).
+			ContainerClass(containerClasses[containerName]).
+			PluginContext(s.pluginContext).
 			Data(result).
 			PodSpecHash(podSpecHash).
 			Get()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		reports = append(reports, report)
 
+		if sbomPlugin == nil {
+			continue
+		}
+
+		logsStream, err = s.logsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
+		if err != nil {
+			return nil, nil, err
+		}
+		sbomData, err := sbomPlugin.ParseSBOMReportData(s.pluginContext, containerImage, logsStream)
+		_ = logsStream.Close()
+		if errors.Is(err, ErrSBOMNotGenerated) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sbomReport, err := sbomreport.NewReportBuilder(s.scheme).
+			Controller(owner).
+			Container(containerName).
+			Data(sbomData).
+			PodSpecHash(podSpecHash).
+			Get()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sbomReports = append(sbomReports, sbomReport)
 	}
-	return reports, nil
+	return reports, sbomReports, nil
 }
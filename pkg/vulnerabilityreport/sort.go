@@ -17,16 +17,8 @@ func (s Vulnerabilities) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 // Vulnerabilities value.
 type BySeverity struct{ Vulnerabilities }
 
-var severityOrder = map[v1alpha1.Severity]int{
-	v1alpha1.SeverityCritical: 0,
-	v1alpha1.SeverityHigh:     1,
-	v1alpha1.SeverityMedium:   2,
-	v1alpha1.SeverityLow:      3,
-	v1alpha1.SeverityUnknown:  4,
-}
-
 func (s BySeverity) Less(i, j int) bool {
-	return severityOrder[s.Vulnerabilities[i].Severity] < severityOrder[s.Vulnerabilities[j].Severity]
+	return s.Vulnerabilities[i].Severity.Compare(s.Vulnerabilities[j].Severity) > 0
 }
 
 type LessFunc func(p1, p2 *v1alpha1.VulnerabilityReport) bool
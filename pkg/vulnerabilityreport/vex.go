@@ -0,0 +1,43 @@
+package vulnerabilityreport
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/vex"
+)
+
+// ApplyVEX returns a copy of the given report data where vulnerabilities are
+// annotated with the status and the justification of the most recent VEX
+// statement that applies to them. Vulnerabilities that are not_affected or
+// fixed are excluded from the summary. Annotations of vulnerabilities that no
+// statement applies to anymore are removed and the summary is restored.
+func ApplyVEX(data v1alpha1.VulnerabilityReportData, statements []vex.Statement) v1alpha1.VulnerabilityReportData {
+	copied := *data.DeepCopy()
+
+	for i, vulnerability := range copied.Vulnerabilities {
+		updated := vulnerability
+		updated.VEXStatus = ""
+		updated.VEXJustification = ""
+		if statement := vex.Find(statements, copied.Registry, copied.Artifact, vulnerability); statement != nil {
+			updated.VEXStatus = string(statement.Status)
+			updated.VEXJustification = statement.Justification
+		}
+		updateVulnerability(&copied, i, updated)
+	}
+
+	return copied
+}
+
+// updateVulnerability replaces the vulnerability at the specified index with
+// the updated one and adjusts the summary if the vulnerability is excluded
+// from or included in the summary as a result.
+func updateVulnerability(data *v1alpha1.VulnerabilityReportData, i int, updated v1alpha1.Vulnerability) {
+	before := data.Vulnerabilities[i].IsExcluded()
+	after := updated.IsExcluded()
+	switch {
+	case !before && after:
+		updateSummary(&data.Summary, updated.Severity, -1)
+	case before && !after:
+		updateSummary(&data.Summary, updated.Severity, 1)
+	}
+	data.Vulnerabilities[i] = updated
+}
@@ -0,0 +1,141 @@
+package vulnerabilityreport
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vex"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// VEXController watches ConfigMaps that store VEX documents and reapplies VEX
+// statements to vulnerability reports whenever a document is created, updated,
+// or deleted. Documents stored in the operator namespace apply to reports in
+// all namespaces. Reports are written with the ReadWriter, therefore
+// vulnerabilities that are no longer resolved by VEX statements are announced
+// like newly discovered ones.
+type VEXController struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	vex.StatementsReader
+	ReadWriter
+}
+
+func (r *VEXController) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+	operatorNamespace, err := r.Config.GetOperatorNamespace()
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("vex").
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			IsVEXDocument,
+			predicate.Or(InNamespace(operatorNamespace), installModePredicate),
+		)).
+		Complete(r)
+}
+
+func (r *VEXController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("namespace", req.Namespace)
+
+	operatorNamespace, err := r.Config.GetOperatorNamespace()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var listOpts []client.ListOption
+	if req.Namespace != operatorNamespace {
+		listOpts = append(listOpts, client.InNamespace(req.Namespace))
+	}
+
+	// Statements are cached by namespace of reports, because documents stored
+	// in the operator namespace apply to reports in all namespaces.
+	statementsByNamespace := make(map[string][]vex.Statement)
+	findStatements := func(namespace string) ([]vex.Statement, error) {
+		if statements, ok := statementsByNamespace[namespace]; ok {
+			return statements, nil
+		}
+		statements, err := r.FindStatements(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		statementsByNamespace[namespace] = statements
+		return statements, nil
+	}
+
+	var reportList v1alpha1.VulnerabilityReportList
+	err = r.Client.List(ctx, &reportList, listOpts...)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	for _, report := range reportList.Items {
+		statements, err := findStatements(report.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		data := ApplyVEX(report.Report, statements)
+		if reflect.DeepEqual(data, report.Report) {
+			continue
+		}
+		log.V(1).Info("Updating VEX statuses of vulnerabilities", "report", report.Namespace+"/"+report.Name)
+		copied := report.DeepCopy()
+		copied.Report = data
+		err = r.ReadWriter.Write(ctx, []v1alpha1.VulnerabilityReport{*copied})
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("updating vulnerability report: %w", err)
+		}
+	}
+
+	// Reports for static Pods are cluster-scoped, but still subject to documents
+	// stored in the namespace of the Pod.
+	var clusterListOpts []client.ListOption
+	if req.Namespace != operatorNamespace {
+		clusterListOpts = append(clusterListOpts, client.MatchingLabels{
+			starboard.LabelResourceNamespace: req.Namespace,
+		})
+	}
+	var clusterReportList v1alpha1.ClusterVulnerabilityReportList
+	err = r.Client.List(ctx, &clusterReportList, clusterListOpts...)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing cluster vulnerability reports: %w", err)
+	}
+	for _, report := range clusterReportList.Items {
+		if IsCachedReport(report.ObjectMeta) {
+			continue
+		}
+		statements, err := findStatements(report.Labels[starboard.LabelResourceNamespace])
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		data := ApplyVEX(report.Report, statements)
+		if reflect.DeepEqual(data, report.Report) {
+			continue
+		}
+		log.V(1).Info("Updating VEX statuses of vulnerabilities", "report", report.Name)
+		copied := report.DeepCopy()
+		copied.Report = data
+		err = r.ReadWriter.WriteCluster(ctx, []v1alpha1.ClusterVulnerabilityReport{*copied})
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("updating cluster vulnerability report: %w", err)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
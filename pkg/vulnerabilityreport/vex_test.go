@@ -0,0 +1,93 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/vex"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyVEX(t *testing.T) {
+	data := v1alpha1.VulnerabilityReportData{
+		Registry: v1alpha1.Registry{Server: "index.docker.io"},
+		Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+		Summary: v1alpha1.VulnerabilitySummary{
+			CriticalCount: 1,
+			HighCount:     2,
+			MediumCount:   1,
+		},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2020-1967", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2020-1971", Resource: "openssl", Severity: v1alpha1.SeverityHigh},
+			{VulnerabilityID: "CVE-2021-3449", Resource: "libssl", Severity: v1alpha1.SeverityHigh},
+			{VulnerabilityID: "CVE-2020-3810", Resource: "apt", Severity: v1alpha1.SeverityMedium},
+		},
+	}
+	statements := []vex.Statement{
+		{VulnerabilityID: "CVE-2020-1967", Products: []string{"nginx"}, Status: vex.StatusNotAffected, Justification: "vulnerable_code_not_present"},
+		{VulnerabilityID: "CVE-2020-1971", Products: []string{"pkg:deb/debian/openssl"}, Status: vex.StatusFixed},
+		{VulnerabilityID: "CVE-2021-3449", Products: []string{"nginx"}, Status: vex.StatusUnderInvestigation},
+		{VulnerabilityID: "CVE-2020-3810", Products: []string{"quay.io/nginx/nginx"}, Status: vex.StatusNotAffected},
+	}
+
+	result := vulnerabilityreport.ApplyVEX(data, statements)
+
+	statuses := map[string]string{}
+	for _, vulnerability := range result.Vulnerabilities {
+		if vulnerability.VEXStatus != "" {
+			statuses[vulnerability.VulnerabilityID] = vulnerability.VEXStatus
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"CVE-2020-1967": "not_affected",
+		"CVE-2020-1971": "fixed",
+		"CVE-2021-3449": "under_investigation",
+	}, statuses)
+	assert.Equal(t, "vulnerable_code_not_present", result.Vulnerabilities[0].VEXJustification)
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{HighCount: 1, MediumCount: 1}, result.Summary)
+
+	// Applying the same statements again must not change the result.
+	assert.Equal(t, result, vulnerabilityreport.ApplyVEX(result, statements))
+
+	// Removing statements must restore the original report data.
+	assert.Equal(t, data, vulnerabilityreport.ApplyVEX(result, nil))
+}
+
+func TestApplyVEX_WithExceptions(t *testing.T) {
+	data := v1alpha1.VulnerabilityReportData{
+		Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2020-1967", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+		},
+	}
+	exceptions := []v1alpha1.VulnerabilityException{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "accept-cve-2020-1967"},
+			Spec: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityIDs: []string{"CVE-2020-1967"},
+			},
+		},
+	}
+	statements := []vex.Statement{
+		{VulnerabilityID: "CVE-2020-1967", Products: []string{"pkg:deb/debian/openssl"}, Status: vex.StatusNotAffected},
+	}
+
+	suppressed, err := vulnerabilityreport.ApplyExceptions(data, exceptions, nil)
+	require.NoError(t, err)
+	result := vulnerabilityreport.ApplyVEX(suppressed, statements)
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{}, result.Summary,
+		"vulnerability that is both suppressed and not affected must be excluded once")
+
+	// Removing the exception must keep the vulnerability excluded by VEX.
+	result, err = vulnerabilityreport.ApplyExceptions(result, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{}, result.Summary)
+
+	// Removing the statement must include the vulnerability again.
+	result = vulnerabilityreport.ApplyVEX(result, nil)
+	assert.Equal(t, data, result)
+}